@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"k8s-dojo/pkg/cluster"
+	"k8s-dojo/pkg/engine"
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/packs"
+	"k8s-dojo/pkg/scenario"
+)
+
+// skipPreflight is the value of the global --skip-preflight flag.
+var skipPreflight bool
+
+// clientOptions are the API client's limits, set by the global --kube-qps,
+// --kube-burst and --kube-attempts flags.
+var clientOptions = k8s.DefaultClientOptions()
+
+// connectCluster creates the dojo cluster if needed (or reuses it) and returns a client.
+func connectCluster(version string) (*k8s.Client, error) {
+	v, err := cluster.ParseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := useRemote()
+	if err != nil {
+		return nil, err
+	}
+	opts := cluster.DefaultManagerOptions()
+	opts.SkipPreflight = skipPreflight
+	opts.Warn = func(w string) { fmt.Fprintf(os.Stderr, "warning: %s\n", w) }
+
+	kubeconfig, err := cluster.NewManagerWithOptions(opts).EnsureCluster(v)
+	if err != nil {
+		return nil, err
+	}
+	if h != nil {
+		if kubeconfig, err = h.Connect(context.Background(), kubeconfig); err != nil {
+			return nil, err
+		}
+	}
+	return k8s.NewClientFromKubeconfigWithOptions(kubeconfig, clientOptions)
+}
+
+// offlineRegistry loads all scenarios without a cluster, for listing and completion.
+// Scenarios only use their client once started.
+func offlineRegistry() *scenario.Registry {
+	client := &k8s.Client{}
+	reg := scenario.NewRegistry(client)
+	_ = reg.LoadYAML(scenario.DefaultScenarioDir())
+	if m, err := packs.NewManager(""); err == nil {
+		_ = m.LoadInto(reg)
+	}
+	return reg
+}
+
+// newEngine builds a registry and engine bound to the client.
+func newEngine(client *k8s.Client) (*scenario.Registry, *engine.Engine) {
+	reg := scenario.NewRegistry(client)
+	_ = reg.LoadYAML(scenario.DefaultScenarioDir())
+	if m, err := packs.NewManager(""); err == nil {
+		_ = m.LoadInto(reg)
+	}
+	return reg, engine.NewEngine(reg, client.Clientset)
+}
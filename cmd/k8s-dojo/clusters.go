@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/cluster"
+)
+
+// newClusterCmd groups lifecycle commands for the dojo's Kind cluster.
+func newClusterCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Pause, resume and inspect the dojo cluster",
+	}
+	cmd.AddCommand(
+		newClusterActionCmd("pause", "Stop the cluster's node containers to save battery, keeping the cluster", "Paused", (*cluster.Manager).Pause),
+		newClusterActionCmd("resume", "Start a paused cluster and wait until it is ready", "Resumed", (*cluster.Manager).Resume),
+		newClusterStatusCmd(),
+		newFleetCmd(),
+	)
+	return cmd
+}
+
+func newClusterActionCmd(use, short, done string, action func(*cluster.Manager) error) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := useRemote(); err != nil {
+				return err
+			}
+			if err := action(cluster.NewManager()); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s cluster %s\n", done, cluster.ClusterName)
+			return nil
+		},
+	}
+}
+
+func newClusterStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the cluster is running, paused or missing",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := useRemote(); err != nil {
+				return err
+			}
+			status, err := cluster.NewManager().Status()
+			if err != nil {
+				return err
+			}
+			return printOutput(cmd, map[string]string{"cluster": cluster.ClusterName, "status": string(status)}, func() error {
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "Cluster %s is %s\n", cluster.ClusterName, status)
+				return err
+			})
+		},
+	}
+}
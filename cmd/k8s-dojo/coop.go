@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"k8s-dojo/pkg/coop"
+	"k8s-dojo/pkg/tui"
+)
+
+// setupCoop shares the TUI for co-op, or joins the dojo shared under token.
+func setupCoop(m *tui.AppModel, share bool, token, session string) error {
+	store := coop.NewStore("")
+	if share {
+		_, err := m.ShareSession(store, learnerName(session))
+		return err
+	}
+	s, err := store.Get(token)
+	if errors.Is(err, coop.ErrNoShare) {
+		return fmt.Errorf("%w %q; ask your partner for the token in their header", err, token)
+	}
+	if err != nil {
+		return err
+	}
+	m.JoinSession(store, s, learnerName(session))
+	return nil
+}
+
+// learnerName names the learner to their co-op partner: the session, else the OS user.
+func learnerName(session string) string {
+	if session != "" {
+		return session
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return "partner"
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/tui"
+)
+
+func newDemoCmd() *cobra.Command {
+	var (
+		typingDelay time.Duration
+		rec         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "demo <scenario-id>",
+		Short: "Start a scenario and replay its solution in the terminal, with captions",
+		Long: `Start a scenario on the latest supported Kubernetes version and type its
+canonical solution into the embedded terminal at human speed, captioning each
+step. Useful for conference demos and recording tutorial GIFs.
+
+The script comes from the scenario's guide, so only scenarios that offer one
+(see --guided) can be demoed.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeScenarioIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := offlineRegistry().Get(args[0])
+			if s == nil {
+				return fmt.Errorf("scenario not found: %s", args[0])
+			}
+			if len(scenario.DemoScript(s)) == 0 {
+				return fmt.Errorf("scenario %s has no demo script", args[0])
+			}
+
+			return runTUI(func(m *tui.AppModel) error {
+				m.EnableDemoMode(args[0], typingDelay)
+				if rec {
+					return m.EnableRecording("")
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&typingDelay, "typing-delay", 60*time.Millisecond, "Delay between typed characters")
+	cmd.Flags().BoolVar(&rec, "record", false, "Record the session to ~/.k8s-dojo/recordings, e.g. to export a GIF")
+	return cmd
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/calibrate"
+	"k8s-dojo/pkg/harness"
+	"k8s-dojo/pkg/importer"
+	"k8s-dojo/pkg/scaffold"
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/telemetry"
+)
+
+// newDevCmd groups tooling for scenario authors.
+func newDevCmd() *cobra.Command {
+	dev := &cobra.Command{
+		Use:   "dev",
+		Short: "Tools for scenario authors",
+	}
+	dev.AddCommand(newDevTestCmd(), newDevNewScenarioCmd(), newDevCalibrateCmd(), newDevImportKillercodaCmd())
+	return dev
+}
+
+func newDevTestCmd() *cobra.Command {
+	var (
+		version string
+		keep    bool
+		opts    = harness.DefaultOptions()
+	)
+
+	cmd := &cobra.Command{
+		Use:               "test <scenario-id>",
+		Short:             "Run a scenario end to end: setup, broken check, solve, validate, cleanup",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeScenarioIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			client, err := connectCluster(version)
+			if err != nil {
+				return err
+			}
+			reg, eng := newEngine(client)
+			if reg.Get(args[0]) == nil {
+				return fmt.Errorf("scenario not found: %s", args[0])
+			}
+
+			opts.KeepOnFailure = keep
+			report := harness.Run(ctx, eng, args[0], opts)
+			if err := printOutput(cmd, report, func() error {
+				printReport(cmd, report)
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			if !report.Passed {
+				return fmt.Errorf("scenario %s failed", args[0])
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&version, "k8s-version", "", "Kubernetes version for a new cluster (default: latest supported)")
+	cmd.Flags().BoolVar(&keep, "keep", false, "Keep the scenario namespace when a step fails")
+	cmd.Flags().DurationVar(&opts.SolveTimeout, "timeout", opts.SolveTimeout, "How long to wait for Validate to pass after Solve")
+	return cmd
+}
+
+func newDevNewScenarioCmd() *cobra.Command {
+	var (
+		opts scaffold.Options
+		dir  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "new-scenario",
+		Short: "Scaffold a new Go or YAML scenario",
+		Example: `  k8s-dojo dev new-scenario --category Networking --id my-scenario
+  k8s-dojo dev new-scenario --category Storage --id pvc-stuck --format yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			if dir == "" {
+				dir = scenario.DefaultScenarioDir()
+			}
+
+			files, err := scaffold.Generate(opts, root, dir)
+			if err != nil {
+				return err
+			}
+
+			if machineOutput() {
+				return printOutput(cmd, files, nil)
+			}
+			out := cmd.OutOrStdout()
+			for _, f := range files {
+				fmt.Fprintf(out, "wrote %s\n", f)
+			}
+			fmt.Fprintf(out, "Fill in the TODOs, then run: k8s-dojo dev test %s\n", opts.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.ID, "id", "", "Scenario ID, e.g. my-scenario")
+	cmd.Flags().StringVar(&opts.Category, "category", "", "Scenario category, e.g. Networking")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Display name (default: derived from category and ID)")
+	cmd.Flags().StringVar(&opts.Difficulty, "difficulty", "easy", "easy, medium or hard")
+	cmd.Flags().StringVar(&opts.Format, "format", scaffold.FormatGo, "Skeleton format: go or yaml")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory for YAML scenarios (default: ~/.k8s-dojo/scenarios)")
+	_ = cmd.MarkFlagRequired("id")
+	_ = cmd.MarkFlagRequired("category")
+	return cmd
+}
+
+// importedScenario is the machine-readable result of an import.
+type importedScenario struct {
+	ID       string   `json:"id"`
+	Path     string   `json:"path"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func newDevImportKillercodaCmd() *cobra.Command {
+	var (
+		opts       importer.Options
+		difficulty string
+		dir        string
+		force      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import-killercoda <folder>",
+		Short: "Convert a Killercoda scenario folder into a YAML scenario",
+		Long: `Convert a Killercoda-style scenario folder (index.json, step markdown, verify
+and background scripts) into a YAML scenario. Steps become guided-mode steps,
+each verify script becomes a script check run in a read-only validator pod, and
+the background scripts are dropped. With --trust-scripts, the background
+scripts run at setup and the verify scripts on this machine, with KUBECONFIG
+pointing at the dojo cluster and the scenario namespace as its default. What
+the scripts do on the VM itself doesn't carry over and is reported as a warning.`,
+		Example: `  k8s-dojo dev import-killercoda ./scenarios/broken-deployment
+  k8s-dojo dev import-killercoda ./rbac-lab --id rbac-lab --category Security --difficulty hard
+  k8s-dojo dev import-killercoda ./my-own-lab --trust-scripts`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch strings.ToLower(difficulty) {
+			case "easy":
+				opts.Difficulty = scenario.DifficultyEasy
+			case "medium", "":
+				opts.Difficulty = scenario.DifficultyMedium
+			case "hard":
+				opts.Difficulty = scenario.DifficultyHard
+			default:
+				return fmt.Errorf("invalid difficulty %q", difficulty)
+			}
+
+			res, err := importer.Killercoda(args[0], opts)
+			if err != nil {
+				return err
+			}
+			data, err := res.YAML()
+			if err != nil {
+				return err
+			}
+
+			if dir == "" {
+				dir = scenario.DefaultScenarioDir()
+			}
+			path := filepath.Join(dir, res.Definition.ID+".yaml")
+			if _, err := os.Stat(path); err == nil && !force {
+				return fmt.Errorf("%s already exists, pass --force to overwrite it", path)
+			}
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create scenario directory: %w", err)
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return fmt.Errorf("failed to write scenario: %w", err)
+			}
+
+			imported := importedScenario{ID: res.Definition.ID, Path: path, Warnings: res.Warnings}
+			return printOutput(cmd, imported, func() error {
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "wrote %s\n", path)
+				for _, w := range res.Warnings {
+					fmt.Fprintf(out, "warning: %s\n", w)
+				}
+				fmt.Fprintf(out, "Review it and add a solve manifest, then run: k8s-dojo dev test %s\n", res.Definition.ID)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.ID, "id", "", "Scenario ID (default: derived from the folder name)")
+	cmd.Flags().StringVar(&opts.Category, "category", importer.DefaultCategory, "Scenario category")
+	cmd.Flags().StringVar(&difficulty, "difficulty", "medium", "easy, medium or hard")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory for YAML scenarios (default: ~/.k8s-dojo/scenarios)")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing scenario with the same ID")
+	cmd.Flags().BoolVar(&opts.TrustScripts, "trust-scripts", false, "Keep the background scripts and run the scripts on this machine")
+	return cmd
+}
+
+func newDevCalibrateCmd() *cobra.Command {
+	var (
+		files       []string
+		flaggedOnly bool
+		statsPath   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "calibrate",
+		Short: "Compare declared difficulty with observed solve times and hint usage",
+		Long: fmt.Sprintf(`Aggregate attempt records and flag scenarios whose declared difficulty doesn't
+match how players fare. The observed difficulty combines the median solve time,
+hints per attempt and solve rate; scenarios with fewer than %d attempts are not
+flagged.
+
+Attempt files are JSON lines of telemetry records, such as the local history
+(the default) or records collected by a telemetry endpoint.`, calibrate.MinAttempts),
+		Example: `  k8s-dojo dev calibrate
+  k8s-dojo dev calibrate --attempts collected.jsonl --flagged
+  k8s-dojo dev calibrate --attempts collected.jsonl --write-stats community-stats.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(files) == 0 {
+				files = []string{telemetry.DefaultHistoryPath()}
+			}
+			var attempts []telemetry.Attempt
+			for _, f := range files {
+				a, err := telemetry.LoadAttempts(f)
+				if err != nil {
+					return err
+				}
+				attempts = append(attempts, a...)
+			}
+
+			stats := calibrate.Aggregate(attempts)
+			if statsPath != "" {
+				if err := calibrate.SaveStats(statsPath, stats); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "wrote %s\n", statsPath)
+			}
+
+			findings := []calibrate.Finding{}
+			for _, f := range calibrate.Report(offlineRegistry().List(), stats) {
+				if !flaggedOnly || f.Flagged {
+					findings = append(findings, f)
+				}
+			}
+
+			return printOutput(cmd, findings, func() error {
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "ID\tDECLARED\tOBSERVED\tATTEMPTS\tMEDIAN\tSOLVED\tHINTS\t")
+				for _, f := range findings {
+					observed, median, solved, hints := "-", "-", "-", "-"
+					if f.Stats.Attempts > 0 {
+						solved = fmt.Sprintf("%.0f%%", f.Stats.SolveRate()*100)
+						hints = fmt.Sprintf("%.1f", f.Stats.MeanHints)
+					}
+					if f.Stats.Solved > 0 {
+						median = f.Stats.MedianTime().String()
+					}
+					if f.Observed != "" {
+						observed = string(f.Observed)
+					}
+					flag := ""
+					if f.Flagged {
+						flag = "⚠ miscalibrated"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n", f.ScenarioID, f.Declared, observed, f.Stats.Attempts, median, solved, hints, flag)
+				}
+				return w.Flush()
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&files, "attempts", nil, "Attempt record files (default: ~/.k8s-dojo/attempts.jsonl)")
+	cmd.Flags().BoolVar(&flaggedOnly, "flagged", false, "Only show miscalibrated scenarios")
+	cmd.Flags().StringVar(&statsPath, "write-stats", "", "Also write per-scenario averages, shown as community averages in the scenario preview")
+	return cmd
+}
+
+// printReport writes a human-readable step summary.
+func printReport(cmd *cobra.Command, report harness.Report) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "\n=== %s ===\n", report.ScenarioID)
+	for _, s := range report.Steps {
+		mark := "✅"
+		if !s.Passed {
+			mark = "❌"
+		}
+		fmt.Fprintf(out, "  %s %-9s %6.1fs  %s\n", mark, s.Name, s.Duration.Seconds(), s.Details)
+	}
+	result := "PASS"
+	if !report.Passed {
+		result = "FAIL"
+	}
+	fmt.Fprintf(out, "  %s in %.1fs\n", result, report.Duration.Seconds())
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/exam"
+)
+
+// examPlan is an exam drawn from a blueprint, as printed by `exam plan`.
+type examPlan struct {
+	Name         string         `json:"name"`
+	Duration     string         `json:"duration"`
+	PassingScore int            `json:"passingScore"`
+	Seed         int64          `json:"seed"`
+	Scenarios    []scenarioInfo `json:"scenarios"`
+}
+
+func newExamCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exam",
+		Short: "Work with mock exam blueprints",
+	}
+	cmd.AddCommand(newExamPlanCmd())
+	return cmd
+}
+
+func newExamPlanCmd() *cobra.Command {
+	var seed int64
+
+	cmd := &cobra.Command{
+		Use:   "plan <blueprint>",
+		Short: "Validate an exam blueprint and show the scenarios it draws",
+		Long: `Load an exam blueprint, given as a YAML file or as the name of one in
+~/.k8s-dojo/exams, and draw an exam from it. Each topic gets its share of the
+scenarios by weight, drawn at random from those with one of its tags.`,
+		Example: `  k8s-dojo exam plan cka
+  k8s-dojo exam plan ./team-mock.yaml --seed 42`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bp, err := exam.LoadBlueprint(blueprintPath(args[0]))
+			if err != nil {
+				return err
+			}
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+
+			drawn, err := bp.Compose(offlineRegistry().Metadata(), rand.New(rand.NewSource(seed)))
+			if err != nil {
+				return fmt.Errorf("failed to draw exam %s: %w", bp.Name, err)
+			}
+
+			plan := examPlan{Name: bp.Name, Duration: bp.Duration.Duration.String(), PassingScore: bp.PassingScore, Seed: seed}
+			for _, meta := range drawn {
+				plan.Scenarios = append(plan.Scenarios, scenarioInfo{
+					ID:         meta.ID,
+					Name:       meta.Name,
+					Category:   meta.Category,
+					Difficulty: string(meta.Difficulty),
+					Version:    meta.ContentVersion(),
+					Tags:       meta.Tags,
+				})
+			}
+
+			return printOutput(cmd, plan, func() error {
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "📝 %s: %d scenarios in %s, pass with %d%% (seed %d)\n\n", plan.Name, len(plan.Scenarios), plan.Duration, plan.PassingScore, plan.Seed)
+				w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "ID\tCATEGORY\tDIFFICULTY\tNAME")
+				for _, i := range plan.Scenarios {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", i.ID, i.Category, i.Difficulty, i.Name)
+				}
+				return w.Flush()
+			})
+		},
+	}
+
+	cmd.Flags().Int64Var(&seed, "seed", 0, "Seed for drawing the scenarios, for a reproducible exam (default: random)")
+	return cmd
+}
+
+// blueprintPath resolves a blueprint argument: an existing file, or a name in the blueprint directory.
+func blueprintPath(arg string) string {
+	if _, err := os.Stat(arg); err == nil {
+		return arg
+	}
+	return filepath.Join(exam.DefaultBlueprintDir(), arg+".yaml")
+}
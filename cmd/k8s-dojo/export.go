@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/export"
+	"k8s-dojo/pkg/harness"
+)
+
+func newExportCmd() *cobra.Command {
+	var (
+		version string
+		dir     string
+		opts    = export.Options{Harness: harness.DefaultOptions()}
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export <scenario-id>",
+		Short: "Write a scenario's broken (and fixed) manifests to disk",
+		Long: `Write the manifests of a scenario to <dir>/<scenario-id>/broken.yaml, and with
+--with-solution also fixed.yaml, for use outside the dojo.
+
+Go scenarios are run in the dojo cluster and their namespace is captured.
+Cluster-scoped objects such as PersistentVolumes and CRDs are not included.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeScenarioIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			client, err := connectCluster(version)
+			if err != nil {
+				return err
+			}
+			_, eng := newEngine(client)
+
+			files, err := export.Export(ctx, eng, client, args[0], dir, opts)
+			if err != nil {
+				return err
+			}
+			return printOutput(cmd, files, func() error {
+				for _, f := range files {
+					fmt.Fprintf(cmd.OutOrStdout(), "wrote %s\n", f)
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.WithSolution, "with-solution", false, "Also apply the reference solution and write the fixed manifests")
+	cmd.Flags().StringVar(&dir, "dir", ".", "Output directory")
+	cmd.Flags().StringVar(&version, "k8s-version", "", "Kubernetes version for a new cluster (default: latest supported)")
+	cmd.Flags().DurationVar(&opts.Harness.SolveTimeout, "timeout", opts.Harness.SolveTimeout, "How long to wait for the solution to validate")
+	return cmd
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/cluster"
+)
+
+// newFleetCmd groups commands for a classroom host running one cluster per student.
+func newFleetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fleet",
+		Short: "Run one dojo cluster per student on a classroom host",
+	}
+	cmd.AddCommand(newFleetCreateCmd(), newFleetStandbyCmd(), newFleetListCmd(), newFleetAssignCmd(), newFleetRecreateCmd(), newFleetReleaseCmd(), newFleetDestroyCmd(), newFleetWatchCmd(), newFleetHintCmd(), newFleetNotifyCmd())
+	return cmd
+}
+
+// newFleet returns the host's fleet with the usual cluster options.
+func newFleet() (*cluster.Fleet, error) {
+	if _, err := useRemote(); err != nil {
+		return nil, err
+	}
+	opts := cluster.DefaultManagerOptions()
+	opts.SkipPreflight = skipPreflight
+	opts.Warn = func(w string) { fmt.Fprintf(os.Stderr, "warning: %s\n", w) }
+	return cluster.NewFleet(opts, ""), nil
+}
+
+func newFleetCreateCmd() *cobra.Command {
+	var version string
+	cmd := &cobra.Command{
+		Use:   "create <count>",
+		Short: "Create clusters until the fleet has count of them",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return fmt.Errorf("count must be a positive number, got %q", args[0])
+			}
+			v, err := cluster.ParseVersion(version)
+			if err != nil {
+				return err
+			}
+			fleet, err := newFleet()
+			if err != nil {
+				return err
+			}
+			names, err := fleet.Create(n, v)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Fleet ready with %d cluster(s): %s to %s\n", len(names), names[0], names[len(names)-1])
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&version, "k8s-version", "", "Kubernetes version for new clusters (default: latest supported)")
+	return cmd
+}
+
+// fleetEntry is one cluster in `fleet list` output.
+type fleetEntry struct {
+	Cluster string `json:"cluster"`
+	Status  string `json:"status"`
+	Session string `json:"session,omitempty"`
+}
+
+func newFleetListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the fleet's clusters and the sessions using them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fleet, err := newFleet()
+			if err != nil {
+				return err
+			}
+			names, err := fleet.Clusters()
+			if err != nil {
+				return err
+			}
+			sessions, err := fleet.Assignments()
+			if err != nil {
+				return err
+			}
+			owner := make(map[string]string, len(sessions))
+			for s, c := range sessions {
+				owner[c] = s
+			}
+
+			entries := []fleetEntry{}
+			for _, name := range names {
+				status, err := fleet.Manager(name).Status()
+				if err != nil {
+					return err
+				}
+				entries = append(entries, fleetEntry{Cluster: name, Status: string(status), Session: owner[name]})
+			}
+			return printOutput(cmd, entries, func() error {
+				if len(entries) == 0 {
+					_, err := fmt.Fprintln(cmd.OutOrStdout(), "No fleet clusters. Create them with: k8s-dojo cluster fleet create <count>")
+					return err
+				}
+				for _, e := range entries {
+					session := e.Session
+					if session == "" {
+						session = "-"
+					}
+					fmt.Fprintf(cmd.OutOrStdout(), "%-14s %-8s %s\n", e.Cluster, e.Status, session)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func newFleetAssignCmd() *cobra.Command {
+	var standby int
+	var version string
+	cmd := &cobra.Command{
+		Use:   "assign <session>",
+		Short: "Give a session its own cluster and write the cluster's kubeconfig",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fleet, err := newFleet()
+			if err != nil {
+				return err
+			}
+			name, err := fleet.Assign(args[0])
+			if err != nil {
+				return err
+			}
+			if err := printFleetSession(cmd, fleet, args[0], name); err != nil {
+				return err
+			}
+			if standby > 0 {
+				return topUpStandby(cmd, fleet, standby, version)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&standby, "standby", 0, "Afterwards, create clusters until this many are free for the next sessions")
+	cmd.Flags().StringVar(&version, "k8s-version", "", "Kubernetes version for standby clusters (default: latest supported)")
+	return cmd
+}
+
+func newFleetRecreateCmd() *cobra.Command {
+	var standby int
+	var version string
+	cmd := &cobra.Command{
+		Use:   "recreate <session>",
+		Short: "Move a session to a fresh standby cluster and delete its old one",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fleet, err := newFleet()
+			if err != nil {
+				return err
+			}
+			name, err := fleet.Recreate(args[0])
+			if name == "" {
+				return err
+			}
+			if perr := printFleetSession(cmd, fleet, args[0], name); perr != nil {
+				return perr
+			}
+			if err != nil {
+				return err
+			}
+			if standby > 0 {
+				return topUpStandby(cmd, fleet, standby, version)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&standby, "standby", 1, "Afterwards, create clusters until this many are free again")
+	cmd.Flags().StringVar(&version, "k8s-version", "", "Kubernetes version for standby clusters (default: latest supported)")
+	return cmd
+}
+
+func newFleetStandbyCmd() *cobra.Command {
+	var version string
+	var watch time.Duration
+	cmd := &cobra.Command{
+		Use:   "standby <count>",
+		Short: "Keep count clusters free and warm for the next sessions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 0 {
+				return fmt.Errorf("count must be a number, got %q", args[0])
+			}
+			fleet, err := newFleet()
+			if err != nil {
+				return err
+			}
+			for {
+				if err := topUpStandby(cmd, fleet, n, version); err != nil {
+					return err
+				}
+				if watch <= 0 {
+					return nil
+				}
+				time.Sleep(watch)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&version, "k8s-version", "", "Kubernetes version for standby clusters (default: latest supported)")
+	cmd.Flags().DurationVar(&watch, "watch", 0, "Keep running and top up at this interval as sessions take clusters, e.g. 30s")
+	return cmd
+}
+
+// topUpStandby creates clusters until n of the fleet's clusters are free.
+func topUpStandby(cmd *cobra.Command, fleet *cluster.Fleet, n int, version string) error {
+	v, err := cluster.ParseVersion(version)
+	if err != nil {
+		return err
+	}
+	created, err := fleet.Standby(n, v)
+	for _, name := range created {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Standby cluster %s is ready\n", name)
+	}
+	return err
+}
+
+// printFleetSession writes the kubeconfig of a session's cluster and reports where.
+func printFleetSession(cmd *cobra.Command, fleet *cluster.Fleet, session, name string) error {
+	kubeconfig, err := fleet.Kubeconfig(name)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(filepath.Dir(cluster.DefaultFleetPath()), "fleet", name+".kubeconfig")
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create kubeconfig directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(kubeconfig), 0600); err != nil {
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	return printOutput(cmd, map[string]string{"session": session, "cluster": name, "kubeconfig": path}, func() error {
+		_, err := fmt.Fprintf(cmd.OutOrStdout(), "Session %s uses cluster %s\nKubeconfig: %s\n", session, name, path)
+		return err
+	})
+}
+
+func newFleetReleaseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "release <session>",
+		Short: "Free a session's cluster for the next student",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fleet, err := newFleet()
+			if err != nil {
+				return err
+			}
+			if err := fleet.Release(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Released the cluster of session %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newFleetDestroyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "destroy",
+		Short: "Delete every fleet cluster and forget all sessions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fleet, err := newFleet()
+			if err != nil {
+				return err
+			}
+			if err := fleet.Destroy(); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Fleet destroyed")
+			return nil
+		},
+	}
+}
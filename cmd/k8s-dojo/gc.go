@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/cluster"
+	"k8s-dojo/pkg/gc"
+)
+
+// gcKindCluster marks a dangling Kind cluster among the swept items.
+const gcKindCluster = "cluster"
+
+func newGCCmd() *cobra.Command {
+	var (
+		opts         = gc.DefaultOptions()
+		skipClusters bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove stale kubeconfigs, empty recordings, old crash reports and dangling clusters",
+		Long: `Remove what k8s-dojo leaves behind after crashes and interrupted runs:
+
+  - temporary kubeconfigs of terminals and shells
+  - recordings without any terminal output
+  - crash reports past --crash-age
+  - classroom session and co-op files nobody updates
+  - staging directories of interrupted pack installs
+  - dojo Kind clusters whose creation never finished
+
+Files younger than --older-than are kept, as a running dojo may still use
+them. Paused clusters, clusters created in the last few minutes and Kind
+clusters that aren't the dojo's are never touched.`,
+		Example: `  k8s-dojo gc --dry-run
+  k8s-dojo gc --older-than 1h --skip-clusters`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			items, err := gc.Sweep(opts, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if !skipClusters {
+				if _, err := useRemote(); err != nil {
+					return err
+				}
+				// Files were swept already, so report them even without Docker
+				dangling, err := cluster.DanglingClusters(cluster.DefaultManagerOptions())
+				if errors.Is(err, cluster.ErrDockerDown) {
+					fmt.Fprintln(cmd.ErrOrStderr(), "Docker is not running, skipping clusters")
+				} else if err != nil {
+					return err
+				}
+				for _, name := range dangling {
+					if !opts.DryRun {
+						managerOpts := cluster.DefaultManagerOptions()
+						managerOpts.Name = name
+						if err := cluster.NewManagerWithOptions(managerOpts).DeleteCluster(); err != nil {
+							return fmt.Errorf("failed to delete cluster %s: %w", name, err)
+						}
+					}
+					items = append(items, gc.Item{Kind: gcKindCluster, Path: name, Reason: "control plane never came up"})
+				}
+			}
+
+			return printOutput(cmd, items, func() error {
+				printSweep(cmd, items, opts.DryRun)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Only list what would be removed")
+	cmd.Flags().DurationVar(&opts.TempAge, "older-than", opts.TempAge, "Keep temporary files younger than this")
+	cmd.Flags().DurationVar(&opts.CrashAge, "crash-age", opts.CrashAge, "Keep crash reports younger than this")
+	cmd.Flags().BoolVar(&skipClusters, "skip-clusters", false, "Don't look for dangling Kind clusters")
+	return cmd
+}
+
+// printSweep lists the swept items and how much space they took.
+func printSweep(cmd *cobra.Command, items []gc.Item, dryRun bool) {
+	out := cmd.OutOrStdout()
+	if len(items) == 0 {
+		fmt.Fprintln(out, "Nothing to clean up")
+		return
+	}
+
+	var total int64
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tPATH\tSIZE\tREASON")
+	for _, item := range items {
+		size := "-"
+		if item.Kind != gcKindCluster {
+			size = formatSize(item.Bytes)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.Kind, item.Path, size, item.Reason)
+		total += item.Bytes
+	}
+	w.Flush()
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	fmt.Fprintf(out, "%s %d item(s), %s\n", verb, len(items), formatSize(total))
+}
+
+// formatSize renders a byte count with a binary unit, e.g. "1.5 MiB".
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// newGenCmd generates packaging artifacts: shell completions and man pages.
+// It is hidden because it is only used by release tooling.
+func newGenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "gen",
+		Short:  "Generate shell completions and man pages",
+		Hidden: true,
+	}
+	cmd.AddCommand(newGenCompletionsCmd(), newGenManCmd())
+	return cmd
+}
+
+func newGenCompletionsCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "completions",
+		Short: "Write bash, zsh and fish completion scripts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			root := cmd.Root()
+			scripts := []struct {
+				file string
+				gen  func(string) error
+			}{
+				{"k8s-dojo.bash", func(p string) error { return root.GenBashCompletionFileV2(p, true) }},
+				{"_k8s-dojo", root.GenZshCompletionFile},
+				{"k8s-dojo.fish", func(p string) error { return root.GenFishCompletionFile(p, true) }},
+			}
+			for _, s := range scripts {
+				path := filepath.Join(dir, s.file)
+				if err := s.gen(path); err != nil {
+					return fmt.Errorf("failed to write %s: %w", s.file, err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "wrote %s\n", path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "completions", "Output directory")
+	return cmd
+}
+
+func newGenManCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: "Write man pages for every command",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			root := cmd.Root()
+			root.DisableAutoGenTag = true
+			header := &doc.GenManHeader{Title: "K8S-DOJO", Section: "1", Source: "k8s-dojo"}
+			if err := doc.GenManTree(root, header, dir); err != nil {
+				return fmt.Errorf("failed to write man pages: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "wrote man pages to %s\n", dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "man", "Output directory")
+	return cmd
+}
+
+// completeScenarioIDs completes scenario IDs from the registry, with their names as descriptions.
+func completeScenarioIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var ids []string
+	for _, meta := range offlineRegistry().Metadata() {
+		ids = append(ids, meta.ID+"\t"+meta.Name)
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCategories completes scenario categories from the registry.
+func completeCategories(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	seen := map[string]bool{}
+	var categories []string
+	for _, meta := range offlineRegistry().Metadata() {
+		if c := meta.Category; !seen[c] {
+			seen[c] = true
+			categories = append(categories, c)
+		}
+	}
+	sort.Strings(categories)
+	return categories, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTags completes scenario tags from the registry.
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	seen := map[string]bool{}
+	var tags []string
+	for _, meta := range offlineRegistry().Metadata() {
+		for _, t := range meta.Tags {
+			if !seen[t] {
+				seen[t] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/state"
+)
+
+// newGoalCmd shows the training streak and sets the weekly scenario goal.
+func newGoalCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "goal [scenarios-per-week]",
+		Short: "Show your training streak, or set a weekly scenario goal",
+		Long: `Without an argument, show your streak (consecutive days with at least one
+solve) and progress towards your weekly goal. With an argument, set the number
+of scenarios to solve each week, shown in the TUI header; 0 clears the goal.`,
+		Example: `  k8s-dojo goal
+  k8s-dojo goal 5`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := state.NewManager("")
+			if err != nil {
+				return err
+			}
+			if len(args) == 1 {
+				goal, err := strconv.Atoi(args[0])
+				if err != nil {
+					return fmt.Errorf("invalid weekly goal %q: %w", args[0], err)
+				}
+				if err := m.SetWeeklyGoal(goal); err != nil {
+					return err
+				}
+			}
+
+			st, err := m.Load()
+			if err != nil {
+				return err
+			}
+			training := st.Training(time.Now())
+			return printOutput(cmd, training, func() error {
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "🔥 Streak: %d day(s)\n", training.Streak)
+				if training.WeeklyGoal == 0 {
+					fmt.Fprintf(out, "🎯 This week: %d solved (no goal set; try: k8s-dojo goal 5)\n", training.WeekSolves)
+					return nil
+				}
+				fmt.Fprintf(out, "🎯 This week: %d of %d solved\n", training.WeekSolves, training.WeeklyGoal)
+				return nil
+			})
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/grade"
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/packs"
+	"k8s-dojo/pkg/scenario"
+)
+
+func newGradeCmd() *cobra.Command {
+	var (
+		id         string
+		kubeconfig string
+		namespace  string
+		answer     string
+		timeout    time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "grade",
+		Short: "Validate a scenario against an existing cluster and print a JSON report",
+		Long: `Run only the scenario's validation against a cluster the student prepared,
+without setting anything up or cleaning up. The report is printed as JSON
+(or YAML with --output yaml) and the exit code is 0 when the scenario is solved
+and 1 otherwise, so scenarios can be used as graded assignments, e.g. with
+GitHub Classroom autograders.`,
+		Example: `  k8s-dojo grade --scenario image-pull-backoff --kubeconfig ./kubeconfig
+  k8s-dojo grade --scenario net-service-selector --namespace net-101`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if kubeconfig == "" {
+				kubeconfig = defaultKubeconfig()
+			}
+			data, err := os.ReadFile(kubeconfig)
+			if err != nil {
+				return fmt.Errorf("failed to read kubeconfig: %w", err)
+			}
+			client, err := k8s.NewClientFromKubeconfigWithOptions(string(data), clientOptions)
+			if err != nil {
+				return err
+			}
+
+			reg := scenario.NewRegistry(client)
+			_ = reg.LoadYAML(scenario.DefaultScenarioDir())
+			if m, err := packs.NewManager(""); err == nil {
+				_ = m.LoadInto(reg)
+			}
+			s := reg.Get(id)
+			if s == nil {
+				return fmt.Errorf("scenario not found: %s", id)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			report := grade.GradeWithAnswer(ctx, s, namespace, answer)
+
+			if !machineOutput() {
+				outputFormat = outputJSON
+			}
+			if err := printOutput(cmd, report, nil); err != nil {
+				return err
+			}
+			if !report.Passed {
+				return fmt.Errorf("scenario %s not solved: %s", id, report.Message)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "scenario", "", "Scenario ID to grade")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the student's kubeconfig (default: $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace holding the student's resources (default: the scenario's namespace)")
+	cmd.Flags().StringVar(&answer, "answer", "", "The student's answer, for quiz scenarios that ask a question")
+	cmd.Flags().DurationVar(&timeout, "timeout", time.Minute, "How long validation may take")
+	_ = cmd.MarkFlagRequired("scenario")
+	_ = cmd.RegisterFlagCompletionFunc("scenario", completeScenarioIDs)
+	return cmd
+}
+
+// defaultKubeconfig returns the kubeconfig path kubectl would use.
+func defaultKubeconfig() string {
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return filepath.SplitList(env)[0]
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".kube", "config")
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/grade"
+	"k8s-dojo/pkg/scenario"
+)
+
+func newLintSolutionCmd() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "lint-solution <scenario-id>",
+		Short: "Check a fix written as a local manifest, without a cluster",
+		Long: `Validate a manifest against a scenario's success criteria offline. Only
+scenarios whose checks read object specs (e.g. sec-privileged-policy or
+life-graceful-shutdown) support this; the rest need a running cluster.
+The exit code is 0 when the manifest solves the scenario and 1 otherwise.`,
+		Example: `  k8s-dojo lint-solution sec-privileged-policy -f fix.yaml
+  k8s-dojo lint-solution life-graceful-shutdown -f - < fix.yaml`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeScenarioIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := offlineRegistry().Get(args[0])
+			if s == nil {
+				return fmt.Errorf("scenario not found: %s", args[0])
+			}
+
+			var data []byte
+			var err error
+			if file == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(file)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read manifest: %w", err)
+			}
+
+			res, err := scenario.ValidateManifest(s, string(data))
+			if err != nil {
+				return err
+			}
+			report := grade.NewReport(s, res)
+			if err := printOutput(cmd, report, func() error {
+				out := cmd.OutOrStdout()
+				for _, c := range res.Checks {
+					mark := "✗"
+					if c.Passed {
+						mark = "✓"
+					}
+					fmt.Fprintf(out, "%s %s\n", mark, c.Name)
+				}
+				fmt.Fprintln(out, res.Message)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if !res.Solved {
+				return fmt.Errorf("manifest does not solve %s", args[0])
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Manifest with the fixed objects, or - for stdin")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/state"
+)
+
+// scenarioInfo is the list entry printed for each scenario.
+type scenarioInfo struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Category   string `json:"category"`
+	Difficulty string `json:"difficulty"`
+	Version    int    `json:"version"`
+	Completed  bool   `json:"completed"`
+	Outdated   bool   `json:"outdated,omitempty"` // Completed against an older version
+
+	Tags []string `json:"tags,omitempty"`
+}
+
+func newListCmd() *cobra.Command {
+	var category, tag, search string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List available scenarios and your progress",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reg := offlineRegistry()
+
+			progress := &state.State{}
+			if m, err := state.NewManager(""); err == nil {
+				if st, err := m.Load(); err == nil {
+					progress = st
+				}
+			}
+
+			scenarios := reg.List()
+			if search != "" {
+				scenarios = scenario.NewIndex(scenarios).Search(search)
+			}
+
+			infos := []scenarioInfo{}
+			for _, s := range scenarios {
+				meta := s.GetMetadata()
+				if category != "" && meta.Category != category {
+					continue
+				}
+				if tag != "" && !meta.HasTag(tag) {
+					continue
+				}
+				infos = append(infos, scenarioInfo{
+					ID:         meta.ID,
+					Name:       meta.Name,
+					Category:   meta.Category,
+					Difficulty: string(meta.Difficulty),
+					Version:    meta.ContentVersion(),
+					Completed:  progress.CompletedScenarios[meta.ID],
+					Outdated:   progress.IsOutdated(meta.ID, meta.ContentVersion()),
+					Tags:       meta.Tags,
+				})
+			}
+
+			return printOutput(cmd, infos, func() error {
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "ID\tCATEGORY\tDIFFICULTY\tSTATUS\tNAME")
+				for _, i := range infos {
+					status := "-"
+					if i.Outdated {
+						status = "outdated"
+					} else if i.Completed {
+						status = "done"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", i.ID, i.Category, i.Difficulty, status, i.Name)
+				}
+				return w.Flush()
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&category, "category", "", "Only list scenarios in this category")
+	_ = cmd.RegisterFlagCompletionFunc("category", completeCategories)
+	cmd.Flags().StringVar(&tag, "tag", "", "Only list scenarios with this tag, e.g. dns or cka")
+	_ = cmd.RegisterFlagCompletionFunc("tag", completeTags)
+	cmd.Flags().StringVar(&search, "search", "", "Only list scenarios matching these words, best matches first")
+	return cmd
+}
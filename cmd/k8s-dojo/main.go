@@ -3,31 +3,162 @@ package main
 
 import (
 	"fmt"
-	"io"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 
+	"k8s-dojo/pkg/diag"
+	"k8s-dojo/pkg/engine"
 	"k8s-dojo/pkg/tui"
 )
 
 func init() {
-	// Silence klog to avoid polluting the TUI
-	klog.SetOutput(io.Discard)
+	// Keep klog out of the TUI; recent lines go into crash bundles
+	klog.SetOutput(diag.Logs)
 	klog.LogToStderr(false)
 }
 
 func main() {
+	err := newRootCmd().Execute()
+	closeRemote()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// newRootCmd builds the command tree. Running without a subcommand starts the TUI.
+func newRootCmd() *cobra.Command {
+	var dev, guided, hard, rec, restricted, guardrails bool
+	var confirmChecks int
+	var idlePause, idleCleanup time.Duration
+	var idlePauseCluster bool
+	var session, join string
+	var share bool
+	root := &cobra.Command{
+		Use:               "k8s-dojo",
+		Short:             "Zero-setup Kubernetes troubleshooting training",
+		SilenceUsage:      true,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: validateOutput,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTUI(func(m *tui.AppModel) error {
+				m.SetConfirmChecks(confirmChecks)
+				m.SetIdlePause(idlePause)
+				m.SetIdlePolicy(tui.IdlePolicy{After: idleCleanup, PauseCluster: idlePauseCluster})
+				if dev {
+					m.EnableDevMode()
+				}
+				if guided {
+					m.EnableGuidedMode()
+				}
+				if hard {
+					m.EnableHardMode()
+				}
+				// Guided mode is for beginners, so it restricts kubectl unless told otherwise
+				if restricted || (guided && !cmd.Flags().Changed("restricted")) {
+					m.EnableRestrictedMode()
+				}
+				if guardrails {
+					m.EnableGuardrails()
+				}
+				if session != "" {
+					if err := joinSession(m, session, join == ""); err != nil {
+						return err
+					}
+				}
+				if share || join != "" {
+					if err := setupCoop(m, share, join, session); err != nil {
+						return err
+					}
+				}
+				if rec {
+					return m.EnableRecording("")
+				}
+				return nil
+			})
+		},
+	}
+	root.Flags().BoolVar(&dev, "dev", false, "Reload YAML scenarios from ~/.k8s-dojo/scenarios when they change")
+	root.PersistentFlags().StringVar(&remoteTarget, "remote", "", "Run Kind on a remote Docker host over SSH, e.g. user@vm (the terminal opens a shell there)")
+	root.PersistentFlags().BoolVar(&skipPreflight, "skip-preflight", false, "Create the cluster even if the host has too little CPU, memory or disk")
+	root.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "Output format for commands: text, json or yaml")
+	root.PersistentFlags().Float32Var(&clientOptions.QPS, "kube-qps", clientOptions.QPS, "Requests per second the Kubernetes client may send; lower it when many dojos share an API server")
+	root.PersistentFlags().IntVar(&clientOptions.Burst, "kube-burst", clientOptions.Burst, "Requests the Kubernetes client may send at once above --kube-qps")
+	root.PersistentFlags().IntVar(&clientOptions.Retry.Steps, "kube-attempts", clientOptions.Retry.Steps, "Attempts per read request that is throttled or fails transiently (1 disables retries)")
+	root.Flags().BoolVar(&guided, "guided", false, "Beginner mode: walk through scenarios that offer a guide step by step")
+	root.Flags().BoolVar(&hard, "hard", false, "Hard mode: inject extra faults (pod deletions, label flips) when replaying completed scenarios")
+	root.Flags().BoolVar(&restricted, "restricted", false, "Limit kubectl in the terminal to the scenario namespace, with read-only access elsewhere (default with --guided)")
+	root.Flags().BoolVar(&guardrails, "guardrails", false, "Warn when nodes or objects in system namespaces such as kube-system are deleted")
+	root.Flags().BoolVar(&rec, "record", false, "Record the terminal session to ~/.k8s-dojo/recordings")
+	root.Flags().IntVar(&confirmChecks, "confirm-checks", engine.DefaultConfirmChecks, "Consecutive passing checks needed before a fix counts, so flapping pods don't pass early")
+	root.Flags().DurationVar(&idlePause, "idle-pause", tui.DefaultIdlePause, "Pause the scenario timer and checks after this long without input (0 disables)")
+	root.Flags().DurationVar(&idleCleanup, "idle-cleanup", tui.DefaultIdleCleanup, "Clean up scenarios left running this long without input (0 disables)")
+	root.Flags().BoolVar(&idlePauseCluster, "idle-pause-cluster", false, "After --idle-cleanup, also pause the cluster and quit")
+	root.Flags().BoolVar(&share, "share", false, "Co-op: let a partner on this host join your scenarios with the token shown in the header")
+	root.Flags().StringVar(&join, "join", "", "Co-op: follow the scenarios of the partner who shared this token, in their cluster")
+	root.MarkFlagsMutuallyExclusive("share", "join")
+	root.Flags().StringVar(&session, "session", "", "On a classroom host: train on this session's fleet cluster and show progress in the instructor's live view")
+
+	_ = root.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{outputText, outputJSON, outputYAML}, cobra.ShellCompDirectiveNoFileComp))
+
+	root.AddCommand(newDevCmd(), newPacksCmd(), newExportCmd(), newTelemetryCmd(), newVerifyAllCmd(), newListCmd(), newGradeCmd(), newDemoCmd(), newRecordCmd(), newPreflightCmd(), newClusterCmd(), newSandboxCmd(), newGenCmd(), newGoalCmd(), newLintSolutionCmd(), newExamCmd(), newGCCmd())
+	return root
+}
+
+// runTUI runs the interactive terminal UI. configure enables optional modes on the model.
+func runTUI(configure func(m *tui.AppModel) error) error {
 	// Run the TUI with the new enhanced architecture
 	model := tui.NewAppModel()
-	p := tea.NewProgram(&model, tea.WithAltScreen())
+	if err := configure(&model); err != nil {
+		return err
+	}
+	h, err := useRemote()
+	if err != nil {
+		return err
+	}
+	if h != nil {
+		model.EnableRemote(h)
+	}
+	if skipPreflight {
+		model.SkipPreflight()
+	}
+	model.SetClientOptions(clientOptions)
+	defer func() {
+		path, err := model.StopRecording()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Session recording incomplete: %v\n", err)
+		}
+		if path != "" {
+			fmt.Fprintf(os.Stderr, "Session recorded to %s\nExport it with: k8s-dojo record export --last\n", path)
+		}
+	}()
+	guard := tui.NewCrashGuard(&model)
+	p := tea.NewProgram(guard, tea.WithAltScreen())
 
 	// Set the program reference on the terminal for async output refresh
 	model.SetTerminalProgram(p)
+	guard.SetProgram(p)
 
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running k8s-dojo: %v\n", err)
-		os.Exit(1)
+	_, err = p.Run()
+	if guard.Crashed() {
+		path, werr := guard.WriteBundle()
+		if werr != nil {
+			return fmt.Errorf("k8s-dojo crashed and the diagnostic bundle could not be saved: %w", werr)
+		}
+		fmt.Fprintf(os.Stderr, "k8s-dojo crashed. A diagnostic bundle was written to:\n  %s\nPlease attach it when reporting the issue.\n", path)
+		return fmt.Errorf("k8s-dojo crashed")
+	}
+	if err != nil {
+		return fmt.Errorf("error running k8s-dojo: %w", err)
+	}
+	if name, steps := model.KeptEnvironment(); name != "" {
+		fmt.Fprintf(os.Stderr, "Left %q running for inspection. Clean it up later with:\n", name)
+		for _, step := range steps {
+			fmt.Fprintf(os.Stderr, "  %s\n", step.Command)
+		}
 	}
+	return nil
 }
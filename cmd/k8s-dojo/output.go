@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats accepted by the global --output flag.
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+// outputFormat is the value of the global --output flag.
+var outputFormat = outputText
+
+// validateOutput rejects unknown --output values before a command runs.
+func validateOutput(cmd *cobra.Command, args []string) error {
+	switch outputFormat {
+	case outputText, outputJSON, outputYAML:
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q (want text, json or yaml)", outputFormat)
+}
+
+// machineOutput reports whether --output asks for JSON or YAML.
+func machineOutput() bool {
+	return outputFormat == outputJSON || outputFormat == outputYAML
+}
+
+// printOutput writes v in the --output format. In text mode it calls text instead.
+func printOutput(cmd *cobra.Command, v interface{}, text func() error) error {
+	var (
+		data []byte
+		err  error
+	)
+	switch outputFormat {
+	case outputJSON:
+		data, err = json.MarshalIndent(v, "", "  ")
+		data = append(data, '\n')
+	case outputYAML:
+		data, err = yaml.Marshal(v)
+	default:
+		return text()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	_, err = cmd.OutOrStdout().Write(data)
+	return err
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/packs"
+)
+
+// newPacksCmd groups commands for managing downloadable scenario packs.
+func newPacksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "packs",
+		Short: "Install and manage scenario packs",
+	}
+	cmd.AddCommand(newPacksAddCmd(), newPacksListCmd(), newPacksRemoveCmd(), newPacksUpdateCmd())
+	return cmd
+}
+
+func newPacksAddCmd() *cobra.Command {
+	var opts packs.AddOptions
+
+	cmd := &cobra.Command{
+		Use:   "add <url>",
+		Short: "Download, verify and install a pack of YAML scenarios",
+		Long: `Download a .tar.gz of YAML scenarios and install it under ~/.k8s-dojo/packs.
+
+The URL must be https. The tarball must match --sha256, or the checksum
+published at <url>.sha256. With --public-key, <url>.sig must also hold a
+valid ed25519 signature. A published checksum comes from the same server as
+the tarball and proves nothing against it, so without --sha256 or
+--public-key the pack is installed as unverified, with a warning.
+
+Script checks of a pack must run in a validator pod, never with runIn: local.
+A pack with a setup script, or quick commands other than plain kubectl
+invocations, which run on this machine with your kubeconfig, is refused
+unless --trust-scripts is given; the choice is recorded and kept by updates.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			m, err := packs.NewManager("")
+			if err != nil {
+				return err
+			}
+			p, err := m.Add(ctx, args[0], opts)
+			if err != nil {
+				return err
+			}
+			warnUnverified(cmd, p)
+			return printOutput(cmd, p, func() error {
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "Installed %s (%d scenarios) under category %q\n", p.Name, len(p.Scenarios), p.Category())
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Pack name (default: derived from the URL)")
+	cmd.Flags().StringVar(&opts.SHA256, "sha256", "", "Expected SHA-256 of the tarball (default: fetched from <url>.sha256)")
+	cmd.Flags().StringVar(&opts.PublicKey, "public-key", "", "Base64 ed25519 public key to verify <url>.sig")
+	cmd.Flags().BoolVar(&opts.TrustScripts, "trust-scripts", false, "Let the pack's setup scripts and shell commands run on this machine")
+	return cmd
+}
+
+func newPacksListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List installed packs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := packs.NewManager("")
+			if err != nil {
+				return err
+			}
+			list, err := m.List()
+			if err != nil {
+				return err
+			}
+			if list == nil {
+				list = []*packs.Pack{}
+			}
+
+			return printOutput(cmd, list, func() error {
+				if len(list) == 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "No packs installed.")
+					return nil
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "NAME\tSCENARIOS\tVERIFIED\tSCRIPTS\tINSTALLED\tURL")
+				for _, p := range list {
+					verified := "no"
+					switch {
+					case p.PublicKey != "":
+						verified = "signed"
+					case p.Verified:
+						verified = "checksum"
+					}
+					scripts := "pod"
+					if p.TrustScripts {
+						scripts = "trusted"
+					}
+					fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n", p.Name, len(p.Scenarios), verified, scripts, p.InstalledAt.Format("2006-01-02"), p.URL)
+				}
+				return w.Flush()
+			})
+		},
+	}
+}
+
+func newPacksRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Uninstall a pack",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := packs.NewManager("")
+			if err != nil {
+				return err
+			}
+			if err := m.Remove(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newPacksUpdateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "update [name...]",
+		Short: "Re-download packs from their source URLs (default: all)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			m, err := packs.NewManager("")
+			if err != nil {
+				return err
+			}
+			names := args
+			if len(names) == 0 {
+				list, err := m.List()
+				if err != nil {
+					return err
+				}
+				for _, p := range list {
+					names = append(names, p.Name)
+				}
+			}
+
+			var failed int
+			updated := []*packs.Pack{}
+			for _, name := range names {
+				p, err := m.Update(ctx, name)
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", name, err)
+					failed++
+					continue
+				}
+				updated = append(updated, p)
+				warnUnverified(cmd, p)
+				if !machineOutput() {
+					fmt.Fprintf(cmd.OutOrStdout(), "Updated %s (%d scenarios)\n", p.Name, len(p.Scenarios))
+				}
+			}
+			if machineOutput() {
+				if err := printOutput(cmd, updated, nil); err != nil {
+					return err
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d packs failed to update", failed, len(names))
+			}
+			return nil
+		},
+	}
+}
+
+// warnUnverified tells the user when a pack was installed without a checksum
+// or signature they supplied.
+func warnUnverified(cmd *cobra.Command, p *packs.Pack) {
+	if p.Verified {
+		return
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "WARNING: pack %s is UNVERIFIED: its checksum came from the same server as the tarball.\n"+
+		"Only use it if you trust %s; add it with --sha256 or --public-key to verify it.\n", p.Name, p.URL)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/cluster"
+)
+
+func newPreflightCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "preflight",
+		Short: "Check that the Docker host has enough CPU, memory and disk for the dojo",
+		Long: fmt.Sprintf(`Check the resources available to Docker before creating the dojo cluster.
+This runs automatically when a cluster is created.
+
+To keep the dojo from starving the host, e.g. on small laptops in workshops,
+constrain the Kind node container in %s:
+
+  {"cpus": 2, "memory": "3g"}
+
+Limits are applied whenever the cluster is started.`, cluster.DefaultLimitsPath()),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := useRemote(); err != nil {
+				return err
+			}
+			limits, err := cluster.LoadLimits("")
+			if err != nil {
+				return err
+			}
+			report, err := cluster.Preflight(limits)
+			if err != nil {
+				return err
+			}
+
+			if err := printOutput(cmd, report, func() error {
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "CPUs:    %d\nMemory:  %.1f GiB\n", report.CPUs, float64(report.MemoryBytes)/(1<<30))
+				if report.DiskFreeBytes > 0 {
+					fmt.Fprintf(out, "Disk:    %.1f GiB free\n", float64(report.DiskFreeBytes)/(1<<30))
+				}
+				if limits.CPUs > 0 || limits.Memory != "" {
+					fmt.Fprintf(out, "Limits:  cpus=%g memory=%s\n", limits.CPUs, limits.Memory)
+				}
+				for _, w := range report.Warnings {
+					fmt.Fprintf(out, "⚠ %s\n", w)
+				}
+				if len(report.Warnings) == 0 && len(report.Problems) == 0 {
+					fmt.Fprintln(out, "✅ Ready for the dojo")
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			return report.Err()
+		},
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/proctor"
+	"k8s-dojo/pkg/tui"
+)
+
+// watchCheckWidth truncates check messages so a row fits on one line.
+const watchCheckWidth = 40
+
+func newFleetWatchCmd() *cobra.Command {
+	var interval time.Duration
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Live view of every student session: scenario, time, checks and commands",
+		Long: `Show what each student started with ` + "`k8s-dojo --session <name>`" + ` is doing:
+their scenario, how long they have been on it, the last check and their most
+recent kubectl command. The view refreshes until interrupted; with
+--interval 0 or --output json it is printed once.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			board := proctor.NewBoard("")
+			for {
+				statuses, err := board.Statuses()
+				if err != nil {
+					return err
+				}
+				if interval <= 0 || outputFormat != outputText {
+					return printOutput(cmd, statuses, func() error {
+						return printWatch(cmd.OutOrStdout(), statuses)
+					})
+				}
+				fmt.Fprint(cmd.OutOrStdout(), "\033[H\033[2J") // Redraw in place
+				if err := printWatch(cmd.OutOrStdout(), statuses); err != nil {
+					return err
+				}
+				time.Sleep(interval)
+			}
+		},
+	}
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "Refresh interval, 0 to print once")
+	return cmd
+}
+
+// printWatch renders the sessions as a table.
+func printWatch(out io.Writer, statuses []proctor.Status) error {
+	if len(statuses) == 0 {
+		_, err := fmt.Fprintln(out, "No student sessions. Students join with: k8s-dojo --session <name>")
+		return err
+	}
+	now := time.Now()
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SESSION\tCLUSTER\tSCENARIO\tELAPSED\tCHECK\tLAST COMMAND")
+	for _, s := range statuses {
+		scenario, elapsed, check, command := "-", "-", "-", "-"
+		if s.Scenario != "" {
+			scenario = s.Scenario
+			elapsed = s.Elapsed.Round(time.Second).String()
+			check = truncate(s.Check, watchCheckWidth)
+			if s.Solved {
+				check = "✅ solved"
+			}
+		}
+		if len(s.Commands) > 0 {
+			command = s.Commands[len(s.Commands)-1]
+		}
+		if s.Stale(now) {
+			check = fmt.Sprintf("disconnected %s ago", now.Sub(s.Updated).Round(time.Second))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", s.Session, s.Cluster, scenario, elapsed, check, command)
+	}
+	return w.Flush()
+}
+
+// truncate shortens s to n runes, ending in an ellipsis when cut.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
+
+func newFleetHintCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "hint <session> <text>...",
+		Short:   "Push a hint to a student's dojo",
+		Example: `  k8s-dojo cluster fleet hint alice "Compare the Service selector with the pod labels"`,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := proctor.Message{Kind: proctor.KindHint, Text: strings.Join(args[1:], " "), From: "instructor"}
+			if err := proctor.NewBoard("").Push(args[0], msg); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Hint sent to %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newFleetNotifyCmd() *cobra.Command {
+	var to, from string
+	cmd := &cobra.Command{
+		Use:   "notify <text>...",
+		Short: "Show a notice in the header of every connected student's dojo",
+		Long: `Show a notice in the header banner of the students' dojos for a few
+minutes, e.g. to announce a break. It goes to every session that is still
+connected, or only to the one given with --to.`,
+		Example: `  k8s-dojo cluster fleet notify "Break in 5 minutes"
+  k8s-dojo cluster fleet notify --to alice "Your cluster is being recreated, hang on"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			board := proctor.NewBoard("")
+			msg := proctor.Message{Kind: proctor.KindNotice, Text: strings.Join(args, " "), From: from}
+			if to != "" {
+				if err := board.Push(to, msg); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Notice sent to %s\n", to)
+				return nil
+			}
+			sent, err := board.Broadcast(msg)
+			if err != nil {
+				return err
+			}
+			if len(sent) == 0 {
+				return fmt.Errorf("no connected sessions to notify")
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Notice sent to %s\n", strings.Join(sent, ", "))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&to, "to", "", "Only notify this session")
+	cmd.Flags().StringVar(&from, "from", "instructor", "Sender shown with the notice, e.g. system")
+	return cmd
+}
+
+// joinSession points the TUI at the session's fleet cluster, assigning one on
+// first use, and publishes its status for the instructor. Without assign the
+// session trains elsewhere, e.g. in a co-op partner's cluster.
+func joinSession(m *tui.AppModel, session string, assign bool) error {
+	if !assign {
+		m.EnableSession(session, "", proctor.NewBoard(""))
+		return nil
+	}
+	fleet, err := newFleet()
+	if err != nil {
+		return err
+	}
+	name, err := fleet.Assign(session)
+	if err != nil {
+		return err
+	}
+	m.EnableSession(session, name, proctor.NewBoard(""))
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/record"
+)
+
+// Export formats for recordings.
+const formatAsciicast = "asciicast"
+
+// newRecordCmd manages terminal session recordings made with --record.
+func newRecordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "List and export terminal session recordings",
+		Long: `Sessions started with --record are saved under ~/.k8s-dojo/recordings.
+Export them to asciicast to play them back with asciinema or convert them to GIFs.`,
+	}
+	cmd.AddCommand(newRecordListCmd(), newRecordExportCmd())
+	return cmd
+}
+
+func newRecordListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List recorded sessions, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths, err := record.List("")
+			if err != nil {
+				return err
+			}
+			if paths == nil {
+				paths = []string{}
+			}
+			return printOutput(cmd, paths, func() error {
+				if len(paths) == 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "No recordings. Start the TUI with --record.")
+					return nil
+				}
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "RECORDING\tSIZE")
+				for _, p := range paths {
+					size := "?"
+					if info, err := os.Stat(p); err == nil {
+						size = fmt.Sprintf("%dK", (info.Size()+1023)/1024)
+					}
+					fmt.Fprintf(w, "%s\t%s\n", p, size)
+				}
+				return w.Flush()
+			})
+		},
+	}
+}
+
+func newRecordExportCmd() *cobra.Command {
+	var (
+		last   bool
+		format string
+		out    string
+		title  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export [recording]",
+		Short: "Convert a recording to an asciicast (.cast) file",
+		Example: `  k8s-dojo record export --last
+  k8s-dojo record export ~/.k8s-dojo/recordings/session-20250101-120000.rec --out talk.cast`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != formatAsciicast {
+				return fmt.Errorf("unsupported format %q (want %s)", format, formatAsciicast)
+			}
+
+			var path string
+			switch {
+			case len(args) == 1:
+				path = args[0]
+			case last:
+				p, err := record.Last("")
+				if err != nil {
+					return err
+				}
+				path = p
+			default:
+				return fmt.Errorf("specify a recording or --last")
+			}
+
+			h, events, err := record.Read(path)
+			if err != nil {
+				return err
+			}
+			if out == "" {
+				out = strings.TrimSuffix(filepath.Base(path), record.Ext) + ".cast"
+			}
+			if title == "" {
+				title = "k8s-dojo " + h.Started.Format("2006-01-02 15:04")
+			}
+
+			f, err := os.Create(out)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", out, err)
+			}
+			if err := record.WriteAsciicast(f, h, events, title); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("failed to write %s: %w", out, err)
+			}
+
+			return printOutput(cmd, []string{out}, func() error {
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "wrote %s\n", out)
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&last, "last", false, "Export the most recent recording")
+	cmd.Flags().StringVar(&format, "format", formatAsciicast, "Export format: asciicast")
+	cmd.Flags().StringVar(&out, "out", "", "Output file (default: <recording>.cast in the current directory)")
+	cmd.Flags().StringVar(&title, "title", "", "Title shown by asciinema players")
+	return cmd
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"k8s-dojo/pkg/remote"
+)
+
+// remoteTarget is the value of the global --remote flag.
+var remoteTarget string
+
+// remoteHost is the remote dojo host in use, nil for a local cluster.
+var remoteHost *remote.Host
+
+// useRemote points Kind at the Docker daemon of the --remote host, if any.
+// It returns nil when running locally.
+func useRemote() (*remote.Host, error) {
+	if remoteTarget == "" || remoteHost != nil {
+		return remoteHost, nil
+	}
+	h, err := remote.New(remoteTarget)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Setenv("DOCKER_HOST", h.DockerHost()); err != nil {
+		return nil, fmt.Errorf("failed to set DOCKER_HOST: %w", err)
+	}
+	remoteHost = h
+	return h, nil
+}
+
+// closeRemote tears down the SSH tunnel of the remote host, if any.
+func closeRemote() {
+	if remoteHost != nil {
+		remoteHost.Close()
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/grade"
+	"k8s-dojo/pkg/sandbox"
+)
+
+// sandboxTimeout bounds how long up and reset wait for the stack to become healthy.
+const sandboxTimeout = 5 * time.Minute
+
+// newSandboxCmd groups the break-and-fix free-play commands.
+func newSandboxCmd() *cobra.Command {
+	var version string
+	cmd := &cobra.Command{
+		Use:   "sandbox",
+		Short: "Free play: deploy a healthy app, break it on purpose and fix it",
+		Long: `Deploy a reference frontend/backend/db stack into the ` + sandbox.Namespace + ` namespace,
+inject breakages from a menu and fix them with kubectl. There are no success
+criteria; "sandbox status" shows which components are healthy.`,
+	}
+	cmd.PersistentFlags().StringVar(&version, "k8s-version", "", "Kubernetes version of the cluster (default: latest)")
+
+	connect := func() (*sandbox.Sandbox, error) {
+		client, err := connectCluster(version)
+		if err != nil {
+			return nil, err
+		}
+		return sandbox.New(client), nil
+	}
+
+	cmd.AddCommand(
+		newSandboxDeployCmd("up", "Deploy the healthy reference stack", connect, (*sandbox.Sandbox).Deploy),
+		newSandboxDeployCmd("reset", "Replace the stack with a fresh healthy one, undoing every breakage", connect, (*sandbox.Sandbox).Reset),
+		newSandboxBreakCmd(connect),
+		newSandboxStatusCmd(connect),
+		newSandboxDownCmd(connect),
+	)
+	return cmd
+}
+
+func newSandboxDeployCmd(use, short string, connect func() (*sandbox.Sandbox, error), deploy func(*sandbox.Sandbox, context.Context) error) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			sb, err := connect()
+			if err != nil {
+				return err
+			}
+			if err := deploy(sb, ctx); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), "Waiting for the stack to become healthy...")
+			waitCtx, cancel := context.WithTimeout(ctx, sandboxTimeout)
+			defer cancel()
+			if err := sb.WaitHealthy(waitCtx); err != nil {
+				return fmt.Errorf("stack did not become healthy: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Sandbox is healthy in namespace %s. Break it with: k8s-dojo sandbox break <breakage>\n", sandbox.Namespace)
+			return nil
+		},
+	}
+}
+
+type breakageInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Component   string `json:"component"`
+	Description string `json:"description"`
+}
+
+func newSandboxBreakCmd(connect func() (*sandbox.Sandbox, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "break [breakage]",
+		Short: "Inject a breakage, or list them when called without one",
+		Args:  cobra.MaximumNArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var ids []string
+			for _, b := range sandbox.Breakages() {
+				ids = append(ids, b.ID+"\t"+b.Name)
+			}
+			return ids, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				var infos []breakageInfo
+				for _, b := range sandbox.Breakages() {
+					infos = append(infos, breakageInfo{ID: b.ID, Name: b.Name, Component: b.Component, Description: b.Description})
+				}
+				return printOutput(cmd, infos, func() error {
+					w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+					fmt.Fprintln(w, "ID\tCOMPONENT\tBREAKAGE")
+					for _, b := range infos {
+						fmt.Fprintf(w, "%s\t%s\t%s\n", b.ID, b.Component, b.Name)
+					}
+					return w.Flush()
+				})
+			}
+
+			b := sandbox.GetBreakage(args[0])
+			if b == nil {
+				return fmt.Errorf("unknown breakage: %s (run \"k8s-dojo sandbox break\" to list them)", args[0])
+			}
+			sb, err := connect()
+			if err != nil {
+				return err
+			}
+			if err := sb.Break(context.Background(), *b); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "💥 %s\nSomething is wrong with the %s. Investigate with kubectl -n %s.\n", b.Name, b.Component, sandbox.Namespace)
+			return nil
+		},
+	}
+}
+
+func newSandboxStatusCmd(connect func() (*sandbox.Sandbox, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show which components of the stack are healthy",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sb, err := connect()
+			if err != nil {
+				return err
+			}
+			checks := sb.Health(context.Background())
+			reports := make([]grade.CheckReport, len(checks))
+			for i, c := range checks {
+				reports[i] = grade.CheckReport{Name: c.Name, Passed: c.Passed, Details: c.Details}
+			}
+			return printOutput(cmd, reports, func() error {
+				for _, c := range checks {
+					mark := "✓"
+					if !c.Passed {
+						mark = "✗"
+					}
+					fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", mark, c.Name)
+					if !c.Passed && c.Details != "" {
+						fmt.Fprintf(cmd.OutOrStdout(), "    %s\n", c.Details)
+					}
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func newSandboxDownCmd(connect func() (*sandbox.Sandbox, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "down",
+		Short: "Delete the sandbox namespace",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sb, err := connect()
+			if err != nil {
+				return err
+			}
+			if err := sb.Teardown(context.Background()); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted namespace %s\n", sandbox.Namespace)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/telemetry"
+)
+
+// newTelemetryCmd manages the anonymous, opt-in scenario analytics.
+func newTelemetryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Manage opt-in anonymous scenario analytics",
+		Long: `Telemetry is off by default. When enabled, one anonymous record is sent per
+scenario attempt: the scenario ID, whether it was solved or abandoned, the time
+taken, which hints were used and which checks were still failing. It helps
+scenario authors find hints that are insufficient and validations that are too
+strict. No cluster contents, commands or personal data are sent.`,
+	}
+	cmd.AddCommand(newTelemetryEnableCmd(), newTelemetryDisableCmd(), newTelemetryStatusCmd())
+	return cmd
+}
+
+func newTelemetryEnableCmd() *cobra.Command {
+	var endpoint string
+
+	cmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Opt in to sending anonymous attempt records",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := telemetry.LoadConfig("")
+			if err != nil {
+				return err
+			}
+			cfg.Enabled = true
+			cfg.Endpoint = endpoint
+			if err := telemetry.SaveConfig("", cfg); err != nil {
+				return err
+			}
+			return printOutput(cmd, cfg, func() error {
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "Telemetry enabled, sending to %s\n", endpoint)
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&endpoint, "endpoint", "", "URL that receives attempt records as JSON POSTs")
+	_ = cmd.MarkFlagRequired("endpoint")
+	return cmd
+}
+
+func newTelemetryDisableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable",
+		Short: "Stop sending attempt records",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := telemetry.LoadConfig("")
+			if err != nil {
+				return err
+			}
+			cfg.Enabled = false
+			if err := telemetry.SaveConfig("", cfg); err != nil {
+				return err
+			}
+			return printOutput(cmd, cfg, func() error {
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), "Telemetry disabled")
+				return err
+			})
+		},
+	}
+}
+
+func newTelemetryStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether telemetry is enabled",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := telemetry.LoadConfig("")
+			if err != nil {
+				return err
+			}
+			return printOutput(cmd, cfg, func() error {
+				if !cfg.Enabled {
+					_, err := fmt.Fprintln(cmd.OutOrStdout(), "Telemetry is disabled")
+					return err
+				}
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "Telemetry is enabled\n  endpoint:   %s\n  install id: %s\n", cfg.Endpoint, cfg.InstallID)
+				return err
+			})
+		},
+	}
+}
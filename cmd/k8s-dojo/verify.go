@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"k8s-dojo/pkg/harness"
+	"k8s-dojo/pkg/scenario"
+)
+
+func newVerifyAllCmd() *cobra.Command {
+	var (
+		version  string
+		category string
+		failFast bool
+		opts     = harness.DefaultOptions()
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify-all",
+		Short: "Run setup, solve and cleanup for every scenario and print a result matrix",
+		Long: `Run every registered scenario through the harness one after another:
+setup, broken check, solve, validate and cleanup. Use it before a release or
+when bumping the supported Kubernetes versions.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			client, err := connectCluster(version)
+			if err != nil {
+				return err
+			}
+			reg, eng := newEngine(client)
+
+			var scenarios []scenario.Scenario
+			for _, s := range reg.List() {
+				if category == "" || s.GetMetadata().Category == category {
+					scenarios = append(scenarios, s)
+				}
+			}
+			if len(scenarios) == 0 {
+				return fmt.Errorf("no scenarios to verify")
+			}
+
+			errOut := cmd.ErrOrStderr()
+			var reports []harness.Report
+			failed := 0
+			for i, s := range scenarios {
+				id := s.GetMetadata().ID
+				fmt.Fprintf(errOut, "[%d/%d] %s ... ", i+1, len(scenarios), id)
+
+				report := harness.Run(ctx, eng, id, opts)
+				reports = append(reports, report)
+				if report.Passed {
+					fmt.Fprintf(errOut, "PASS (%.0fs)\n", report.Duration.Seconds())
+					continue
+				}
+
+				failed++
+				step := report.Failed()
+				if step == nil {
+					fmt.Fprintln(errOut, "FAIL")
+				} else {
+					fmt.Fprintf(errOut, "FAIL at %s: %s\n", step.Name, step.Details)
+				}
+				if failFast || ctx.Err() != nil {
+					break
+				}
+			}
+
+			if err := printOutput(cmd, reports, func() error {
+				fmt.Fprintln(cmd.OutOrStdout())
+				return harness.WriteMatrix(cmd.OutOrStdout(), reports)
+			}); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d scenarios failed", failed, len(reports))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&version, "k8s-version", "", "Kubernetes version for a new cluster (default: latest supported)")
+	cmd.Flags().StringVar(&category, "category", "", "Only verify scenarios in this category")
+	cmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop at the first failing scenario")
+	cmd.Flags().DurationVar(&opts.SolveTimeout, "timeout", opts.SolveTimeout, "How long to wait for Validate to pass after Solve, per scenario")
+	_ = cmd.RegisterFlagCompletionFunc("category", completeCategories)
+	return cmd
+}
@@ -0,0 +1,88 @@
+// Package apps provides realistic reference application stacks that the sandbox
+// and scenarios deploy when they need more context than a single pod.
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/scenario/check"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ReadyTimeout bounds how long WaitReady waits by default, including image pulls.
+const ReadyTimeout = 3 * time.Minute
+
+// App is a multi-tier application described by a manifest.
+type App struct {
+	Name        string
+	Description string
+
+	// Components are the app's Deployments; each has a Service of the same name.
+	Components []string
+
+	Manifest string
+}
+
+// List returns the available apps.
+func List() []App {
+	return []App{
+		{
+			Name:        "three-tier",
+			Description: "nginx frontend with an HPA, nginx API backend and Postgres on a PVC, wired with ConfigMaps and Services",
+			Components:  []string{"frontend", "backend", "db"},
+			Manifest:    threeTierManifest,
+		},
+	}
+}
+
+// Get returns the app with the given name, or nil.
+func Get(name string) *App {
+	for _, a := range List() {
+		if a.Name == name {
+			return &a
+		}
+	}
+	return nil
+}
+
+// Deploy applies the app into an existing namespace, its objects in parallel.
+// Re-deploying reverts changes to every field the manifest sets.
+func (a App) Deploy(ctx context.Context, client *k8s.Client, namespace string) error {
+	if err := client.ApplyBulkYAML(ctx, a.Manifest, namespace, 0); err != nil {
+		return fmt.Errorf("failed to deploy app %s: %w", a.Name, err)
+	}
+	return nil
+}
+
+// Health reports whether each component is available and reachable through its Service.
+func (a App) Health(ctx context.Context, clientset kubernetes.Interface, namespace string) []check.Check {
+	var checks []check.Check
+	for _, name := range a.Components {
+		checks = append(checks,
+			check.DeploymentAvailable(ctx, clientset, namespace, name),
+			check.EndpointsNonEmpty(ctx, clientset, namespace, name),
+		)
+	}
+	return checks
+}
+
+// WaitReady blocks until every component is healthy.
+func (a App) WaitReady(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+	err := wait.PollUntilContextCancel(ctx, 2*time.Second, true, func(ctx context.Context) (bool, error) {
+		for _, c := range a.Health(ctx, clientset, namespace) {
+			if !c.Passed {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("app %s did not become ready: %w", a.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package apps
+
+import (
+	"testing"
+
+	"k8s-dojo/pkg/k8s"
+)
+
+func TestManifests(t *testing.T) {
+	for _, app := range List() {
+		objs, err := k8s.DecodeManifest(app.Manifest)
+		if err != nil {
+			t.Fatalf("%s: DecodeManifest failed: %v", app.Name, err)
+		}
+
+		kinds := map[string]map[string]bool{}
+		for _, obj := range objs {
+			if kinds[obj.GetKind()] == nil {
+				kinds[obj.GetKind()] = map[string]bool{}
+			}
+			kinds[obj.GetKind()][obj.GetName()] = true
+			if obj.GetNamespace() != "" {
+				t.Errorf("%s: %s %s must not hardcode a namespace", app.Name, obj.GetKind(), obj.GetName())
+			}
+		}
+		// Health checks expect a Deployment and a Service per component
+		for _, c := range app.Components {
+			if !kinds["Deployment"][c] || !kinds["Service"][c] {
+				t.Errorf("%s: component %s needs a Deployment and a Service", app.Name, c)
+			}
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	if a := Get("three-tier"); a == nil || len(a.Components) != 3 {
+		t.Errorf("Expected three-tier app with 3 components, got %+v", a)
+	}
+	if Get("nope") != nil {
+		t.Error("Expected nil for unknown app")
+	}
+}
@@ -0,0 +1,247 @@
+// Package assistant provides optional LLM-generated hints.
+//
+// The assistant is strictly opt-in: it is disabled unless
+// ~/.k8s-dojo/assistant.json exists. It sends the scenario description,
+// the learner's recent kubectl commands and a summary of the namespace to an
+// OpenAI-compatible endpoint or a local Ollama server, after redacting
+// anything that looks like kubeconfig credentials.
+package assistant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Supported providers.
+const (
+	ProviderOpenAI = "openai" // Any OpenAI-compatible /chat/completions endpoint
+	ProviderOllama = "ollama"
+)
+
+// Config selects and configures the LLM provider.
+type Config struct {
+	Provider string `json:"provider"`
+	Endpoint string `json:"endpoint,omitempty"` // Defaults per provider
+	Model    string `json:"model"`
+
+	// APIKeyEnv names the environment variable holding the API key, so the
+	// key itself is never written to the config file. Defaults to OPENAI_API_KEY.
+	APIKeyEnv string `json:"apiKeyEnv,omitempty"`
+
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DefaultConfigPath returns ~/.k8s-dojo/assistant.json.
+func DefaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".k8s-dojo", "assistant.json")
+	}
+	return filepath.Join(home, ".k8s-dojo", "assistant.json")
+}
+
+// LoadConfig reads the assistant config. A missing file returns nil: the assistant is disabled.
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		path = DefaultConfigPath()
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read assistant config: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse assistant config: %w", err)
+	}
+
+	switch cfg.Provider {
+	case ProviderOpenAI:
+		if cfg.Endpoint == "" {
+			cfg.Endpoint = "https://api.openai.com/v1"
+		}
+		if cfg.APIKeyEnv == "" {
+			cfg.APIKeyEnv = "OPENAI_API_KEY"
+		}
+	case ProviderOllama:
+		if cfg.Endpoint == "" {
+			cfg.Endpoint = "http://localhost:11434"
+		}
+	default:
+		return nil, fmt.Errorf("unknown assistant provider %q (use %s or %s)", cfg.Provider, ProviderOpenAI, ProviderOllama)
+	}
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("assistant config is missing model")
+	}
+	if cfg.Timeout.Duration == 0 {
+		cfg.Timeout.Duration = 30 * time.Second
+	}
+	return cfg, nil
+}
+
+// Request is the context the assistant bases its nudge on.
+type Request struct {
+	Scenario    string   // Scenario name
+	Description string   // What the learner sees
+	Namespace   string   // Scenario namespace
+	Commands    []string // Recent commands typed in the terminal
+	State       string   // Summary of live resources, see DescribeNamespace
+	Failing     []string // Details of failing validation checks
+}
+
+// Assistant asks the configured provider for hints.
+type Assistant struct {
+	cfg    *Config
+	client *http.Client
+
+	// Secrets are additional strings to redact, e.g. the kubeconfig path.
+	Secrets []string
+}
+
+// New creates an assistant for cfg.
+func New(cfg *Config) *Assistant {
+	return &Assistant{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout.Duration},
+	}
+}
+
+const systemPrompt = `You are a Kubernetes troubleshooting coach in a training game.
+Give the learner one short nudge (at most three sentences) toward the next
+thing to investigate. Never give the full solution or the exact fix command.`
+
+// Hint returns a contextual nudge for the learner.
+func (a *Assistant) Hint(ctx context.Context, req Request) (string, error) {
+	prompt := Redact(buildPrompt(req), a.Secrets...)
+
+	var (
+		text string
+		err  error
+	)
+	switch a.cfg.Provider {
+	case ProviderOllama:
+		text, err = a.ollama(ctx, prompt)
+	default:
+		text, err = a.openAI(ctx, prompt)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(text), nil
+}
+
+func buildPrompt(req Request) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Scenario: %s\n%s\n\nNamespace: %s\n", req.Scenario, req.Description, req.Namespace)
+	if len(req.Failing) > 0 {
+		b.WriteString("\nFailing checks:\n")
+		for _, f := range req.Failing {
+			fmt.Fprintf(&b, "- %s\n", f)
+		}
+	}
+	if len(req.Commands) > 0 {
+		b.WriteString("\nCommands the learner ran:\n")
+		for _, c := range req.Commands {
+			fmt.Fprintf(&b, "$ %s\n", c)
+		}
+	} else {
+		b.WriteString("\nThe learner has not run any commands yet.\n")
+	}
+	if req.State != "" {
+		fmt.Fprintf(&b, "\nCurrent resources:\n%s\n", req.State)
+	}
+	return b.String()
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+func (a *Assistant) messages(prompt string) []chatMessage {
+	return []chatMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: prompt},
+	}
+}
+
+func (a *Assistant) openAI(ctx context.Context, prompt string) (string, error) {
+	body := map[string]interface{}{
+		"model":    a.cfg.Model,
+		"messages": a.messages(prompt),
+	}
+	var resp struct {
+		Choices []struct {
+			Message chatMessage `json:"message"`
+		} `json:"choices"`
+	}
+	headers := map[string]string{}
+	if key := os.Getenv(a.cfg.APIKeyEnv); key != "" {
+		headers["Authorization"] = "Bearer " + key
+	}
+	if err := a.post(ctx, strings.TrimSuffix(a.cfg.Endpoint, "/")+"/chat/completions", headers, body, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("assistant returned no answer")
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+func (a *Assistant) ollama(ctx context.Context, prompt string) (string, error) {
+	body := map[string]interface{}{
+		"model":    a.cfg.Model,
+		"messages": a.messages(prompt),
+		"stream":   false,
+	}
+	var resp struct {
+		Message chatMessage `json:"message"`
+	}
+	if err := a.post(ctx, strings.TrimSuffix(a.cfg.Endpoint, "/")+"/api/chat", nil, body, &resp); err != nil {
+		return "", err
+	}
+	return resp.Message.Content, nil
+}
+
+func (a *Assistant) post(ctx context.Context, url string, headers map[string]string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach assistant: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("assistant returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode assistant response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package assistant
+
+import (
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// kubeconfig and secret fields, e.g. "client-key-data: LS0t..."
+	secretFields = regexp.MustCompile(`(?i)((?:certificate-authority|client-certificate|client-key)-data|token|password|client-secret|api[-_]?key)(["']?\s*[:=]\s*["']?)[^\s"',]+`)
+	bearer       = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	pemBlock     = regexp.MustCompile(`-----BEGIN [A-Z ]+-----[\s\S]*?-----END [A-Z ]+-----`)
+	longBase64   = regexp.MustCompile(`[A-Za-z0-9+/]{40,}={0,2}`)
+)
+
+// Redact removes credentials from text before it leaves the machine:
+// kubeconfig key material, tokens, PEM blocks, long base64 blobs and any
+// extra literal strings such as the kubeconfig path.
+func Redact(text string, extra ...string) string {
+	for _, s := range extra {
+		if s != "" {
+			text = strings.ReplaceAll(text, s, redacted)
+		}
+	}
+	text = pemBlock.ReplaceAllString(text, redacted)
+	text = secretFields.ReplaceAllString(text, "${1}${2}"+redacted)
+	text = bearer.ReplaceAllString(text, "${1}"+redacted)
+	text = longBase64.ReplaceAllString(text, redacted)
+	return text
+}
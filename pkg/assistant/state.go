@@ -0,0 +1,67 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxEvents bounds how many warning events are included in the summary.
+const maxEvents = 10
+
+// DescribeNamespace summarizes pods, services and recent warning events in namespace.
+// Secret and ConfigMap contents are never included.
+func DescribeNamespace(ctx context.Context, clientset kubernetes.Interface, namespace string) string {
+	var b strings.Builder
+
+	if pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{}); err == nil {
+		for _, pod := range pods.Items {
+			fmt.Fprintf(&b, "Pod %s: %s%s\n", pod.Name, pod.Status.Phase, containerReasons(pod))
+		}
+	}
+
+	if svcs, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{}); err == nil {
+		for _, svc := range svcs.Items {
+			fmt.Fprintf(&b, "Service %s: selector=%v ports=%d\n", svc.Name, svc.Spec.Selector, len(svc.Spec.Ports))
+		}
+	}
+
+	if events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=Warning"}); err == nil {
+		items := events.Items
+		sort.Slice(items, func(i, j int) bool { return items[i].LastTimestamp.After(items[j].LastTimestamp.Time) })
+		if len(items) > maxEvents {
+			items = items[:maxEvents]
+		}
+		for _, ev := range items {
+			fmt.Fprintf(&b, "Warning %s on %s/%s: %s\n", ev.Reason, ev.InvolvedObject.Kind, ev.InvolvedObject.Name, ev.Message)
+		}
+	}
+
+	return b.String()
+}
+
+// containerReasons lists waiting or terminated reasons of a pod's containers.
+func containerReasons(pod corev1.Pod) string {
+	var reasons []string
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		switch {
+		case cs.State.Waiting != nil:
+			reasons = append(reasons, cs.Name+"="+cs.State.Waiting.Reason)
+		case cs.State.Terminated != nil:
+			reasons = append(reasons, cs.Name+"="+cs.State.Terminated.Reason)
+		}
+		if cs.RestartCount > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s restarts=%d", cs.Name, cs.RestartCount))
+		}
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(reasons, ", ") + ")"
+}
@@ -0,0 +1,41 @@
+// Package browser opens URLs in the user's web browser.
+package browser
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Open opens url in the browser named by $BROWSER or, failing that, the
+// platform's default one. It doesn't wait for the browser to exit.
+func Open(url string) error {
+	name, args := command(runtime.GOOS, os.Getenv("BROWSER"), url)
+	if name == "" {
+		return fmt.Errorf("don't know how to open a browser on %s", runtime.GOOS)
+	}
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	// Reap the process; the browser's exit status doesn't matter.
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// command returns the program and arguments that open url on goos.
+func command(goos, browser, url string) (string, []string) {
+	if browser != "" {
+		return browser, []string{url}
+	}
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "xdg-open", []string{url}
+	}
+	return "", nil
+}
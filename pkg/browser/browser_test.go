@@ -0,0 +1,27 @@
+package browser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	const url = "https://kubernetes.io/docs/"
+	tests := []struct {
+		goos, browser string
+		name          string
+		args          []string
+	}{
+		{"linux", "", "xdg-open", []string{url}},
+		{"darwin", "", "open", []string{url}},
+		{"windows", "", "rundll32", []string{"url.dll,FileProtocolHandler", url}},
+		{"linux", "firefox", "firefox", []string{url}},
+		{"plan9", "", "", nil},
+	}
+	for _, tt := range tests {
+		name, args := command(tt.goos, tt.browser, url)
+		if name != tt.name || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("command(%q, %q) = %q %v, want %q %v", tt.goos, tt.browser, name, args, tt.name, tt.args)
+		}
+	}
+}
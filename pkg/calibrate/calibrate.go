@@ -0,0 +1,185 @@
+// Package calibrate compares scenarios' declared difficulty with how players
+// actually fare, based on attempt records from the local history or telemetry.
+package calibrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/telemetry"
+)
+
+// MinAttempts is the sample size below which a scenario is never flagged.
+const MinAttempts = 5
+
+// Thresholds between difficulty levels for each signal.
+const (
+	EasyTime   = 5 * time.Minute  // Median solve time below this looks Easy
+	MediumTime = 15 * time.Minute // ...below this Medium, above Hard
+
+	EasyHints   = 1.0 // Mean hints per attempt below this looks Easy
+	MediumHints = 2.0
+
+	EasySolveRate   = 0.8 // Solve rate above this looks Easy
+	MediumSolveRate = 0.5
+)
+
+// Stats summarises the attempts at one scenario.
+type Stats struct {
+	Attempts      int     `json:"attempts"`
+	Solved        int     `json:"solved"`
+	MedianSeconds int     `json:"median_seconds"` // Over solved attempts
+	MeanHints     float64 `json:"mean_hints"`
+}
+
+// SolveRate returns the fraction of attempts that were solved.
+func (s Stats) SolveRate() float64 {
+	if s.Attempts == 0 {
+		return 0
+	}
+	return float64(s.Solved) / float64(s.Attempts)
+}
+
+// MedianTime returns the median solve time.
+func (s Stats) MedianTime() time.Duration {
+	return time.Duration(s.MedianSeconds) * time.Second
+}
+
+// Aggregate groups attempts by scenario.
+func Aggregate(attempts []telemetry.Attempt) map[string]Stats {
+	times := make(map[string][]int)
+	hints := make(map[string]int)
+	stats := make(map[string]Stats)
+	for _, a := range attempts {
+		s := stats[a.ScenarioID]
+		s.Attempts++
+		if a.Outcome == telemetry.OutcomeSolved {
+			s.Solved++
+			times[a.ScenarioID] = append(times[a.ScenarioID], a.Seconds)
+		}
+		hints[a.ScenarioID] += len(a.HintsUsed)
+		stats[a.ScenarioID] = s
+	}
+	for id, s := range stats {
+		s.MedianSeconds = median(times[id])
+		s.MeanHints = float64(hints[id]) / float64(s.Attempts)
+		stats[id] = s
+	}
+	return stats
+}
+
+func median(values []int) int {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+var levels = []scenario.Difficulty{scenario.DifficultyEasy, scenario.DifficultyMedium, scenario.DifficultyHard}
+
+// level returns 0, 1 or 2 depending on which side of the two thresholds v falls.
+func level(v, easy, medium float64) int {
+	switch {
+	case v < easy:
+		return 0
+	case v < medium:
+		return 1
+	}
+	return 2
+}
+
+// Observed estimates the difficulty players experience: the rounded mean of the
+// levels suggested by median solve time, hints per attempt and solve rate.
+// A scenario nobody solved is Hard.
+func Observed(s Stats) scenario.Difficulty {
+	if s.Solved == 0 {
+		return scenario.DifficultyHard
+	}
+	t := level(s.MedianTime().Minutes(), EasyTime.Minutes(), MediumTime.Minutes())
+	h := level(s.MeanHints, EasyHints, MediumHints)
+	r := level(1-s.SolveRate(), 1-EasySolveRate, 1-MediumSolveRate)
+	return levels[int(math.Round(float64(t+h+r)/3))]
+}
+
+// Finding is the calibration result for one scenario.
+type Finding struct {
+	ScenarioID string              `json:"scenario_id"`
+	Declared   scenario.Difficulty `json:"declared"`
+	Observed   scenario.Difficulty `json:"observed,omitempty"`
+	Stats      Stats               `json:"stats"`
+	Flagged    bool                `json:"flagged"`
+}
+
+// Report calibrates every scenario. Scenarios with fewer than MinAttempts
+// attempts get no observed difficulty and are never flagged.
+func Report(scenarios []scenario.Scenario, stats map[string]Stats) []Finding {
+	var findings []Finding
+	for _, s := range scenarios {
+		meta := s.GetMetadata()
+		f := Finding{ScenarioID: meta.ID, Declared: meta.Difficulty, Stats: stats[meta.ID]}
+		if f.Stats.Attempts >= MinAttempts {
+			f.Observed = Observed(f.Stats)
+			f.Flagged = f.Observed != f.Declared
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// DefaultStatsPath returns ~/.k8s-dojo/community-stats.json.
+func DefaultStatsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".k8s-dojo", "community-stats.json")
+	}
+	return filepath.Join(home, ".k8s-dojo", "community-stats.json")
+}
+
+// SaveStats writes aggregated stats, e.g. for publishing community averages.
+func SaveStats(path string, stats map[string]Stats) error {
+	if path == "" {
+		path = DefaultStatsPath()
+	}
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal stats: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create stats directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write stats: %w", err)
+	}
+	return nil
+}
+
+// LoadStats reads aggregated stats. A missing file yields no stats.
+func LoadStats(path string) (map[string]Stats, error) {
+	if path == "" {
+		path = DefaultStatsPath()
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats: %w", err)
+	}
+	var stats map[string]Stats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("failed to parse stats: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,93 @@
+package calibrate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/telemetry"
+)
+
+func attempts(id string, n, solved, seconds, hints int) []telemetry.Attempt {
+	var out []telemetry.Attempt
+	for i := 0; i < n; i++ {
+		a := telemetry.Attempt{ScenarioID: id, Outcome: telemetry.OutcomeAbandoned, Seconds: seconds, HintsUsed: make([]int, hints)}
+		if i < solved {
+			a.Outcome = telemetry.OutcomeSolved
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+func TestAggregate(t *testing.T) {
+	in := []telemetry.Attempt{
+		{ScenarioID: "a", Outcome: telemetry.OutcomeSolved, Seconds: 100, HintsUsed: []int{0}},
+		{ScenarioID: "a", Outcome: telemetry.OutcomeSolved, Seconds: 300},
+		{ScenarioID: "a", Outcome: telemetry.OutcomeAbandoned, Seconds: 5000, HintsUsed: []int{0, 1}},
+	}
+	s := Aggregate(in)["a"]
+	if s.Attempts != 3 || s.Solved != 2 {
+		t.Fatalf("got %+v", s)
+	}
+	if s.MedianSeconds != 200 {
+		t.Errorf("median = %d, want 200 (abandoned attempts excluded)", s.MedianSeconds)
+	}
+	if s.MeanHints != 1 {
+		t.Errorf("mean hints = %v, want 1", s.MeanHints)
+	}
+}
+
+func TestObserved(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats Stats
+		want  scenario.Difficulty
+	}{
+		{"quick, no hints", Stats{Attempts: 10, Solved: 10, MedianSeconds: 120}, scenario.DifficultyEasy},
+		{"medium time", Stats{Attempts: 10, Solved: 8, MedianSeconds: 600, MeanHints: 1.2}, scenario.DifficultyMedium},
+		{"slow and hint heavy", Stats{Attempts: 10, Solved: 4, MedianSeconds: 1800, MeanHints: 2.5}, scenario.DifficultyHard},
+		{"never solved", Stats{Attempts: 10}, scenario.DifficultyHard},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Observed(tt.stats); got != tt.want {
+				t.Errorf("Observed() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	var in []telemetry.Attempt
+	in = append(in, attempts("sched-node-affinity", 6, 6, 60, 0)...)
+	in = append(in, attempts("sched-taint-toleration", 2, 0, 60, 3)...)
+	findings := Report([]scenario.Scenario{scenario.NewSchedNodeAffinity(nil), scenario.NewSchedTaintToleration(nil)}, Aggregate(in))
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings", len(findings))
+	}
+	if f := findings[0]; f.Observed != scenario.DifficultyEasy || f.Flagged != (f.Declared != scenario.DifficultyEasy) {
+		t.Errorf("sched-node-affinity: %+v", f)
+	}
+	if f := findings[1]; f.Flagged || f.Observed != "" {
+		t.Errorf("sched-taint-toleration has too few attempts to flag: %+v", f)
+	}
+}
+
+func TestStatsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if got, err := LoadStats(path); err != nil || got != nil {
+		t.Fatalf("missing file: %v, %v", got, err)
+	}
+	want := map[string]Stats{"a": {Attempts: 3, Solved: 2, MedianSeconds: 200, MeanHints: 1}}
+	if err := SaveStats(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadStats(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["a"] != want["a"] {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
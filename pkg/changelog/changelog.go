@@ -0,0 +1,71 @@
+// Package changelog holds the release notes built into k8s-dojo, so the TUI
+// can show what's new after an upgrade.
+package changelog
+
+import (
+	_ "embed"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed changelog.yaml
+var embedded []byte
+
+// Release lists what one k8s-dojo release added.
+type Release struct {
+	Version  string   `json:"version"`
+	Date     string   `json:"date,omitempty"`
+	Features []string `json:"features,omitempty"`
+
+	// Scenarios are the IDs of the scenarios the release added.
+	Scenarios []string `json:"scenarios,omitempty"`
+}
+
+// Load returns the built-in releases, newest first.
+func Load() ([]Release, error) {
+	return Parse(embedded)
+}
+
+// Parse reads a changelog: a YAML list of releases, newest first.
+func Parse(data []byte) ([]Release, error) {
+	var releases []Release
+	if err := yaml.UnmarshalStrict(data, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse changelog: %w", err)
+	}
+	seen := make(map[string]bool)
+	for i, r := range releases {
+		if r.Version == "" {
+			return nil, fmt.Errorf("release %d has no version", i+1)
+		}
+		if seen[r.Version] {
+			return nil, fmt.Errorf("release %s is listed twice", r.Version)
+		}
+		seen[r.Version] = true
+	}
+	return releases, nil
+}
+
+// Since returns the releases newer than version. Nothing is new to a fresh
+// install (an empty version); a version the changelog no longer lists is
+// older than all of it.
+func Since(releases []Release, version string) []Release {
+	if version == "" {
+		return nil
+	}
+	for i, r := range releases {
+		if r.Version == version {
+			return releases[:i]
+		}
+	}
+	return releases
+}
+
+// Scenarios returns the IDs of the scenarios the releases added.
+func Scenarios(releases []Release) []string {
+	var ids []string
+	for _, r := range releases {
+		ids = append(ids, r.Scenarios...)
+	}
+	return ids
+}
@@ -0,0 +1,54 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	releases, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) == 0 {
+		t.Fatal("the built-in changelog lists no releases")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		"- date: 2026-01-01":                        "has no version",
+		"- version: 1.0.0\n- version: 1.0.0":        "listed twice",
+		"- version: 1.0.0\n  features: [a]\n  x: 1": "failed to parse",
+	}
+	for data, want := range tests {
+		_, err := Parse([]byte(data))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want %q", data, err, want)
+		}
+	}
+}
+
+func TestSince(t *testing.T) {
+	releases := []Release{
+		{Version: "3", Scenarios: []string{"c"}},
+		{Version: "2", Scenarios: []string{"b1", "b2"}},
+		{Version: "1", Scenarios: []string{"a"}},
+	}
+	tests := map[string]int{
+		"":  0, // Fresh install
+		"3": 0,
+		"2": 1,
+		"1": 2,
+		"0": 3, // Pruned from the changelog
+	}
+	for version, want := range tests {
+		if got := Since(releases, version); len(got) != want {
+			t.Errorf("Since(%q) = %d releases, want %d", version, len(got), want)
+		}
+	}
+
+	if got := strings.Join(Scenarios(Since(releases, "1")), ","); got != "c,b1,b2" {
+		t.Errorf("Scenarios = %q, want c,b1,b2", got)
+	}
+}
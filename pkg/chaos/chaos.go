@@ -0,0 +1,187 @@
+// Package chaos provides hard mode: minor faults injected into a running
+// scenario's namespace so advanced users practice under noise.
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// protectedNamespaces are never touched, whatever a scenario reports as its namespace.
+var protectedNamespaces = map[string]bool{
+	"":                   true,
+	"default":            true,
+	"kube-system":        true,
+	"kube-public":        true,
+	"kube-node-lease":    true,
+	"local-path-storage": true,
+}
+
+// LabelSuffix is appended to a label value by the label flip fault.
+const LabelSuffix = "-chaos"
+
+// Fault injects one disruption into a namespace and describes what it did.
+// It returns an empty description when there was nothing to disrupt.
+type Fault func(ctx context.Context, clientset kubernetes.Interface, namespace string, rnd *rand.Rand) (string, error)
+
+// Injection is the record of a fault that was applied.
+type Injection struct {
+	Time        time.Time
+	Namespace   string
+	Description string
+}
+
+// Options configures a Controller.
+type Options struct {
+	// Interval is the mean time between faults; each wait is jittered by ±50%.
+	Interval time.Duration
+
+	// Faults are picked from at random. Empty means DefaultFaults.
+	Faults []Fault
+
+	// OnInject, if set, is called after every applied fault.
+	OnInject func(Injection)
+
+	// Seed makes fault selection reproducible. 0 seeds from the clock.
+	Seed int64
+}
+
+// DefaultOptions returns the options used for hard mode.
+func DefaultOptions() Options {
+	return Options{
+		Interval: 90 * time.Second,
+	}
+}
+
+// DefaultFaults are the minor faults used by hard mode.
+func DefaultFaults() []Fault {
+	return []Fault{DeletePod, FlipLabel}
+}
+
+// Controller periodically injects faults into a single namespace.
+type Controller struct {
+	clientset kubernetes.Interface
+	namespace string
+	opts      Options
+	rnd       *rand.Rand
+}
+
+// New creates a controller scoped to namespace. System namespaces are refused.
+func New(clientset kubernetes.Interface, namespace string, opts Options) (*Controller, error) {
+	if protectedNamespaces[namespace] {
+		return nil, fmt.Errorf("refusing to inject chaos into namespace %q", namespace)
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultOptions().Interval
+	}
+	if len(opts.Faults) == 0 {
+		opts.Faults = DefaultFaults()
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Controller{
+		clientset: clientset,
+		namespace: namespace,
+		opts:      opts,
+		rnd:       rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// Run injects faults until ctx is cancelled. Failed injections are skipped.
+func (c *Controller) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.nextDelay()):
+		}
+		c.Inject(ctx)
+	}
+}
+
+// Inject applies one randomly chosen fault and reports whether anything changed.
+func (c *Controller) Inject(ctx context.Context) bool {
+	fault := c.opts.Faults[c.rnd.Intn(len(c.opts.Faults))]
+	desc, err := fault(ctx, c.clientset, c.namespace, c.rnd)
+	if err != nil || desc == "" {
+		return false
+	}
+	if c.opts.OnInject != nil {
+		c.opts.OnInject(Injection{Time: time.Now(), Namespace: c.namespace, Description: desc})
+	}
+	return true
+}
+
+func (c *Controller) nextDelay() time.Duration {
+	half := int64(c.opts.Interval / 2)
+	return time.Duration(half + c.rnd.Int63n(2*half+1))
+}
+
+// DeletePod deletes a random running pod. Controllers recreate their pods,
+// so the user sees restarts and fresh pod names.
+func DeletePod(ctx context.Context, clientset kubernetes.Interface, namespace string, rnd *rand.Rand) (string, error) {
+	pods, err := runningPods(ctx, clientset, namespace)
+	if err != nil || len(pods) == 0 {
+		return "", err
+	}
+	pod := pods[rnd.Intn(len(pods))]
+	if err := clientset.CoreV1().Pods(namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+		return "", fmt.Errorf("failed to delete pod %s: %w", pod.Name, err)
+	}
+	return fmt.Sprintf("deleted pod %s", pod.Name), nil
+}
+
+// FlipLabel changes one label of a random running pod, so it drops out of
+// Service selectors and its ReplicaSet replaces it.
+func FlipLabel(ctx context.Context, clientset kubernetes.Interface, namespace string, rnd *rand.Rand) (string, error) {
+	pods, err := runningPods(ctx, clientset, namespace)
+	if err != nil {
+		return "", err
+	}
+	var labelled []corev1.Pod
+	for _, p := range pods {
+		if len(p.Labels) > 0 {
+			labelled = append(labelled, p)
+		}
+	}
+	if len(labelled) == 0 {
+		return "", nil
+	}
+
+	pod := labelled[rnd.Intn(len(labelled))]
+	keys := make([]string, 0, len(pod.Labels))
+	for k := range pod.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys) // Deterministic for a given seed
+	key := keys[rnd.Intn(len(keys))]
+	pod.Labels[key] += LabelSuffix
+
+	if _, err := clientset.CoreV1().Pods(namespace).Update(ctx, &pod, metav1.UpdateOptions{}); err != nil {
+		return "", fmt.Errorf("failed to relabel pod %s: %w", pod.Name, err)
+	}
+	return fmt.Sprintf("changed label %s on pod %s", key, pod.Name), nil
+}
+
+func runningPods(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]corev1.Pod, error) {
+	list, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var pods []corev1.Pod
+	for _, p := range list.Items {
+		if p.Status.Phase == corev1.PodRunning && p.DeletionTimestamp == nil {
+			pods = append(pods, p)
+		}
+	}
+	return pods, nil
+}
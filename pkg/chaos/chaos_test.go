@@ -0,0 +1,94 @@
+package chaos
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func pod(namespace, name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app": "web"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestNewRefusesSystemNamespaces(t *testing.T) {
+	for _, ns := range []string{"", "default", "kube-system"} {
+		if _, err := New(fake.NewSimpleClientset(), ns, DefaultOptions()); err == nil {
+			t.Errorf("Expected namespace %q to be refused", ns)
+		}
+	}
+	if _, err := New(fake.NewSimpleClientset(), "dojo-level-1-abcde", DefaultOptions()); err != nil {
+		t.Errorf("Unexpected error for scenario namespace: %v", err)
+	}
+}
+
+func TestFaultsStayInNamespace(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(pod("dojo", "web-1"), pod("other", "bystander"))
+
+	var injections []Injection
+	c, err := New(clientset, "dojo", Options{
+		Faults:   []Fault{FlipLabel, DeletePod},
+		Seed:     1,
+		OnInject: func(i Injection) { injections = append(injections, i) },
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		c.Inject(ctx)
+	}
+
+	// The bystander in another namespace is untouched
+	other, err := clientset.CoreV1().Pods("other").Get(ctx, "bystander", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Pod outside the namespace was deleted: %v", err)
+	}
+	if other.Labels["app"] != "web" {
+		t.Errorf("Pod outside the namespace was relabelled: %v", other.Labels)
+	}
+
+	if len(injections) == 0 {
+		t.Fatal("Expected at least one injection")
+	}
+	for _, i := range injections {
+		if i.Namespace != "dojo" || !strings.Contains(i.Description, "web-1") {
+			t.Errorf("Unexpected injection: %+v", i)
+		}
+	}
+}
+
+func TestFlipLabel(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(pod("dojo", "web-1"))
+
+	desc, err := FlipLabel(ctx, clientset, "dojo", newRand())
+	if err != nil || desc == "" {
+		t.Fatalf("FlipLabel = %q, %v", desc, err)
+	}
+	p, _ := clientset.CoreV1().Pods("dojo").Get(ctx, "web-1", metav1.GetOptions{})
+	if p.Labels["app"] != "web"+LabelSuffix {
+		t.Errorf("Expected flipped label, got %v", p.Labels)
+	}
+
+	// Nothing to disrupt in an empty namespace
+	if desc, err := DeletePod(ctx, clientset, "empty", newRand()); err != nil || desc != "" {
+		t.Errorf("DeletePod on empty namespace = %q, %v", desc, err)
+	}
+}
+
+func newRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
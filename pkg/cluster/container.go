@@ -0,0 +1,58 @@
+package cluster
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// DefaultHostGateway is the hostname containers use to reach the Docker host.
+// The supplied compose file maps it to host-gateway, as Docker Desktop does by default.
+const DefaultHostGateway = "host.docker.internal"
+
+// InContainer reports whether k8s-dojo itself runs inside a container.
+// K8S_DOJO_IN_CONTAINER=1 or 0 overrides the detection.
+func InContainer() bool {
+	switch os.Getenv("K8S_DOJO_IN_CONTAINER") {
+	case "1", "true":
+		return true
+	case "0", "false":
+		return false
+	}
+	_, err := os.Stat("/.dockerenv")
+	return err == nil
+}
+
+// ContainerKubeconfig rewrites a Kind kubeconfig for use inside a container that
+// shares the host's Docker socket: Kind publishes the API server on the host, so
+// 127.0.0.1 is replaced with the host gateway. The TLS server name is pinned to
+// localhost, which is in the API server certificate.
+func ContainerKubeconfig(kubeconfig, gateway string) (string, error) {
+	cfg, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	for _, c := range cfg.Clusters {
+		u, err := url.Parse(c.Server)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse server URL: %w", err)
+		}
+		host := u.Hostname()
+		if host != "127.0.0.1" && host != "localhost" && host != "0.0.0.0" {
+			continue
+		}
+		u.Host = net.JoinHostPort(gateway, u.Port())
+		c.Server = u.String()
+		if c.TLSServerName == "" {
+			c.TLSServerName = "localhost"
+		}
+	}
+	out, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,36 @@
+package cluster
+
+import (
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestContainerKubeconfig(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:41235
+  name: kind-k8s-dojo
+- cluster:
+    server: https://prod.example.com:6443
+  name: other
+`
+	out, err := ContainerKubeconfig(kubeconfig, "host.docker.internal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := clientcmd.Load([]byte(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kind := cfg.Clusters["kind-k8s-dojo"]
+	if kind.Server != "https://host.docker.internal:41235" || kind.TLSServerName != "localhost" {
+		t.Errorf("kind cluster = %s (tls %q)", kind.Server, kind.TLSServerName)
+	}
+	if other := cfg.Clusters["other"]; other.Server != "https://prod.example.com:6443" || other.TLSServerName != "" {
+		t.Errorf("non-local cluster was rewritten: %s", other.Server)
+	}
+}
@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+// danglingGrace is how old a cluster must be before it counts as dangling, so
+// one being created by another k8s-dojo process right now is left alone.
+const danglingGrace = 15 * time.Minute
+
+// DanglingClusters returns the dojo's Kind clusters, the usual one and fleet
+// ones, left half-created by a crashed run: their nodes run, but the control
+// plane never came up, so there is no kubeconfig to read. Paused clusters
+// and other Kind clusters are never reported.
+func DanglingClusters(opts ManagerOptions) ([]string, error) {
+	provider := cluster.NewProvider()
+	all, err := provider.List()
+	if err != nil {
+		return nil, dockerError(fmt.Errorf("failed to list clusters: %w", err))
+	}
+
+	var dangling []string
+	for _, name := range all {
+		if name != ClusterName && !isFleetCluster(name) {
+			continue
+		}
+		o := opts
+		o.Name = name
+		m := &Manager{provider: provider, opts: o}
+		ok, err := m.dangling(time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			dangling = append(dangling, name)
+		}
+	}
+	return dangling, nil
+}
+
+// dangling reports whether the cluster's nodes run without a usable control plane.
+func (m *Manager) dangling(now time.Time) (bool, error) {
+	status, err := m.Status()
+	if err != nil || status != StatusRunning {
+		return false, err
+	}
+	names, err := m.nodeNames()
+	if err != nil {
+		return false, err
+	}
+
+	args := append([]string{"inspect", "--format", "{{.Created}}"}, names...)
+	out, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		return false, dockerError(fmt.Errorf("failed to inspect cluster nodes: %w", err))
+	}
+	for _, created := range strings.Fields(string(out)) {
+		t, err := time.Parse(time.RFC3339Nano, created)
+		if err != nil || now.Sub(t) < danglingGrace {
+			return false, nil
+		}
+	}
+
+	_, err = m.kubeconfig()
+	return err != nil, nil
+}
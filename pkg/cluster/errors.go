@@ -0,0 +1,37 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+var (
+	// ErrDockerDown means Docker isn't installed or its daemon can't be reached.
+	ErrDockerDown = errors.New("docker is not running")
+
+	// ErrVersionUnsupported means there is no dojo node image for a Kubernetes version.
+	ErrVersionUnsupported = errors.New("unsupported Kubernetes version")
+)
+
+// ParseVersion returns the supported version named version, or the latest
+// one when version is empty.
+func ParseVersion(version string) (SupportedVersion, error) {
+	v := GetVersion(version)
+	if version != "" && v.Version != version {
+		return v, fmt.Errorf("%w: %s", ErrVersionUnsupported, version)
+	}
+	return v, nil
+}
+
+// dockerError marks err with ErrDockerDown when the Docker daemon doesn't
+// answer, so a failed docker or Kind call can be told apart from other failures.
+func dockerError(err error) error {
+	if err == nil || errors.Is(err, ErrDockerDown) {
+		return err
+	}
+	if exec.Command("docker", "info", "--format", "{{.ServerVersion}}").Run() == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrDockerDown, err)
+}
@@ -0,0 +1,19 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	latest := LatestVersion()
+	if v, err := ParseVersion(""); err != nil || v != latest {
+		t.Errorf("ParseVersion(\"\") = %v, %v; want the latest version", v, err)
+	}
+	if v, err := ParseVersion(latest.Version); err != nil || v != latest {
+		t.Errorf("ParseVersion(%q) = %v, %v", latest.Version, v, err)
+	}
+	if _, err := ParseVersion("v0.1.0"); !errors.Is(err, ErrVersionUnsupported) {
+		t.Errorf("Expected ErrVersionUnsupported, got %v", err)
+	}
+}
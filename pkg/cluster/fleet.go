@@ -0,0 +1,344 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+// fleetPrefix starts the name of every fleet cluster, e.g. k8s-dojo-03.
+const fleetPrefix = ClusterName + "-"
+
+// FleetClusterName returns the name of the i-th fleet cluster, counting from 1.
+func FleetClusterName(i int) string {
+	return fmt.Sprintf("%s%02d", fleetPrefix, i)
+}
+
+// isFleetCluster reports whether a Kind cluster name belongs to the fleet.
+func isFleetCluster(name string) bool {
+	n, err := strconv.Atoi(strings.TrimPrefix(name, fleetPrefix))
+	return strings.HasPrefix(name, fleetPrefix) && err == nil && n > 0
+}
+
+// DefaultFleetPath returns ~/.k8s-dojo/fleet.json.
+func DefaultFleetPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".k8s-dojo", "fleet.json")
+}
+
+// Fleet manages a pool of dojo clusters on one classroom host, one per
+// student. Sessions, such as a student's name, are assigned a cluster of
+// their own; the assignments are kept in a file so every k8s-dojo process on
+// the host agrees on them.
+type Fleet struct {
+	provider *cluster.Provider
+	opts     ManagerOptions
+	path     string
+
+	mu          sync.Mutex
+	kubeconfigs map[string]string // By cluster name, fetched once
+}
+
+// fleetState is the on-disk form of the session assignments.
+type fleetState struct {
+	Sessions map[string]string `json:"sessions"` // Session to cluster name
+}
+
+// NewFleet creates a Fleet whose clusters use opts. Assignments are stored at
+// path (default: ~/.k8s-dojo/fleet.json).
+func NewFleet(opts ManagerOptions, path string) *Fleet {
+	if path == "" {
+		path = DefaultFleetPath()
+	}
+	return &Fleet{
+		provider:    cluster.NewProvider(),
+		opts:        opts,
+		path:        path,
+		kubeconfigs: map[string]string{},
+	}
+}
+
+// Manager returns a Manager for the named fleet cluster.
+func (f *Fleet) Manager(name string) *Manager {
+	opts := f.opts
+	opts.Name = name
+	return &Manager{provider: f.provider, opts: opts}
+}
+
+// Clusters lists the fleet's clusters in order.
+func (f *Fleet) Clusters() ([]string, error) {
+	all, err := f.provider.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+	var names []string
+	for _, c := range all {
+		if isFleetCluster(c) {
+			names = append(names, c)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Create brings the fleet up to n clusters of the given version, creating
+// the missing ones and resuming paused ones. It returns the cluster names.
+func (f *Fleet) Create(n int, version SupportedVersion) ([]string, error) {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = FleetClusterName(i + 1)
+		kubeconfig, err := f.Manager(names[i]).EnsureCluster(version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cluster %s: %w", names[i], err)
+		}
+		f.mu.Lock()
+		f.kubeconfigs[names[i]] = kubeconfig
+		f.mu.Unlock()
+	}
+	return names, nil
+}
+
+// Standby tops the fleet up so at least n clusters are free, creating new
+// ones after the highest existing number. Students then get a cluster in
+// seconds instead of waiting minutes for one to be created. It returns the
+// created clusters.
+func (f *Fleet) Standby(n int, version SupportedVersion) ([]string, error) {
+	names, err := f.Clusters()
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := f.Assignments()
+	if err != nil {
+		return nil, err
+	}
+
+	var created []string
+	for _, name := range standbyPlan(names, sessions, n) {
+		kubeconfig, err := f.Manager(name).EnsureCluster(version)
+		if err != nil {
+			return created, fmt.Errorf("failed to create standby cluster %s: %w", name, err)
+		}
+		f.mu.Lock()
+		f.kubeconfigs[name] = kubeconfig
+		f.mu.Unlock()
+		created = append(created, name)
+	}
+	return created, nil
+}
+
+// Recreate gives session a fresh cluster: a free standby one if there is
+// one, and deletes the cluster it had. It returns the new cluster.
+func (f *Fleet) Recreate(session string) (string, error) {
+	names, err := f.Clusters()
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	state, err := f.load()
+	if err != nil {
+		f.mu.Unlock()
+		return "", err
+	}
+	old, ok := state.Sessions[session]
+	if !ok {
+		f.mu.Unlock()
+		return "", fmt.Errorf("session %q has no cluster", session)
+	}
+	name, ok := freeCluster(state.Sessions, names)
+	if !ok {
+		f.mu.Unlock()
+		return "", fmt.Errorf("no standby cluster to switch to, add some with `k8s-dojo cluster fleet standby`")
+	}
+	state.Sessions[session] = name
+	delete(f.kubeconfigs, old)
+	err = f.save(state)
+	f.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	if err := f.Manager(old).DeleteCluster(); err != nil {
+		return name, fmt.Errorf("failed to delete cluster %s: %w", old, err)
+	}
+	return name, nil
+}
+
+// standbyPlan returns the clusters to create so that n of them are free.
+func standbyPlan(clusters []string, sessions map[string]string, n int) []string {
+	taken := make(map[string]bool, len(sessions))
+	for _, c := range sessions {
+		taken[c] = true
+	}
+	free, highest := 0, 0
+	for _, c := range clusters {
+		if !taken[c] {
+			free++
+		}
+		if i, err := strconv.Atoi(strings.TrimPrefix(c, fleetPrefix)); err == nil && i > highest {
+			highest = i
+		}
+	}
+
+	var plan []string
+	for ; free < n; free++ {
+		highest++
+		plan = append(plan, FleetClusterName(highest))
+	}
+	return plan
+}
+
+// Destroy deletes every fleet cluster and forgets all assignments.
+func (f *Fleet) Destroy() error {
+	names, err := f.Clusters()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := f.Manager(name).DeleteCluster(); err != nil {
+			return fmt.Errorf("failed to delete cluster %s: %w", name, err)
+		}
+	}
+
+	f.mu.Lock()
+	f.kubeconfigs = map[string]string{}
+	f.mu.Unlock()
+	if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove fleet assignments: %w", err)
+	}
+	return nil
+}
+
+// Kubeconfig returns the kubeconfig of a fleet cluster, fetching it only once.
+func (f *Fleet) Kubeconfig(name string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if kubeconfig, ok := f.kubeconfigs[name]; ok {
+		return kubeconfig, nil
+	}
+	kubeconfig, err := f.Manager(name).kubeconfig()
+	if err != nil {
+		return "", err
+	}
+	f.kubeconfigs[name] = kubeconfig
+	return kubeconfig, nil
+}
+
+// Assignments returns the cluster assigned to each session.
+func (f *Fleet) Assignments() (map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	return state.Sessions, nil
+}
+
+// Assign returns the cluster of session, assigning a free one on first use.
+func (f *Fleet) Assign(session string) (string, error) {
+	names, err := f.Clusters()
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	name, err := assignCluster(state.Sessions, names, session)
+	if err != nil {
+		return "", err
+	}
+	return name, f.save(state)
+}
+
+// Release frees the cluster assigned to session for the next student. The
+// cluster itself, and whatever the session left in it, is kept.
+func (f *Fleet) Release(session string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := state.Sessions[session]; !ok {
+		return fmt.Errorf("session %q has no cluster", session)
+	}
+	delete(state.Sessions, session)
+	return f.save(state)
+}
+
+// assignCluster records and returns the cluster of session in sessions,
+// picking the first of clusters no other session holds.
+func assignCluster(sessions map[string]string, clusters []string, session string) (string, error) {
+	if session == "" {
+		return "", fmt.Errorf("session name is empty")
+	}
+	if c, ok := sessions[session]; ok {
+		return c, nil
+	}
+	if c, ok := freeCluster(sessions, clusters); ok {
+		sessions[session] = c
+		return c, nil
+	}
+	return "", fmt.Errorf("all %d fleet clusters are assigned, create more with `k8s-dojo cluster fleet create`", len(clusters))
+}
+
+// freeCluster returns the first of clusters no session holds.
+func freeCluster(sessions map[string]string, clusters []string) (string, bool) {
+	taken := make(map[string]bool, len(sessions))
+	for _, c := range sessions {
+		taken[c] = true
+	}
+	for _, c := range clusters {
+		if !taken[c] {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+func (f *Fleet) load() (*fleetState, error) {
+	state := &fleetState{Sessions: map[string]string{}}
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fleet assignments: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", f.path, err)
+	}
+	if state.Sessions == nil {
+		state.Sessions = map[string]string{}
+	}
+	return state, nil
+}
+
+func (f *Fleet) save(state *fleetState) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("failed to create fleet directory: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fleet assignments: %w", err)
+	}
+	if err := os.WriteFile(f.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write fleet assignments: %w", err)
+	}
+	return nil
+}
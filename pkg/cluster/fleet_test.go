@@ -0,0 +1,65 @@
+package cluster
+
+import "testing"
+
+func TestIsFleetCluster(t *testing.T) {
+	tests := map[string]bool{
+		FleetClusterName(1):  true,
+		FleetClusterName(12): true,
+		ClusterName:          false,
+		"k8s-dojo-":          false,
+		"k8s-dojo-00":        false,
+		"k8s-dojo-dev":       false,
+		"kind":               false,
+	}
+	for name, want := range tests {
+		if got := isFleetCluster(name); got != want {
+			t.Errorf("isFleetCluster(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestAssignCluster(t *testing.T) {
+	clusters := []string{FleetClusterName(1), FleetClusterName(2)}
+	sessions := map[string]string{}
+
+	alice, err := assignCluster(sessions, clusters, "alice")
+	if err != nil || alice != clusters[0] {
+		t.Fatalf("alice got %q, %v; want %s", alice, err, clusters[0])
+	}
+	bob, err := assignCluster(sessions, clusters, "bob")
+	if err != nil || bob != clusters[1] {
+		t.Fatalf("bob got %q, %v; want %s", bob, err, clusters[1])
+	}
+	if again, _ := assignCluster(sessions, clusters, "alice"); again != alice {
+		t.Errorf("alice reassigned to %q, want %s", again, alice)
+	}
+	if _, err := assignCluster(sessions, clusters, "carol"); err == nil {
+		t.Error("expected an error once every cluster is assigned")
+	}
+
+	delete(sessions, "alice")
+	if carol, err := assignCluster(sessions, clusters, "carol"); err != nil || carol != alice {
+		t.Errorf("carol got %q, %v; want the released %s", carol, err, alice)
+	}
+	if _, err := assignCluster(sessions, clusters, ""); err == nil {
+		t.Error("expected an error for an empty session")
+	}
+}
+
+func TestStandbyPlan(t *testing.T) {
+	clusters := []string{FleetClusterName(1), FleetClusterName(2), FleetClusterName(4)}
+	sessions := map[string]string{"alice": FleetClusterName(1), "bob": FleetClusterName(4)}
+
+	if plan := standbyPlan(clusters, sessions, 1); len(plan) != 0 {
+		t.Errorf("one cluster is already free, got plan %v", plan)
+	}
+	plan := standbyPlan(clusters, sessions, 3)
+	want := []string{FleetClusterName(5), FleetClusterName(6)}
+	if len(plan) != len(want) || plan[0] != want[0] || plan[1] != want[1] {
+		t.Errorf("standbyPlan = %v, want %v", plan, want)
+	}
+	if plan := standbyPlan(nil, nil, 1); len(plan) != 1 || plan[0] != FleetClusterName(1) {
+		t.Errorf("empty fleet: standbyPlan = %v", plan)
+	}
+}
@@ -3,36 +3,85 @@ package cluster
 
 import (
 	"fmt"
+	"os"
 
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 	"sigs.k8s.io/kind/pkg/cluster"
 )
 
 const (
-	// ClusterName is the name of the Kind cluster used by k8s-dojo.
+	// ClusterName is the name of the Kind cluster used by k8s-dojo, unless
+	// ManagerOptions.Name picks another, e.g. for a classroom Fleet.
 	ClusterName = "k8s-dojo"
 )
 
 // Manager handles Kind cluster lifecycle operations.
 type Manager struct {
 	provider *cluster.Provider
+	opts     ManagerOptions
 }
 
-// NewManager creates a new cluster Manager.
+// ManagerOptions configures how clusters are created and reached.
+type ManagerOptions struct {
+	// Name is the Kind cluster to manage (default: ClusterName).
+	Name string
+
+	// InContainer is set when k8s-dojo runs in a container using the host's Docker socket.
+	// The API server then listens on all host interfaces and the kubeconfig points at HostGateway.
+	InContainer bool
+
+	// HostGateway is how the container reaches the Docker host (default: host.docker.internal).
+	HostGateway string
+
+	// Limits constrain the node container. Nil loads them from ~/.k8s-dojo/cluster.json.
+	Limits *Limits
+
+	// SkipPreflight creates the cluster even if the host looks too small.
+	SkipPreflight bool
+
+	// Warn receives preflight warnings. Nil discards them.
+	Warn func(string)
+}
+
+// DefaultManagerOptions detects container mode. K8S_DOJO_HOST_GATEWAY overrides the gateway.
+func DefaultManagerOptions() ManagerOptions {
+	gateway := os.Getenv("K8S_DOJO_HOST_GATEWAY")
+	if gateway == "" {
+		gateway = DefaultHostGateway
+	}
+	return ManagerOptions{InContainer: InContainer(), HostGateway: gateway}
+}
+
+// NewManager creates a new cluster Manager with the default options.
 func NewManager() *Manager {
+	return NewManagerWithOptions(DefaultManagerOptions())
+}
+
+// NewManagerWithOptions creates a new cluster Manager.
+func NewManagerWithOptions(opts ManagerOptions) *Manager {
 	return &Manager{
 		provider: cluster.NewProvider(),
+		opts:     opts,
 	}
 }
 
+// Name returns the name of the managed Kind cluster.
+func (m *Manager) Name() string {
+	if m.opts.Name == "" {
+		return ClusterName
+	}
+	return m.opts.Name
+}
+
 // ClusterExists checks if the k8s-dojo cluster already exists.
 func (m *Manager) ClusterExists() (bool, error) {
 	clusters, err := m.provider.List()
 	if err != nil {
-		return false, fmt.Errorf("failed to list clusters: %w", err)
+		return false, dockerError(fmt.Errorf("failed to list clusters: %w", err))
 	}
 
 	for _, c := range clusters {
-		if c == ClusterName {
+		if c == m.Name() {
 			return true, nil
 		}
 	}
@@ -46,30 +95,113 @@ func (m *Manager) EnsureCluster(version SupportedVersion) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	limits := Limits{}
+	if m.opts.Limits != nil {
+		limits = *m.opts.Limits
+	} else if limits, err = LoadLimits(""); err != nil {
+		return "", err
+	}
 
 	if !exists {
+		if !m.opts.SkipPreflight {
+			report, err := Preflight(limits)
+			if err != nil {
+				return "", err
+			}
+			if err := report.Err(); err != nil {
+				return "", err
+			}
+			for _, w := range report.Warnings {
+				m.warn(w)
+			}
+		}
+
 		err = m.provider.Create(
-			ClusterName,
+			m.Name(),
+			cluster.CreateWithV1Alpha4Config(m.clusterConfig()),
 			cluster.CreateWithNodeImage(version.NodeImage),
 			cluster.CreateWithWaitForReady(0), // Wait indefinitely for cluster to be ready
 			cluster.CreateWithDisplayUsage(false),
 			cluster.CreateWithDisplaySalutation(false),
 		)
 		if err != nil {
-			return "", fmt.Errorf("failed to create cluster: %w", err)
+			return "", dockerError(fmt.Errorf("failed to create cluster: %w", err))
+		}
+	}
+
+	// A paused cluster is resumed rather than recreated
+	if exists {
+		status, err := m.Status()
+		if err != nil {
+			return "", err
 		}
+		if status == StatusPaused {
+			if err := m.Resume(); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	// Limits are reapplied on every start, so edits to cluster.json take effect
+	names, err := m.nodeNames()
+	if err != nil {
+		return "", err
+	}
+	if err := applyLimits(names, limits); err != nil {
+		return "", err
 	}
 
-	// Get kubeconfig (in-memory)
-	kubeconfig, err := m.provider.KubeConfig(ClusterName, false)
+	return m.kubeconfig()
+}
+
+// Reconnect brings an existing cluster back after its node containers stopped,
+// e.g. because Docker restarted, and returns a fresh kubeconfig.
+func (m *Manager) Reconnect() (string, error) {
+	status, err := m.Status()
+	if err != nil {
+		return "", err
+	}
+	switch status {
+	case StatusMissing:
+		return "", fmt.Errorf("cluster %s no longer exists", m.Name())
+	case StatusPaused:
+		if err := m.Resume(); err != nil {
+			return "", err
+		}
+	}
+	return m.kubeconfig()
+}
+
+// kubeconfig returns the cluster's kubeconfig (in-memory), adjusted for container mode.
+func (m *Manager) kubeconfig() (string, error) {
+	kubeconfig, err := m.provider.KubeConfig(m.Name(), false)
 	if err != nil {
 		return "", fmt.Errorf("failed to get kubeconfig: %w", err)
 	}
 
+	if m.opts.InContainer {
+		return ContainerKubeconfig(kubeconfig, m.opts.HostGateway)
+	}
 	return kubeconfig, nil
 }
 
-// DeleteCluster removes the k8s-dojo cluster.
+func (m *Manager) warn(msg string) {
+	if m.opts.Warn != nil {
+		m.opts.Warn(msg)
+	}
+}
+
+// clusterConfig returns the Kind cluster configuration for new clusters.
+func (m *Manager) clusterConfig() *v1alpha4.Cluster {
+	cfg := &v1alpha4.Cluster{}
+	if m.opts.InContainer {
+		// The default 127.0.0.1 is unreachable from other containers via the host gateway
+		cfg.Networking.APIServerAddress = "0.0.0.0"
+	}
+	return cfg
+}
+
+// DeleteCluster removes the managed cluster.
 func (m *Manager) DeleteCluster() error {
 	exists, err := m.ClusterExists()
 	if err != nil {
@@ -80,6 +212,6 @@ func (m *Manager) DeleteCluster() error {
 		return nil // Nothing to delete
 	}
 
-	fmt.Printf("Deleting cluster %s...\n", ClusterName)
-	return m.provider.Delete(ClusterName, "")
+	fmt.Printf("Deleting cluster %s...\n", m.Name())
+	return m.provider.Delete(m.Name(), "")
 }
@@ -0,0 +1,102 @@
+package cluster
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// resumeTimeout bounds how long Resume waits for the API server to come back.
+const resumeTimeout = 2 * time.Minute
+
+// Status is the lifecycle state of the dojo cluster.
+type Status string
+
+const (
+	StatusMissing Status = "missing" // No cluster; it will be created
+	StatusRunning Status = "running"
+	StatusPaused  Status = "paused" // Node containers stopped; resume instead of recreating
+)
+
+// nodeNames returns the names of the cluster's node containers.
+func (m *Manager) nodeNames() ([]string, error) {
+	nodeList, err := m.provider.ListNodes(m.Name())
+	if err != nil {
+		return nil, dockerError(fmt.Errorf("failed to list cluster nodes: %w", err))
+	}
+	names := make([]string, len(nodeList))
+	for i, n := range nodeList {
+		names[i] = n.String()
+	}
+	return names, nil
+}
+
+// Status reports whether the cluster is missing, running or paused.
+func (m *Manager) Status() (Status, error) {
+	names, err := m.nodeNames()
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return StatusMissing, nil
+	}
+
+	args := append([]string{"inspect", "--format", "{{.State.Running}}"}, names...)
+	out, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		return "", dockerError(fmt.Errorf("failed to inspect cluster nodes: %w", err))
+	}
+	for _, running := range strings.Fields(string(out)) {
+		if running != "true" {
+			return StatusPaused, nil
+		}
+	}
+	return StatusRunning, nil
+}
+
+// Pause stops the node containers without deleting them, freeing CPU and memory
+// until Resume. Scenario progress in the cluster is kept.
+func (m *Manager) Pause() error {
+	names, err := m.nodeNames()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("cluster %s does not exist", m.Name())
+	}
+	args := append([]string{"stop"}, names...)
+	if out, err := exec.Command("docker", args...).CombinedOutput(); err != nil {
+		return dockerError(fmt.Errorf("failed to stop cluster nodes: %w: %s", err, strings.TrimSpace(string(out))))
+	}
+	return nil
+}
+
+// Resume starts paused node containers and waits until the API server is ready.
+func (m *Manager) Resume() error {
+	names, err := m.nodeNames()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("cluster %s does not exist", m.Name())
+	}
+	args := append([]string{"start"}, names...)
+	if out, err := exec.Command("docker", args...).CombinedOutput(); err != nil {
+		return dockerError(fmt.Errorf("failed to start cluster nodes: %w: %s", err, strings.TrimSpace(string(out))))
+	}
+
+	controlPlane := m.Name() + "-control-plane"
+	deadline := time.Now().Add(resumeTimeout)
+	for {
+		err := exec.Command("docker", "exec", controlPlane,
+			"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf", "get", "--raw=/readyz").Run()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("API server not ready %s after resume: %w", resumeTimeout, err)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
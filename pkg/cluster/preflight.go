@@ -0,0 +1,217 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Resource thresholds for a single-node Kind cluster running dojo scenarios.
+// Below the minimum, cluster creation is refused; below the recommendation, it warns.
+const (
+	MinCPUs        = 2
+	RecCPUs        = 4
+	MinMemoryBytes = 2 << 30
+	RecMemoryBytes = 4 << 30
+	MinDiskBytes   = 5 << 30
+	RecDiskBytes   = 10 << 30
+)
+
+// Limits constrain the Kind node container so the dojo does not starve the host.
+// Zero values mean unlimited.
+type Limits struct {
+	CPUs   float64 `json:"cpus,omitempty"`   // e.g. 2 or 1.5
+	Memory string  `json:"memory,omitempty"` // Docker notation, e.g. "3g" or "2048m"
+}
+
+// DefaultLimitsPath returns ~/.k8s-dojo/cluster.json.
+func DefaultLimitsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".k8s-dojo", "cluster.json")
+}
+
+// LoadLimits reads node limits from path (default: ~/.k8s-dojo/cluster.json).
+// A missing file means no limits.
+func LoadLimits(path string) (Limits, error) {
+	var l Limits
+	if path == "" {
+		path = DefaultLimitsPath()
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return l, fmt.Errorf("failed to read cluster limits: %w", err)
+	}
+	if err := json.Unmarshal(data, &l); err != nil {
+		return l, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if _, err := l.MemoryBytes(); err != nil {
+		return l, err
+	}
+	return l, nil
+}
+
+// MemoryBytes parses Memory, returning 0 when unset.
+func (l Limits) MemoryBytes() (int64, error) {
+	return parseBytes(l.Memory)
+}
+
+// PreflightReport describes the resources available to the Docker host.
+type PreflightReport struct {
+	CPUs          int      `json:"cpus"`
+	MemoryBytes   int64    `json:"memory_bytes"`
+	DiskFreeBytes int64    `json:"disk_free_bytes,omitempty"` // 0 when the Docker data dir is not local
+	Warnings      []string `json:"warnings,omitempty"`        // Below the recommended size; the dojo may be slow
+	Problems      []string `json:"problems,omitempty"`        // Below the minimum; cluster creation is refused
+}
+
+// Err returns an error listing the problems with guidance, or nil.
+func (r PreflightReport) Err() error {
+	if len(r.Problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("not enough resources to run the dojo cluster:\n  - %s\nFree up resources or raise Docker's limits (Docker Desktop: Settings > Resources), or pass --skip-preflight to try anyway",
+		strings.Join(r.Problems, "\n  - "))
+}
+
+// Preflight checks the CPUs, memory and disk available to Docker against the
+// dojo's minimum and recommended sizes, taking node limits into account.
+func Preflight(limits Limits) (PreflightReport, error) {
+	info, err := dockerInfo()
+	if err != nil {
+		return PreflightReport{}, err
+	}
+	report := PreflightReport{CPUs: info.NCPU, MemoryBytes: info.MemTotal}
+	if localDocker() {
+		report.DiskFreeBytes = diskFree(info.DockerRootDir)
+	}
+	report.evaluate(limits)
+	return report, nil
+}
+
+// evaluate fills in warnings and problems for the measured resources.
+func (r *PreflightReport) evaluate(limits Limits) {
+	cpus := float64(r.CPUs)
+	if limits.CPUs > 0 && limits.CPUs < cpus {
+		cpus = limits.CPUs
+	}
+	memory := r.MemoryBytes
+	if m, _ := limits.MemoryBytes(); m > 0 && m < memory {
+		memory = m
+	}
+
+	switch {
+	case cpus < MinCPUs:
+		r.Problems = append(r.Problems, fmt.Sprintf("%g CPUs available, at least %d needed", cpus, MinCPUs))
+	case cpus < RecCPUs:
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%g CPUs available, %d recommended", cpus, RecCPUs))
+	}
+	switch {
+	case memory < MinMemoryBytes:
+		r.Problems = append(r.Problems, fmt.Sprintf("%s memory available, at least %s needed", formatBytes(memory), formatBytes(MinMemoryBytes)))
+	case memory < RecMemoryBytes:
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%s memory available, %s recommended", formatBytes(memory), formatBytes(RecMemoryBytes)))
+	}
+	if r.DiskFreeBytes > 0 {
+		switch {
+		case r.DiskFreeBytes < MinDiskBytes:
+			r.Problems = append(r.Problems, fmt.Sprintf("%s free disk for Docker, at least %s needed", formatBytes(r.DiskFreeBytes), formatBytes(MinDiskBytes)))
+		case r.DiskFreeBytes < RecDiskBytes:
+			r.Warnings = append(r.Warnings, fmt.Sprintf("%s free disk for Docker, %s recommended", formatBytes(r.DiskFreeBytes), formatBytes(RecDiskBytes)))
+		}
+	}
+}
+
+type dockerInfoResult struct {
+	NCPU          int
+	MemTotal      int64
+	DockerRootDir string
+}
+
+func dockerInfo() (dockerInfoResult, error) {
+	var info dockerInfoResult
+	out, err := exec.Command("docker", "info", "--format", "{{json .}}").Output()
+	if err != nil {
+		return info, fmt.Errorf("%w: failed to query Docker: %w", ErrDockerDown, err)
+	}
+	if err := json.Unmarshal(out, &info); err != nil {
+		return info, fmt.Errorf("failed to parse docker info: %w", err)
+	}
+	return info, nil
+}
+
+// localDocker reports whether the Docker data directory is on this machine's filesystem.
+func localDocker() bool {
+	host := os.Getenv("DOCKER_HOST")
+	return (host == "" || strings.HasPrefix(host, "unix://")) && !InContainer()
+}
+
+func diskFree(path string) int64 {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0
+	}
+	return int64(st.Bavail) * int64(st.Bsize)
+}
+
+// applyLimits constrains the running node containers with docker update.
+func applyLimits(nodes []string, limits Limits) error {
+	if limits.CPUs <= 0 && limits.Memory == "" {
+		return nil
+	}
+	args := []string{"update"}
+	if limits.CPUs > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(limits.CPUs, 'f', -1, 64))
+	}
+	if limits.Memory != "" {
+		// Swap must be limited too, or Docker rejects the new memory limit
+		args = append(args, "--memory", limits.Memory, "--memory-swap", limits.Memory)
+	}
+	args = append(args, nodes...)
+	if out, err := exec.Command("docker", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to apply node limits: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// parseBytes parses Docker-style sizes such as "512m" or "4g" (binary units).
+func parseBytes(s string) (int64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, nil
+	}
+	mult := int64(1)
+	switch s[len(s)-1] {
+	case 'k':
+		mult = 1 << 10
+	case 'm':
+		mult = 1 << 20
+	case 'g':
+		mult = 1 << 30
+	case 'b':
+	default:
+		if s[len(s)-1] < '0' || s[len(s)-1] > '9' {
+			return 0, fmt.Errorf("invalid memory size %q (want e.g. 512m or 4g)", s)
+		}
+		s += "b"
+	}
+	n, err := strconv.ParseFloat(s[:len(s)-1], 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid memory size %q (want e.g. 512m or 4g)", s)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+func formatBytes(n int64) string {
+	return fmt.Sprintf("%.1f GiB", float64(n)/(1<<30))
+}
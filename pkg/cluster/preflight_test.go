@@ -0,0 +1,44 @@
+package cluster
+
+import "testing"
+
+func TestPreflightEvaluate(t *testing.T) {
+	tests := []struct {
+		name               string
+		report             PreflightReport
+		limits             Limits
+		warnings, problems int
+	}{
+		{"roomy", PreflightReport{CPUs: 8, MemoryBytes: 16 << 30, DiskFreeBytes: 100 << 30}, Limits{}, 0, 0},
+		{"small laptop", PreflightReport{CPUs: 2, MemoryBytes: 3 << 30, DiskFreeBytes: 8 << 30}, Limits{}, 3, 0},
+		{"too small", PreflightReport{CPUs: 1, MemoryBytes: 1 << 30, DiskFreeBytes: 1 << 30}, Limits{}, 0, 3},
+		{"remote disk unknown", PreflightReport{CPUs: 8, MemoryBytes: 16 << 30}, Limits{}, 0, 0},
+		{"limited below minimum", PreflightReport{CPUs: 8, MemoryBytes: 16 << 30}, Limits{CPUs: 1, Memory: "3g"}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		r := tt.report
+		r.evaluate(tt.limits)
+		if len(r.Warnings) != tt.warnings || len(r.Problems) != tt.problems {
+			t.Errorf("%s: warnings %v, problems %v; want %d and %d", tt.name, r.Warnings, r.Problems, tt.warnings, tt.problems)
+		}
+		if (r.Err() != nil) != (tt.problems > 0) {
+			t.Errorf("%s: Err() = %v", tt.name, r.Err())
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := map[string]int64{"": 0, "512m": 512 << 20, "4g": 4 << 30, "1.5G": 3 << 29, "1024": 1024, "2k": 2048}
+	for in, want := range tests {
+		got, err := parseBytes(in)
+		if err != nil || got != want {
+			t.Errorf("parseBytes(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"4x", "g", "-1g"} {
+		if _, err := parseBytes(in); err == nil {
+			t.Errorf("parseBytes(%q) succeeded, want error", in)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Usage is what the cluster's node containers consume on the Docker host.
+type Usage struct {
+	CPUPercent      float64 // Of one CPU, so 250 is two and a half cores
+	MemoryBytes     int64
+	HostCPUs        int
+	HostMemoryBytes int64
+	Nodes           []NodeUsage
+}
+
+// NodeUsage is what one node container consumes.
+type NodeUsage struct {
+	Name        string
+	CPUPercent  float64
+	MemoryBytes int64
+}
+
+// HostCPUPercent returns the CPU use as a share of all the host's CPUs.
+func (u Usage) HostCPUPercent() float64 {
+	if u.HostCPUs <= 0 {
+		return 0
+	}
+	return u.CPUPercent / float64(u.HostCPUs)
+}
+
+// HostMemoryPercent returns the memory use as a share of the host's memory.
+func (u Usage) HostMemoryPercent() float64 {
+	if u.HostMemoryBytes <= 0 {
+		return 0
+	}
+	return 100 * float64(u.MemoryBytes) / float64(u.HostMemoryBytes)
+}
+
+// Usage samples the CPU and memory use of the cluster's node containers
+// with docker stats, which takes a second or two.
+func (m *Manager) Usage() (*Usage, error) {
+	names, err := m.nodeNames()
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("cluster %s does not exist", m.Name())
+	}
+
+	args := append([]string{"stats", "--no-stream", "--format", "{{json .}}"}, names...)
+	out, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		return nil, dockerError(fmt.Errorf("failed to read node stats: %w", err))
+	}
+	usage, err := parseStats(out)
+	if err != nil {
+		return nil, err
+	}
+	info, err := dockerInfo()
+	if err != nil {
+		return nil, err
+	}
+	usage.HostCPUs, usage.HostMemoryBytes = info.NCPU, info.MemTotal
+	return usage, nil
+}
+
+// dockerStat is one line of docker stats --format '{{json .}}'.
+type dockerStat struct {
+	Name     string
+	CPUPerc  string // e.g. "12.50%"
+	MemUsage string // e.g. "812.3MiB / 7.66GiB"
+}
+
+// parseStats sums the per-container lines of docker stats.
+func parseStats(out []byte) (*Usage, error) {
+	usage := &Usage{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var stat dockerStat
+		if err := json.Unmarshal(line, &stat); err != nil {
+			return nil, fmt.Errorf("failed to parse docker stats: %w", err)
+		}
+		cpu, err := strconv.ParseFloat(strings.TrimSuffix(stat.CPUPerc, "%"), 64)
+		if err != nil {
+			cpu = 0 // "--" while a container starts
+		}
+		used, _, _ := strings.Cut(stat.MemUsage, "/")
+		mem, err := parseDockerSize(used)
+		if err != nil {
+			return nil, err
+		}
+		usage.Nodes = append(usage.Nodes, NodeUsage{Name: stat.Name, CPUPercent: cpu, MemoryBytes: mem})
+		usage.CPUPercent += cpu
+		usage.MemoryBytes += mem
+	}
+	return usage, scanner.Err()
+}
+
+// dockerSizeUnits are the units docker stats prints sizes in.
+var dockerSizeUnits = []struct {
+	suffix string
+	mult   float64
+}{
+	// Longest suffixes first, so "MiB" isn't read as "B"
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"kB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"B", 1},
+}
+
+// parseDockerSize parses sizes such as "812.3MiB" as printed by docker stats.
+func parseDockerSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "--" {
+		return 0, nil
+	}
+	for _, u := range dockerSizeUnits {
+		if num, ok := strings.CutSuffix(s, u.suffix); ok {
+			v, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid size %q: %w", s, err)
+			}
+			return int64(v * u.mult), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid size %q", s)
+}
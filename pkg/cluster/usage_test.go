@@ -0,0 +1,58 @@
+package cluster
+
+import "testing"
+
+func TestParseStats(t *testing.T) {
+	out := []byte(`{"Name":"k8s-dojo-control-plane","CPUPerc":"37.50%","MemUsage":"1.5GiB / 7.66GiB"}
+{"Name":"k8s-dojo-worker","CPUPerc":"--","MemUsage":"512MiB / 7.66GiB"}
+`)
+	usage, err := parseStats(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage.Nodes) != 2 {
+		t.Fatalf("got %d nodes, want 2", len(usage.Nodes))
+	}
+	if usage.CPUPercent != 37.5 {
+		t.Errorf("CPUPercent = %v, want 37.5", usage.CPUPercent)
+	}
+	if want := int64(1.5*(1<<30) + 512*(1<<20)); usage.MemoryBytes != want {
+		t.Errorf("MemoryBytes = %d, want %d", usage.MemoryBytes, want)
+	}
+
+	usage.HostCPUs, usage.HostMemoryBytes = 4, 8<<30
+	if got := usage.HostCPUPercent(); got != 9.375 {
+		t.Errorf("HostCPUPercent = %v, want 9.375", got)
+	}
+	if got := usage.HostMemoryPercent(); got != 25 {
+		t.Errorf("HostMemoryPercent = %v, want 25", got)
+	}
+}
+
+func TestParseStatsInvalid(t *testing.T) {
+	if _, err := parseStats([]byte("not json")); err == nil {
+		t.Error("parseStats of garbage succeeded")
+	}
+	if _, err := parseStats([]byte(`{"Name":"n","CPUPerc":"1%","MemUsage":"12 parsecs / 1GiB"}`)); err == nil {
+		t.Error("parseStats of an unknown unit succeeded")
+	}
+}
+
+func TestParseDockerSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"812MiB", 812 << 20},
+		{"1.5GiB", 3 << 29},
+		{"900kB", 900_000},
+		{"12B", 12},
+		{"--", 0},
+	}
+	for _, tt := range tests {
+		got, err := parseDockerSize(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseDockerSize(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Package coop lets two learners on one host troubleshoot a scenario together.
+// One shares their dojo under a token; the partner joins with it and follows
+// the sharer's scenario in the same cluster and namespace, both running the
+// checks against it.
+package coop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+// StaleAfter is how long a share or partner may go without an update before
+// it counts as gone.
+const StaleAfter = 30 * time.Second
+
+const tokenLength = 8
+
+// ErrNoShare means no dojo is shared under the token.
+var ErrNoShare = errors.New("no co-op session with this token")
+
+// Share is the state of a shared dojo, published by its owner.
+type Share struct {
+	Token     string    `json:"token"`
+	Owner     string    `json:"owner"` // Who shared, e.g. their session name
+	Cluster   string    `json:"cluster"`
+	Scenario  string    `json:"scenario,omitempty"` // ID of the running scenario, "" between scenarios
+	Namespace string    `json:"namespace,omitempty"`
+	Started   time.Time `json:"started,omitempty"` // Tells attempts of the same scenario apart
+	Check     string    `json:"check,omitempty"`   // Message of the owner's last check
+	Solved    bool      `json:"solved,omitempty"`
+	Updated   time.Time `json:"updated"`
+}
+
+// Stale reports whether the owner stopped publishing before now.
+func (s Share) Stale(now time.Time) bool {
+	return now.Sub(s.Updated) > StaleAfter
+}
+
+// presence is the on-disk form of a partner's heartbeat.
+type presence struct {
+	Name    string    `json:"name"`
+	Updated time.Time `json:"updated"`
+}
+
+// Store is the directory shares are kept in, one file per share and one per
+// partner.
+type Store struct {
+	dir string
+}
+
+// DefaultDir returns ~/.k8s-dojo/coop.
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".k8s-dojo", "coop")
+	}
+	return filepath.Join(home, ".k8s-dojo", "coop")
+}
+
+// NewStore returns the store in dir (default: ~/.k8s-dojo/coop).
+func NewStore(dir string) *Store {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	return &Store{dir: dir}
+}
+
+// Open shares owner's dojo on cluster under a new token.
+func (st *Store) Open(owner, cluster string) (*Share, error) {
+	share := &Share{Token: utilrand.String(tokenLength), Owner: owner, Cluster: cluster}
+	if err := st.Publish(share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// Publish records the share's current state.
+func (st *Store) Publish(share *Share) error {
+	share.Updated = time.Now()
+	return st.write(share.Token, ".json", share)
+}
+
+// Get returns the share with the token, or ErrNoShare once it closed or went stale.
+func (st *Store) Get(token string) (*Share, error) {
+	share := &Share{}
+	if err := st.read(token, ".json", share); err != nil {
+		return nil, err
+	}
+	if share.Stale(time.Now()) {
+		return nil, ErrNoShare
+	}
+	return share, nil
+}
+
+// Close ends the share, e.g. when its owner quits.
+func (st *Store) Close(token string) error {
+	for _, ext := range []string{".json", ".partner"} {
+		path, err := st.path(token, ext)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to close co-op session: %w", err)
+		}
+	}
+	return nil
+}
+
+// Greet tells the share's owner that partner is following along.
+func (st *Store) Greet(token, partner string) error {
+	return st.write(token, ".partner", presence{Name: partner, Updated: time.Now()})
+}
+
+// Partner returns the name of the partner following the share, if one greeted recently.
+func (st *Store) Partner(token string) (string, bool) {
+	var p presence
+	if err := st.read(token, ".partner", &p); err != nil || time.Since(p.Updated) > StaleAfter {
+		return "", false
+	}
+	return p.Name, true
+}
+
+func (st *Store) write(token, ext string, v any) error {
+	path, err := st.path(token, ext)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode co-op state: %w", err)
+	}
+	if err := os.MkdirAll(st.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create co-op directory: %w", err)
+	}
+	// Written aside and renamed, so the other dojo never reads half a file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write co-op state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write co-op state: %w", err)
+	}
+	return nil
+}
+
+func (st *Store) read(token, ext string, v any) error {
+	path, err := st.path(token, ext)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ErrNoShare
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read co-op state: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse co-op state: %w", err)
+	}
+	return nil
+}
+
+// path returns the token's file with the given extension.
+func (st *Store) path(token, ext string) (string, error) {
+	if token == "" || strings.Trim(token, "abcdefghijklmnopqrstuvwxyz0123456789") != "" {
+		return "", fmt.Errorf("invalid co-op token %q", token)
+	}
+	return filepath.Join(st.dir, token+ext), nil
+}
@@ -0,0 +1,74 @@
+package coop
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShareAndJoin(t *testing.T) {
+	st := NewStore(t.TempDir())
+	share, err := st.Open("alice", "k8s-dojo-01")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if len(share.Token) != tokenLength {
+		t.Errorf("got token %q, want %d characters", share.Token, tokenLength)
+	}
+
+	share.Scenario, share.Namespace = "rbac-forbidden", "rbac-forbidden-x7k2p"
+	if err := st.Publish(share); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	got, err := st.Get(share.Token)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Owner != "alice" || got.Cluster != "k8s-dojo-01" || got.Namespace != "rbac-forbidden-x7k2p" {
+		t.Errorf("got %+v", got)
+	}
+
+	if _, ok := st.Partner(share.Token); ok {
+		t.Error("partner reported before anyone joined")
+	}
+	if err := st.Greet(share.Token, "bob"); err != nil {
+		t.Fatalf("Greet: %v", err)
+	}
+	if name, ok := st.Partner(share.Token); !ok || name != "bob" {
+		t.Errorf("Partner = %q, %t, want bob", name, ok)
+	}
+
+	if err := st.Close(share.Token); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := st.Get(share.Token); !errors.Is(err, ErrNoShare) {
+		t.Errorf("Get after Close = %v, want ErrNoShare", err)
+	}
+	if _, ok := st.Partner(share.Token); ok {
+		t.Error("partner reported after Close")
+	}
+}
+
+func TestGetStaleShare(t *testing.T) {
+	st := NewStore(t.TempDir())
+	share, err := st.Open("alice", "k8s-dojo")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	share.Updated = time.Now().Add(-time.Hour)
+	if err := st.write(share.Token, ".json", share); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.Get(share.Token); !errors.Is(err, ErrNoShare) {
+		t.Errorf("Get of a stale share = %v, want ErrNoShare", err)
+	}
+}
+
+func TestInvalidToken(t *testing.T) {
+	st := NewStore(t.TempDir())
+	for _, token := range []string{"", "../x", "ABC", "a.b"} {
+		if _, err := st.Get(token); err == nil || errors.Is(err, ErrNoShare) {
+			t.Errorf("Get(%q) = %v, want an invalid token error", token, err)
+		}
+	}
+}
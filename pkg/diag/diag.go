@@ -0,0 +1,145 @@
+// Package diag provides crash diagnostics: a ring buffer of recent log lines
+// and diagnostic bundles written when the TUI panics.
+package diag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Logs keeps the most recent log lines for crash bundles.
+var Logs = NewRing(500)
+
+// Ring is an io.Writer that keeps the last lines written to it.
+type Ring struct {
+	mu      sync.Mutex
+	lines   []string
+	max     int
+	partial string
+}
+
+// NewRing creates a ring buffer holding up to max lines.
+func NewRing(max int) *Ring {
+	return &Ring{max: max}
+}
+
+// Write implements io.Writer.
+func (r *Ring) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	text := r.partial + string(p)
+	parts := strings.Split(text, "\n")
+	r.partial = parts[len(parts)-1]
+	for _, line := range parts[:len(parts)-1] {
+		r.lines = append(r.lines, line)
+	}
+	if over := len(r.lines) - r.max; over > 0 {
+		r.lines = append([]string(nil), r.lines[over:]...)
+	}
+	return len(p), nil
+}
+
+// Lines returns the buffered lines, oldest first.
+func (r *Ring) Lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines := append([]string(nil), r.lines...)
+	if r.partial != "" {
+		lines = append(lines, r.partial)
+	}
+	return lines
+}
+
+// Section is a titled part of a diagnostic bundle.
+type Section struct {
+	Title string
+	Body  string
+}
+
+// Bundle describes a crash.
+type Bundle struct {
+	Panic    interface{}
+	Stack    []byte
+	Time     time.Time
+	Sections []Section // App state, e.g. the active scenario and cluster info
+}
+
+// Write saves the bundle as a text file in dir and returns its path.
+// If dir is empty, it defaults to ~/.k8s-dojo/crash, falling back to the temp directory.
+func (b Bundle) Write(dir string) (string, error) {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create crash directory: %w", err)
+	}
+	if b.Time.IsZero() {
+		b.Time = time.Now()
+	}
+
+	var s strings.Builder
+	fmt.Fprintf(&s, "k8s-dojo crash report\n\nTime:    %s\nGo:      %s %s/%s\n\n", b.Time.Format(time.RFC3339), runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&s, "== Panic ==\n%v\n\n%s\n", b.Panic, b.Stack)
+	for _, sec := range b.Sections {
+		fmt.Fprintf(&s, "\n== %s ==\n%s\n", sec.Title, strings.TrimRight(sec.Body, "\n"))
+	}
+	fmt.Fprintf(&s, "\n== Recent logs ==\n%s\n", strings.Join(Logs.Lines(), "\n"))
+
+	path := filepath.Join(dir, "crash-"+b.Time.Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(s.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return path, nil
+}
+
+// DefaultDir returns ~/.k8s-dojo/crash, or the temp directory without a home.
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return os.TempDir()
+	}
+	return filepath.Join(home, ".k8s-dojo", "crash")
+}
+
+// ClusterInfo summarizes the server version and nodes, bounded by a short timeout.
+func ClusterInfo(clientset kubernetes.Interface) string {
+	if clientset == nil {
+		return "not connected"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var b strings.Builder
+	if v, err := clientset.Discovery().ServerVersion(); err == nil {
+		fmt.Fprintf(&b, "Server version: %s\n", v.GitVersion)
+	} else {
+		fmt.Fprintf(&b, "Server version: unavailable (%v)\n", err)
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		fmt.Fprintf(&b, "Nodes: unavailable (%v)\n", err)
+		return b.String()
+	}
+	for _, n := range nodes.Items {
+		ready := "NotReady"
+		for _, c := range n.Status.Conditions {
+			if c.Type == "Ready" && c.Status == "True" {
+				ready = "Ready"
+			}
+		}
+		fmt.Fprintf(&b, "Node %s: %s, kubelet %s\n", n.Name, ready, n.Status.NodeInfo.KubeletVersion)
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package diag
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	r := NewRing(2)
+	r.Write([]byte("one\ntwo\nthr"))
+	r.Write([]byte("ee\nfour"))
+
+	got := strings.Join(r.Lines(), ",")
+	if got != "two,three,four" {
+		t.Errorf("Lines() = %q, want %q", got, "two,three,four")
+	}
+}
+
+func TestBundleWrite(t *testing.T) {
+	b := Bundle{
+		Panic:    "boom",
+		Stack:    []byte("goroutine 1 [running]:"),
+		Sections: []Section{{Title: "Active scenario", Body: "ID: net-dns\n"}},
+	}
+	path, err := b.Write(t.TempDir())
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"boom", "goroutine 1", "== Active scenario ==", "ID: net-dns", "== Recent logs =="} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("bundle missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package engine
+
+// AttemptStats counts what happened during the current scenario attempt.
+type AttemptStats struct {
+	Checks    int // Validations run
+	HintsUsed int // Distinct hints revealed
+}
+
+// attempt accumulates AttemptStats from published events. Guarded by attemptMu.
+type attempt struct {
+	checks int
+	hints  map[int]bool
+}
+
+// track updates the attempt counters for an event about to be published.
+func (e *Engine) track(ev Event) {
+	e.attemptMu.Lock()
+	defer e.attemptMu.Unlock()
+	switch ev.Type {
+	case EventScenarioStarted:
+		e.attempt = attempt{hints: make(map[int]bool)}
+	case EventCheckPerformed:
+		e.attempt.checks++
+	case EventHintUsed:
+		if e.attempt.hints == nil {
+			e.attempt.hints = make(map[int]bool)
+		}
+		e.attempt.hints[ev.HintIndex] = true
+	}
+}
+
+// AttemptStats returns the counters for the current attempt. A reset keeps
+// counting, since it continues the same attempt.
+func (e *Engine) AttemptStats() AttemptStats {
+	e.attemptMu.Lock()
+	defer e.attemptMu.Unlock()
+	return AttemptStats{Checks: e.attempt.checks, HintsUsed: len(e.attempt.hints)}
+}
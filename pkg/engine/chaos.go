@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"context"
+
+	"k8s-dojo/pkg/chaos"
+	"k8s-dojo/pkg/scenario"
+)
+
+// SetChaos turns hard mode on for the scenarios started from now on.
+// The controller runs until the scenario is solved or cleaned up; nil turns hard mode off.
+func (e *Engine) SetChaos(opts *chaos.Options) {
+	e.chaosOpts = opts
+}
+
+// startChaos launches the chaos controller for a freshly started scenario.
+// It is scoped to the scenario's namespace and never touches anything else.
+func (e *Engine) startChaos(s scenario.Scenario) {
+	e.stopChaos()
+	if e.chaosOpts == nil || e.clientset == nil {
+		return
+	}
+
+	opts := *e.chaosOpts
+	id := s.GetMetadata().ID
+	onInject := opts.OnInject
+	opts.OnInject = func(inj chaos.Injection) {
+		e.events.Publish(Event{Type: EventChaosInjected, ScenarioID: id, Time: inj.Time, Detail: inj.Description})
+		if onInject != nil {
+			onInject(inj)
+		}
+	}
+
+	ctrl, err := chaos.New(e.clientset, s.GetNamespace(), opts)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e.mu.Lock()
+	e.chaosStop = cancel
+	e.mu.Unlock()
+	go ctrl.Run(ctx)
+}
+
+// stopChaos stops the running chaos controller, if any.
+func (e *Engine) stopChaos() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.chaosStop != nil {
+		e.chaosStop()
+		e.chaosStop = nil
+	}
+}
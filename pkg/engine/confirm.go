@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"fmt"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// DefaultConfirmChecks is how many consecutive checks must pass before a fix counts.
+// A crash-looping pod is often briefly Running; one passing check isn't proof.
+const DefaultConfirmChecks = 3
+
+// SetConfirmChecks sets how many consecutive checks must pass before a
+// scenario is solved. Values below 1 mean the first passing check counts.
+func (e *Engine) SetConfirmChecks(n int) {
+	if n < 1 {
+		n = 1
+	}
+	e.confirmMu.Lock()
+	defer e.confirmMu.Unlock()
+	e.confirmChecks = n
+}
+
+// Confirming returns how many consecutive checks have passed for a fix that
+// isn't confirmed yet, and how many are needed. passed is 0 when no fix is
+// being confirmed.
+func (e *Engine) Confirming() (passed, needed int) {
+	e.confirmMu.Lock()
+	defer e.confirmMu.Unlock()
+	return e.confirmStreak, e.confirmChecks
+}
+
+// confirm damps a passing result until it has held for the configured number
+// of consecutive checks. Until then, it is reported as not solved yet.
+func (e *Engine) confirm(result scenario.Result) scenario.Result {
+	e.confirmMu.Lock()
+	defer e.confirmMu.Unlock()
+	if !result.Solved {
+		e.confirmStreak = 0
+		return result
+	}
+	e.confirmStreak++
+	if e.confirmStreak >= e.confirmChecks {
+		e.confirmStreak = 0
+		return result
+	}
+	result.Solved = false
+	result.Message = fmt.Sprintf("Confirming fix… (%d/%d checks passed in a row)", e.confirmStreak, e.confirmChecks)
+	return result
+}
+
+// resetConfirm forgets a fix being confirmed, e.g. when the scenario restarts.
+func (e *Engine) resetConfirm() {
+	e.confirmMu.Lock()
+	defer e.confirmMu.Unlock()
+	e.confirmStreak = 0
+}
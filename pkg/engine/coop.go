@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// ErrJoined means the current scenario belongs to a co-op partner, who alone
+// may reset or clean it up.
+var ErrJoined = errors.New("the scenario belongs to your co-op partner")
+
+// Join runs a scenario a co-op partner set up in namespace, as if it had
+// started at started. Setup is skipped, and since the partner owns the
+// scenario's resources, Cleanup and BeginCleanup leave them in place and
+// ResetScenario is refused.
+func (e *Engine) Join(id, namespace string, started time.Time) error {
+	s := e.registry.Get(id)
+	if s == nil {
+		return fmt.Errorf("scenario not found: %s", id)
+	}
+	if e.currentScenario != nil && !e.Joined() {
+		return fmt.Errorf("scenario %s is still running", e.currentScenario.GetMetadata().ID)
+	}
+	e.Detach()
+
+	if alloc, ok := s.(scenario.NamespaceAllocator); ok {
+		alloc.UseNamespace(namespace)
+	} else if s.GetNamespace() != namespace {
+		return fmt.Errorf("scenario %s can't run in namespace %s", id, namespace)
+	}
+	if started.IsZero() {
+		started = time.Now()
+	}
+
+	e.snapshot = nil
+	e.currentScenario = s
+	e.state = StateRunning
+	e.startTime = started
+	e.resetPause()
+	e.resetGuide(s)
+	e.SubmitAnswer("")
+	e.resetConfirm()
+	e.forgetCheck()
+	e.setJoined(true)
+
+	e.publish(EventScenarioStarted, nil)
+	return nil
+}
+
+// Joined reports whether the current scenario was joined from a co-op partner.
+func (e *Engine) Joined() bool {
+	e.joinMu.Lock()
+	defer e.joinMu.Unlock()
+	return e.joined
+}
+
+func (e *Engine) setJoined(joined bool) {
+	e.joinMu.Lock()
+	e.joined = joined
+	e.joinMu.Unlock()
+}
+
+// forgetJoin ends a joined scenario, pointing it back at its own namespaces
+// so a later attempt of the learner's own can't touch the partner's.
+func (e *Engine) forgetJoin() {
+	if !e.Joined() {
+		return
+	}
+	if alloc, ok := e.currentScenario.(scenario.NamespaceAllocator); ok {
+		alloc.UseNamespace(alloc.BaseNamespace())
+	}
+	e.setJoined(false)
+}
@@ -0,0 +1,29 @@
+package engine
+
+import (
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// MinCheckInterval is the least time between two validations of a scenario.
+// Repeated key presses and the auto-check tick would otherwise stack API calls.
+const MinCheckInterval = time.Second
+
+// checkCall is one run of Check, shared with callers that arrive while it runs.
+type checkCall struct {
+	done   chan struct{} // Closed once result and err are set
+	result scenario.Result
+	err    error
+	at     time.Time // When the check finished
+	gen    int       // checkGen when the check started
+}
+
+// forgetCheck drops the cached result, so the next Check validates again.
+// A check already running still completes for its callers but isn't cached.
+func (e *Engine) forgetCheck() {
+	e.checkMu.Lock()
+	defer e.checkMu.Unlock()
+	e.lastCheck = nil
+	e.checkGen++
+}
@@ -4,9 +4,17 @@ package engine
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	"k8s-dojo/pkg/chaos"
+	"k8s-dojo/pkg/k8s"
 	"k8s-dojo/pkg/scenario"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
 )
 
 // State represents the current state of the game.
@@ -19,20 +27,113 @@ const (
 	StateCleaning  State = "cleaning"
 )
 
+// ReadyTimeout bounds how long StartScenario waits for a scenario's readiness gate.
+const ReadyTimeout = 2 * time.Minute
+
+// CleanupTimeout bounds how long a background cleanup waits for its namespace to disappear.
+const CleanupTimeout = 3 * time.Minute
+
 // Engine manages the lifecycle of scenarios.
 type Engine struct {
 	registry        *scenario.Registry
+	clientset       kubernetes.Interface
 	currentScenario scenario.Scenario
 	state           State
 	startTime       time.Time
+	events          *EventBus
+
+	snapshotter Snapshotter
+	snapshot    *k8s.Snapshot // Initial state of the current scenario
+
+	chaosOpts *chaos.Options     // Hard mode, nil when off
+	chaosStop context.CancelFunc // Stops the running chaos controller; guarded by mu
+
+	mu      sync.Mutex
+	pending map[string]chan struct{} // Scenario ID -> closed when its cleanup finishes
+
+	guideMu   sync.Mutex
+	guide     []scenario.GuideStep
+	guideStep int
+
+	attemptMu sync.Mutex
+	attempt   attempt
+
+	answerMu sync.Mutex
+	answer   string // Submitted answer to a quiz scenario
+
+	confirmMu     sync.Mutex
+	confirmChecks int // Consecutive passing checks that confirm a fix
+	confirmStreak int // Consecutive passing checks so far
+
+	checkMu   sync.Mutex
+	inflight  *checkCall // Check being run, nil when idle
+	lastCheck *checkCall // Last finished check, reused within MinCheckInterval
+	checkGen  int        // Bumped when cached results go stale
+
+	pauseMu sync.Mutex
+	pause   pauseClock
+
+	progress chan string // Setup steps of the scenario being started
+
+	joinMu sync.Mutex
+	joined bool // Current scenario is a co-op partner's, see Join
 }
 
 // NewEngine creates a new game engine.
-func NewEngine(registry *scenario.Registry) *Engine {
+// The clientset is used to confirm namespaces are gone after cleanup; it may be nil.
+func NewEngine(registry *scenario.Registry, clientset kubernetes.Interface) *Engine {
 	return &Engine{
-		registry: registry,
-		state:    StateIdle,
+		registry:  registry,
+		clientset: clientset,
+		state:     StateIdle,
+		events:    NewEventBus(),
+		pending:   make(map[string]chan struct{}),
+		progress:  make(chan string, progressBuffer),
+
+		confirmChecks: DefaultConfirmChecks,
+	}
+}
+
+// Events returns the engine's event bus.
+func (e *Engine) Events() *EventBus {
+	return e.events
+}
+
+// Subscribe registers a handler for engine events and returns an unsubscribe function.
+func (e *Engine) Subscribe(h Handler) func() {
+	return e.events.Subscribe(h)
+}
+
+// Use registers a plugin on the engine's event bus.
+func (e *Engine) Use(p Plugin) {
+	p.Register(e.events)
+}
+
+// RecordHint notes that the user revealed the hint at the given index.
+func (e *Engine) RecordHint(index int) {
+	if e.currentScenario == nil {
+		return
+	}
+	e.publish(EventHintUsed, func(ev *Event) {
+		ev.HintIndex = index
+	})
+}
+
+// publish emits an event for the current scenario.
+func (e *Engine) publish(t EventType, fill func(*Event)) {
+	ev := Event{
+		Type:    t,
+		Time:    time.Now(),
+		Elapsed: e.GetElapsedTime(),
+	}
+	if e.currentScenario != nil {
+		ev.ScenarioID = e.currentScenario.GetMetadata().ID
 	}
+	if fill != nil {
+		fill(&ev)
+	}
+	e.track(ev)
+	e.events.Publish(ev)
 }
 
 // ListScenarios returns all available scenarios.
@@ -47,33 +148,128 @@ func (e *Engine) StartScenario(ctx context.Context, id string) error {
 		return fmt.Errorf("scenario not found: %s", id)
 	}
 
+	e.stopChaos()
+	if e.Joined() {
+		e.Detach() // A partner's resources are theirs to clean up
+	}
+
+	// Don't reuse a scenario while its previous attempt is still being torn down
+	if err := e.waitPending(ctx, id); err != nil {
+		return fmt.Errorf("previous cleanup still in progress: %w", err)
+	}
+
+	e.drainProgress()
+	ctx = k8s.WithProgress(ctx, e.reportProgress)
+
 	// Ensure clean slate by cleaning up any previous state
 	fmt.Printf("Ensuring clean state for scenario: %s\n", s.GetMetadata().Name)
+	k8s.ReportProgress(ctx, "Cleaning up any previous attempt…")
 	// We ignore the error here because it's likely "not found" if the scenario wasn't running
 	_ = s.Cleanup(ctx)
 
+	// Use a fresh namespace so a previous attempt that is still terminating can't interfere
+	if alloc, ok := s.(scenario.NamespaceAllocator); ok {
+		alloc.AllocateNamespace()
+	}
+
 	// Setup the scenario
 	fmt.Printf("Setting up scenario: %s\n", s.GetMetadata().Name)
+	k8s.ReportProgress(ctx, "Creating resources…")
 	if err := s.Setup(ctx); err != nil {
-		return fmt.Errorf("failed to setup scenario: %w", err)
+		return fmt.Errorf("failed to setup scenario: %w", e.classifySetupError(ctx, s, err))
 	}
 
+	// Wait until the broken state is visible before presenting it
+	if gate, ok := s.(scenario.ReadinessGate); ok {
+		k8s.ReportProgress(ctx, "Waiting for the broken state to show…")
+		readyCtx, cancel := context.WithTimeout(ctx, ReadyTimeout)
+		err := gate.WaitReady(readyCtx)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("scenario did not reach its initial state: %w", e.classifyReadyError(ctx, s, err))
+		}
+	}
+
+	e.capture(ctx, s)
 	e.currentScenario = s
 	e.state = StateRunning
 	e.startTime = time.Now()
+	e.resetPause()
+	e.resetGuide(s)
+	e.SubmitAnswer("")
+	e.resetConfirm()
+	e.forgetCheck()
+
+	e.publish(EventScenarioStarted, nil)
+	e.startChaos(s)
 
 	return nil
 }
 
 // Check validates if the current scenario is solved.
+// Calls made while a check is running share its result, and calls within
+// MinCheckInterval of the last check reuse that one instead of validating again.
 func (e *Engine) Check(ctx context.Context) (scenario.Result, error) {
+	for {
+		e.checkMu.Lock()
+		call := e.inflight
+		if call == nil {
+			break
+		}
+		current := call.gen == e.checkGen // Started before a reset or new answer otherwise
+		e.checkMu.Unlock()
+		<-call.done
+		if current {
+			return call.result, call.err
+		}
+	}
+	if last := e.lastCheck; last != nil && time.Since(last.at) < MinCheckInterval {
+		e.checkMu.Unlock()
+		return last.result, last.err
+	}
+	call := &checkCall{done: make(chan struct{}), gen: e.checkGen}
+	e.inflight = call
+	e.checkMu.Unlock()
+
+	call.result, call.err = e.check(ctx)
+	call.at = time.Now()
+
+	e.checkMu.Lock()
+	e.inflight = nil
+	if call.gen == e.checkGen {
+		e.lastCheck = call
+	}
+	e.checkMu.Unlock()
+	close(call.done)
+	return call.result, call.err
+}
+
+// check runs the current scenario's validation once.
+func (e *Engine) check(ctx context.Context) (scenario.Result, error) {
 	if e.currentScenario == nil {
 		return scenario.Result{}, fmt.Errorf("no scenario is running")
 	}
 
 	result := e.currentScenario.Validate(ctx)
+	if q, ok := e.currentScenario.(scenario.Quiz); ok {
+		result = scenario.GradeAnswer(ctx, q, result, e.Answer())
+	}
+	if e.state != StateValidated {
+		result = e.confirm(result)
+	}
+	e.publish(EventCheckPerformed, func(ev *Event) {
+		ev.Result = &result
+	})
+
 	if result.Solved {
+		firstSolve := e.state != StateValidated
 		e.state = StateValidated
+		if firstSolve {
+			e.stopChaos()
+			e.publish(EventSolved, func(ev *Event) {
+				ev.Result = &result
+			})
+		}
 	}
 
 	return result, nil
@@ -84,6 +280,10 @@ func (e *Engine) Cleanup(ctx context.Context) error {
 	if e.currentScenario == nil {
 		return nil
 	}
+	if e.Joined() {
+		e.Detach()
+		return nil
+	}
 
 	e.state = StateCleaning
 	fmt.Printf("Cleaning up scenario: %s\n", e.currentScenario.GetMetadata().Name)
@@ -92,12 +292,164 @@ func (e *Engine) Cleanup(ctx context.Context) error {
 		return fmt.Errorf("failed to cleanup scenario: %w", err)
 	}
 
+	e.publish(EventCleanedUp, nil)
+
+	e.stopChaos()
 	e.currentScenario = nil
+	e.snapshot = nil
 	e.state = StateIdle
+	e.resetGuide(nil)
 
 	return nil
 }
 
+// Abandon stops the current scenario without cleaning it up, leaving its
+// resources in the cluster for inspection.
+func (e *Engine) Abandon() {
+	if e.currentScenario == nil {
+		return
+	}
+	e.publish(EventAbandoned, nil)
+
+	e.stopChaos()
+	e.forgetJoin()
+	e.currentScenario = nil
+	e.snapshot = nil
+	e.state = StateIdle
+	e.resetGuide(nil)
+}
+
+// BeginCleanup detaches the current scenario and returns a function that removes its
+// resources and waits until its namespace is fully deleted. The function is safe to run
+// in the background; StartScenario for the same scenario blocks until it completes.
+// It returns an empty ID and nil function when no scenario is running.
+func (e *Engine) BeginCleanup() (string, func(ctx context.Context) error) {
+	d := e.Detach()
+	if d == nil {
+		return "", nil
+	}
+	return d.ScenarioID(), d.BeginCleanup()
+}
+
+// Detached is a scenario that is no longer current but whose resources are
+// still in the cluster.
+type Detached struct {
+	engine   *Engine
+	scenario scenario.Scenario
+	elapsed  time.Duration // Time spent in the scenario, excluding teardown
+	joined   bool          // Owned by a co-op partner, so never cleaned up here
+}
+
+// Detach stops tracking the current scenario without removing its resources,
+// e.g. so the user can keep exploring the fixed system. It returns nil when no
+// scenario is running.
+func (e *Engine) Detach() *Detached {
+	s := e.currentScenario
+	if s == nil {
+		return nil
+	}
+	d := &Detached{engine: e, scenario: s, elapsed: e.GetElapsedTime(), joined: e.Joined()}
+
+	e.stopChaos()
+	e.forgetJoin()
+	e.currentScenario = nil
+	e.snapshot = nil
+	e.state = StateIdle
+	e.resetGuide(nil)
+
+	return d
+}
+
+// ScenarioID returns the detached scenario's ID.
+func (d *Detached) ScenarioID() string {
+	return d.scenario.GetMetadata().ID
+}
+
+// Scenario returns the detached scenario.
+func (d *Detached) Scenario() scenario.Scenario {
+	return d.scenario
+}
+
+// BeginCleanup marks the scenario as cleaning up and returns the function that
+// does it, as Engine.BeginCleanup.
+func (d *Detached) BeginCleanup() func(ctx context.Context) error {
+	if d.joined {
+		return func(ctx context.Context) error { return nil }
+	}
+	e, s, id := d.engine, d.scenario, d.ScenarioID()
+	done := make(chan struct{})
+
+	e.mu.Lock()
+	e.pending[id] = done
+	e.mu.Unlock()
+
+	return func(ctx context.Context) error {
+		defer func() {
+			e.mu.Lock()
+			delete(e.pending, id)
+			e.mu.Unlock()
+			close(done)
+		}()
+
+		if err := s.Cleanup(ctx); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to cleanup scenario: %w", err)
+		}
+		if err := e.waitNamespaceGone(ctx, s.GetNamespace()); err != nil {
+			return fmt.Errorf("failed waiting for namespace %s: %w", s.GetNamespace(), err)
+		}
+
+		e.events.Publish(Event{Type: EventCleanedUp, ScenarioID: id, Elapsed: d.elapsed})
+		return nil
+	}
+}
+
+// CleaningUp returns the IDs of scenarios whose cleanup is still running.
+func (e *Engine) CleaningUp() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ids := make([]string, 0, len(e.pending))
+	for id := range e.pending {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// waitPending blocks until a background cleanup of the scenario finishes.
+func (e *Engine) waitPending(ctx context.Context, id string) error {
+	e.mu.Lock()
+	done, ok := e.pending[id]
+	e.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitNamespaceGone polls until the namespace no longer exists.
+func (e *Engine) waitNamespaceGone(ctx context.Context, namespace string) error {
+	if e.clientset == nil || namespace == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, CleanupTimeout)
+	defer cancel()
+
+	return wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		_, err := e.clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, nil
+	})
+}
+
 // GetState returns the current state.
 func (e *Engine) GetState() State {
 	return e.state
@@ -108,10 +460,19 @@ func (e *Engine) GetCurrentScenario() scenario.Scenario {
 	return e.currentScenario
 }
 
-// GetElapsedTime returns how long the current scenario has been running.
+// GetElapsedTime returns how long the current scenario has been running,
+// not counting time it was paused.
 func (e *Engine) GetElapsedTime() time.Duration {
 	if e.state == StateIdle || e.startTime.IsZero() {
 		return 0
 	}
-	return time.Since(e.startTime)
+	return time.Since(e.startTime) - e.pausedTime()
+}
+
+// StartedAt returns when the current scenario started, zero when none is running.
+func (e *Engine) StartedAt() time.Time {
+	if e.currentScenario == nil {
+		return time.Time{}
+	}
+	return e.startTime
 }
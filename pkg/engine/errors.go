@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s-dojo/pkg/scenario"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// ErrImagePullTimeout means a scenario didn't reach its broken state in
+	// time because its images were still downloading.
+	ErrImagePullTimeout = errors.New("images are still being pulled")
+
+	// ErrNamespaceTerminating means a scenario's namespace from an earlier
+	// attempt is still being deleted.
+	ErrNamespaceTerminating = errors.New("namespace is still terminating")
+)
+
+// pullingReasons are the waiting reasons of containers whose image isn't there yet.
+var pullingReasons = map[string]bool{
+	"ContainerCreating": true,
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+}
+
+// classifySetupError marks a Setup failure caused by the scenario's
+// namespace still terminating with ErrNamespaceTerminating.
+func (e *Engine) classifySetupError(ctx context.Context, s scenario.Scenario, err error) error {
+	if apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
+		return fmt.Errorf("%w: %w", ErrNamespaceTerminating, err)
+	}
+	if apierrors.IsAlreadyExists(err) {
+		ns, getErr := e.clientset.CoreV1().Namespaces().Get(ctx, s.GetNamespace(), metav1.GetOptions{})
+		if getErr == nil && ns.Status.Phase == corev1.NamespaceTerminating {
+			return fmt.Errorf("%w: %w", ErrNamespaceTerminating, err)
+		}
+	}
+	return err
+}
+
+// classifyReadyError marks a readiness timeout with ErrImagePullTimeout when
+// the scenario's pods are still waiting for their images.
+func (e *Engine) classifyReadyError(ctx context.Context, s scenario.Scenario, err error) error {
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	pods, listErr := e.clientset.CoreV1().Pods(s.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if listErr != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if cs.State.Waiting != nil && pullingReasons[cs.State.Waiting.Reason] {
+				return fmt.Errorf("%w (pod %s is %s): %w", ErrImagePullTimeout, pod.Name, cs.State.Waiting.Reason, err)
+			}
+		}
+	}
+	return err
+}
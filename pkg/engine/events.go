@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// EventType identifies what happened in the engine.
+type EventType string
+
+const (
+	EventScenarioStarted EventType = "scenario_started"
+	EventCheckPerformed  EventType = "check_performed"
+	EventHintUsed        EventType = "hint_used"
+	EventGuideStepDone   EventType = "guide_step_done"
+	EventSolved          EventType = "solved"
+	EventScenarioReset   EventType = "scenario_reset"
+	EventChaosInjected   EventType = "chaos_injected"
+	EventCleanedUp       EventType = "cleaned_up"
+	EventAbandoned       EventType = "abandoned" // Left running without cleanup
+	EventPaused          EventType = "paused"
+	EventResumed         EventType = "resumed"
+)
+
+// Event describes a single engine lifecycle event.
+type Event struct {
+	Type       EventType
+	ScenarioID string
+	Time       time.Time
+	Elapsed    time.Duration    // Time since the scenario started, less pauses
+	Result     *scenario.Result // Set for CheckPerformed and Solved
+	HintIndex  int              // Set for HintUsed
+	StepIndex  int              // Set for GuideStepDone
+	Detail     string           // Set for ChaosInjected
+}
+
+// Handler receives engine events.
+// Handlers are invoked synchronously and should offload slow work.
+type Handler func(Event)
+
+// Plugin is a feature that hooks into the engine by subscribing to its events.
+type Plugin interface {
+	// Name returns a short identifier for the plugin.
+	Name() string
+
+	// Register subscribes the plugin's handlers on the bus.
+	Register(bus *EventBus)
+}
+
+type subscription struct {
+	id      int
+	handler Handler
+}
+
+// EventBus dispatches engine events to registered handlers.
+type EventBus struct {
+	mu     sync.RWMutex
+	subs   []subscription
+	nextID int
+}
+
+// NewEventBus creates an empty event bus.
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe registers a handler and returns a function that removes it.
+func (b *EventBus) Subscribe(h Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.subs = append(b.subs, subscription{id: id, handler: h})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, s := range b.subs {
+			if s.id == id {
+				b.subs = append(b.subs[:i], b.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish delivers an event to all handlers in registration order.
+func (b *EventBus) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := make([]Handler, len(b.subs))
+	for i, s := range b.subs {
+		handlers[i] = s.handler
+	}
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(e)
+	}
+}
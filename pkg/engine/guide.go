@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"context"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// resetGuide loads the guided steps of s, or clears them when s is nil or not guided.
+func (e *Engine) resetGuide(s scenario.Scenario) {
+	var steps []scenario.GuideStep
+	if g, ok := s.(scenario.Guided); ok {
+		steps = g.Guide()
+	}
+
+	e.guideMu.Lock()
+	e.guide = steps
+	e.guideStep = 0
+	e.guideMu.Unlock()
+}
+
+// Guide returns the running scenario's guided steps and the index of the active one.
+// The index equals len(steps) once every step is done; steps is empty for unguided scenarios.
+func (e *Engine) Guide() ([]scenario.GuideStep, int) {
+	e.guideMu.Lock()
+	defer e.guideMu.Unlock()
+	return e.guide, e.guideStep
+}
+
+// CheckGuide verifies the active step and advances past every step that is complete.
+// Steps without verification stay active until AdvanceGuide is called.
+// It returns the index of the active step.
+func (e *Engine) CheckGuide(ctx context.Context) int {
+	for {
+		steps, current := e.Guide()
+		if current >= len(steps) {
+			return current
+		}
+		verify := steps[current].Verify
+		if verify == nil || !verify(ctx).Passed {
+			return current
+		}
+		e.completeStep(current)
+	}
+}
+
+// AdvanceGuide completes the active step if it is one the learner advances manually.
+// It returns the index of the active step.
+func (e *Engine) AdvanceGuide() int {
+	steps, current := e.Guide()
+	if current < len(steps) && steps[current].Verify == nil {
+		e.completeStep(current)
+	}
+	_, current = e.Guide()
+	return current
+}
+
+// completeStep marks step index as done if it is still the active one.
+func (e *Engine) completeStep(index int) {
+	e.guideMu.Lock()
+	if e.guideStep != index {
+		e.guideMu.Unlock()
+		return
+	}
+	e.guideStep++
+	e.guideMu.Unlock()
+
+	e.publish(EventGuideStepDone, func(ev *Event) {
+		ev.StepIndex = index
+	})
+}
@@ -0,0 +1,74 @@
+package engine
+
+import "time"
+
+// pauseClock tracks time the learner stepped away from the current scenario.
+// Guarded by pauseMu.
+type pauseClock struct {
+	since time.Time     // Start of the current pause, zero when running
+	total time.Duration // Finished pauses
+}
+
+// Pause stops the scenario clock as of at, e.g. the learner's last input when
+// pausing for inactivity. Paused time doesn't count towards the solve time, so
+// it doesn't cost par-time points. Pausing twice keeps the first pause.
+func (e *Engine) Pause(at time.Time) {
+	if e.currentScenario == nil || e.state != StateRunning {
+		return
+	}
+	e.pauseMu.Lock()
+	if !e.pause.since.IsZero() {
+		e.pauseMu.Unlock()
+		return
+	}
+	if at.Before(e.startTime) {
+		at = e.startTime
+	}
+	if now := time.Now(); at.After(now) {
+		at = now
+	}
+	e.pause.since = at
+	e.pauseMu.Unlock()
+	e.publish(EventPaused, nil)
+}
+
+// Resume restarts the scenario clock after Pause and returns how long the
+// pause lasted.
+func (e *Engine) Resume() time.Duration {
+	e.pauseMu.Lock()
+	if e.pause.since.IsZero() {
+		e.pauseMu.Unlock()
+		return 0
+	}
+	away := time.Since(e.pause.since)
+	e.pause.total += away
+	e.pause.since = time.Time{}
+	e.pauseMu.Unlock()
+	e.publish(EventResumed, nil)
+	return away
+}
+
+// Paused reports whether the scenario clock is paused.
+func (e *Engine) Paused() bool {
+	e.pauseMu.Lock()
+	defer e.pauseMu.Unlock()
+	return !e.pause.since.IsZero()
+}
+
+// pausedTime returns how long the current scenario has been paused in total.
+func (e *Engine) pausedTime() time.Duration {
+	e.pauseMu.Lock()
+	defer e.pauseMu.Unlock()
+	d := e.pause.total
+	if !e.pause.since.IsZero() {
+		d += time.Since(e.pause.since)
+	}
+	return d
+}
+
+// resetPause clears the pause state for a freshly started scenario.
+func (e *Engine) resetPause() {
+	e.pauseMu.Lock()
+	defer e.pauseMu.Unlock()
+	e.pause = pauseClock{}
+}
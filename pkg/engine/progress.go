@@ -0,0 +1,31 @@
+package engine
+
+// progressBuffer is how many setup steps are kept for a slow reader. Further
+// steps are dropped until it catches up.
+const progressBuffer = 32
+
+// Progress returns the setup steps of the scenario being started, such as
+// "Creating deployment…" or "Waiting for pod api-7d9 (ImagePullBackOff)…",
+// for showing while StartScenario runs.
+func (e *Engine) Progress() <-chan string {
+	return e.progress
+}
+
+// reportProgress publishes a setup step without blocking StartScenario.
+func (e *Engine) reportProgress(step string) {
+	select {
+	case e.progress <- step:
+	default:
+	}
+}
+
+// drainProgress discards steps left over from an earlier start.
+func (e *Engine) drainProgress() {
+	for {
+		select {
+		case <-e.progress:
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package engine
+
+import "strings"
+
+// SubmitAnswer records the learner's answer to the running quiz scenario.
+// It is graded by the next Check.
+func (e *Engine) SubmitAnswer(answer string) {
+	e.answerMu.Lock()
+	e.answer = strings.TrimSpace(answer)
+	e.answerMu.Unlock()
+	e.forgetCheck() // The last result was graded without this answer
+}
+
+// Answer returns the submitted answer, or "" when none was submitted this attempt.
+func (e *Engine) Answer() string {
+	e.answerMu.Lock()
+	defer e.answerMu.Unlock()
+	return e.answer
+}
@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/scenario"
+)
+
+// Snapshotter captures and restores the objects in a namespace.
+// *k8s.Client implements it.
+type Snapshotter interface {
+	Snapshot(ctx context.Context, namespace string) (*k8s.Snapshot, error)
+	Restore(ctx context.Context, snap *k8s.Snapshot) error
+}
+
+// EnableSnapshots captures each scenario's initial state once it is ready,
+// so ResetScenario can restore it in place.
+func (e *Engine) EnableSnapshots(s Snapshotter) {
+	e.snapshotter = s
+}
+
+// CanReset reports whether the current scenario has a snapshot to reset to.
+func (e *Engine) CanReset() bool {
+	return e.currentScenario != nil && e.snapshot != nil
+}
+
+// capture records the initial state of a freshly started scenario.
+// Scenarios without a snapshot fall back to a full restart.
+func (e *Engine) capture(ctx context.Context, s scenario.Scenario) {
+	e.snapshot = nil
+	if e.snapshotter == nil || s.GetNamespace() == "" {
+		return
+	}
+	if snap, err := e.snapshotter.Snapshot(ctx, s.GetNamespace()); err == nil {
+		e.snapshot = snap
+	}
+}
+
+// ResetScenario restores the current scenario's initial broken state in place,
+// reverting the user's edits without deleting its namespace. The timer keeps running.
+func (e *Engine) ResetScenario(ctx context.Context) error {
+	s := e.currentScenario
+	if s == nil {
+		return fmt.Errorf("no scenario is running")
+	}
+	if e.Joined() {
+		return ErrJoined
+	}
+	if e.snapshot == nil {
+		return fmt.Errorf("scenario %s has no snapshot to reset to", s.GetMetadata().ID)
+	}
+
+	if err := e.snapshotter.Restore(ctx, e.snapshot); err != nil {
+		return fmt.Errorf("failed to reset scenario: %w", err)
+	}
+	if gate, ok := s.(scenario.ReadinessGate); ok {
+		readyCtx, cancel := context.WithTimeout(ctx, ReadyTimeout)
+		err := gate.WaitReady(readyCtx)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("scenario did not return to its initial state: %w", err)
+		}
+	}
+
+	e.state = StateRunning
+	e.resetGuide(s)
+	e.SubmitAnswer("") // The reset state may change the right answer
+	e.resetConfirm()
+	e.forgetCheck()
+	e.publish(EventScenarioReset, nil)
+	return nil
+}
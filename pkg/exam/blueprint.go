@@ -0,0 +1,164 @@
+// Package exam defines mock exam blueprints: how many scenarios an exam draws
+// from which topics, how long it runs and the score needed to pass.
+package exam
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// Blueprint describes a mock exam, e.g. a CKA-like one or a team's own.
+type Blueprint struct {
+	Name         string          `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	Count        int             `json:"count"`          // Scenarios in the exam
+	Duration     metav1.Duration `json:"duration"`       // Total time for all of them
+	PassingScore int             `json:"passingScore"`   // Percentage of scenarios to solve
+	Pool         []string        `json:"pool,omitempty"` // Only draw scenarios with one of these tags
+	Topics       []Topic         `json:"topics"`
+	Source       string          `json:"-"` // File the blueprint was loaded from
+}
+
+// Topic is a share of the exam drawn from scenarios with any of its tags.
+type Topic struct {
+	Name   string   `json:"name"`
+	Tags   []string `json:"tags"`
+	Weight int      `json:"weight"` // Relative to the other topics' weights
+}
+
+// ParseBlueprint decodes and validates a YAML exam blueprint.
+func ParseBlueprint(data []byte) (*Blueprint, error) {
+	var bp Blueprint
+	if err := yaml.UnmarshalStrict(data, &bp); err != nil {
+		return nil, fmt.Errorf("failed to parse blueprint: %w", err)
+	}
+	if err := bp.validate(); err != nil {
+		return nil, err
+	}
+	return &bp, nil
+}
+
+func (b *Blueprint) validate() error {
+	switch {
+	case b.Name == "":
+		return fmt.Errorf("blueprint is missing name")
+	case b.Count <= 0:
+		return fmt.Errorf("blueprint %s: count must be positive", b.Name)
+	case b.Duration.Duration <= 0:
+		return fmt.Errorf("blueprint %s: duration must be positive", b.Name)
+	case b.PassingScore < 0 || b.PassingScore > 100:
+		return fmt.Errorf("blueprint %s: passingScore must be a percentage", b.Name)
+	case len(b.Topics) == 0:
+		return fmt.Errorf("blueprint %s has no topics", b.Name)
+	}
+	for i, t := range b.Topics {
+		switch {
+		case t.Name == "":
+			return fmt.Errorf("blueprint %s: topic %d is missing name", b.Name, i+1)
+		case len(t.Tags) == 0:
+			return fmt.Errorf("blueprint %s: topic %s has no tags", b.Name, t.Name)
+		case t.Weight <= 0:
+			return fmt.Errorf("blueprint %s: topic %s needs a positive weight", b.Name, t.Name)
+		}
+	}
+	return nil
+}
+
+// LoadBlueprint reads and validates the blueprint at path.
+func LoadBlueprint(path string) (*Blueprint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blueprint: %w", err)
+	}
+	bp, err := ParseBlueprint(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	bp.Source = path
+	return bp, nil
+}
+
+// DefaultBlueprintDir returns the directory blueprints are looked up in (~/.k8s-dojo/exams).
+func DefaultBlueprintDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".k8s-dojo", "exams")
+	}
+	return filepath.Join(home, ".k8s-dojo", "exams")
+}
+
+// Slots splits the exam's scenario count across its topics by weight. Seats
+// left over after rounding down go to the largest remainders, earlier topics
+// first on ties.
+func (b *Blueprint) Slots() []int {
+	total := 0
+	for _, t := range b.Topics {
+		total += t.Weight
+	}
+
+	slots := make([]int, len(b.Topics))
+	remainders := make([]int, len(b.Topics))
+	left := b.Count
+	for i, t := range b.Topics {
+		slots[i] = b.Count * t.Weight / total
+		remainders[i] = b.Count * t.Weight % total
+		left -= slots[i]
+	}
+	order := make([]int, len(b.Topics))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return remainders[order[i]] > remainders[order[j]] })
+	for _, i := range order[:left] {
+		slots[i]++
+	}
+	return slots
+}
+
+// Passed reports whether solving solved of the exam's scenarios meets the passing score.
+func (b *Blueprint) Passed(solved int) bool {
+	return solved*100 >= b.PassingScore*b.Count
+}
+
+// Compose draws the exam's scenarios from catalog, topic by topic, never the
+// same scenario twice. It fails when a topic has too few scenarios for its
+// share of the exam.
+func (b *Blueprint) Compose(catalog []scenario.Metadata, rng *rand.Rand) ([]scenario.Metadata, error) {
+	used := map[string]bool{}
+	var exam []scenario.Metadata
+	for i, n := range b.Slots() {
+		topic := b.Topics[i]
+		var candidates []scenario.Metadata
+		for _, meta := range catalog {
+			if !used[meta.ID] && hasAnyTag(meta, b.Pool, true) && hasAnyTag(meta, topic.Tags, false) {
+				candidates = append(candidates, meta)
+			}
+		}
+		if len(candidates) < n {
+			return nil, fmt.Errorf("topic %s needs %d scenarios but only %d are tagged %v", topic.Name, n, len(candidates), topic.Tags)
+		}
+		rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+		for _, meta := range candidates[:n] {
+			used[meta.ID] = true
+			exam = append(exam, meta)
+		}
+	}
+	return exam, nil
+}
+
+// hasAnyTag reports whether meta has one of tags; an empty list matches when ifEmpty is set.
+func hasAnyTag(meta scenario.Metadata, tags []string, ifEmpty bool) bool {
+	if len(tags) == 0 {
+		return ifEmpty
+	}
+	return slices.ContainsFunc(tags, meta.HasTag)
+}
@@ -0,0 +1,121 @@
+package exam
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+const ckaBlueprint = `
+name: CKA mock
+count: 4
+duration: 2h
+passingScore: 66
+pool: [cka]
+topics:
+- name: Networking
+  tags: [services, dns]
+  weight: 2
+- name: Troubleshooting
+  tags: [crashloop, logs]
+  weight: 1
+- name: Storage
+  tags: [pvc]
+  weight: 1
+`
+
+var catalog = []scenario.Metadata{
+	{ID: "svc-1", Tags: []string{"services", "cka"}},
+	{ID: "svc-2", Tags: []string{"services", "ckad"}},
+	{ID: "dns-1", Tags: []string{"dns", "cka"}},
+	{ID: "dns-2", Tags: []string{"DNS", "cka"}},
+	{ID: "crash-1", Tags: []string{"crashloop", "logs", "cka"}},
+	{ID: "pvc-1", Tags: []string{"pvc", "cka"}},
+}
+
+func TestParseBlueprint(t *testing.T) {
+	bp, err := ParseBlueprint([]byte(ckaBlueprint))
+	if err != nil {
+		t.Fatalf("ParseBlueprint: %v", err)
+	}
+	if bp.Count != 4 || bp.Duration.Duration != 2*time.Hour || len(bp.Topics) != 3 {
+		t.Errorf("got %+v", bp)
+	}
+}
+
+func TestParseBlueprintRejectsInvalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"unknown field": ckaBlueprint + "extra: true\n",
+		"no count":      strings.Replace(ckaBlueprint, "count: 4", "count: 0", 1),
+		"bad score":     strings.Replace(ckaBlueprint, "passingScore: 66", "passingScore: 120", 1),
+		"zero weight":   strings.Replace(ckaBlueprint, "weight: 2", "weight: 0", 1),
+		"no duration":   strings.Replace(ckaBlueprint, "duration: 2h\n", "", 1),
+	} {
+		if _, err := ParseBlueprint([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSlots(t *testing.T) {
+	for _, tc := range []struct {
+		count   int
+		weights []int
+		want    []int
+	}{
+		{4, []int{2, 1, 1}, []int{2, 1, 1}},
+		{5, []int{30, 25, 20, 15, 10}, []int{2, 1, 1, 1, 0}},
+		{3, []int{1, 1}, []int{2, 1}},
+	} {
+		bp := &Blueprint{Count: tc.count}
+		for _, w := range tc.weights {
+			bp.Topics = append(bp.Topics, Topic{Weight: w})
+		}
+		if got := bp.Slots(); !slices.Equal(got, tc.want) {
+			t.Errorf("Slots(%d, %v) = %v, want %v", tc.count, tc.weights, got, tc.want)
+		}
+	}
+}
+
+func TestCompose(t *testing.T) {
+	bp, err := ParseBlueprint([]byte(ckaBlueprint))
+	if err != nil {
+		t.Fatalf("ParseBlueprint: %v", err)
+	}
+	exam, err := bp.Compose(catalog, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Compose: %v", err)
+	}
+
+	var ids []string
+	for _, meta := range exam {
+		ids = append(ids, meta.ID)
+	}
+	if len(ids) != 4 || slices.Contains(ids, "svc-2") {
+		t.Fatalf("got %v, want 4 scenarios from the cka pool", ids)
+	}
+	if ids[2] != "crash-1" || ids[3] != "pvc-1" {
+		t.Errorf("got %v, want crash-1 and pvc-1 after the networking scenarios", ids)
+	}
+}
+
+func TestComposeShortTopic(t *testing.T) {
+	bp, err := ParseBlueprint([]byte(strings.Replace(ckaBlueprint, "count: 4", "count: 8", 1)))
+	if err != nil {
+		t.Fatalf("ParseBlueprint: %v", err)
+	}
+	if _, err := bp.Compose(catalog, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("expected an error for a topic with too few scenarios")
+	}
+}
+
+func TestPassed(t *testing.T) {
+	bp := &Blueprint{Count: 3, PassingScore: 66}
+	if bp.Passed(1) || !bp.Passed(2) {
+		t.Error("want 2 of 3 to pass at 66%")
+	}
+}
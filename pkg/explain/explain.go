@@ -0,0 +1,150 @@
+// Package explain provides a built-in knowledge base of common Kubernetes errors.
+package explain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Entry explains one family of errors.
+type Entry struct {
+	Title       string
+	Explanation string
+	Scenarios   []string // IDs of scenarios that practice this error
+
+	pattern *regexp.Regexp
+}
+
+// Match is an entry found in terminal output.
+type Match struct {
+	*Entry
+	Line string // The line that matched
+}
+
+// entries are checked in order; more specific patterns come first.
+var entries = []*Entry{
+	{
+		Title:       "Image pull failed",
+		Explanation: "The kubelet could not pull the container image. Usually the tag or repository name is wrong, the image is private and no imagePullSecret is set, or the registry is unreachable. `kubectl describe pod` shows the exact pull error in Events.",
+		Scenarios:   []string{"image-pull-backoff", "sec-image-digest"},
+		pattern:     regexp.MustCompile(`ImagePullBackOff|ErrImagePull|ErrImageNeverPull|InvalidImageName`),
+	},
+	{
+		Title:       "Init container crashing",
+		Explanation: "An init container keeps failing, so the main containers never start. Check its logs with `kubectl logs <pod> -c <init-container>`; init containers run in order and must all exit 0.",
+		Scenarios:   []string{"init-container-crash"},
+		pattern:     regexp.MustCompile(`Init:CrashLoopBackOff|Init:Error`),
+	},
+	{
+		Title:       "Container crash loop",
+		Explanation: "The container starts and exits repeatedly; Kubernetes backs off between restarts. Read the previous run's output with `kubectl logs <pod> --previous` and check the exit code in `kubectl describe pod`. Common causes are missing config, a bad command, or a failing liveness probe.",
+		Scenarios:   []string{"crashloop-missing-config", "probe-liveness-fail"},
+		pattern:     regexp.MustCompile(`CrashLoopBackOff`),
+	},
+	{
+		Title:       "Out of memory",
+		Explanation: "The container exceeded its memory limit and the kernel killed it (exit code 137). Raise resources.limits.memory or fix the memory usage.",
+		Scenarios:   []string{"kernel-oom-disable"},
+		pattern:     regexp.MustCompile(`OOMKilled`),
+	},
+	{
+		Title:       "Container config error",
+		Explanation: "The container cannot be created because something it references is missing, typically a ConfigMap, Secret or key used in env or envFrom. `kubectl describe pod` names the missing object.",
+		Scenarios:   []string{"crashloop-missing-config"},
+		pattern:     regexp.MustCompile(`CreateContainerConfigError|CreateContainerError`),
+	},
+	{
+		Title:       "Volume mount failed",
+		Explanation: "The kubelet could not mount a volume: the ConfigMap, Secret or PVC does not exist, or the volume is attached elsewhere. Events on the pod show which volume failed.",
+		Scenarios:   []string{"crashloop-missing-config", "storage-pvc-pending"},
+		pattern:     regexp.MustCompile(`FailedMount|MountVolume\.SetUp failed|Unable to attach or mount volumes`),
+	},
+	{
+		Title:       "Scheduling: taint not tolerated",
+		Explanation: "Every node has a taint the pod does not tolerate. Either add a matching toleration to the pod spec or remove the taint with `kubectl taint nodes <node> <key>-`.",
+		Scenarios:   []string{"sched-taint-toleration"},
+		pattern:     regexp.MustCompile(`untolerated taint|had taint.*that the pod didn't tolerate`),
+	},
+	{
+		Title:       "Scheduling: node cordoned",
+		Explanation: "The node is cordoned (SchedulingDisabled), usually left over from `kubectl drain` during maintenance. Once the work is done, run `kubectl uncordon <node>`.",
+		Scenarios:   []string{"ops-upgrade-drill"},
+		pattern:     regexp.MustCompile(`node\(s\) were unschedulable|SchedulingDisabled`),
+	},
+	{
+		Title:       "Scheduling: node affinity or selector",
+		Explanation: "No node carries the labels the pod requires through nodeSelector or nodeAffinity. Compare the pod's requirements with `kubectl get nodes --show-labels`.",
+		Scenarios:   []string{"sched-node-affinity", "storage-zonal-affinity"},
+		pattern:     regexp.MustCompile(`didn't match Pod's node affinity|node\(s\) didn't match node selector|volume node affinity conflict`),
+	},
+	{
+		Title:       "Scheduling: insufficient resources",
+		Explanation: "No node has enough free CPU or memory for the pod's requests. Lower the requests, free capacity, or add nodes.",
+		Scenarios:   []string{"resource-limit-range"},
+		pattern:     regexp.MustCompile(`Insufficient (cpu|memory|ephemeral-storage)`),
+	},
+	{
+		Title:       "Scheduling: unbound PersistentVolumeClaim",
+		Explanation: "The pod uses a PVC that is not bound to a volume yet. Check `kubectl get pvc`: the StorageClass may not exist or no PersistentVolume matches.",
+		Scenarios:   []string{"storage-pvc-pending"},
+		pattern:     regexp.MustCompile(`unbound immediate PersistentVolumeClaims|pod has unbound`),
+	},
+	{
+		Title:       "Scheduling failed",
+		Explanation: "The scheduler could not place the pod. The message lists, per reason, how many nodes were rejected. Also check the pod's schedulerName: a pod naming a scheduler that is not running stays Pending with no events.",
+		Scenarios:   []string{"sched-missing-scheduler"},
+		pattern:     regexp.MustCompile(`FailedScheduling|0/\d+ nodes are available`),
+	},
+	{
+		Title:       "Quota exceeded",
+		Explanation: "Creating the object would exceed a ResourceQuota in the namespace. Inspect it with `kubectl describe resourcequota` and lower the request or raise the quota.",
+		Scenarios:   []string{"resource-quota-exceeded"},
+		pattern:     regexp.MustCompile(`exceeded quota|must specify limits|must specify requests`),
+	},
+	{
+		Title:       "RBAC: forbidden",
+		Explanation: "The caller is authenticated but not allowed to perform this verb on this resource. The message names the user or ServiceAccount; `kubectl auth can-i <verb> <resource> --as <user>` confirms, and a Role plus RoleBinding grants it.",
+		Scenarios:   []string{"rbac-forbidden"},
+		pattern:     regexp.MustCompile(`is forbidden: User|forbidden: .*cannot (get|list|watch|create|update|patch|delete)`),
+	},
+	{
+		Title:       "Pod Security admission",
+		Explanation: "The namespace enforces a Pod Security Standard and the pod spec violates it, e.g. runs privileged or as root. Adjust the securityContext to comply.",
+		Scenarios:   []string{"sec-privileged-policy"},
+		pattern:     regexp.MustCompile(`violates PodSecurity`),
+	},
+	{
+		Title:       "Missing CRD",
+		Explanation: "The API server does not know this kind, so the CustomResourceDefinition is not installed (or has a different group/version). Install the CRD first, then the resource.",
+		Scenarios:   []string{"crd-missing"},
+		pattern:     regexp.MustCompile(`no matches for kind|the server doesn't have a resource type`),
+	},
+	{
+		Title:       "Probe failed",
+		Explanation: "A liveness or readiness probe is failing. Failing liveness probes restart the container; failing readiness probes remove the pod from Service endpoints. Check the probe's path, port and timeouts against the app.",
+		Scenarios:   []string{"probe-liveness-fail", "probe-readiness-timeout"},
+		pattern:     regexp.MustCompile(`(Liveness|Readiness|Startup) probe failed`),
+	},
+	{
+		Title:       "Connection refused",
+		Explanation: "Nothing is listening at that address. For a Service, check that its selector matches ready pods (`kubectl get endpoints`) and that targetPort matches the container port.",
+		Scenarios:   []string{"net-service-selector", "net-target-port-mismatch"},
+		pattern:     regexp.MustCompile(`connection refused|no endpoints available`),
+	},
+}
+
+// Find returns the most recent line in lines that matches a known error, or nil.
+func Find(lines []string) *Match {
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		for _, e := range entries {
+			if e.pattern.MatchString(line) {
+				return &Match{Entry: e, Line: line}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package explain
+
+import "testing"
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		lines []string
+		want  string
+	}{
+		{[]string{"NAME   READY   STATUS             RESTARTS", "web-1  0/1     ImagePullBackOff   0"}, "Image pull failed"},
+		{[]string{"app-1  0/1     Init:CrashLoopBackOff   3"}, "Init container crashing"},
+		{[]string{`Error from server (Forbidden): pods is forbidden: User "dev" cannot list resource "pods"`}, "RBAC: forbidden"},
+		{[]string{"Warning  FailedScheduling  0/1 nodes are available: 1 node(s) had untolerated taint {dedicated: gpu}."}, "Scheduling: taint not tolerated"},
+		// The most recent error wins
+		{[]string{"web-1  0/1  CrashLoopBackOff  4", "web-2  0/1  OOMKilled  1"}, "Out of memory"},
+		{[]string{"$ kubectl get pods", "No resources found"}, ""},
+	}
+
+	for _, tt := range tests {
+		m := Find(tt.lines)
+		got := ""
+		if m != nil {
+			got = m.Title
+		}
+		if got != tt.want {
+			t.Errorf("Find(%q) = %q, want %q", tt.lines, got, tt.want)
+		}
+	}
+}
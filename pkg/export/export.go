@@ -0,0 +1,285 @@
+// Package export provides writing a scenario's broken and fixed manifests to disk.
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s-dojo/pkg/engine"
+	"k8s-dojo/pkg/harness"
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/scenario"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// File names written by Export.
+const (
+	BrokenFile = "broken.yaml"
+	FixedFile  = "fixed.yaml"
+)
+
+// Options tunes an export.
+type Options struct {
+	// WithSolution also applies the scenario's Solve and writes the fixed manifests.
+	WithSolution bool
+
+	// Harness bounds how long Validate is polled after Solve.
+	Harness harness.Options
+}
+
+// resources are the namespaced kinds captured from the cluster, in apply order.
+var resources = []schema.GroupVersionResource{
+	{Version: "v1", Resource: "serviceaccounts"},
+	{Version: "v1", Resource: "configmaps"},
+	{Version: "v1", Resource: "secrets"},
+	{Version: "v1", Resource: "resourcequotas"},
+	{Version: "v1", Resource: "limitranges"},
+	{Version: "v1", Resource: "persistentvolumeclaims"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings"},
+	{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+	{Group: "batch", Version: "v1", Resource: "cronjobs"},
+	{Group: "batch", Version: "v1", Resource: "jobs"},
+	{Version: "v1", Resource: "pods"},
+	{Version: "v1", Resource: "services"},
+	{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+}
+
+// Export writes the manifests of scenario id into dir/<id>/ and returns the files written.
+//
+// YAML scenarios are exported from their definition. Go scenarios are started
+// through the engine and the objects they create in their namespace are
+// captured; with WithSolution, Solve is applied and the namespace captured again.
+func Export(ctx context.Context, eng *engine.Engine, client *k8s.Client, id, dir string, opts Options) ([]string, error) {
+	var s scenario.Scenario
+	for _, candidate := range eng.ListScenarios() {
+		if candidate.GetMetadata().ID == id {
+			s = candidate
+		}
+	}
+	if s == nil {
+		return nil, fmt.Errorf("scenario not found: %s", id)
+	}
+
+	outDir := filepath.Join(dir, id)
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var broken, fixed []byte
+	if y, ok := s.(*scenario.YAMLScenario); ok {
+		def := y.Definition()
+		broken = []byte(namespaceDoc(def.Namespace) + "---\n" + def.Setup)
+		if opts.WithSolution {
+			if strings.TrimSpace(def.Solve) == "" {
+				return nil, fmt.Errorf("scenario %s has no solve manifest", id)
+			}
+			fixed = []byte(def.Solve)
+		}
+	} else {
+		var err error
+		broken, fixed, err = capture(ctx, eng, client, s, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files := []string{filepath.Join(outDir, BrokenFile)}
+	if err := os.WriteFile(files[0], broken, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write manifests: %w", err)
+	}
+	if fixed != nil {
+		files = append(files, filepath.Join(outDir, FixedFile))
+		if err := os.WriteFile(files[1], fixed, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write manifests: %w", err)
+		}
+	}
+	return files, nil
+}
+
+// capture runs the scenario in the cluster and snapshots its namespace before and after Solve.
+func capture(ctx context.Context, eng *engine.Engine, client *k8s.Client, s scenario.Scenario, opts Options) (broken, fixed []byte, err error) {
+	id := s.GetMetadata().ID
+	if err := eng.StartScenario(ctx, id); err != nil {
+		return nil, nil, fmt.Errorf("failed to start scenario: %w", err)
+	}
+	defer func() {
+		if _, run := eng.BeginCleanup(); run != nil {
+			_ = run(ctx)
+		}
+	}()
+
+	// Randomized namespaces are written under the scenario's stable name
+	namespace := s.GetNamespace()
+	portable := namespace
+	if alloc, ok := s.(scenario.NamespaceAllocator); ok {
+		portable = alloc.BaseNamespace()
+	}
+
+	broken, err = snapshot(ctx, client, namespace, portable)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !opts.WithSolution {
+		return broken, nil, nil
+	}
+
+	solver, ok := s.(scenario.Solver)
+	if !ok {
+		return nil, nil, fmt.Errorf("scenario %s does not implement Solve", id)
+	}
+	if err := solver.Solve(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to apply solution: %w", err)
+	}
+	if _, err := harness.WaitSolved(ctx, eng, opts.Harness); err != nil {
+		return nil, nil, err
+	}
+
+	fixed, err = snapshot(ctx, client, namespace, portable)
+	if err != nil {
+		return nil, nil, err
+	}
+	return broken, fixed, nil
+}
+
+// snapshot renders the user-managed objects in namespace as a multi-document
+// manifest, rewritten to live in portable.
+func snapshot(ctx context.Context, client *k8s.Client, namespace, portable string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(namespaceDoc(portable))
+
+	for _, gvr := range resources {
+		list, err := client.Dynamic.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if skip(obj) {
+				continue
+			}
+			clean(obj, portable)
+
+			data, err := yaml.Marshal(obj.Object)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+			}
+			buf.WriteString("---\n")
+			buf.Write(data)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func namespaceDoc(name string) string {
+	return fmt.Sprintf("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: %s\n", name)
+}
+
+// skip filters out objects created by controllers or the API server rather than the scenario.
+func skip(obj *unstructured.Unstructured) bool {
+	if len(obj.GetOwnerReferences()) > 0 {
+		return true
+	}
+	switch obj.GetKind() {
+	case "ConfigMap":
+		return obj.GetName() == "kube-root-ca.crt"
+	case "ServiceAccount":
+		return obj.GetName() == "default"
+	case "Secret":
+		t, _, _ := unstructured.NestedString(obj.Object, "type")
+		return t == "kubernetes.io/service-account-token"
+	}
+	return false
+}
+
+// clean strips server-populated fields so the manifest can be applied to another cluster.
+func clean(obj *unstructured.Unstructured, namespace string) {
+	obj.SetNamespace(namespace)
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	annotations := obj.GetAnnotations()
+	for k := range annotations {
+		if k == "kubectl.kubernetes.io/last-applied-configuration" ||
+			strings.HasPrefix(k, "deployment.kubernetes.io/") ||
+			strings.HasPrefix(k, "pv.kubernetes.io/") ||
+			strings.HasPrefix(k, "volume.kubernetes.io/") ||
+			strings.HasPrefix(k, "volume.beta.kubernetes.io/") {
+			delete(annotations, k)
+		}
+	}
+	obj.SetAnnotations(annotations)
+
+	switch obj.GetKind() {
+	case "Service":
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIP")
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIPs")
+	case "PersistentVolumeClaim":
+		unstructured.RemoveNestedField(obj.Object, "spec", "volumeName")
+	case "Pod":
+		unstructured.RemoveNestedField(obj.Object, "spec", "nodeName")
+		stripInjectedVolumes(obj)
+	}
+}
+
+// stripInjectedVolumes removes the service account token volume added by admission.
+func stripInjectedVolumes(obj *unstructured.Unstructured) {
+	injected := func(name string) bool { return strings.HasPrefix(name, "kube-api-access-") }
+
+	volumes, _, _ := unstructured.NestedSlice(obj.Object, "spec", "volumes")
+	var keptVolumes []interface{}
+	for _, v := range volumes {
+		if m, ok := v.(map[string]interface{}); ok && injected(fmt.Sprint(m["name"])) {
+			continue
+		}
+		keptVolumes = append(keptVolumes, v)
+	}
+	if len(keptVolumes) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "spec", "volumes")
+	} else {
+		_ = unstructured.SetNestedSlice(obj.Object, keptVolumes, "spec", "volumes")
+	}
+
+	for _, field := range []string{"containers", "initContainers"} {
+		containers, found, _ := unstructured.NestedSlice(obj.Object, "spec", field)
+		if !found {
+			continue
+		}
+		for i, c := range containers {
+			cm, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			mounts, _, _ := unstructured.NestedSlice(cm, "volumeMounts")
+			var kept []interface{}
+			for _, mnt := range mounts {
+				if m, ok := mnt.(map[string]interface{}); ok && injected(fmt.Sprint(m["name"])) {
+					continue
+				}
+				kept = append(kept, mnt)
+			}
+			if len(kept) == 0 {
+				delete(cm, "volumeMounts")
+			} else {
+				cm["volumeMounts"] = kept
+			}
+			containers[i] = cm
+		}
+		_ = unstructured.SetNestedSlice(obj.Object, containers, "spec", field)
+	}
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestClean(t *testing.T) {
+	pod := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":            "app",
+			"namespace":       "lab-x7k2p",
+			"uid":             "1234",
+			"resourceVersion": "42",
+		},
+		"spec": map[string]interface{}{
+			"nodeName": "dojo-worker",
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name": "app",
+					"volumeMounts": []interface{}{
+						map[string]interface{}{"name": "config", "mountPath": "/etc/app"},
+						map[string]interface{}{"name": "kube-api-access-abcde", "mountPath": "/var/run/secrets"},
+					},
+				},
+			},
+			"volumes": []interface{}{
+				map[string]interface{}{"name": "config"},
+				map[string]interface{}{"name": "kube-api-access-abcde"},
+			},
+		},
+		"status": map[string]interface{}{"phase": "Running"},
+	}}
+
+	clean(pod, "lab")
+
+	if pod.GetNamespace() != "lab" {
+		t.Errorf("Expected namespace lab, got %s", pod.GetNamespace())
+	}
+	if pod.GetUID() != "" || pod.GetResourceVersion() != "" {
+		t.Error("Expected server-populated metadata to be removed")
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(pod.Object, "status"); found {
+		t.Error("Expected status to be removed")
+	}
+	if _, found, _ := unstructured.NestedString(pod.Object, "spec", "nodeName"); found {
+		t.Error("Expected nodeName to be removed")
+	}
+
+	volumes, _, _ := unstructured.NestedSlice(pod.Object, "spec", "volumes")
+	if len(volumes) != 1 {
+		t.Errorf("Expected 1 volume after stripping the token volume, got %d", len(volumes))
+	}
+	containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", "containers")
+	mounts := containers[0].(map[string]interface{})["volumeMounts"].([]interface{})
+	if len(mounts) != 1 {
+		t.Errorf("Expected 1 volume mount after stripping the token mount, got %d", len(mounts))
+	}
+}
@@ -0,0 +1,165 @@
+// Package gc finds and removes what k8s-dojo leaves behind on disk:
+// temporary kubeconfigs, recordings of sessions that never got going, old
+// crash reports, stale classroom and co-op files and half-installed packs.
+package gc
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s-dojo/pkg/coop"
+	"k8s-dojo/pkg/diag"
+	"k8s-dojo/pkg/packs"
+	"k8s-dojo/pkg/proctor"
+	"k8s-dojo/pkg/record"
+)
+
+// Item kinds.
+const (
+	KindKubeconfig = "kubeconfig"
+	KindRecording  = "recording"
+	KindCrash      = "crash-report"
+	KindSession    = "session"
+	KindCoop       = "coop"
+	KindPack       = "pack-staging"
+)
+
+// Options tune a sweep. Empty directories are skipped.
+type Options struct {
+	TempDir      string // Temporary kubeconfigs
+	RecordingDir string
+	CrashDir     string
+	SessionDir   string // Classroom session board
+	CoopDir      string
+	PackDir      string
+
+	// TempAge is how old temporary files must be to go: kubeconfigs, empty
+	// recordings, session and co-op files and pack staging directories.
+	// Anything younger may still belong to a running dojo.
+	TempAge time.Duration
+
+	// CrashAge is how old crash reports must be to go.
+	CrashAge time.Duration
+
+	// DryRun only reports what would be removed.
+	DryRun bool
+}
+
+// DefaultOptions sweeps the usual locations, keeping crash reports for 30 days.
+func DefaultOptions() Options {
+	return Options{
+		TempDir:      os.TempDir(),
+		RecordingDir: record.DefaultDir(),
+		CrashDir:     diag.DefaultDir(),
+		SessionDir:   proctor.DefaultDir(),
+		CoopDir:      coop.DefaultDir(),
+		PackDir:      packs.DefaultDir(),
+		TempAge:      24 * time.Hour,
+		CrashAge:     30 * 24 * time.Hour,
+	}
+}
+
+// Item is one artifact found by a sweep.
+type Item struct {
+	Kind   string `json:"kind"`
+	Path   string `json:"path"`
+	Bytes  int64  `json:"bytes"`
+	Reason string `json:"reason"`
+}
+
+// Sweep finds stale artifacts and, unless opts.DryRun, removes them. It
+// returns what it found, up to the first failed removal.
+func Sweep(opts Options, now time.Time) ([]Item, error) {
+	var items []Item
+	add := func(kind, dir, pattern string, maxAge time.Duration, reason func(path string) string) {
+		if dir == "" {
+			return
+		}
+		paths, _ := filepath.Glob(filepath.Join(dir, pattern)) // Only fails on a bad pattern
+		for _, p := range paths {
+			info, err := os.Stat(p)
+			if err != nil || now.Sub(info.ModTime()) < maxAge {
+				continue
+			}
+			why := "older than " + formatAge(maxAge)
+			if reason != nil {
+				if why = reason(p); why == "" {
+					continue
+				}
+			}
+			items = append(items, Item{Kind: kind, Path: p, Bytes: size(p, info), Reason: why})
+		}
+	}
+
+	add(KindKubeconfig, opts.TempDir, "k8s-dojo-*.kubeconfig", opts.TempAge, nil)
+	add(KindKubeconfig, opts.TempDir, "k8s-dojo-kubeconfig-*", opts.TempAge, nil)
+	add(KindRecording, opts.RecordingDir, "*"+record.Ext, opts.TempAge, orphanedRecording)
+	add(KindCrash, opts.CrashDir, "crash-*.txt", opts.CrashAge, nil)
+	for _, pattern := range []string{"*.json", "*.inbox"} {
+		add(KindSession, opts.SessionDir, pattern, opts.TempAge, nil)
+	}
+	for _, pattern := range []string{"*.json", "*.partner"} {
+		add(KindCoop, opts.CoopDir, pattern, opts.TempAge, nil)
+	}
+	add(KindPack, opts.PackDir, ".install-*", opts.TempAge, func(string) string {
+		return "left by an interrupted install"
+	})
+
+	if opts.DryRun {
+		return items, nil
+	}
+	for i, item := range items {
+		if err := os.RemoveAll(item.Path); err != nil {
+			return items[:i], fmt.Errorf("failed to remove %s: %w", item.Path, err)
+		}
+	}
+	return items, nil
+}
+
+// orphanedRecording explains why a recording is worth removing: it can't be
+// read or holds no terminal output. Recordings worth watching are kept.
+func orphanedRecording(path string) string {
+	_, events, err := record.Read(path)
+	if err != nil {
+		return "unreadable"
+	}
+	for _, e := range events {
+		if e.Kind == record.KindOutput {
+			return ""
+		}
+	}
+	return "no terminal output"
+}
+
+// formatAge renders an age in whole days or hours where it can, e.g. "30 days".
+func formatAge(d time.Duration) string {
+	switch day := 24 * time.Hour; {
+	case d == day:
+		return "1 day"
+	case d > day && d%day == 0:
+		return fmt.Sprintf("%d days", d/day)
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return d.String()
+}
+
+// size returns the size of a file, or the total size of a directory's files.
+func size(path string, info fs.FileInfo) int64 {
+	if !info.IsDir() {
+		return info.Size()
+	}
+	var total int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if fi, err := d.Info(); err == nil {
+				total += fi.Size()
+			}
+		}
+		return nil
+	})
+	return total
+}
@@ -0,0 +1,159 @@
+package gc
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"k8s-dojo/pkg/record"
+)
+
+// touch writes a file and backdates it by age.
+func touch(t *testing.T, path string, age time.Duration) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	backdate(t, path, age)
+}
+
+func backdate(t *testing.T, path string, age time.Duration) {
+	t.Helper()
+	old := time.Now().Add(-age)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// recording creates the recording name in dir, with terminal output if output is set.
+func recording(t *testing.T, dir, name string, output bool, age time.Duration) {
+	t.Helper()
+	r, err := record.NewRecorder(dir, 80, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Marker("start")
+	if output {
+		r.Output([]byte("$ kubectl get pods\n"))
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Recordings are named by the second they start, so two made now would collide
+	renamed := filepath.Join(dir, name)
+	if err := os.Rename(r.Path(), renamed); err != nil {
+		t.Fatal(err)
+	}
+	backdate(t, renamed, age)
+}
+
+func testOptions(t *testing.T) Options {
+	root := t.TempDir()
+	return Options{
+		TempDir:      filepath.Join(root, "tmp"),
+		RecordingDir: filepath.Join(root, "recordings"),
+		CrashDir:     filepath.Join(root, "crash"),
+		SessionDir:   filepath.Join(root, "sessions"),
+		CoopDir:      filepath.Join(root, "coop"),
+		PackDir:      filepath.Join(root, "packs"),
+		TempAge:      24 * time.Hour,
+		CrashAge:     30 * 24 * time.Hour,
+	}
+}
+
+func TestSweep(t *testing.T) {
+	opts := testOptions(t)
+	day := 24 * time.Hour
+
+	touch(t, filepath.Join(opts.TempDir, "k8s-dojo-123.kubeconfig"), 2*day)
+	touch(t, filepath.Join(opts.TempDir, "k8s-dojo-shell-9.kubeconfig"), 2*day)
+	touch(t, filepath.Join(opts.TempDir, "k8s-dojo-kubeconfig-42"), 2*day)
+	touch(t, filepath.Join(opts.TempDir, "k8s-dojo-fresh.kubeconfig"), time.Hour) // In use
+	touch(t, filepath.Join(opts.TempDir, "other.kubeconfig"), 2*day)              // Not ours
+	recording(t, opts.RecordingDir, "empty"+record.Ext, false, 2*day)
+	recording(t, opts.RecordingDir, "full"+record.Ext, true, 2*day)
+	touch(t, filepath.Join(opts.CrashDir, "crash-20240101-120000.txt"), 40*day)
+	touch(t, filepath.Join(opts.CrashDir, "crash-20240301-120000.txt"), 10*day)
+	touch(t, filepath.Join(opts.SessionDir, "alice.json"), 2*day)
+	touch(t, filepath.Join(opts.SessionDir, "bob.json"), time.Minute)
+	touch(t, filepath.Join(opts.CoopDir, "abcd1234.partner"), 2*day)
+	staging := filepath.Join(opts.PackDir, ".install-777")
+	touch(t, filepath.Join(staging, "a.yaml"), 2*day)
+	backdate(t, staging, 2*day)
+	touch(t, filepath.Join(opts.PackDir, "community", "b.yaml"), 2*day)
+
+	items, err := Sweep(opts, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, item := range items {
+		rel, _ := filepath.Rel(filepath.Dir(opts.TempDir), item.Path)
+		got = append(got, item.Kind+" "+rel)
+		if _, err := os.Stat(item.Path); !os.IsNotExist(err) {
+			t.Errorf("%s was not removed", item.Path)
+		}
+	}
+	sort.Strings(got)
+	want := []string{
+		"coop coop/abcd1234.partner",
+		"crash-report crash/crash-20240101-120000.txt",
+		"kubeconfig tmp/k8s-dojo-123.kubeconfig",
+		"kubeconfig tmp/k8s-dojo-kubeconfig-42",
+		"kubeconfig tmp/k8s-dojo-shell-9.kubeconfig",
+		"pack-staging packs/.install-777",
+		"recording recordings/empty.rec",
+		"session sessions/alice.json",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("swept %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("item %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	for _, kept := range []string{"tmp/k8s-dojo-fresh.kubeconfig", "tmp/other.kubeconfig", "recordings/full.rec", "sessions/bob.json", "packs/community/b.yaml"} {
+		if _, err := os.Stat(filepath.Join(filepath.Dir(opts.TempDir), kept)); err != nil {
+			t.Errorf("%s should be kept: %v", kept, err)
+		}
+	}
+}
+
+func TestSweepDryRun(t *testing.T) {
+	opts := testOptions(t)
+	opts.DryRun = true
+	path := filepath.Join(opts.TempDir, "k8s-dojo-1.kubeconfig")
+	touch(t, path, 48*time.Hour)
+
+	items, err := Sweep(opts, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Bytes != 1 {
+		t.Fatalf("items = %+v, want the kubeconfig", items)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("dry run removed %s", path)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := map[time.Duration]string{
+		24 * time.Hour:      "1 day",
+		30 * 24 * time.Hour: "30 days",
+		36 * time.Hour:      "36h",
+		90 * time.Minute:    "1h30m0s",
+	}
+	for d, want := range tests {
+		if got := formatAge(d); got != want {
+			t.Errorf("formatAge(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Package grade provides autograder reports: a scenario's Validate run against
+// a cluster prepared outside the dojo, e.g. by a student.
+package grade
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// CheckReport is the outcome of one validation condition.
+type CheckReport struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Details string `json:"details,omitempty"`
+}
+
+// Report is the machine-readable grading result for one scenario.
+type Report struct {
+	ScenarioID string        `json:"scenario_id"`
+	Namespace  string        `json:"namespace"`
+	Version    int           `json:"version"`
+	Passed     bool          `json:"passed"`
+	Score      float64       `json:"score"` // Fraction of conditions met (0-1)
+	Message    string        `json:"message"`
+	Checks     []CheckReport `json:"checks"`
+	GradedAt   time.Time     `json:"graded_at"`
+}
+
+// Grade runs only the scenario's Validate in namespace; nothing is set up or cleaned up.
+// An empty namespace keeps the scenario's default (its base namespace).
+func Grade(ctx context.Context, s scenario.Scenario, namespace string) Report {
+	return GradeWithAnswer(ctx, s, namespace, "")
+}
+
+// GradeWithAnswer is Grade for quiz scenarios, which also grade the student's answer.
+// Other scenarios ignore it.
+func GradeWithAnswer(ctx context.Context, s scenario.Scenario, namespace, answer string) Report {
+	if alloc, ok := s.(scenario.NamespaceAllocator); ok {
+		if namespace == "" {
+			namespace = alloc.BaseNamespace()
+		}
+		alloc.UseNamespace(namespace)
+	}
+	res := s.Validate(ctx)
+	if q, ok := s.(scenario.Quiz); ok {
+		res = scenario.GradeAnswer(ctx, q, res, strings.TrimSpace(answer))
+	}
+	return NewReport(s, res)
+}
+
+// NewReport converts a validation result into a report.
+func NewReport(s scenario.Scenario, res scenario.Result) Report {
+	meta := s.GetMetadata()
+	report := Report{
+		ScenarioID: meta.ID,
+		Namespace:  s.GetNamespace(),
+		Version:    meta.ContentVersion(),
+		Passed:     res.Solved,
+		Score:      res.Score(),
+		Message:    res.Message,
+		Checks:     []CheckReport{},
+		GradedAt:   time.Now().UTC(),
+	}
+	for _, c := range res.Checks {
+		report.Checks = append(report.Checks, CheckReport{Name: c.Name, Passed: c.Passed, Details: c.Details})
+	}
+	return report
+}
@@ -0,0 +1,76 @@
+package grade
+
+import (
+	"context"
+	"testing"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+type fakeScenario struct {
+	scenario.BaseScenario
+	result scenario.Result
+}
+
+func (f *fakeScenario) GetMetadata() scenario.Metadata {
+	return scenario.Metadata{ID: "fake", Version: 2}
+}
+func (f *fakeScenario) Setup(ctx context.Context) error              { return nil }
+func (f *fakeScenario) Validate(ctx context.Context) scenario.Result { return f.result }
+func (f *fakeScenario) Cleanup(ctx context.Context) error            { return nil }
+
+func TestGrade(t *testing.T) {
+	s := &fakeScenario{
+		BaseScenario: scenario.BaseScenario{Namespace: "fake"},
+		result: scenario.NewResult("done",
+			scenario.Check{Name: "pods", Passed: true},
+			scenario.Check{Name: "service", Passed: false, Details: "no endpoints"},
+		),
+	}
+
+	r := Grade(context.Background(), s, "student-ns")
+	if r.Namespace != "student-ns" || s.GetNamespace() != "student-ns" {
+		t.Errorf("namespace = %q, want student-ns", r.Namespace)
+	}
+	if r.Passed || r.Score != 0.5 || r.Message != "no endpoints" || r.Version != 2 {
+		t.Errorf("unexpected report: %+v", r)
+	}
+	if len(r.Checks) != 2 || r.Checks[1].Passed {
+		t.Errorf("checks = %+v", r.Checks)
+	}
+
+	// Without a namespace the scenario's base namespace is graded
+	s.UseNamespace("fake-abcde")
+	if r := Grade(context.Background(), s, ""); r.Namespace != "fake" {
+		t.Errorf("default namespace = %q, want fake", r.Namespace)
+	}
+}
+
+type fakeQuiz struct {
+	fakeScenario
+}
+
+func (f *fakeQuiz) Question() string { return "Which pod?" }
+func (f *fakeQuiz) CheckAnswer(ctx context.Context, answer string) scenario.Check {
+	return scenario.Check{Name: "Answer", Passed: answer == "web", Details: "wrong pod"}
+}
+
+func TestGradeWithAnswer(t *testing.T) {
+	s := &fakeQuiz{fakeScenario{result: scenario.NewResult("done")}}
+
+	if r := Grade(context.Background(), s, ""); r.Passed || len(r.Checks) != 1 {
+		t.Errorf("unanswered quiz report = %+v, want not passed", r)
+	}
+	if r := GradeWithAnswer(context.Background(), s, "", "db"); r.Passed || r.Message != "wrong pod" {
+		t.Errorf("wrong answer report = %+v", r)
+	}
+	if r := GradeWithAnswer(context.Background(), s, "", " web "); !r.Passed || r.Message != "done" {
+		t.Errorf("right answer report = %+v", r)
+	}
+
+	// The answer is only graded once the cluster checks pass
+	s.result = scenario.NewResult("done", scenario.Check{Name: "pods", Details: "pods down"})
+	if r := GradeWithAnswer(context.Background(), s, "", "web"); r.Passed || r.Message != "pods down" || len(r.Checks) != 1 {
+		t.Errorf("broken cluster report = %+v", r)
+	}
+}
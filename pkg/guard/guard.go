@@ -0,0 +1,114 @@
+// Package guard warns about destructive changes outside the scenario: deleting
+// nodes or objects in system namespaces. It watches the cluster with informers
+// and diffs what disappears, so it never intercepts the learner's shell.
+package guard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Violation is a destructive change the guard saw.
+type Violation struct {
+	Time      time.Time
+	Kind      string
+	Namespace string // Empty for cluster-scoped objects
+	Name      string
+}
+
+// String describes the violation, e.g. "Deployment kube-system/coredns was deleted".
+func (v Violation) String() string {
+	name := v.Name
+	if v.Namespace != "" {
+		name = v.Namespace + "/" + v.Name
+	}
+	return fmt.Sprintf("%s %s was deleted", v.Kind, name)
+}
+
+// Options configures a Watcher.
+type Options struct {
+	// ProtectedNamespaces are the namespaces whose objects must not be deleted.
+	ProtectedNamespaces []string
+
+	// OnViolation is called for every violation, from an informer goroutine.
+	OnViolation func(Violation)
+}
+
+// DefaultOptions protects the namespaces Kind and Kubernetes set up.
+func DefaultOptions() Options {
+	return Options{
+		ProtectedNamespaces: []string{"default", "kube-system", "kube-public", "kube-node-lease", "local-path-storage"},
+	}
+}
+
+// Watcher reports violations while its informers run.
+type Watcher struct {
+	clientset kubernetes.Interface
+	protected map[string]bool
+	opts      Options
+}
+
+// New creates a watcher; nothing is watched until Start.
+func New(clientset kubernetes.Interface, opts Options) *Watcher {
+	protected := make(map[string]bool, len(opts.ProtectedNamespaces))
+	for _, ns := range opts.ProtectedNamespaces {
+		protected[ns] = true
+	}
+	return &Watcher{clientset: clientset, protected: protected, opts: opts}
+}
+
+// Start begins watching and blocks until the informer caches are synced.
+// Watching continues in the background until ctx is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	factory := informers.NewSharedInformerFactory(w.clientset, 0)
+
+	w.watch(factory.Core().V1().Nodes().Informer(), "Node", false)
+	w.watch(factory.Core().V1().Namespaces().Informer(), "Namespace", true)
+	w.watch(factory.Core().V1().Services().Informer(), "Service", true)
+	w.watch(factory.Core().V1().ConfigMaps().Informer(), "ConfigMap", true)
+	w.watch(factory.Core().V1().ServiceAccounts().Informer(), "ServiceAccount", true)
+	w.watch(factory.Apps().V1().Deployments().Informer(), "Deployment", true)
+	w.watch(factory.Apps().V1().DaemonSets().Informer(), "DaemonSet", true)
+
+	factory.Start(ctx.Done())
+	for typ, ok := range factory.WaitForCacheSync(ctx.Done()) {
+		if !ok {
+			return fmt.Errorf("failed to sync %v informer", typ)
+		}
+	}
+	return nil
+}
+
+// watch reports deletions seen by informer. Scoped kinds only count in protected
+// namespaces; for namespaces themselves the object's own name is checked.
+func (w *Watcher) watch(informer cache.SharedIndexInformer, kind string, scoped bool) {
+	_, _ = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj any) {
+			if tomb, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tomb.Obj
+			}
+			m, err := meta.Accessor(obj)
+			if err != nil {
+				return
+			}
+			if scoped {
+				ns := m.GetNamespace()
+				if kind == "Namespace" {
+					ns = m.GetName()
+				}
+				if !w.protected[ns] {
+					return
+				}
+			}
+			if w.opts.OnViolation != nil {
+				w.opts.OnViolation(Violation{Time: time.Now(), Kind: kind, Namespace: m.GetNamespace(), Name: m.GetName()})
+			}
+		},
+	})
+}
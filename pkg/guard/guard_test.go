@@ -0,0 +1,80 @@
+package guard
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestViolationString(t *testing.T) {
+	if got := (Violation{Kind: "Deployment", Namespace: "kube-system", Name: "coredns"}).String(); got != "Deployment kube-system/coredns was deleted" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (Violation{Kind: "Node", Name: "kind-control-plane"}).String(); got != "Node kind-control-plane was deleted" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "kind-control-plane"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "dojo-level-1"}},
+	)
+
+	// The fake only delivers events to watches that are already open
+	var watchers atomic.Int32
+	clientset.PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		w, err := clientset.Tracker().Watch(action.GetResource(), action.GetNamespace())
+		watchers.Add(1)
+		return true, w, err
+	})
+
+	violations := make(chan Violation, 10)
+	opts := DefaultOptions()
+	opts.OnViolation = func(v Violation) { violations <- v }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := New(clientset, opts).Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); watchers.Load() < 7; {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d watches started", watchers.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_ = clientset.AppsV1().Deployments("dojo-level-1").Delete(ctx, "web", metav1.DeleteOptions{})
+	_ = clientset.AppsV1().Deployments("kube-system").Delete(ctx, "coredns", metav1.DeleteOptions{})
+	_ = clientset.CoreV1().Nodes().Delete(ctx, "kind-control-plane", metav1.DeleteOptions{})
+
+	want := map[string]bool{
+		"Deployment kube-system/coredns was deleted": true,
+		"Node kind-control-plane was deleted":        true,
+	}
+	for range want {
+		select {
+		case v := <-violations:
+			if !want[v.String()] {
+				t.Errorf("unexpected violation %q", v)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for violations")
+		}
+	}
+	select {
+	case v := <-violations:
+		t.Errorf("unexpected violation %q", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
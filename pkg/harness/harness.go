@@ -0,0 +1,180 @@
+// Package harness provides an automated Setup → Solve → Validate run for scenario authors and CI.
+package harness
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s-dojo/pkg/engine"
+	"k8s-dojo/pkg/scenario"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Options tunes a harness run.
+type Options struct {
+	// SolveTimeout bounds how long Validate is polled after Solve.
+	SolveTimeout time.Duration
+
+	// PollInterval is the delay between Validate attempts.
+	PollInterval time.Duration
+
+	// KeepOnFailure skips cleanup when a step fails, for debugging.
+	KeepOnFailure bool
+}
+
+// DefaultOptions returns the options used by `k8s-dojo dev test`.
+func DefaultOptions() Options {
+	return Options{
+		SolveTimeout: 3 * time.Minute,
+		PollInterval: 2 * time.Second,
+	}
+}
+
+// Step names reported by Run.
+const (
+	StepSetup   = "setup"
+	StepBroken  = "broken"
+	StepSolve   = "solve"
+	StepValid   = "validate"
+	StepCleanup = "cleanup"
+)
+
+// StepResult records the outcome of one harness step.
+type StepResult struct {
+	Name     string        `json:"name"`
+	Passed   bool          `json:"passed"`
+	Details  string        `json:"details,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report summarizes a harness run for one scenario.
+type Report struct {
+	ScenarioID string        `json:"scenario_id"`
+	Passed     bool          `json:"passed"`
+	Steps      []StepResult  `json:"steps"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// Failed returns the first failing step, or nil.
+func (r Report) Failed() *StepResult {
+	for i := range r.Steps {
+		if !r.Steps[i].Passed {
+			return &r.Steps[i]
+		}
+	}
+	return nil
+}
+
+// Run starts a scenario through the engine, verifies the broken state is observable,
+// applies its Solve, waits for Validate to pass and cleans up.
+func Run(ctx context.Context, eng *engine.Engine, id string, opts Options) Report {
+	report := Report{ScenarioID: id}
+	start := time.Now()
+	defer func() { report.Duration = time.Since(start) }()
+
+	step := func(name string, fn func() (string, error)) bool {
+		t := time.Now()
+		details, err := fn()
+		res := StepResult{Name: name, Passed: err == nil, Details: details, Duration: time.Since(t)}
+		if err != nil {
+			res.Details = err.Error()
+		}
+		report.Steps = append(report.Steps, res)
+		return res.Passed
+	}
+
+	ok := step(StepSetup, func() (string, error) {
+		return "", eng.StartScenario(ctx, id)
+	}) && step(StepBroken, func() (string, error) {
+		res, err := eng.Check(ctx)
+		if err != nil {
+			return "", err
+		}
+		// A pass still being confirmed is just as wrong before the fix
+		if confirming, _ := eng.Confirming(); res.Solved || confirming > 0 {
+			return "", fmt.Errorf("scenario is solved before any fix was applied")
+		}
+		return res.Message, nil
+	}) && step(StepSolve, func() (string, error) {
+		solver, ok := eng.GetCurrentScenario().(scenario.Solver)
+		if !ok {
+			return "", fmt.Errorf("scenario does not implement Solve")
+		}
+		return "", solver.Solve(ctx)
+	}) && step(StepValid, func() (string, error) {
+		return WaitSolved(ctx, eng, opts)
+	})
+
+	report.Passed = ok
+	if ok || !opts.KeepOnFailure {
+		cleaned := step(StepCleanup, func() (string, error) {
+			_, run := eng.BeginCleanup()
+			if run == nil {
+				return "", nil
+			}
+			return "", run(ctx)
+		})
+		report.Passed = report.Passed && cleaned
+	}
+
+	return report
+}
+
+// WaitSolved polls Validate until the scenario is solved or the timeout expires.
+func WaitSolved(ctx context.Context, eng *engine.Engine, opts Options) (string, error) {
+	var last scenario.Result
+	err := wait.PollUntilContextTimeout(ctx, opts.PollInterval, opts.SolveTimeout, true, func(ctx context.Context) (bool, error) {
+		res, err := eng.Check(ctx)
+		if err != nil {
+			return false, err
+		}
+		last = res
+		return res.Solved, nil
+	})
+	if err != nil {
+		if last.Message != "" {
+			return "", fmt.Errorf("not solved after fix: %s", last.Message)
+		}
+		return "", err
+	}
+	return last.Message, nil
+}
+
+// Steps lists the step names in the order Run performs them.
+var Steps = []string{StepSetup, StepBroken, StepSolve, StepValid, StepCleanup}
+
+// WriteMatrix prints one row per report with a column per step.
+// Steps that did not run are shown as "-".
+func WriteMatrix(w io.Writer, reports []Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "SCENARIO\t%s\tRESULT\tTIME\n", strings.ToUpper(strings.Join(Steps, "\t")))
+
+	passed := 0
+	for _, r := range reports {
+		cells := make([]string, len(Steps))
+		for i, name := range Steps {
+			cells[i] = "-"
+			for _, s := range r.Steps {
+				if s.Name == name {
+					cells[i] = "ok"
+					if !s.Passed {
+						cells[i] = "FAIL"
+					}
+				}
+			}
+		}
+		result := "FAIL"
+		if r.Passed {
+			result = "PASS"
+			passed++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.0fs\n", r.ScenarioID, strings.Join(cells, "\t"), result, r.Duration.Seconds())
+	}
+	fmt.Fprintf(tw, "\n%d/%d passed\n", passed, len(reports))
+	return tw.Flush()
+}
@@ -0,0 +1,35 @@
+package harness
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteMatrix(t *testing.T) {
+	reports := []Report{
+		{ScenarioID: "net-dns", Passed: true, Duration: 42 * time.Second, Steps: []StepResult{
+			{Name: StepSetup, Passed: true}, {Name: StepBroken, Passed: true}, {Name: StepSolve, Passed: true},
+			{Name: StepValid, Passed: true}, {Name: StepCleanup, Passed: true},
+		}},
+		{ScenarioID: "pvc-stuck", Steps: []StepResult{
+			{Name: StepSetup, Passed: true}, {Name: StepBroken, Passed: false}, {Name: StepCleanup, Passed: true},
+		}},
+	}
+
+	var b strings.Builder
+	if err := WriteMatrix(&b, reports); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(b.String(), "\n")
+
+	if got := strings.Fields(lines[1]); strings.Join(got, " ") != "net-dns ok ok ok ok ok PASS 42s" {
+		t.Errorf("row 1 = %q", lines[1])
+	}
+	if got := strings.Fields(lines[2]); strings.Join(got, " ") != "pvc-stuck ok FAIL - - ok FAIL 0s" {
+		t.Errorf("row 2 = %q", lines[2])
+	}
+	if !strings.Contains(b.String(), "1/2 passed") {
+		t.Errorf("missing summary in %q", b.String())
+	}
+}
@@ -0,0 +1,262 @@
+// Package importer converts scenarios written for other training platforms
+// into dojo YAML scenarios.
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s-dojo/pkg/scenario"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultCategory is the category of imported scenarios unless one is given.
+const DefaultCategory = "Imported"
+
+// Options tune an import.
+type Options struct {
+	ID         string              // Default: derived from the folder name
+	Category   string              // Default: DefaultCategory
+	Difficulty scenario.Difficulty // Default: medium
+
+	// TrustScripts keeps the background scripts as a setup script and runs
+	// the verify scripts on this machine, with the learner's kubeconfig.
+	// Without it the background scripts are dropped and the verify scripts
+	// run in a validator pod.
+	TrustScripts bool
+}
+
+// Result is an imported scenario and what didn't carry over.
+type Result struct {
+	Definition *scenario.Definition
+	Warnings   []string
+}
+
+// YAML encodes the imported definition, ready to be saved in the scenario directory.
+func (r *Result) YAML() ([]byte, error) {
+	data, err := yaml.Marshal(r.Definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode scenario: %w", err)
+	}
+	return data, nil
+}
+
+// killercodaIndex is the part of a Killercoda index.json the import uses.
+type killercodaIndex struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Details     struct {
+		Intro  killercodaPage   `json:"intro"`
+		Steps  []killercodaPage `json:"steps"`
+		Finish killercodaPage   `json:"finish"`
+	} `json:"details"`
+	Backend struct {
+		ImageID string `json:"imageid"`
+	} `json:"backend"`
+}
+
+// killercodaPage names the files of the intro, a step or the finish page,
+// relative to the scenario folder.
+type killercodaPage struct {
+	Title      string `json:"title"`
+	Text       string `json:"text"`
+	Verify     string `json:"verify"`
+	Background string `json:"background"`
+	Foreground string `json:"foreground"`
+}
+
+var (
+	// actionPattern matches Killercoda's click-to-run markers after code, e.g. `ls`{{exec}}.
+	actionPattern = regexp.MustCompile(`\{\{(exec|execute|copy)[^}]*\}\}`)
+
+	// execPattern captures inline or fenced code marked to be executed.
+	execPattern = regexp.MustCompile("(?s)```[a-z]*\\n(.*?)```\\{\\{exec|`([^`\\n]+)`\\{\\{exec")
+
+	// nodePattern spots scripts that work on the VM rather than through the API.
+	nodePattern = regexp.MustCompile(`\bssh\b|\bsystemctl\b|\bcrictl\b|/etc/kubernetes|/var/lib/kubelet`)
+
+	// idUnsafe matches runs of characters not allowed in scenario IDs.
+	idUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Killercoda imports the Killercoda scenario folder dir: its index.json, the
+// step markdown and the verify and background scripts. Steps become guided
+// steps, verify scripts become script checks, and, if the scripts are
+// trusted, background scripts are joined into the setup script. Foreground
+// scripts, and anything the scripts do on the VM instead of through the
+// cluster API, don't carry over; they are reported as warnings.
+func Killercoda(dir string, opts Options) (*Result, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index.json: %w", err)
+	}
+	var index killercodaIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse index.json: %w", err)
+	}
+	if index.Title == "" {
+		return nil, fmt.Errorf("index.json has no title")
+	}
+
+	id := opts.ID
+	if id == "" {
+		id = strings.Trim(idUnsafe.ReplaceAllString(strings.ToLower(filepath.Base(filepath.Clean(dir))), "-"), "-")
+	}
+	def := &scenario.Definition{
+		ID:            id,
+		Name:          index.Title,
+		Description:   index.Description,
+		Difficulty:    opts.Difficulty,
+		Category:      opts.Category,
+		Tags:          []string{"killercoda"},
+		ClusterAccess: true, // Scripts aren't confined to the scenario namespace
+		Namespace:     id,
+	}
+	if def.Difficulty == "" {
+		def.Difficulty = scenario.DifficultyMedium
+	}
+	if def.Category == "" {
+		def.Category = DefaultCategory
+	}
+
+	res := &Result{Definition: def}
+	read := func(name string) (string, error) {
+		if name == "" {
+			return "", nil
+		}
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	intro, err := read(index.Details.Intro.Text)
+	if err != nil {
+		return nil, err
+	}
+	if def.Description == "" {
+		def.Description = firstParagraph(intro)
+	}
+	finish, err := read(index.Details.Finish.Text)
+	if err != nil {
+		return nil, err
+	}
+	def.SuccessMessage = firstParagraph(finish)
+
+	var setup []string
+	pages := append([]killercodaPage{index.Details.Intro}, index.Details.Steps...)
+	for i, page := range pages {
+		label := "intro"
+		if i > 0 {
+			label = fmt.Sprintf("step %d", i)
+		}
+		background, err := read(page.Background)
+		if err != nil {
+			return nil, err
+		}
+		if background != "" {
+			setup = append(setup, background)
+			if i > 0 {
+				res.warn("%s: background script runs at setup, before the first step", label)
+			}
+			res.checkNodeAccess(label, page.Background, background)
+		}
+		if page.Foreground != "" {
+			res.warn("%s: foreground script %s is not supported and was skipped", label, page.Foreground)
+		}
+		if i == 0 {
+			continue
+		}
+
+		text, err := read(page.Text)
+		if err != nil {
+			return nil, err
+		}
+		verify, err := read(page.Verify)
+		if err != nil {
+			return nil, err
+		}
+		title := page.Title
+		if title == "" {
+			title = fmt.Sprintf("Step %d", i)
+		}
+		step := scenario.GuideSpec{
+			Title:       title,
+			Instruction: actionPattern.ReplaceAllString(text, ""),
+			Command:     firstCommand(text),
+		}
+		if verify != "" {
+			res.checkNodeAccess(label, page.Verify, verify)
+			spec := scenario.CheckSpec{Type: "script", Name: title, Script: verify}
+			if opts.TrustScripts {
+				spec.RunIn = "local"
+			}
+			step.Check = &spec
+			def.Checks = append(def.Checks, spec)
+		}
+		def.Guide = append(def.Guide, step)
+	}
+	if len(def.Checks) == 0 {
+		return nil, fmt.Errorf("no step has a verify script, so there is nothing to validate")
+	}
+	if len(setup) > 0 && !opts.TrustScripts {
+		res.warn("background scripts were dropped: they would run on this machine with your kubeconfig; review them and import with --trust-scripts to keep them")
+	} else {
+		def.SetupScript = strings.Join(setup, "\n\n")
+	}
+
+	if image := index.Backend.ImageID; image != "" && !strings.HasPrefix(image, "kubernetes") {
+		res.warn("the scenario runs on the %q image; only the cluster carries over", image)
+	}
+
+	// Catch anything the dojo would refuse to load
+	data, err = res.YAML()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := scenario.ParseDefinition(data); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Result) warn(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// checkNodeAccess warns about a script that reaches past the cluster API.
+func (r *Result) checkNodeAccess(label, name, script string) {
+	if nodePattern.MatchString(script) {
+		r.warn("%s: %s works on the nodes directly, which the dojo can't do", label, name)
+	}
+}
+
+// firstCommand returns the first code snippet of markdown marked to be executed.
+func firstCommand(markdown string) string {
+	m := execPattern.FindStringSubmatch(markdown)
+	if m == nil {
+		return ""
+	}
+	if m[1] != "" {
+		return strings.TrimSpace(m[1])
+	}
+	return strings.TrimSpace(m[2])
+}
+
+// firstParagraph returns the first paragraph of markdown that isn't a heading, as one line.
+func firstParagraph(markdown string) string {
+	for _, p := range strings.Split(markdown, "\n\n") {
+		p = strings.TrimSpace(actionPattern.ReplaceAllString(p, ""))
+		if p == "" || strings.HasPrefix(p, "#") || strings.HasPrefix(p, "![") {
+			continue
+		}
+		return strings.Join(strings.Fields(p), " ")
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// writeFolder lays out a Killercoda scenario folder in a temp dir.
+func writeFolder(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "Broken_Deployment")
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const index = `{
+  "title": "Fix the Deployment",
+  "details": {
+    "intro": {"text": "intro.md", "background": "setup.sh"},
+    "steps": [
+      {"title": "Find the problem", "text": "step1/text.md"},
+      {"title": "Fix it", "text": "step2/text.md", "verify": "step2/verify.sh", "foreground": "step2/fg.sh"}
+    ],
+    "finish": {"text": "finish.md"}
+  },
+  "backend": {"imageid": "kubernetes-kubeadm-1node"}
+}`
+
+func TestKillercoda(t *testing.T) {
+	dir := writeFolder(t, map[string]string{
+		"index.json":      index,
+		"intro.md":        "# Welcome\n\nDeployment *web* is\nbroken.\n\nMore text.",
+		"setup.sh":        "kubectl create deployment web --image=nginx:typo",
+		"step1/text.md":   "Look at the pods: `kubectl get pods`{{exec}}",
+		"step2/text.md":   "Fix the image:\n\n```\nkubectl set image deploy/web nginx=nginx\n```{{exec}}",
+		"step2/verify.sh": "kubectl rollout status deploy/web --timeout=5s",
+		"finish.md":       "## Done\n\nWell done!",
+	})
+
+	res, err := Killercoda(dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := res.Definition
+	if def.ID != "broken-deployment" || def.Category != DefaultCategory || def.Difficulty != scenario.DifficultyMedium {
+		t.Errorf("got id %q, category %q, difficulty %q", def.ID, def.Category, def.Difficulty)
+	}
+	if def.Description != "Deployment *web* is broken." {
+		t.Errorf("description = %q", def.Description)
+	}
+	if def.SuccessMessage != "Well done!" {
+		t.Errorf("success message = %q", def.SuccessMessage)
+	}
+	// Untrusted scripts don't run on this machine
+	if def.SetupScript != "" {
+		t.Errorf("setup script = %q, want it dropped", def.SetupScript)
+	}
+	if len(def.Checks) != 1 || def.Checks[0].Type != "script" || def.Checks[0].Name != "Fix it" || def.Checks[0].RunIn != "" {
+		t.Fatalf("checks = %+v", def.Checks)
+	}
+
+	if len(def.Guide) != 2 {
+		t.Fatalf("got %d guide steps, want 2", len(def.Guide))
+	}
+	if def.Guide[0].Command != "kubectl get pods" || def.Guide[0].Check != nil {
+		t.Errorf("step 1 = %+v", def.Guide[0])
+	}
+	if strings.Contains(def.Guide[0].Instruction, "{{exec}}") {
+		t.Errorf("step 1 instruction keeps the exec marker: %q", def.Guide[0].Instruction)
+	}
+	if def.Guide[1].Command != "kubectl set image deploy/web nginx=nginx" || def.Guide[1].Check == nil {
+		t.Errorf("step 2 = %+v", def.Guide[1])
+	}
+
+	if len(res.Warnings) != 2 || !strings.Contains(res.Warnings[0], "fg.sh") || !strings.Contains(res.Warnings[1], "--trust-scripts") {
+		t.Errorf("warnings = %q", res.Warnings)
+	}
+
+	data, err := res.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scenario.ParseDefinition(data); err != nil {
+		t.Errorf("imported YAML doesn't load: %v", err)
+	}
+
+	trusted, err := Killercoda(dir, Options{TrustScripts: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	def = trusted.Definition
+	if def.SetupScript != "kubectl create deployment web --image=nginx:typo" {
+		t.Errorf("trusted setup script = %q", def.SetupScript)
+	}
+	if def.Checks[0].RunIn != "local" || def.Guide[1].Check.RunIn != "local" {
+		t.Errorf("trusted checks = %+v", def.Checks)
+	}
+	if len(trusted.Warnings) != 1 {
+		t.Errorf("trusted warnings = %q", trusted.Warnings)
+	}
+}
+
+func TestKillercodaOptions(t *testing.T) {
+	dir := writeFolder(t, map[string]string{
+		"index.json":      `{"title": "T", "description": "D", "details": {"steps": [{"text": "s.md", "verify": "v.sh", "background": "bg.sh"}]}, "backend": {"imageid": "ubuntu"}}`,
+		"s.md":            "Do it",
+		"v.sh":            "ssh node01 test -f /tmp/done",
+		"bg.sh":           "true",
+		"unused/extra.md": "",
+	})
+
+	res, err := Killercoda(dir, Options{ID: "my-import", Category: "Lab", Difficulty: scenario.DifficultyHard, TrustScripts: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := res.Definition
+	if def.ID != "my-import" || def.Namespace != "my-import" || def.Category != "Lab" || def.Difficulty != scenario.DifficultyHard {
+		t.Errorf("got %+v", def)
+	}
+	if def.Description != "D" || def.Guide[0].Title != "Step 1" {
+		t.Errorf("description %q, step title %q", def.Description, def.Guide[0].Title)
+	}
+	// Step background, node access and the non-Kubernetes image
+	if len(res.Warnings) != 3 {
+		t.Errorf("warnings = %q", res.Warnings)
+	}
+}
+
+func TestKillercodaErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"no index", map[string]string{"intro.md": "x"}, "index.json"},
+		{"no title", map[string]string{"index.json": `{"details": {}}`}, "no title"},
+		{"missing file", map[string]string{"index.json": `{"title": "T", "details": {"steps": [{"text": "gone.md"}]}}`}, "gone.md"},
+		{"no verify", map[string]string{"index.json": `{"title": "T", "details": {"steps": [{"title": "A"}]}}`}, "nothing to validate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Killercoda(writeFolder(t, tt.files), Options{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
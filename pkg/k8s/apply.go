@@ -0,0 +1,106 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+)
+
+// FieldManager identifies k8s-dojo as the owner of fields it applies.
+const FieldManager = "k8s-dojo"
+
+// ApplyYAML server-side applies every object in a (multi-document) YAML or JSON manifest.
+// Namespaced objects without a namespace are placed in defaultNamespace.
+// Objects are applied in order, so a CRD can precede its custom resources.
+func (c *Client) ApplyYAML(ctx context.Context, manifest string, defaultNamespace string) error {
+	objs, err := DecodeManifest(manifest)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		if err := c.Apply(ctx, obj, defaultNamespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply server-side applies a single unstructured object with forced ownership.
+func (c *Client) Apply(ctx context.Context, obj *unstructured.Unstructured, defaultNamespace string) error {
+	mapping, err := c.restMapping(obj)
+	if err != nil {
+		return err
+	}
+
+	ri := c.Dynamic.Resource(mapping.Resource)
+	var applier dynamic.ResourceInterface = ri
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns := obj.GetNamespace()
+		if ns == "" {
+			ns = defaultNamespace
+			obj.SetNamespace(ns)
+		}
+		applier = ri.Namespace(ns)
+	}
+
+	_, err = applier.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// restMapping resolves the resource for an object, refreshing discovery once
+// so kinds registered moments ago (e.g. by a CRD) are found.
+func (c *Client) restMapping(obj *unstructured.Unstructured) (*meta.RESTMapping, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := c.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		c.Mapper.Reset()
+		mapping, err = c.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", gvk.String(), err)
+	}
+	return mapping, nil
+}
+
+// DecodeManifest splits a YAML or JSON manifest into unstructured objects.
+// Empty documents are skipped.
+func DecodeManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(manifest), 4096)
+
+	var objs []*unstructured.Unstructured
+	for {
+		var raw map[string]interface{}
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: raw}
+		if obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("manifest object is missing kind or metadata.name")
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"testing"
+)
+
+func TestDecodeManifest(t *testing.T) {
+	manifest := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+---
+# Empty documents are skipped
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: second
+  namespace: other
+`
+
+	objs, err := DecodeManifest(manifest)
+	if err != nil {
+		t.Fatalf("DecodeManifest failed: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("Expected 2 objects, got %d", len(objs))
+	}
+	if objs[0].GetKind() != "ConfigMap" || objs[0].GetName() != "first" {
+		t.Errorf("Unexpected first object: %s/%s", objs[0].GetKind(), objs[0].GetName())
+	}
+	if objs[1].GetNamespace() != "other" {
+		t.Errorf("Expected namespace 'other', got %q", objs[1].GetNamespace())
+	}
+
+	// Objects without a name are rejected
+	if _, err := DecodeManifest("apiVersion: v1\nkind: ConfigMap\n"); err == nil {
+		t.Error("Expected error for object without metadata.name")
+	}
+}
@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultApplyConcurrency bounds how many objects ApplyBulk applies at once.
+const DefaultApplyConcurrency = 8
+
+// prerequisiteKinds are applied one by one before anything else, since other
+// objects can't be created until they exist.
+var prerequisiteKinds = map[string]bool{
+	"Namespace":                true,
+	"CustomResourceDefinition": true,
+}
+
+// ApplyBulkYAML is ApplyYAML for large manifests: see ApplyBulk.
+func (c *Client) ApplyBulkYAML(ctx context.Context, manifest string, defaultNamespace string, concurrency int) error {
+	objs, err := DecodeManifest(manifest)
+	if err != nil {
+		return err
+	}
+	return c.ApplyBulk(ctx, objs, defaultNamespace, concurrency)
+}
+
+// ApplyBulk server-side applies objects that don't depend on each other in
+// parallel, at most concurrency at a time (0 means DefaultApplyConcurrency).
+// Namespaces and CRDs go first, in order. Every object is attempted, and all
+// failures are returned together.
+func (c *Client) ApplyBulk(ctx context.Context, objs []*unstructured.Unstructured, defaultNamespace string, concurrency int) error {
+	first, rest := splitPrerequisites(objs)
+	for _, obj := range first {
+		if err := c.Apply(ctx, obj, defaultNamespace); err != nil {
+			return err
+		}
+	}
+
+	fns := make([]func(context.Context) error, len(rest))
+	for i, obj := range rest {
+		fns[i] = func(ctx context.Context) error {
+			return c.Apply(ctx, obj, defaultNamespace)
+		}
+	}
+	return Parallel(ctx, concurrency, fns...)
+}
+
+// splitPrerequisites separates the objects others depend on, keeping the
+// order within both groups.
+func splitPrerequisites(objs []*unstructured.Unstructured) (first, rest []*unstructured.Unstructured) {
+	for _, obj := range objs {
+		if prerequisiteKinds[obj.GetKind()] {
+			first = append(first, obj)
+		} else {
+			rest = append(rest, obj)
+		}
+	}
+	return first, rest
+}
+
+// Parallel runs fns with at most limit running at once (0 means
+// DefaultApplyConcurrency), e.g. the independent Create calls of a scenario
+// Setup. Every fn runs unless ctx is cancelled first; their errors are joined.
+func Parallel(ctx context.Context, limit int, fns ...func(context.Context) error) error {
+	if limit <= 0 {
+		limit = DefaultApplyConcurrency
+	}
+	errs := make([]error, len(fns))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i, fn := range fns {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(ctx)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallel(t *testing.T) {
+	var running, peak atomic.Int32
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+
+	var fns []func(context.Context) error
+	for i := 0; i < 10; i++ {
+		fns = append(fns, func(context.Context) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			switch i {
+			case 2:
+				return errA
+			case 7:
+				return errB
+			}
+			return nil
+		})
+	}
+
+	err := Parallel(context.Background(), 3, fns...)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Expected both errors, got %v", err)
+	}
+	if p := peak.Load(); p > 3 || p < 2 {
+		t.Errorf("Expected at most 3 concurrent calls, peak was %d", p)
+	}
+
+	if err := Parallel(context.Background(), 0); err != nil {
+		t.Errorf("Expected no error without work, got %v", err)
+	}
+}
+
+func TestSplitPrerequisites(t *testing.T) {
+	objs, err := DecodeManifest(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: shop
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`)
+	if err != nil {
+		t.Fatalf("DecodeManifest failed: %v", err)
+	}
+
+	first, rest := splitPrerequisites(objs)
+	if len(first) != 2 || first[0].GetKind() != "Namespace" || first[1].GetKind() != "CustomResourceDefinition" {
+		t.Errorf("Unexpected prerequisites: %v", first)
+	}
+	if len(rest) != 2 || rest[0].GetName() != "config" || rest[1].GetName() != "web" {
+		t.Errorf("Unexpected remaining objects: %v", rest)
+	}
+}
@@ -2,21 +2,37 @@
 package k8s
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-// Client wraps the Kubernetes clientset with helper methods.
+// Client wraps the Kubernetes clientset with helper methods. Clientset is an
+// interface so tests can wire in a fake one.
 type Client struct {
-	Clientset *kubernetes.Clientset
+	Clientset kubernetes.Interface
+	Dynamic   dynamic.Interface
+	Mapper    *restmapper.DeferredDiscoveryRESTMapper
 	Config    *rest.Config
+
+	opts       ClientOptions
+	kubeconfig string
 }
 
 // NewClientFromKubeconfig creates a new Client from an in-memory kubeconfig string.
 func NewClientFromKubeconfig(kubeconfig string) (*Client, error) {
+	return NewClientFromKubeconfigWithOptions(kubeconfig, DefaultClientOptions())
+}
+
+// NewClientFromKubeconfigWithOptions creates a new Client with custom rate limiting and retries.
+func NewClientFromKubeconfigWithOptions(kubeconfig string, opts ClientOptions) (*Client, error) {
 	// Parse kubeconfig from string
 	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfig))
 	if err != nil {
@@ -24,21 +40,80 @@ func NewClientFromKubeconfig(kubeconfig string) (*Client, error) {
 	}
 
 	// Create clientset
-	// Increase rate limits to prevent "client-side throttling" logs and UI lag
-	config.QPS = 50.0
-	config.Burst = 100
+	config.QPS = opts.QPS
+	config.Burst = opts.Burst
+	config.RateLimiter = opts.RateLimiter
+	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		return progressTransport{next: newRetryTransport(rt, opts.Retry)}
+	}
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create clientset: %w", err)
 	}
 
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
 	return &Client{
 		Clientset: clientset,
+		Dynamic:   dyn,
+		Mapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery())),
 		Config:    config,
+		opts:      opts,
+
+		kubeconfig: kubeconfig,
 	}, nil
 }
 
+// Ping checks that the API server is reachable and ready.
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.Clientset.Discovery().RESTClient().Get().AbsPath("/readyz").Do(ctx).Error(); err != nil {
+		return fmt.Errorf("failed to reach API server: %w", err)
+	}
+	return nil
+}
+
+// Reload rebuilds the client from a new kubeconfig in place. A real
+// Clientset's value is replaced rather than the pointer, so engines and
+// scenarios holding it pick up the new connection. Requests should not be in
+// flight meanwhile.
+func (c *Client) Reload(kubeconfig string) error {
+	fresh, err := NewClientFromKubeconfigWithOptions(kubeconfig, c.opts)
+	if err != nil {
+		return err
+	}
+	if current, ok := c.Clientset.(*kubernetes.Clientset); ok && current != nil {
+		*current = *fresh.Clientset.(*kubernetes.Clientset)
+	} else {
+		c.Clientset = fresh.Clientset
+	}
+	c.Dynamic = fresh.Dynamic
+	c.Mapper = fresh.Mapper
+	c.Config = fresh.Config
+	c.kubeconfig = kubeconfig
+	return nil
+}
+
+// KubeconfigFor returns the client's kubeconfig with namespace as the
+// default of its current context, for tools such as kubectl run on the
+// learner's behalf.
+func (c *Client) KubeconfigFor(namespace string) ([]byte, error) {
+	if c.kubeconfig == "" {
+		return nil, fmt.Errorf("client has no kubeconfig")
+	}
+	config, err := clientcmd.Load([]byte(c.kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	if ctx, ok := config.Contexts[config.CurrentContext]; ok {
+		ctx.Namespace = namespace
+	}
+	return clientcmd.Write(*config)
+}
+
 // GetServerVersion returns the Kubernetes server version string.
 func (c *Client) GetServerVersion() (string, error) {
 	version, err := c.Clientset.Discovery().ServerVersion()
@@ -0,0 +1,57 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ExecResult holds the output of a command run inside a container.
+type ExecResult struct {
+	Stdout string
+	Stderr string
+}
+
+// Exec runs a command in a pod container and waits for it to finish.
+// An empty container name selects the pod's only container.
+// A non-zero exit code is reported as an error alongside the captured output.
+func Exec(ctx context.Context, clientset kubernetes.Interface, config *rest.Config, namespace, pod, container string, command []string) (ExecResult, error) {
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	if err != nil {
+		return ExecResult{}, fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	result := ExecResult{Stdout: stdout.String(), Stderr: stderr.String()}
+	if err != nil {
+		return result, fmt.Errorf("failed to exec in pod %s/%s: %w", namespace, pod, err)
+	}
+	return result, nil
+}
+
+// Exec runs a command in a pod container using this client's credentials.
+func (c *Client) Exec(ctx context.Context, namespace, pod, container string, command []string) (ExecResult, error) {
+	return Exec(ctx, c.Clientset, c.Config, namespace, pod, container, command)
+}
@@ -0,0 +1,79 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Get fetches any object by apiVersion and kind using the dynamic client.
+func (c *Client) Get(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %q: %w", apiVersion, err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gv.WithKind(kind))
+	mapping, err := c.restMapping(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return c.Dynamic.Resource(mapping.Resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	return c.Dynamic.Resource(mapping.Resource).Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetField evaluates a kubectl-style JSONPath expression against an object.
+func (c *Client) GetField(ctx context.Context, apiVersion, kind, namespace, name, path string) (string, error) {
+	obj, err := c.Get(ctx, apiVersion, kind, namespace, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s %s: %w", kind, name, err)
+	}
+
+	jp := jsonpath.New("field").AllowMissingKeys(true)
+	if err := jp.Parse(path); err != nil {
+		return "", fmt.Errorf("invalid path %q: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, obj.Object); err != nil {
+		return "", fmt.Errorf("failed to evaluate %q: %w", path, err)
+	}
+	return buf.String(), nil
+}
+
+// List fetches the objects of apiVersion and kind in namespace that match the label selector.
+func (c *Client) List(ctx context.Context, apiVersion, kind, namespace, selector string) ([]unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %q: %w", apiVersion, err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gv.WithKind(kind))
+	mapping, err := c.restMapping(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := metav1.ListOptions{LabelSelector: selector}
+	var list *unstructured.UnstructuredList
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		list, err = c.Dynamic.Resource(mapping.Resource).Namespace(namespace).List(ctx, opts)
+	} else {
+		list, err = c.Dynamic.Resource(mapping.Resource).List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// ClientOptions controls client-side rate limiting and retries.
+type ClientOptions struct {
+	// QPS and Burst configure the client's token bucket rate limiter.
+	QPS   float32
+	Burst int
+
+	// RateLimiter, when set, overrides QPS/Burst. Share one limiter between
+	// clients to cap the combined load of many pollers.
+	RateLimiter flowcontrol.RateLimiter
+
+	// Retry is the backoff applied to read requests that fail with
+	// throttling or transient server errors. Steps <= 1 disables retries.
+	Retry wait.Backoff
+}
+
+// DefaultClientOptions returns the options used by NewClientFromKubeconfig.
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		// Increase rate limits to prevent "client-side throttling" logs and UI lag
+		QPS:   50.0,
+		Burst: 100,
+		Retry: wait.Backoff{
+			Duration: 200 * time.Millisecond,
+			Factor:   2.0,
+			Jitter:   0.1,
+			Steps:    4,
+			Cap:      5 * time.Second,
+		},
+	}
+}
+
+// retryTransport retries idempotent requests on throttling and transient errors.
+// Watches are left alone: their callers re-establish them.
+type retryTransport struct {
+	next    http.RoundTripper
+	backoff wait.Backoff
+}
+
+func newRetryTransport(next http.RoundTripper, backoff wait.Backoff) http.RoundTripper {
+	if backoff.Steps <= 1 {
+		return next
+	}
+	return &retryTransport{next: next, backoff: backoff}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only requests without a body can be replayed safely
+	if req.Method != http.MethodGet && req.Method != http.MethodHead || isWatch(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	backoff := t.backoff
+	for {
+		resp, err := t.next.RoundTrip(req)
+		if !retryable(resp, err) || backoff.Steps <= 1 {
+			return resp, err
+		}
+
+		delay := backoff.Step()
+		if resp != nil {
+			if after := retryAfter(resp); after > delay {
+				delay = after
+			}
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// isWatch reports whether the request opens a watch.
+func isWatch(req *http.Request) bool {
+	watch := req.URL.Query().Get("watch")
+	return watch == "true" || watch == "1"
+}
+
+// retryable reports whether a response is worth retrying.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
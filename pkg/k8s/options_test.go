@@ -0,0 +1,119 @@
+package k8s
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// scriptedTransport answers each request with the next status in codes.
+type scriptedTransport struct {
+	codes      []int
+	retryAfter string
+	calls      int
+}
+
+func (s *scriptedTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	code := s.codes[len(s.codes)-1]
+	if s.calls < len(s.codes) {
+		code = s.codes[s.calls]
+	}
+	s.calls++
+	if code == 0 {
+		return nil, errors.New("connection reset")
+	}
+	resp := &http.Response{StatusCode: code, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}
+	if s.retryAfter != "" {
+		resp.Header.Set("Retry-After", s.retryAfter)
+	}
+	return resp, nil
+}
+
+func testBackoff(steps int) wait.Backoff {
+	return wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: steps}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		url       string
+		codes     []int
+		wantCode  int
+		wantCalls int
+	}{
+		{"get throttled", http.MethodGet, "/api/v1/pods", []int{429, 200}, 200, 2},
+		{"get unavailable", http.MethodGet, "/api/v1/pods", []int{503, 504, 200}, 200, 3},
+		{"get transport error", http.MethodGet, "/api/v1/pods", []int{0, 200}, 200, 2},
+		{"head throttled", http.MethodHead, "/api/v1/pods", []int{429, 200}, 200, 2},
+		{"get server error", http.MethodGet, "/api/v1/pods", []int{500, 200}, 500, 1},
+		{"get not found", http.MethodGet, "/api/v1/pods/web", []int{404, 200}, 404, 1},
+		{"post throttled", http.MethodPost, "/api/v1/pods", []int{429, 200}, 429, 1},
+		{"delete unavailable", http.MethodDelete, "/api/v1/pods/web", []int{503, 200}, 503, 1},
+		{"watch throttled", http.MethodGet, "/api/v1/pods?watch=true", []int{429, 200}, 429, 1},
+		{"watch numeric", http.MethodGet, "/api/v1/pods?watch=1", []int{503, 200}, 503, 1},
+		{"retries exhausted", http.MethodGet, "/api/v1/pods", []int{503}, 503, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &scriptedTransport{codes: tt.codes}
+			req, err := http.NewRequest(tt.method, "https://cluster.local"+tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := newRetryTransport(next, testBackoff(4)).RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip failed: %v", err)
+			}
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Status = %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if next.calls != tt.wantCalls {
+				t.Errorf("Calls = %d, want %d", next.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryTransportRetryAfter(t *testing.T) {
+	next := &scriptedTransport{codes: []int{429, 200}, retryAfter: "1"}
+	req, _ := http.NewRequest(http.MethodGet, "https://cluster.local/api/v1/pods", nil)
+
+	start := time.Now()
+	resp, err := newRetryTransport(next, testBackoff(4)).RoundTrip(req)
+	if err != nil || resp.StatusCode != 200 {
+		t.Fatalf("RoundTrip = %v, %v", resp, err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Retried after %v, want at least the 1s Retry-After", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":                              0,
+		"3":                             3 * time.Second,
+		"0":                             0,
+		"-2":                            0,
+		"Wed, 21 Oct 2015 07:28:00 GMT": 0,
+	}
+	for header, want := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", header)
+		if got := retryAfter(resp); got != want {
+			t.Errorf("retryAfter(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestNewRetryTransportDisabled(t *testing.T) {
+	next := &scriptedTransport{codes: []int{200}}
+	if rt := newRetryTransport(next, testBackoff(1)); rt != http.RoundTripper(next) {
+		t.Errorf("Steps 1 wrapped the transport: %T", rt)
+	}
+}
@@ -0,0 +1,166 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward opens a tunnel from a random local port to a pod port.
+// The tunnel stays open until stop is called or ctx is done.
+func PortForward(ctx context.Context, clientset kubernetes.Interface, config *rest.Config, namespace, pod string, port int) (localPort int, stop func(), err error) {
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create round tripper: %w", err)
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	fw, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", port)}, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create port forwarder: %w", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() { close(stopCh) })
+	}
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		return 0, nil, fmt.Errorf("failed to forward to pod %s/%s: %w", namespace, pod, err)
+	case <-ctx.Done():
+		stop()
+		return 0, nil, ctx.Err()
+	}
+
+	ports, err := fw.GetPorts()
+	if err != nil || len(ports) == 0 {
+		stop()
+		return 0, nil, fmt.Errorf("failed to get forwarded port: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	return int(ports[0].Local), stop, nil
+}
+
+// PortForwardService forwards to a running pod behind a Service, resolving the
+// Service port to the pod's target port the same way kubectl port-forward does.
+func PortForwardService(ctx context.Context, clientset kubernetes.Interface, config *rest.Config, namespace, service string, port int32) (int, func(), error) {
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, service, metav1.GetOptions{})
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get service %s: %w", service, err)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return 0, nil, fmt.Errorf("service %s has no selector", service)
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to list pods for service %s: %w", service, err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, sp := range svc.Spec.Ports {
+			if sp.Port != port {
+				continue
+			}
+			target, err := resolveTargetPort(sp, &pod)
+			if err != nil {
+				return 0, nil, err
+			}
+			return PortForward(ctx, clientset, config, namespace, pod.Name, target)
+		}
+		return 0, nil, fmt.Errorf("service %s has no port %d", service, port)
+	}
+
+	return 0, nil, fmt.Errorf("no running pods selected by service %s", service)
+}
+
+// resolveTargetPort maps a Service port to a container port, handling named ports.
+func resolveTargetPort(sp corev1.ServicePort, pod *corev1.Pod) (int, error) {
+	switch {
+	case sp.TargetPort.Type == intstr.String:
+		for _, c := range pod.Spec.Containers {
+			for _, cp := range c.Ports {
+				if cp.Name == sp.TargetPort.StrVal {
+					return int(cp.ContainerPort), nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("named target port %q not found in pod %s", sp.TargetPort.StrVal, pod.Name)
+	case sp.TargetPort.IntVal != 0:
+		return int(sp.TargetPort.IntVal), nil
+	default:
+		return int(sp.Port), nil
+	}
+}
+
+// ServiceHTTPGet port-forwards to a Service and performs an HTTP GET, returning the status code.
+func (c *Client) ServiceHTTPGet(ctx context.Context, namespace, service string, port int32, path string) (int, error) {
+	return ServiceHTTPGet(ctx, c.Clientset, c.Config, namespace, service, port, path)
+}
+
+// ServiceHTTPGet is Client.ServiceHTTPGet for any clientset and config.
+func ServiceHTTPGet(ctx context.Context, clientset kubernetes.Interface, config *rest.Config, namespace, service string, port int32, path string) (int, error) {
+	localPort, stop, err := PortForwardService(ctx, clientset, config, namespace, service, port)
+	if err != nil {
+		return 0, err
+	}
+	defer stop()
+
+	return httpGetStatus(ctx, fmt.Sprintf("http://127.0.0.1:%d%s", localPort, path))
+}
+
+// httpGetStatus performs a GET with a short timeout and returns the status code.
+func httpGetStatus(ctx context.Context, url string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,88 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type progressKey struct{}
+
+// WithProgress returns a context whose API writes, and the steps passed to
+// ReportProgress, are reported to fn, e.g. "Creating deployment…". fn must
+// not block.
+func WithProgress(ctx context.Context, fn func(string)) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress reports a step to the context's progress function, if any.
+func ReportProgress(ctx context.Context, format string, args ...interface{}) {
+	if fn, ok := ctx.Value(progressKey{}).(func(string)); ok {
+		fn(fmt.Sprintf(format, args...))
+	}
+}
+
+// progressTransport reports the writes of requests made with WithProgress.
+type progressTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t progressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if step := describeWrite(req.Method, req.URL.Path); step != "" {
+		ReportProgress(req.Context(), "%s", step)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// writeVerbs names what each write method does to an object.
+var writeVerbs = map[string]string{
+	http.MethodPost:   "Creating",
+	http.MethodPut:    "Updating",
+	http.MethodPatch:  "Applying",
+	http.MethodDelete: "Deleting",
+}
+
+// describeWrite describes a write to an API path, e.g. "Creating deployment…"
+// for a POST to /apis/apps/v1/namespaces/demo/deployments. Reads and
+// subresources are not described.
+func describeWrite(method, path string) string {
+	verb, ok := writeVerbs[method]
+	if !ok {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:] // api/v1
+	case len(parts) >= 3 && parts[0] == "apis":
+		parts = parts[3:] // apis/group/version
+	default:
+		return ""
+	}
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		parts = parts[2:] // namespaces/<ns>/<resource>...
+	}
+
+	switch len(parts) {
+	case 1:
+		return fmt.Sprintf("%s %s…", verb, singular(parts[0]))
+	case 2:
+		return fmt.Sprintf("%s %s %s…", verb, singular(parts[0]), parts[1])
+	}
+	return ""
+}
+
+// singular turns a plural resource name into its kind in lower case.
+func singular(resource string) string {
+	switch {
+	case strings.HasSuffix(resource, "ies"):
+		return strings.TrimSuffix(resource, "ies") + "y"
+	case strings.HasSuffix(resource, "sses"):
+		return strings.TrimSuffix(resource, "es")
+	case resource == "endpoints":
+		return resource
+	}
+	return strings.TrimSuffix(resource, "s")
+}
@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDescribeWrite(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{"POST", "/api/v1/namespaces", "Creating namespace…"},
+		{"POST", "/apis/apps/v1/namespaces/demo/deployments", "Creating deployment…"},
+		{"PATCH", "/apis/networking.k8s.io/v1/namespaces/demo/networkpolicies/deny", "Applying networkpolicy deny…"},
+		{"PUT", "/apis/networking.k8s.io/v1/namespaces/demo/ingresses/web", "Updating ingress web…"},
+		{"DELETE", "/api/v1/namespaces/demo", "Deleting namespace demo…"},
+		{"POST", "/apis/storage.k8s.io/v1/storageclasses", "Creating storageclass…"},
+		{"PUT", "/api/v1/namespaces/demo/endpoints/web", "Updating endpoints web…"},
+		{"GET", "/api/v1/namespaces/demo/pods", ""},
+		{"POST", "/api/v1/namespaces/demo/pods/web/eviction", ""},
+		{"POST", "/healthz", ""},
+	}
+	for _, tt := range tests {
+		if got := describeWrite(tt.method, tt.path); got != tt.want {
+			t.Errorf("describeWrite(%s %s) = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestReportProgress(t *testing.T) {
+	ReportProgress(context.Background(), "No reporter, no panic")
+
+	var got []string
+	ctx := WithProgress(context.Background(), func(s string) { got = append(got, s) })
+	ReportProgress(ctx, "Waiting for %d pods…", 2)
+	if len(got) != 1 || got[0] != "Waiting for 2 pods…" {
+		t.Errorf("Unexpected progress: %v", got)
+	}
+}
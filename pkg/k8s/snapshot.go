@@ -0,0 +1,190 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// snapshotResources are the namespaced kinds captured by Snapshot, in restore order.
+var snapshotResources = []schema.GroupVersionResource{
+	{Version: "v1", Resource: "serviceaccounts"},
+	{Version: "v1", Resource: "configmaps"},
+	{Version: "v1", Resource: "secrets"},
+	{Version: "v1", Resource: "resourcequotas"},
+	{Version: "v1", Resource: "limitranges"},
+	{Version: "v1", Resource: "persistentvolumeclaims"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings"},
+	{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+	{Version: "v1", Resource: "services"},
+	{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+	{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"},
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+	{Group: "batch", Version: "v1", Resource: "jobs"},
+	{Group: "batch", Version: "v1", Resource: "cronjobs"},
+	{Version: "v1", Resource: "pods"},
+}
+
+// Snapshot is the captured state of the user-facing objects in a namespace.
+type Snapshot struct {
+	Namespace string
+	Objects   map[schema.GroupVersionResource][]*unstructured.Unstructured
+}
+
+// Snapshot captures the objects in a namespace so Restore can revert later edits.
+// Objects owned by others (e.g. a Deployment's Pods) and ones Kubernetes creates
+// in every namespace are left out; their owners recreate them.
+func (c *Client) Snapshot(ctx context.Context, namespace string) (*Snapshot, error) {
+	snap := &Snapshot{
+		Namespace: namespace,
+		Objects:   make(map[schema.GroupVersionResource][]*unstructured.Unstructured),
+	}
+	for _, gvr := range snapshotResources {
+		objs, err := c.listTracked(ctx, gvr, namespace)
+		if err != nil {
+			return nil, err
+		}
+		for i := range objs {
+			snap.Objects[gvr] = append(snap.Objects[gvr], sanitize(&objs[i]))
+		}
+	}
+	return snap, nil
+}
+
+// Restore reverts a namespace to a snapshot: objects created since are deleted,
+// deleted ones are recreated and edited ones are overwritten. Objects whose
+// changes can't be updated in place (e.g. Pod specs) are replaced.
+func (c *Client) Restore(ctx context.Context, snap *Snapshot) error {
+	if _, err := c.Clientset.CoreV1().Namespaces().Get(ctx, snap.Namespace, metav1.GetOptions{}); err != nil {
+		return fmt.Errorf("failed to find namespace %s: %w", snap.Namespace, err)
+	}
+
+	for _, gvr := range snapshotResources {
+		live, err := c.listTracked(ctx, gvr, snap.Namespace)
+		if err != nil {
+			return err
+		}
+		ri := c.Dynamic.Resource(gvr).Namespace(snap.Namespace)
+
+		wanted := make(map[string]*unstructured.Unstructured)
+		for _, obj := range snap.Objects[gvr] {
+			wanted[obj.GetName()] = obj
+		}
+		current := make(map[string]*unstructured.Unstructured)
+		for i := range live {
+			obj := &live[i]
+			if _, ok := wanted[obj.GetName()]; !ok {
+				if err := ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+					return fmt.Errorf("failed to delete %s %s: %w", obj.GetKind(), obj.GetName(), err)
+				}
+				continue
+			}
+			current[obj.GetName()] = obj
+		}
+
+		for name, want := range wanted {
+			obj := want.DeepCopy()
+			existing, ok := current[name]
+			if ok && unchanged(want, existing) {
+				continue
+			}
+			if ok {
+				obj.SetResourceVersion(existing.GetResourceVersion())
+				_, err = ri.Update(ctx, obj, metav1.UpdateOptions{FieldManager: FieldManager})
+				if err == nil {
+					continue
+				}
+				if !apierrors.IsInvalid(err) {
+					return fmt.Errorf("failed to restore %s %s: %w", obj.GetKind(), name, err)
+				}
+				// Immutable fields changed: replace the object
+				if err := c.deleteAndWait(ctx, gvr, snap.Namespace, name); err != nil {
+					return err
+				}
+				obj.SetResourceVersion("")
+			}
+			if _, err := ri.Create(ctx, obj, metav1.CreateOptions{FieldManager: FieldManager}); err != nil {
+				return fmt.Errorf("failed to recreate %s %s: %w", obj.GetKind(), name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// listTracked lists the objects of a kind that snapshots track.
+func (c *Client) listTracked(ctx context.Context, gvr schema.GroupVersionResource, namespace string) ([]unstructured.Unstructured, error) {
+	list, err := c.Dynamic.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+	}
+	var objs []unstructured.Unstructured
+	for _, obj := range list.Items {
+		if tracked(&obj) {
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+// deleteAndWait deletes an object and waits until it is gone, so a replacement
+// with the same name can be created.
+func (c *Client) deleteAndWait(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) error {
+	ri := c.Dynamic.Resource(gvr).Namespace(namespace)
+	zero := int64(0)
+	if err := ri.Delete(ctx, name, metav1.DeleteOptions{GracePeriodSeconds: &zero}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s %s: %w", gvr.Resource, name, err)
+	}
+	for {
+		if _, err := ri.Get(ctx, name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %s %s to be deleted: %w", gvr.Resource, name, ctx.Err())
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+// tracked reports whether an object belongs in a snapshot.
+func tracked(obj *unstructured.Unstructured) bool {
+	if len(obj.GetOwnerReferences()) > 0 {
+		return false
+	}
+	switch obj.GetKind() {
+	case "ConfigMap":
+		return obj.GetName() != "kube-root-ca.crt"
+	case "ServiceAccount":
+		return obj.GetName() != "default"
+	case "Secret":
+		t, _, _ := unstructured.NestedString(obj.Object, "type")
+		return t != "kubernetes.io/service-account-token"
+	}
+	return true
+}
+
+// sanitize strips server-populated fields so an object can be recreated.
+func sanitize(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	out := obj.DeepCopy()
+	for _, field := range []string{"uid", "resourceVersion", "creationTimestamp", "generation", "managedFields", "deletionTimestamp", "deletionGracePeriodSeconds", "selfLink"} {
+		unstructured.RemoveNestedField(out.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(out.Object, "metadata", "annotations", "deployment.kubernetes.io/revision")
+	unstructured.RemoveNestedField(out.Object, "status")
+	return out
+}
+
+// unchanged reports whether a live object still matches its snapshot.
+func unchanged(want, live *unstructured.Unstructured) bool {
+	got := sanitize(live)
+	return equality.Semantic.DeepEqual(want.Object, got.Object)
+}
@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func object(kind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace("dojo")
+	return obj
+}
+
+func TestTracked(t *testing.T) {
+	owned := object("Pod", "web-abc")
+	owned.SetOwnerReferences([]metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web"}})
+
+	token := object("Secret", "builder-token")
+	token.Object["type"] = "kubernetes.io/service-account-token"
+
+	tests := []struct {
+		obj  *unstructured.Unstructured
+		want bool
+	}{
+		{object("Pod", "standalone"), true},
+		{owned, false},
+		{object("ConfigMap", "kube-root-ca.crt"), false},
+		{object("ConfigMap", "app-config"), true},
+		{object("ServiceAccount", "default"), false},
+		{object("ServiceAccount", "builder"), true},
+		{token, false},
+		{object("Secret", "db-password"), true},
+	}
+	for _, tt := range tests {
+		if got := tracked(tt.obj); got != tt.want {
+			t.Errorf("tracked(%s/%s) = %v, want %v", tt.obj.GetKind(), tt.obj.GetName(), got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeAndUnchanged(t *testing.T) {
+	live := object("ConfigMap", "app-config")
+	live.SetResourceVersion("42")
+	live.SetUID("1234")
+	live.Object["data"] = map[string]interface{}{"mode": "broken"}
+	live.Object["status"] = map[string]interface{}{"phase": "Active"}
+
+	snap := sanitize(live)
+	if snap.GetResourceVersion() != "" || snap.GetUID() != "" {
+		t.Errorf("Expected server fields to be stripped, got %v", snap.Object["metadata"])
+	}
+	if _, ok := snap.Object["status"]; ok {
+		t.Error("Expected status to be stripped")
+	}
+	if live.GetResourceVersion() != "42" {
+		t.Error("sanitize must not modify its input")
+	}
+
+	// A new resourceVersion alone is not a user edit
+	live.SetResourceVersion("43")
+	if !unchanged(snap, live) {
+		t.Error("Expected object with only a new resourceVersion to be unchanged")
+	}
+
+	live.Object["data"] = map[string]interface{}{"mode": "fixed"}
+	if unchanged(snap, live) {
+		t.Error("Expected edited data to be detected")
+	}
+}
@@ -0,0 +1,421 @@
+// Package packs provides installation of downloadable scenario packs.
+//
+// A pack is a .tar.gz of YAML scenario definitions. It is verified against a
+// SHA-256 checksum and, when a public key is given, an ed25519 signature
+// before being installed under ~/.k8s-dojo/packs/<name>. Its script checks
+// must run in a validator pod, and setup scripts, which run on the learner's
+// machine, are refused unless the pack is trusted.
+package packs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+const (
+	manifestFile = "pack.json"
+
+	// maxPackSize bounds downloads and extracted content.
+	maxPackSize = 32 << 20
+)
+
+// ErrNotInstalled is returned for operations on an unknown pack.
+var ErrNotInstalled = errors.New("pack not installed")
+
+var namePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Pack describes an installed scenario pack.
+type Pack struct {
+	Name         string    `json:"name"`
+	URL          string    `json:"url"`
+	SHA256       string    `json:"sha256"`
+	PublicKey    string    `json:"publicKey,omitempty"`    // base64 ed25519 key used to verify updates
+	Verified     bool      `json:"verified"`               // Whether a checksum passed by the user or a signature matched, see AddOptions
+	TrustScripts bool      `json:"trustScripts,omitempty"` // Whether setup scripts and shell commands may run on this machine, see AddOptions
+	Scenarios    []string  `json:"scenarios"`
+	InstalledAt  time.Time `json:"installedAt"`
+
+	dir string
+}
+
+// Dir returns the directory holding the pack's scenario files.
+func (p *Pack) Dir() string {
+	return p.dir
+}
+
+// Category returns the sidebar category the pack's scenarios are listed under.
+func (p *Pack) Category() string {
+	return "Pack: " + p.Name
+}
+
+// AddOptions control how a pack is verified and named.
+type AddOptions struct {
+	// Name overrides the name derived from the URL.
+	Name string
+	// SHA256 is the expected checksum. If empty, <url>.sha256 is fetched,
+	// which only catches corrupted downloads: the pack is then installed
+	// with Verified false unless PublicKey is set.
+	SHA256 string
+	// PublicKey is a base64 ed25519 key. If set, <url>.sig must hold a valid
+	// base64 signature of the tarball.
+	PublicKey string
+	// TrustScripts allows scenarios with a setup script or quick commands
+	// other than plain kubectl invocations, which run on this machine with
+	// the learner's kubeconfig. Without it such a pack is refused. Script
+	// checks must run in a validator pod either way.
+	TrustScripts bool
+}
+
+// Manager installs and lists packs in a directory.
+type Manager struct {
+	dir    string
+	client *http.Client
+}
+
+// DefaultDir returns ~/.k8s-dojo/packs.
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".k8s-dojo", "packs")
+}
+
+// NewManager creates a pack manager.
+// If dir is empty, it defaults to ~/.k8s-dojo/packs
+func NewManager(dir string) (*Manager, error) {
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".k8s-dojo", "packs")
+	}
+	return &Manager{
+		dir:    dir,
+		client: &http.Client{Timeout: 2 * time.Minute},
+	}, nil
+}
+
+// Add downloads, verifies and installs the pack at rawURL, which must be https.
+func (m *Manager) Add(ctx context.Context, rawURL string, opts AddOptions) (*Pack, error) {
+	if u, err := url.Parse(rawURL); err != nil || u.Scheme != "https" {
+		return nil, fmt.Errorf("refusing %s: packs must be downloaded over https", rawURL)
+	}
+
+	name := opts.Name
+	if name == "" {
+		derived, err := nameFromURL(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		name = derived
+	}
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid pack name %q: use lowercase letters, digits and dashes", name)
+	}
+
+	data, err := m.download(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	sum, err := m.verify(ctx, rawURL, data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Pack{
+		Name:         name,
+		URL:          rawURL,
+		SHA256:       sum,
+		PublicKey:    opts.PublicKey,
+		Verified:     opts.SHA256 != "" || opts.PublicKey != "",
+		TrustScripts: opts.TrustScripts,
+		InstalledAt:  time.Now(),
+	}
+	if err := m.install(p, data); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Update re-downloads a pack from its recorded URL.
+// The checksum is taken from <url>.sha256 and the recorded public key, if any, must still verify;
+// without a key the updated pack is unverified.
+// Scripts stay trusted only if they were when the pack was added.
+func (m *Manager) Update(ctx context.Context, name string) (*Pack, error) {
+	p, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.Add(ctx, p.URL, AddOptions{Name: p.Name, PublicKey: p.PublicKey, TrustScripts: p.TrustScripts})
+}
+
+// Remove uninstalls a pack.
+func (m *Manager) Remove(name string) error {
+	if _, err := m.Get(name); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(m.dir, name)); err != nil {
+		return fmt.Errorf("failed to remove pack: %w", err)
+	}
+	return nil
+}
+
+// Get returns an installed pack by name.
+func (m *Manager) Get(name string) (*Pack, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %s", ErrNotInstalled, name)
+	}
+	dir := filepath.Join(m.dir, name)
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotInstalled, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pack manifest: %w", err)
+	}
+
+	p := &Pack{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to parse pack manifest: %w", err)
+	}
+	p.dir = dir
+	return p, nil
+}
+
+// List returns the installed packs sorted by name.
+func (m *Manager) List() ([]*Pack, error) {
+	entries, err := os.ReadDir(m.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packs: %w", err)
+	}
+
+	var packs []*Pack
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		p, err := m.Get(e.Name())
+		if err != nil {
+			continue
+		}
+		packs = append(packs, p)
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, nil
+}
+
+// LoadInto adds the scenarios of every installed pack to the registry,
+// each pack under its own category.
+func (m *Manager) LoadInto(reg *scenario.Registry) error {
+	packs, err := m.List()
+	if err != nil {
+		return err
+	}
+	for _, p := range packs {
+		if err := reg.LoadPack(p.Dir(), p.Category(), p.TrustScripts); err != nil {
+			return fmt.Errorf("pack %s: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) download(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", rawURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPackSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	if len(data) > maxPackSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", rawURL, maxPackSize)
+	}
+	return data, nil
+}
+
+// verify checks the tarball's checksum and optional signature and returns its hex digest.
+func (m *Manager) verify(ctx context.Context, rawURL string, data []byte, opts AddOptions) (string, error) {
+	digest := sha256.Sum256(data)
+	sum := hex.EncodeToString(digest[:])
+
+	want := opts.SHA256
+	if want == "" {
+		published, err := m.download(ctx, rawURL+".sha256")
+		if err != nil {
+			return "", fmt.Errorf("no checksum available (pass one explicitly or publish %s.sha256): %w", rawURL, err)
+		}
+		fields := strings.Fields(string(published))
+		if len(fields) == 0 {
+			return "", fmt.Errorf("empty checksum file %s.sha256", rawURL)
+		}
+		want = fields[0]
+	}
+	if !strings.EqualFold(want, sum) {
+		return "", fmt.Errorf("checksum mismatch: expected %s, got %s", want, sum)
+	}
+
+	if opts.PublicKey == "" {
+		return sum, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(opts.PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return "", fmt.Errorf("invalid public key: expected base64 ed25519 key")
+	}
+	rawSig, err := m.download(ctx, rawURL+".sig")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch signature: %w", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(rawSig)))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode signature: %w", err)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), data, sig) {
+		return "", fmt.Errorf("signature verification failed")
+	}
+	return sum, nil
+}
+
+// install extracts the tarball into a staging directory, validates the
+// scenarios and swaps it into place.
+func (m *Manager) install(p *Pack, data []byte) error {
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create packs directory: %w", err)
+	}
+	staging, err := os.MkdirTemp(m.dir, ".install-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err := extractYAML(data, staging); err != nil {
+		return err
+	}
+	defs, err := scenario.LoadDefinitions(staging)
+	if err != nil {
+		return fmt.Errorf("invalid pack: %w", err)
+	}
+	if len(defs) == 0 {
+		return fmt.Errorf("invalid pack: no scenarios found")
+	}
+	seen := make(map[string]bool)
+	for _, def := range defs {
+		if seen[def.ID] {
+			return fmt.Errorf("invalid pack: duplicate scenario id %s", def.ID)
+		}
+		seen[def.ID] = true
+		if err := def.RefusePackScripts(p.TrustScripts); err != nil {
+			return fmt.Errorf("invalid pack: %w", err)
+		}
+		p.Scenarios = append(p.Scenarios, def.ID)
+	}
+
+	manifest, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pack manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, manifestFile), manifest, 0644); err != nil {
+		return fmt.Errorf("failed to write pack manifest: %w", err)
+	}
+
+	dest := filepath.Join(m.dir, p.Name)
+	if err := os.RemoveAll(dest); err != nil {
+		return fmt.Errorf("failed to replace existing pack: %w", err)
+	}
+	if err := os.Rename(staging, dest); err != nil {
+		return fmt.Errorf("failed to install pack: %w", err)
+	}
+	p.dir = dest
+	return nil
+}
+
+// extractYAML writes the .yaml files of a gzipped tarball into dir.
+// Directory structure is flattened, so entries can never escape dir.
+func extractYAML(data []byte, dir string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to read pack: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	var total int64
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read pack: %w", err)
+		}
+
+		base := path.Base(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg || !strings.HasSuffix(base, ".yaml") || strings.HasPrefix(base, ".") {
+			continue
+		}
+		total += hdr.Size
+		if total > maxPackSize {
+			return fmt.Errorf("pack contents exceed %d bytes", maxPackSize)
+		}
+
+		content, err := io.ReadAll(io.LimitReader(tr, hdr.Size))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, base), content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", base, err)
+		}
+	}
+}
+
+// nameFromURL derives a pack name from the tarball's file name.
+func nameFromURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	base := path.Base(u.Path)
+	for _, ext := range []string{".tar.gz", ".tgz"} {
+		base = strings.TrimSuffix(base, ext)
+	}
+	name := strings.ToLower(base)
+	if !namePattern.MatchString(name) {
+		return "", fmt.Errorf("cannot derive a pack name from %s", rawURL)
+	}
+	return name, nil
+}
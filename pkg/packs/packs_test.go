@@ -0,0 +1,208 @@
+package packs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testScenario = `id: pack-demo
+name: "Demo"
+category: Networking
+setup: |
+  apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: demo
+checks:
+  - type: fieldEquals
+    kind: ConfigMap
+    object: demo
+    path: "{.data.ok}"
+    value: "true"
+`
+
+func testTarball(t *testing.T) []byte {
+	return tarball(t, map[string]string{
+		"demo-pack/demo.yaml": testScenario,
+		"demo-pack/README.md": "ignored",
+		"../../escape.yaml":   strings.Replace(testScenario, "pack-demo", "pack-escape", 1),
+	})
+}
+
+func tarball(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(body)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestManager(t *testing.T) {
+	tarball := testTarball(t)
+	digest := sha256.Sum256(tarball)
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, tarball))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/demo-pack.tar.gz", func(w http.ResponseWriter, r *http.Request) { w.Write(tarball) })
+	mux.HandleFunc("/demo-pack.tar.gz.sha256", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(hex.EncodeToString(digest[:]) + "  demo-pack.tar.gz\n"))
+	})
+	mux.HandleFunc("/demo-pack.tar.gz.sig", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(sig)) })
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	url := srv.URL + "/demo-pack.tar.gz"
+
+	m, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	m.client = srv.Client()
+	ctx := context.Background()
+
+	// Plain http is refused before anything is downloaded
+	insecure := strings.Replace(url, "https://", "http://", 1)
+	if _, err := m.Add(ctx, insecure, AddOptions{}); err == nil || !strings.Contains(err.Error(), "https") {
+		t.Errorf("Expected an http URL to be refused, got %v", err)
+	}
+
+	// Only the published checksum: installed, but unverified
+	p, err := m.Add(ctx, url, AddOptions{})
+	if err != nil {
+		t.Fatalf("Add with the published checksum failed: %v", err)
+	}
+	if p.Verified {
+		t.Error("Expected a pack checked only against <url>.sha256 to be unverified")
+	}
+	if got, _ := m.Get("demo-pack"); got == nil || got.Verified {
+		t.Error("Expected verified=false in the manifest")
+	}
+
+	// Wrong checksum and wrong key are rejected
+	if _, err := m.Add(ctx, url, AddOptions{SHA256: "deadbeef"}); err == nil {
+		t.Error("Expected checksum mismatch")
+	}
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+	if _, err := m.Add(ctx, url, AddOptions{PublicKey: base64.StdEncoding.EncodeToString(otherPub)}); err == nil {
+		t.Error("Expected signature verification to fail")
+	}
+
+	// Published checksum and valid signature are accepted
+	p, err = m.Add(ctx, url, AddOptions{PublicKey: base64.StdEncoding.EncodeToString(pub)})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if !p.Verified {
+		t.Error("Expected a signed pack to be verified")
+	}
+	if p.Name != "demo-pack" {
+		t.Errorf("Expected name demo-pack, got %s", p.Name)
+	}
+	if len(p.Scenarios) != 2 {
+		t.Errorf("Expected 2 scenarios, got %v", p.Scenarios)
+	}
+	if _, err := os.Stat(filepath.Join(p.Dir(), "escape.yaml")); err != nil {
+		t.Errorf("Expected tar paths to be flattened into the pack dir: %v", err)
+	}
+
+	list, err := m.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 pack, got %d", len(list))
+	}
+
+	if p, err := m.Update(ctx, "demo-pack"); err != nil {
+		t.Errorf("Update failed: %v", err)
+	} else if !p.Verified {
+		t.Error("Expected the recorded key to keep the update verified")
+	}
+
+	if err := m.Remove("demo-pack"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := m.Get("demo-pack"); err == nil {
+		t.Error("Expected pack to be removed")
+	}
+}
+
+func TestAddRefusesHostScripts(t *testing.T) {
+	scripted := tarball(t, map[string]string{
+		"demo.yaml": testScenario + "setupScript: kubectl create deployment web --image=nginx\n",
+	})
+	local := tarball(t, map[string]string{
+		"demo.yaml": testScenario + "  - type: script\n    runIn: local\n    script: kubectl get pods\n",
+	})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/scripted.tar.gz", func(w http.ResponseWriter, r *http.Request) { w.Write(scripted) })
+	mux.HandleFunc("/local.tar.gz", func(w http.ResponseWriter, r *http.Request) { w.Write(local) })
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	url := srv.URL + "/scripted.tar.gz"
+	digest := sha256.Sum256(scripted)
+	sum := hex.EncodeToString(digest[:])
+
+	m, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	m.client = srv.Client()
+	ctx := context.Background()
+
+	if _, err := m.Add(ctx, url, AddOptions{SHA256: sum}); err == nil || !strings.Contains(err.Error(), "--trust-scripts") {
+		t.Errorf("Expected an untrusted setup script to be refused, got %v", err)
+	}
+	if _, err := m.Get("scripted"); err == nil {
+		t.Error("Expected the refused pack not to be installed")
+	}
+
+	if _, err := m.Add(ctx, url, AddOptions{SHA256: sum, TrustScripts: true}); err != nil {
+		t.Fatalf("Add with trusted scripts failed: %v", err)
+	}
+	p, err := m.Get("scripted")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !p.TrustScripts {
+		t.Error("Expected the trust to be recorded in the manifest")
+	}
+	if !p.Verified {
+		t.Error("Expected a pack matching --sha256 to be verified")
+	}
+
+	// Trust never extends to script checks run on this machine
+	digest = sha256.Sum256(local)
+	if _, err := m.Add(ctx, srv.URL+"/local.tar.gz", AddOptions{SHA256: hex.EncodeToString(digest[:]), TrustScripts: true}); err == nil {
+		t.Error("Expected a runIn: local check to be refused")
+	}
+}
@@ -0,0 +1,74 @@
+// Package policy evaluates success conditions written in CEL, the Common
+// Expression Language Kubernetes uses for validating admission policies,
+// over objects fetched from the cluster.
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// costLimit bounds the work one evaluation may do, so a runaway expression
+// over a large list can't stall validation.
+const costLimit = 1_000_000
+
+// Expression is a compiled CEL condition that yields a bool.
+type Expression struct {
+	source  string
+	program cel.Program
+}
+
+// Compile compiles source with each of vars in scope as a dynamically typed
+// variable. Unknown variables and non-boolean results are compile errors.
+func Compile(source string, vars ...string) (*Expression, error) {
+	opts := []cel.EnvOption{ext.Strings()}
+	for _, v := range vars {
+		opts = append(opts, cel.Variable(v, cel.DynType))
+	}
+	env, err := cel.NewEnv(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	ast, iss := env.Compile(source)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("invalid expression: %w", iss.Err())
+	}
+	if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+		return nil, fmt.Errorf("expression yields %s, not bool", t)
+	}
+	program, err := env.Program(ast, cel.CostLimit(costLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build expression: %w", err)
+	}
+	return &Expression{source: source, program: program}, nil
+}
+
+// String returns the expression's source.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Eval evaluates the expression with vars bound to their values, typically
+// objects in their unstructured form.
+func (e *Expression) Eval(vars map[string]interface{}) (bool, error) {
+	out, _, err := e.program.Eval(vars)
+	if err != nil {
+		// Fields left unset trip "no such key"; has() guards against them
+		return false, fmt.Errorf("failed to evaluate %s: %w", e.source, err)
+	}
+	ok, isBool := out.Value().(bool)
+	if !isBool {
+		return false, fmt.Errorf("%s yields %v, not bool", e.source, out.Value())
+	}
+	return ok, nil
+}
+
+// KindVariable returns the variable an object of kind is bound to besides
+// "object", e.g. deployment for Deployment.
+func KindVariable(kind string) string {
+	return strings.ToLower(kind)
+}
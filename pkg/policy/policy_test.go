@@ -0,0 +1,85 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+)
+
+func deployment(privileged bool) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "labels": map[string]interface{}{"app": "web"}},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "image": "nginx:1.27"},
+						map[string]interface{}{"name": "sidecar", "image": "busybox", "securityContext": map[string]interface{}{"privileged": privileged}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr       string
+		privileged bool
+		want       bool
+	}{
+		{"deployment.spec.template.spec.containers.all(c, !has(c.securityContext) || !c.securityContext.privileged)", false, true},
+		{"deployment.spec.template.spec.containers.all(c, !has(c.securityContext) || !c.securityContext.privileged)", true, false},
+		{"object.spec.replicas >= 2 && object.metadata.labels.app == 'web'", false, true},
+		{"object.spec.template.spec.containers.exists(c, c.image.startsWith('nginx:'))", false, true},
+		{"object.spec.template.spec.containers.all(c, c.image.contains(':'))", false, false},
+	}
+	for _, tt := range tests {
+		expr, err := Compile(tt.expr, "object", KindVariable("Deployment"))
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", tt.expr, err)
+		}
+		obj := deployment(tt.privileged)
+		got, err := expr.Eval(map[string]interface{}{"object": obj, "deployment": obj})
+		if err != nil {
+			t.Fatalf("Eval(%q): %v", tt.expr, err)
+		}
+		if got != tt.want {
+			t.Errorf("Eval(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvalList(t *testing.T) {
+	expr, err := Compile("size(objects) == 2 && objects.all(o, o.spec.replicas > 0)", "objects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := expr.Eval(map[string]interface{}{"objects": []interface{}{deployment(false), deployment(true)}})
+	if err != nil || !got {
+		t.Errorf("Eval = %v, %v, want true", got, err)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct{ expr, want string }{
+		{"object.spec.replicas >", "invalid expression"},
+		{"pod.spec.nodeName == ''", "undeclared reference"},
+		{"'not a bool'", "not bool"},
+	}
+	for _, tt := range tests {
+		if _, err := Compile(tt.expr, "object"); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Compile(%q) = %v, want an error mentioning %q", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestEvalMissingField(t *testing.T) {
+	expr, err := Compile("object.spec.template.spec.containers.all(c, !c.securityContext.privileged)", "object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := expr.Eval(map[string]interface{}{"object": deployment(false)}); err == nil {
+		t.Error("Eval over an unset field succeeded, want an error")
+	}
+}
@@ -0,0 +1,271 @@
+// Package proctor lets an instructor follow the students on a classroom host.
+// Each student's dojo publishes its status to a shared directory, where the
+// instructor's live view reads it and leaves messages for the students to
+// pick up. The directory is private to its Unix user, so the students' dojos
+// and the instructor's live view must all run as that user on one host.
+package proctor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// StaleAfter is how long a status may go without an update before the
+// session is shown as disconnected.
+const StaleAfter = 30 * time.Second
+
+// Status is what a student's dojo publishes for the instructor.
+type Status struct {
+	Session  string        `json:"session"`
+	Cluster  string        `json:"cluster,omitempty"`
+	Scenario string        `json:"scenario,omitempty"` // ID of the running scenario, "" on the dashboard
+	Name     string        `json:"name,omitempty"`
+	Elapsed  time.Duration `json:"elapsed,omitempty"`
+	Check    string        `json:"check,omitempty"` // Message of the last check
+	Solved   bool          `json:"solved,omitempty"`
+	Commands []string      `json:"commands,omitempty"` // Recent kubectl commands, oldest first
+	Updated  time.Time     `json:"updated"`
+}
+
+// Stale reports whether the session stopped publishing before now.
+func (s Status) Stale(now time.Time) bool {
+	return now.Sub(s.Updated) > StaleAfter
+}
+
+// MessageKind says how a student's dojo shows a message.
+type MessageKind string
+
+const (
+	KindHint   MessageKind = "hint"   // Shown in the scenario panel
+	KindNotice MessageKind = "notice" // Shown in the header banner, e.g. "break in 5 minutes"
+)
+
+// Message is pushed to a session by the instructor or the system.
+type Message struct {
+	Kind MessageKind `json:"kind"`
+	Text string      `json:"text"`
+	From string      `json:"from,omitempty"` // e.g. "instructor"
+	Sent time.Time   `json:"sent"`
+}
+
+// Board is the directory the sessions publish to, one status file and one
+// message inbox per session.
+type Board struct {
+	dir string
+}
+
+// DefaultDir returns ~/.k8s-dojo/sessions.
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".k8s-dojo", "sessions")
+	}
+	return filepath.Join(home, ".k8s-dojo", "sessions")
+}
+
+// NewBoard returns the board in dir (default: ~/.k8s-dojo/sessions).
+func NewBoard(dir string) *Board {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	return &Board{dir: dir}
+}
+
+// Publish replaces the session's status.
+func (b *Board) Publish(s Status) error {
+	path, err := b.path(s.Session, ".json")
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode status: %w", err)
+	}
+	if err := b.ensureDir(); err != nil {
+		return err
+	}
+	// Written aside and renamed, so the live view never reads half a status
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write status: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write status: %w", err)
+	}
+	return nil
+}
+
+// Leave removes the session's status, e.g. when the student quits.
+func (b *Board) Leave(session string) error {
+	path, err := b.path(session, ".json")
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove status: %w", err)
+	}
+	return nil
+}
+
+// Statuses returns the status of every session, by session name.
+func (b *Board) Statuses() ([]Status, error) {
+	files, err := filepath.Glob(filepath.Join(b.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	var statuses []Status
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if os.IsNotExist(err) {
+			continue // The session just left
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f, err)
+		}
+		var s Status
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f, err)
+		}
+		statuses = append(statuses, s)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Session < statuses[j].Session })
+	return statuses, nil
+}
+
+// Push leaves a message in the session's inbox.
+func (b *Board) Push(session string, msg Message) error {
+	path, err := b.path(session, ".inbox")
+	if err != nil {
+		return err
+	}
+	msg.Text = strings.Join(strings.Fields(msg.Text), " ")
+	if msg.Text == "" {
+		return fmt.Errorf("message is empty")
+	}
+	if msg.Sent.IsZero() {
+		msg.Sent = time.Now()
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	if err := b.ensureDir(); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open inbox: %w", err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintf(f, "%s\n", data); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
+
+// Broadcast pushes msg to every session that is still connected and returns their names.
+func (b *Board) Broadcast(msg Message) ([]string, error) {
+	statuses, err := b.Statuses()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var sent []string
+	for _, s := range statuses {
+		if s.Stale(now) {
+			continue
+		}
+		if err := b.Push(s.Session, msg); err != nil {
+			return sent, fmt.Errorf("failed to message %s: %w", s.Session, err)
+		}
+		sent = append(sent, s.Session)
+	}
+	return sent, nil
+}
+
+// Take empties the session's inbox and returns the messages in it, oldest first.
+func (b *Board) Take(session string) ([]Message, error) {
+	path, err := b.path(session, ".inbox")
+	if err != nil {
+		return nil, err
+	}
+	// Moved aside first, so a message pushed meanwhile waits for the next take
+	taken := path + ".taken"
+	if err := os.Rename(path, taken); os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to take messages: %w", err)
+	}
+	defer os.Remove(taken)
+	if err := checkPrivate(taken); err != nil {
+		return nil, fmt.Errorf("refused messages for %s: %w", session, err)
+	}
+	f, err := os.Open(taken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read messages: %w", err)
+	}
+	defer f.Close()
+
+	var msgs []Message
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			return nil, fmt.Errorf("failed to parse message: %w", err)
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read messages: %w", err)
+	}
+	return msgs, nil
+}
+
+// ensureDir creates the board's directory, readable by its user only.
+func (b *Board) ensureDir() error {
+	if err := os.MkdirAll(b.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	// Older dojos created the directory world-readable
+	if err := os.Chmod(b.dir, 0700); err != nil {
+		return fmt.Errorf("failed to restrict session directory: %w", err)
+	}
+	return nil
+}
+
+// checkPrivate fails unless path is a regular file that only its owner, the
+// current user, can write: anyone else could have put messages in it.
+func checkPrivate(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return fmt.Errorf("failed to check inbox: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("inbox is not a regular file")
+	}
+	if info.Mode().Perm()&0022 != 0 {
+		return fmt.Errorf("inbox is writable by other users")
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("inbox is owned by uid %d", stat.Uid)
+	}
+	return nil
+}
+
+// path returns the session's file with the given extension.
+func (b *Board) path(session, ext string) (string, error) {
+	if session == "" || session != filepath.Base(session) || strings.HasPrefix(session, ".") {
+		return "", fmt.Errorf("invalid session name %q", session)
+	}
+	return filepath.Join(b.dir, session+ext), nil
+}
@@ -0,0 +1,175 @@
+package proctor
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPublishAndStatuses(t *testing.T) {
+	b := NewBoard(t.TempDir())
+	now := time.Now()
+	for _, s := range []Status{
+		{Session: "bob", Scenario: "net-dns-ndots", Updated: now},
+		{Session: "alice", Updated: now},
+		{Session: "bob", Scenario: "rbac-forbidden", Solved: true, Updated: now},
+	} {
+		if err := b.Publish(s); err != nil {
+			t.Fatalf("Publish(%s): %v", s.Session, err)
+		}
+	}
+
+	statuses, err := b.Statuses()
+	if err != nil {
+		t.Fatalf("Statuses: %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].Session != "alice" || statuses[1].Scenario != "rbac-forbidden" || !statuses[1].Solved {
+		t.Fatalf("got %+v, want alice then bob's latest status", statuses)
+	}
+
+	if err := b.Leave("alice"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+	if statuses, _ := b.Statuses(); len(statuses) != 1 {
+		t.Errorf("got %d statuses after alice left, want 1", len(statuses))
+	}
+}
+
+func texts(msgs []Message) []string {
+	var out []string
+	for _, m := range msgs {
+		out = append(out, m.Text)
+	}
+	return out
+}
+
+func TestMessages(t *testing.T) {
+	b := NewBoard(t.TempDir())
+	if msgs, err := b.Take("alice"); err != nil || msgs != nil {
+		t.Fatalf("Take on an empty inbox = %v, %v", msgs, err)
+	}
+
+	for _, text := range []string{"check the selector", "look at\nthe endpoints"} {
+		if err := b.Push("alice", Message{Kind: KindHint, Text: text}); err != nil {
+			t.Fatalf("Push: %v", err)
+		}
+	}
+	if err := b.Push("alice", Message{Kind: KindHint, Text: "  "}); err == nil {
+		t.Error("expected an empty message to be refused")
+	}
+
+	msgs, err := b.Take("alice")
+	if err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+	if want := []string{"check the selector", "look at the endpoints"}; !slices.Equal(texts(msgs), want) {
+		t.Errorf("got %q, want %q", texts(msgs), want)
+	}
+	if msgs[0].Kind != KindHint || msgs[0].Sent.IsZero() {
+		t.Errorf("got %+v, want a hint with its send time", msgs[0])
+	}
+	if msgs, _ := b.Take("alice"); msgs != nil {
+		t.Errorf("got %q on the second take, want none", texts(msgs))
+	}
+}
+
+func TestBroadcastSkipsDisconnected(t *testing.T) {
+	b := NewBoard(t.TempDir())
+	now := time.Now()
+	for _, s := range []Status{
+		{Session: "alice", Updated: now},
+		{Session: "bob", Updated: now.Add(-time.Hour)},
+		{Session: "carol", Updated: now},
+	} {
+		if err := b.Publish(s); err != nil {
+			t.Fatalf("Publish(%s): %v", s.Session, err)
+		}
+	}
+
+	sent, err := b.Broadcast(Message{Kind: KindNotice, Text: "break in 5 minutes"})
+	if err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	if want := []string{"alice", "carol"}; !slices.Equal(sent, want) {
+		t.Errorf("sent to %v, want %v", sent, want)
+	}
+	if msgs, _ := b.Take("carol"); len(msgs) != 1 || msgs[0].Kind != KindNotice {
+		t.Errorf("carol got %+v, want the notice", msgs)
+	}
+	if msgs, _ := b.Take("bob"); msgs != nil {
+		t.Errorf("disconnected bob got %+v", msgs)
+	}
+}
+
+func TestInvalidSession(t *testing.T) {
+	b := NewBoard(t.TempDir())
+	for _, session := range []string{"", "../alice", "a/b", ".hidden"} {
+		if err := b.Publish(Status{Session: session}); err == nil {
+			t.Errorf("expected session %q to be refused", session)
+		}
+	}
+}
+
+func TestStale(t *testing.T) {
+	now := time.Now()
+	if (Status{Updated: now.Add(-time.Second)}).Stale(now) {
+		t.Error("fresh status reported stale")
+	}
+	if !(Status{Updated: now.Add(-time.Minute)}).Stale(now) {
+		t.Error("old status not reported stale")
+	}
+}
+
+func TestBoardIsPrivate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "sessions")
+	if err := os.MkdirAll(dir, 0755); err != nil { // As older dojos left it
+		t.Fatal(err)
+	}
+	b := NewBoard(dir)
+	if err := b.Publish(Status{Session: "alice", Updated: time.Now()}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	for path, want := range map[string]os.FileMode{dir: 0700, filepath.Join(dir, "alice.json"): 0600} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("%s has mode %o, want %o", path, got, want)
+		}
+	}
+}
+
+func TestTakeRefusesForeignInbox(t *testing.T) {
+	b := NewBoard(t.TempDir())
+	if err := b.Push("alice", Message{Kind: KindHint, Text: "check the selector"}); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	inbox := filepath.Join(b.dir, "alice.inbox")
+	if info, err := os.Stat(inbox); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("Expected a 0600 inbox, got %v, %v", info, err)
+	}
+
+	// Messages another user could have written
+	if err := os.Chmod(inbox, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if msgs, err := b.Take("alice"); err == nil || msgs != nil {
+		t.Errorf("Take of a world-writable inbox = %v, %v; want an error", texts(msgs), err)
+	}
+	forged := filepath.Join(t.TempDir(), "forged")
+	if err := os.WriteFile(forged, []byte(`{"kind":"hint","text":"run this"}`+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(forged, inbox); err != nil {
+		t.Fatal(err)
+	}
+	if msgs, err := b.Take("alice"); err == nil || msgs != nil {
+		t.Errorf("Take of a symlinked inbox = %v, %v; want an error", texts(msgs), err)
+	}
+	if msgs, err := b.Take("alice"); err != nil || msgs != nil {
+		t.Errorf("Expected refused inboxes to be discarded, got %v, %v", texts(msgs), err)
+	}
+}
@@ -0,0 +1,162 @@
+// Package rbac issues restricted credentials for the learner's terminal: a
+// ServiceAccount that can change anything in the scenario namespace but only
+// read the rest of the cluster, so a stray command can't delete kube-system.
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// ServiceAccountName is the learner's account in each scenario namespace.
+	ServiceAccountName = "k8s-dojo-learner"
+
+	// ReadOnlyRole is the ClusterRole granting read access to cluster-scoped resources.
+	ReadOnlyRole = "k8s-dojo-learner-view"
+
+	// TokenTTL is how long issued tokens stay valid.
+	TokenTTL = 24 * time.Hour
+)
+
+// readOnlyRules cover the cluster-scoped resources scenarios ask learners to
+// inspect. Namespaced reads come from the built-in view role.
+var readOnlyRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"nodes", "namespaces", "persistentvolumes"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"storage.k8s.io"},
+		Resources: []string{"storageclasses"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"apiextensions.k8s.io"},
+		Resources: []string{"customresourcedefinitions"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"networking.k8s.io"},
+		Resources: []string{"ingressclasses"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+}
+
+// Provision creates the learner's ServiceAccount in namespace, grants it admin
+// there and read-only access elsewhere, and returns a kubeconfig for it based on
+// the cluster entry of adminKubeconfig. Every object is owned by the namespace,
+// so deleting it revokes everything and resetting the scenario leaves them alone.
+func Provision(ctx context.Context, clientset kubernetes.Interface, adminKubeconfig, namespace string) (string, error) {
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+
+	owner := []metav1.OwnerReference{{APIVersion: "v1", Kind: "Namespace", Name: ns.Name, UID: ns.UID}}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: ServiceAccountName, Namespace: namespace, OwnerReferences: owner}}
+	if err := create(func() error {
+		_, err := clientset.CoreV1().ServiceAccounts(namespace).Create(ctx, sa, metav1.CreateOptions{})
+		return err
+	}); err != nil {
+		return "", fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: ServiceAccountName, Namespace: namespace}}
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: ServiceAccountName, Namespace: namespace, OwnerReferences: owner},
+		Subjects:   subjects,
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+	}
+	if err := create(func() error {
+		_, err := clientset.RbacV1().RoleBindings(namespace).Create(ctx, binding, metav1.CreateOptions{})
+		return err
+	}); err != nil {
+		return "", fmt.Errorf("failed to create role binding: %w", err)
+	}
+
+	role := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: ReadOnlyRole}, Rules: readOnlyRules}
+	if err := create(func() error {
+		_, err := clientset.RbacV1().ClusterRoles().Create(ctx, role, metav1.CreateOptions{})
+		return err
+	}); err != nil {
+		return "", fmt.Errorf("failed to create cluster role: %w", err)
+	}
+
+	for _, ref := range []string{"view", ReadOnlyRole} {
+		crb := &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s-%s", ServiceAccountName, namespace, ref), OwnerReferences: owner},
+			Subjects:   subjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: ref},
+		}
+		if err := create(func() error {
+			_, err := clientset.RbacV1().ClusterRoleBindings().Create(ctx, crb, metav1.CreateOptions{})
+			return err
+		}); err != nil {
+			return "", fmt.Errorf("failed to create cluster role binding: %w", err)
+		}
+	}
+
+	ttl := int64(TokenTTL.Seconds())
+	tr, err := clientset.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, ServiceAccountName, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &ttl},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to issue token: %w", err)
+	}
+	return Kubeconfig(adminKubeconfig, namespace, tr.Status.Token)
+}
+
+// create runs fn, treating an existing object as success so Provision can be
+// repeated, e.g. after a reconnect.
+func create(fn func() error) error {
+	if err := fn(); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// Kubeconfig returns a kubeconfig that authenticates with token against the
+// current cluster of adminKubeconfig, defaulting to namespace. The admin
+// credentials are dropped.
+func Kubeconfig(adminKubeconfig, namespace, token string) (string, error) {
+	admin, err := clientcmd.Load([]byte(adminKubeconfig))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	current, ok := admin.Contexts[admin.CurrentContext]
+	if !ok {
+		return "", fmt.Errorf("kubeconfig has no current context")
+	}
+	cluster, ok := admin.Clusters[current.Cluster]
+	if !ok {
+		return "", fmt.Errorf("kubeconfig has no cluster %q", current.Cluster)
+	}
+
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters[current.Cluster] = cluster
+	cfg.AuthInfos[ServiceAccountName] = &clientcmdapi.AuthInfo{Token: token}
+	cfg.Contexts[admin.CurrentContext] = &clientcmdapi.Context{
+		Cluster:   current.Cluster,
+		AuthInfo:  ServiceAccountName,
+		Namespace: namespace,
+	}
+	cfg.CurrentContext = admin.CurrentContext
+
+	out, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,101 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const adminKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: kind-k8s-dojo
+  cluster:
+    server: https://127.0.0.1:6443
+    certificate-authority-data: Y2E=
+users:
+- name: kind-k8s-dojo
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+contexts:
+- name: kind-k8s-dojo
+  context:
+    cluster: kind-k8s-dojo
+    user: kind-k8s-dojo
+current-context: kind-k8s-dojo
+`
+
+func TestKubeconfig(t *testing.T) {
+	out, err := Kubeconfig(adminKubeconfig, "pod-stuck", "secret-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := clientcmd.Load([]byte(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := cfg.Contexts[cfg.CurrentContext]
+	if cfg.CurrentContext != "kind-k8s-dojo" || ctx == nil || ctx.Namespace != "pod-stuck" {
+		t.Fatalf("context = %q %+v, want kind-k8s-dojo in namespace pod-stuck", cfg.CurrentContext, ctx)
+	}
+	if got := cfg.Clusters[ctx.Cluster].Server; got != "https://127.0.0.1:6443" {
+		t.Errorf("server = %q", got)
+	}
+	if len(cfg.AuthInfos) != 1 {
+		t.Fatalf("auth infos = %d, want only the learner's", len(cfg.AuthInfos))
+	}
+	user := cfg.AuthInfos[ctx.AuthInfo]
+	if user.Token != "secret-token" || len(user.ClientKeyData) != 0 {
+		t.Errorf("user = %+v, want token auth without the admin key", user)
+	}
+}
+
+func TestProvision(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pod-stuck", UID: "ns-uid"}})
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{Token: "issued"}}, nil
+	})
+	ctx := context.Background()
+
+	// Provisioning twice, e.g. after a reconnect, reuses the existing objects
+	for range 2 {
+		out, err := Provision(ctx, clientset, adminKubeconfig, "pod-stuck")
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg, _ := clientcmd.Load([]byte(out))
+		if got := cfg.AuthInfos[ServiceAccountName].Token; got != "issued" {
+			t.Errorf("token = %q, want issued", got)
+		}
+	}
+
+	sa, err := clientset.CoreV1().ServiceAccounts("pod-stuck").Get(ctx, ServiceAccountName, metav1.GetOptions{})
+	if err != nil || len(sa.OwnerReferences) != 1 {
+		t.Errorf("service account = %+v, %v; want one owned by the namespace", sa, err)
+	}
+	rb, err := clientset.RbacV1().RoleBindings("pod-stuck").Get(ctx, ServiceAccountName, metav1.GetOptions{})
+	if err != nil || rb.RoleRef.Name != "admin" {
+		t.Errorf("role binding = %+v, %v; want admin", rb, err)
+	}
+	crbs, _ := clientset.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if len(crbs.Items) != 2 {
+		t.Fatalf("cluster role bindings = %d, want 2", len(crbs.Items))
+	}
+	for _, crb := range crbs.Items {
+		if len(crb.OwnerReferences) != 1 || crb.OwnerReferences[0].UID != "ns-uid" {
+			t.Errorf("%s owners = %+v, want the namespace", crb.Name, crb.OwnerReferences)
+		}
+	}
+}
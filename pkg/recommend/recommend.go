@@ -0,0 +1,179 @@
+// Package recommend suggests which scenarios to train next, from per-category
+// completion in the saved state and struggle signals in the attempt history.
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/state"
+	"k8s-dojo/pkg/telemetry"
+)
+
+// DefaultCount is the number of suggestions shown on the dashboard.
+const DefaultCount = 3
+
+// StruggleThreshold is the struggle score above which a category is treated as
+// weak: suggestions drop back to its easiest scenarios.
+const StruggleThreshold = 0.5
+
+// Recommendation is a suggested next scenario.
+type Recommendation struct {
+	ScenarioID string
+	Name       string
+	Category   string
+	Reason     string
+	Score      float64
+}
+
+// categoryStats summarises a user's progress in one category.
+type categoryStats struct {
+	total, completed    int
+	attempts, abandoned int
+	hints               int
+	maxCompleted        int // Highest difficulty rank completed, -1 for none
+}
+
+// completion returns the fraction of the category completed.
+func (c categoryStats) completion() float64 {
+	if c.total == 0 {
+		return 1
+	}
+	return float64(c.completed) / float64(c.total)
+}
+
+// struggle scores from 0 to 1 how hard the category has been: the mean of the
+// abandon rate and hint usage, where three hints per attempt counts as full.
+func (c categoryStats) struggle() float64 {
+	if c.attempts == 0 {
+		return 0
+	}
+	abandonRate := float64(c.abandoned) / float64(c.attempts)
+	hintRate := math.Min(1, float64(c.hints)/float64(c.attempts)/3)
+	return (abandonRate + hintRate) / 2
+}
+
+// target is the difficulty rank to suggest next: one step above the hardest
+// completed, or the easiest while the user is struggling.
+func (c categoryStats) target() int {
+	if c.struggle() > StruggleThreshold {
+		return 0
+	}
+	return min(c.maxCompleted+1, 2)
+}
+
+func rank(d scenario.Difficulty) int {
+	switch d {
+	case scenario.DifficultyMedium:
+		return 1
+	case scenario.DifficultyHard:
+		return 2
+	}
+	return 0
+}
+
+// Recommend returns up to n scenarios to train next, best first.
+//
+// Scenarios that are not completed (or were completed against an older
+// version) are scored by how weak their category is, less completion and
+// more struggle scoring higher, and by how close their difficulty is to the
+// category's target. The first pass takes at most one scenario per category
+// so suggestions cover different weaknesses.
+func Recommend(catalog []scenario.Metadata, progress *state.State, history []telemetry.Attempt, n int) []Recommendation {
+	if progress == nil {
+		progress = &state.State{}
+	}
+
+	categoryOf := make(map[string]string)
+	stats := make(map[string]*categoryStats)
+	for _, meta := range catalog {
+		categoryOf[meta.ID] = meta.Category
+		c := stats[meta.Category]
+		if c == nil {
+			c = &categoryStats{maxCompleted: -1}
+			stats[meta.Category] = c
+		}
+		c.total++
+		if progress.CompletedScenarios[meta.ID] {
+			c.completed++
+			c.maxCompleted = max(c.maxCompleted, rank(meta.Difficulty))
+		}
+	}
+
+	abandoned := make(map[string]bool)
+	for _, a := range history {
+		c := stats[categoryOf[a.ScenarioID]]
+		if c == nil {
+			continue // Scenario no longer installed
+		}
+		c.attempts++
+		c.hints += len(a.HintsUsed)
+		if a.Outcome == telemetry.OutcomeAbandoned {
+			c.abandoned++
+			abandoned[a.ScenarioID] = true
+		}
+	}
+
+	var candidates []Recommendation
+	for _, meta := range catalog {
+		outdated := progress.IsOutdated(meta.ID, meta.ContentVersion())
+		if progress.CompletedScenarios[meta.ID] && !outdated {
+			continue
+		}
+		c := stats[meta.Category]
+		score := 2*(1-c.completion()) + 2*c.struggle()
+		score -= 0.5 * math.Abs(float64(rank(meta.Difficulty)-c.target()))
+		if outdated {
+			score -= 0.5
+		}
+		if abandoned[meta.ID] && c.struggle() <= StruggleThreshold {
+			score += 0.25 // Unfinished business the user is ready for
+		}
+		candidates = append(candidates, Recommendation{
+			ScenarioID: meta.ID,
+			Name:       meta.Name,
+			Category:   meta.Category,
+			Reason:     reason(meta.Category, *c, outdated, abandoned[meta.ID]),
+			Score:      score,
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	var picked []Recommendation
+	taken := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, r := range candidates {
+		if len(picked) < n && !seen[r.Category] {
+			picked = append(picked, r)
+			taken[r.ScenarioID] = true
+			seen[r.Category] = true
+		}
+	}
+	for _, r := range candidates {
+		if len(picked) < n && !taken[r.ScenarioID] {
+			picked = append(picked, r)
+		}
+	}
+	return picked
+}
+
+// reason explains a suggestion in a few words.
+func reason(category string, c categoryStats, outdated, abandoned bool) string {
+	switch {
+	case outdated:
+		return "updated since you completed it"
+	case c.struggle() > StruggleThreshold && c.abandoned > 0:
+		return fmt.Sprintf("%s has been tough: %d of %d attempts abandoned", category, c.abandoned, c.attempts)
+	case c.struggle() > StruggleThreshold:
+		return fmt.Sprintf("you lean on hints in %s", category)
+	case abandoned:
+		return "you left this one unfinished"
+	case c.completed == 0:
+		return fmt.Sprintf("you haven't tried %s yet", category)
+	}
+	return fmt.Sprintf("%s: %d of %d completed", category, c.completed, c.total)
+}
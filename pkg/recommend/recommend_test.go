@@ -0,0 +1,90 @@
+package recommend
+
+import (
+	"testing"
+
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/state"
+	"k8s-dojo/pkg/telemetry"
+)
+
+var catalog = []scenario.Metadata{
+	{ID: "net-easy", Category: "Networking", Difficulty: scenario.DifficultyEasy},
+	{ID: "net-medium", Category: "Networking", Difficulty: scenario.DifficultyMedium},
+	{ID: "net-hard", Category: "Networking", Difficulty: scenario.DifficultyHard},
+	{ID: "storage-easy", Category: "Storage", Difficulty: scenario.DifficultyEasy},
+	{ID: "storage-hard", Category: "Storage", Difficulty: scenario.DifficultyHard},
+	{ID: "sec-medium", Category: "Security", Difficulty: scenario.DifficultyMedium},
+}
+
+func progress(completed ...string) *state.State {
+	st := &state.State{CompletedScenarios: map[string]bool{}, CompletedVersions: map[string]int{}}
+	for _, id := range completed {
+		st.CompletedScenarios[id] = true
+	}
+	return st
+}
+
+func ids(recs []Recommendation) []string {
+	var out []string
+	for _, r := range recs {
+		out = append(out, r.ScenarioID)
+	}
+	return out
+}
+
+func TestRecommendSkipsCompleted(t *testing.T) {
+	recs := Recommend(catalog, progress("net-easy", "storage-easy"), nil, 10)
+	for _, r := range recs {
+		if r.ScenarioID == "net-easy" || r.ScenarioID == "storage-easy" {
+			t.Errorf("recommended completed scenario %s", r.ScenarioID)
+		}
+	}
+	if len(recs) != 4 {
+		t.Errorf("got %v, want the 4 uncompleted scenarios", ids(recs))
+	}
+}
+
+func TestRecommendPrefersWeakCategory(t *testing.T) {
+	// Networking is mostly done; Storage is untouched
+	recs := Recommend(catalog, progress("net-easy", "net-medium", "sec-medium"), nil, DefaultCount)
+	if len(recs) == 0 || recs[0].ScenarioID != "storage-easy" {
+		t.Fatalf("got %v, want storage-easy first", ids(recs))
+	}
+}
+
+func TestRecommendStruggleFallsBackToEasy(t *testing.T) {
+	history := []telemetry.Attempt{
+		{ScenarioID: "net-easy", Outcome: telemetry.OutcomeSolved, HintsUsed: []int{0, 1, 2}},
+		{ScenarioID: "net-hard", Outcome: telemetry.OutcomeAbandoned, HintsUsed: []int{0, 1}},
+		{ScenarioID: "net-hard", Outcome: telemetry.OutcomeAbandoned, HintsUsed: []int{0, 1, 2}},
+	}
+	recs := Recommend(catalog, progress("net-easy", "storage-easy", "storage-hard", "sec-medium"), history, 1)
+	if len(recs) != 1 || recs[0].ScenarioID != "net-medium" {
+		t.Fatalf("got %v, want net-medium (closest to easy)", ids(recs))
+	}
+	if recs[0].Reason != "Networking has been tough: 2 of 3 attempts abandoned" {
+		t.Errorf("reason = %q", recs[0].Reason)
+	}
+}
+
+func TestRecommendSpreadsCategories(t *testing.T) {
+	recs := Recommend(catalog, progress(), nil, DefaultCount)
+	seen := map[string]bool{}
+	for _, r := range recs {
+		if seen[r.Category] {
+			t.Errorf("category %s suggested twice in %v", r.Category, ids(recs))
+		}
+		seen[r.Category] = true
+	}
+}
+
+func TestRecommendOutdated(t *testing.T) {
+	meta := []scenario.Metadata{{ID: "a", Category: "Ops", Version: 2}}
+	st := progress("a")
+	st.CompletedVersions["a"] = 1
+	recs := Recommend(meta, st, nil, DefaultCount)
+	if len(recs) != 1 || recs[0].Reason != "updated since you completed it" {
+		t.Errorf("got %+v", recs)
+	}
+}
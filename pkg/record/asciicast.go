@@ -0,0 +1,87 @@
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// asciicastHeader is the header line of an asciicast v2 file.
+type asciicastHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// WriteAsciicast converts a recording to asciicast v2, as played by asciinema.
+// A resize before the first output sets the initial size. Output is split on
+// UTF-8 boundaries since PTY reads can end inside a multi-byte character.
+func WriteAsciicast(w io.Writer, h Header, events []Event, title string) error {
+	width, height := h.Width, h.Height
+	for _, e := range events {
+		if e.Kind == KindOutput {
+			break
+		}
+		if e.Kind == KindResize {
+			width, height = e.Width, e.Height
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	header := asciicastHeader{Version: 2, Width: width, Height: height, Title: title}
+	if !h.Started.IsZero() {
+		header.Timestamp = h.Started.Unix()
+	}
+	if err := enc.Encode(header); err != nil {
+		return fmt.Errorf("failed to write asciicast header: %w", err)
+	}
+
+	var pending []byte
+	started := false
+	for _, e := range events {
+		var line []interface{}
+		switch e.Kind {
+		case KindOutput:
+			started = true
+			data := append(pending, e.Data...)
+			cut := validPrefix(data)
+			pending = append([]byte(nil), data[cut:]...)
+			if cut == 0 {
+				continue
+			}
+			line = []interface{}{e.Time, "o", strings.ToValidUTF8(string(data[:cut]), "�")}
+		case KindResize:
+			if !started {
+				continue // Folded into the header
+			}
+			line = []interface{}{e.Time, "r", fmt.Sprintf("%dx%d", e.Width, e.Height)}
+		case KindMarker:
+			line = []interface{}{e.Time, "m", e.Label}
+		default:
+			continue
+		}
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("failed to write asciicast event: %w", err)
+		}
+	}
+	return nil
+}
+
+// validPrefix returns the length of data without a trailing incomplete UTF-8 sequence.
+func validPrefix(data []byte) int {
+	// A UTF-8 sequence is at most 4 bytes, so only the tail needs checking
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+		if !utf8.FullRune(data[i:]) {
+			return i
+		}
+		break
+	}
+	return len(data)
+}
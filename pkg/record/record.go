@@ -0,0 +1,203 @@
+// Package record provides recordings of the embedded terminal's PTY stream
+// and their export to formats such as asciicast.
+package record
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Ext is the file extension of recordings.
+const Ext = ".rec"
+
+// Event kinds.
+const (
+	KindOutput = "o" // Bytes read from the PTY
+	KindResize = "r" // Terminal resized
+	KindMarker = "m" // Named point in time, e.g. a scenario start
+)
+
+// Header is the first line of a recording.
+type Header struct {
+	Version int       `json:"version"`
+	Width   int       `json:"width"`
+	Height  int       `json:"height"`
+	Started time.Time `json:"started"`
+}
+
+// Event is one line of a recording after the header.
+type Event struct {
+	Time   float64 `json:"t"` // Seconds since the recording started
+	Kind   string  `json:"k"`
+	Data   []byte  `json:"d,omitempty"` // Raw output for KindOutput
+	Label  string  `json:"l,omitempty"` // Marker label for KindMarker
+	Width  int     `json:"w,omitempty"` // Set for KindResize
+	Height int     `json:"h,omitempty"` // Set for KindResize
+}
+
+// Recorder appends terminal events to a recording file. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	file  *os.File
+	enc   *json.Encoder
+	start time.Time
+	path  string
+	err   error
+}
+
+// DefaultDir returns ~/.k8s-dojo/recordings.
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".k8s-dojo", "recordings")
+}
+
+// NewRecorder creates a new recording in dir (default: DefaultDir) for a terminal of the given size.
+func NewRecorder(dir string, width, height int) (*Recorder, error) {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+
+	start := time.Now()
+	path := filepath.Join(dir, "session-"+start.Format("20060102-150405")+Ext)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+
+	r := &Recorder{file: f, enc: json.NewEncoder(f), start: start, path: path}
+	if err := r.enc.Encode(Header{Version: 1, Width: width, Height: height, Started: start}); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write recording header: %w", err)
+	}
+	return r, nil
+}
+
+// Path returns the recording's file path.
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// Output records bytes read from the PTY.
+func (r *Recorder) Output(p []byte) {
+	r.write(Event{Kind: KindOutput, Data: append([]byte(nil), p...)})
+}
+
+// Resize records a change of the terminal size.
+func (r *Recorder) Resize(width, height int) {
+	r.write(Event{Kind: KindResize, Width: width, Height: height})
+}
+
+// Marker records a named point in time.
+func (r *Recorder) Marker(label string) {
+	r.write(Event{Kind: KindMarker, Label: label})
+}
+
+func (r *Recorder) write(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Recording is best effort; after the first error further events are dropped.
+	if r.file == nil || r.err != nil {
+		return
+	}
+	e.Time = time.Since(r.start).Seconds()
+	r.err = r.enc.Encode(e)
+}
+
+// Close finishes the recording.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	if r.err != nil {
+		return fmt.Errorf("failed to write recording: %w", r.err)
+	}
+	return err
+}
+
+// List returns the recordings in dir (default: DefaultDir), oldest first.
+func List(dir string) ([]string, error) {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recordings directory: %w", err)
+	}
+
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), Ext) {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	// Names embed the start time, so lexical order is chronological
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Last returns the most recent recording in dir.
+func Last(dir string) (string, error) {
+	paths, err := List(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no recordings found; start the TUI with --record")
+	}
+	return paths[len(paths)-1], nil
+}
+
+// Read loads a recording.
+func Read(path string) (Header, []Event, error) {
+	var h Header
+	f, err := os.Open(path)
+	if err != nil {
+		return h, nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	if !scanner.Scan() {
+		return h, nil, fmt.Errorf("recording %s is empty", path)
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+		return h, nil, fmt.Errorf("failed to parse recording header: %w", err)
+	}
+
+	var events []Event
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// A crash can leave a truncated last line; keep what was recorded
+			break
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return h, nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	return h, events, nil
+}
@@ -0,0 +1,68 @@
+package record
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndExport(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewRecorder(dir, 80, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Resize(100, 30)
+	r.Marker("image-pull-backoff")
+	// "é" split across two PTY reads
+	r.Output([]byte("caf\xc3"))
+	r.Output([]byte("\xa9\r\n"))
+	r.Resize(120, 40)
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	last, err := Last(dir)
+	if err != nil || last != r.Path() {
+		t.Fatalf("Last() = %q, %v; want %q", last, err, r.Path())
+	}
+	h, events, err := Read(last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("got %d events, want 5", len(events))
+	}
+
+	var b strings.Builder
+	if err := WriteAsciicast(&b, h, events, "demo"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+
+	var header asciicastHeader
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatal(err)
+	}
+	if header.Version != 2 || header.Width != 100 || header.Height != 30 || header.Title != "demo" {
+		t.Errorf("header = %+v", header)
+	}
+
+	var kinds, output []string
+	for _, l := range lines[1:] {
+		var e []interface{}
+		if err := json.Unmarshal([]byte(l), &e); err != nil {
+			t.Fatalf("invalid event %q: %v", l, err)
+		}
+		kinds = append(kinds, e[1].(string))
+		if e[1] == "o" {
+			output = append(output, e[2].(string))
+		}
+	}
+	if got := strings.Join(kinds, ","); got != "m,o,o,r" {
+		t.Errorf("event kinds = %s, want m,o,o,r", got)
+	}
+	if got := strings.Join(output, ""); got != "café\r\n" {
+		t.Errorf("output = %q", got)
+	}
+}
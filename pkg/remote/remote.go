@@ -0,0 +1,200 @@
+// Package remote provides the remote dojo host mode: Kind runs in Docker on a
+// host reached over SSH, the API server is tunnelled back to this machine and
+// the embedded terminal opens a shell on the remote host.
+//
+// It shells out to the system ssh client so the user's ~/.ssh/config, keys and
+// agent apply as usual.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// KubeconfigPath is where the kubeconfig is pushed on the remote host, relative to $HOME.
+const KubeconfigPath = ".k8s-dojo/kubeconfig"
+
+// tunnelTimeout bounds how long Forward waits for the SSH tunnel to come up.
+const tunnelTimeout = 30 * time.Second
+
+// Host is a remote machine running Docker, reached as an SSH destination like "user@host".
+type Host struct {
+	Target string
+
+	mu     sync.Mutex
+	tunnel *exec.Cmd
+}
+
+// New validates an SSH destination.
+func New(target string) (*Host, error) {
+	if target == "" || strings.ContainsAny(target, " \t'\"") || strings.HasPrefix(target, "-") {
+		return nil, fmt.Errorf("invalid SSH destination %q (want user@host)", target)
+	}
+	return &Host{Target: target}, nil
+}
+
+// DockerHost returns the DOCKER_HOST value that makes Kind provision on the remote host.
+func (h *Host) DockerHost() string {
+	return "ssh://" + h.Target
+}
+
+// PushKubeconfig writes the cluster's kubeconfig to ~/.k8s-dojo/kubeconfig on the remote host,
+// readable only by the user.
+func (h *Host) PushKubeconfig(ctx context.Context, kubeconfig string) error {
+	script := "mkdir -p ~/.k8s-dojo && umask 077 && cat > ~/" + KubeconfigPath
+	cmd := exec.CommandContext(ctx, "ssh", "-o", "BatchMode=yes", h.Target, script)
+	cmd.Stdin = strings.NewReader(kubeconfig)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to push kubeconfig to %s: %w: %s", h.Target, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Connect pushes the kubeconfig of a cluster created on the remote host and
+// returns a kubeconfig that reaches its API server through an SSH tunnel.
+func (h *Host) Connect(ctx context.Context, kubeconfig string) (string, error) {
+	if err := h.PushKubeconfig(ctx, kubeconfig); err != nil {
+		return "", err
+	}
+	return h.Forward(kubeconfig)
+}
+
+// ShellCommand returns the command that opens an interactive shell on the remote
+// host with KUBECONFIG pointing at the pushed kubeconfig.
+func (h *Host) ShellCommand() (string, []string) {
+	remote := `export KUBECONFIG="$HOME/` + KubeconfigPath + `" PS1='$ '; exec "${SHELL:-/bin/sh}" -i`
+	return "ssh", []string{"-t", h.Target, remote}
+}
+
+// Forward tunnels the API server of a remote kubeconfig to a free local port and
+// returns a kubeconfig for this machine. The tunnel stays up until Close.
+func (h *Host) Forward(kubeconfig string) (string, error) {
+	remotePort, err := serverPort(kubeconfig)
+	if err != nil {
+		return "", err
+	}
+	localPort, err := freePort()
+	if err != nil {
+		return "", err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.tunnel != nil {
+		h.stop()
+	}
+
+	spec := fmt.Sprintf("%d:127.0.0.1:%d", localPort, remotePort)
+	cmd := exec.Command("ssh", "-N", "-o", "BatchMode=yes", "-o", "ExitOnForwardFailure=yes", "-L", spec, h.Target)
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("failed to start SSH tunnel: %w", err)
+	}
+	h.tunnel = cmd
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(localPort))
+	deadline := time.Now().Add(tunnelTimeout)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		select {
+		case err := <-exited:
+			h.tunnel = nil
+			return "", fmt.Errorf("SSH tunnel to %s exited: %v", h.Target, err)
+		case <-time.After(250 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			h.stop()
+			return "", fmt.Errorf("timed out waiting for SSH tunnel to %s", h.Target)
+		}
+	}
+
+	return RewriteServer(kubeconfig, localPort)
+}
+
+// Close tears down the API server tunnel.
+func (h *Host) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stop()
+}
+
+func (h *Host) stop() {
+	if h.tunnel != nil && h.tunnel.Process != nil {
+		_ = h.tunnel.Process.Kill()
+	}
+	h.tunnel = nil
+}
+
+// RewriteServer points every cluster in the kubeconfig at 127.0.0.1:port.
+// The TLS server name is kept so certificate verification still succeeds.
+func RewriteServer(kubeconfig string, port int) (string, error) {
+	cfg, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	for _, c := range cfg.Clusters {
+		u, err := url.Parse(c.Server)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse server URL: %w", err)
+		}
+		if c.TLSServerName == "" && net.ParseIP(u.Hostname()) == nil {
+			c.TLSServerName = u.Hostname()
+		}
+		u.Host = net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+		c.Server = u.String()
+	}
+	out, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	return string(out), nil
+}
+
+// serverPort returns the API server port of the kubeconfig's current context.
+func serverPort(kubeconfig string) (int, error) {
+	cfg, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	ctx, ok := cfg.Contexts[cfg.CurrentContext]
+	if !ok {
+		return 0, fmt.Errorf("kubeconfig has no current context")
+	}
+	c, ok := cfg.Clusters[ctx.Cluster]
+	if !ok {
+		return 0, fmt.Errorf("kubeconfig has no cluster %q", ctx.Cluster)
+	}
+	u, err := url.Parse(c.Server)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse server URL: %w", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		return 0, fmt.Errorf("server URL %s has no port", c.Server)
+	}
+	return port, nil
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free local port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
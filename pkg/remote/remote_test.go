@@ -0,0 +1,66 @@
+package remote
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const kindKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:41235
+  name: kind-k8s-dojo
+contexts:
+- context:
+    cluster: kind-k8s-dojo
+    user: kind-k8s-dojo
+  name: kind-k8s-dojo
+current-context: kind-k8s-dojo
+users:
+- name: kind-k8s-dojo
+  user:
+    token: secret
+`
+
+func TestRewriteServer(t *testing.T) {
+	port, err := serverPort(kindKubeconfig)
+	if err != nil || port != 41235 {
+		t.Fatalf("serverPort() = %d, %v; want 41235", port, err)
+	}
+
+	out, err := RewriteServer(kindKubeconfig, 50000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := clientcmd.Load([]byte(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Clusters["kind-k8s-dojo"].Server; got != "https://127.0.0.1:50000" {
+		t.Errorf("server = %q", got)
+	}
+	if cfg.AuthInfos["kind-k8s-dojo"].Token != "secret" {
+		t.Error("credentials were not preserved")
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, target := range []string{"", "-oProxyCommand=x", "user@host; rm -rf /", "a'b"} {
+		if _, err := New(target); err == nil {
+			t.Errorf("New(%q) succeeded, want error", target)
+		}
+	}
+	h, err := New("dojo@vm.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.DockerHost() != "ssh://dojo@vm.example.com" {
+		t.Errorf("DockerHost() = %q", h.DockerHost())
+	}
+	if name, args := h.ShellCommand(); name != "ssh" || !strings.Contains(args[len(args)-1], KubeconfigPath) {
+		t.Errorf("ShellCommand() = %s %v", name, args)
+	}
+}
@@ -0,0 +1,170 @@
+// Package sandbox provides free-play mode: a healthy reference application
+// that users break on purpose and then fix, without scenario success criteria.
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s-dojo/pkg/apps"
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/scenario/check"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Namespace holds the reference stack.
+const Namespace = "dojo-sandbox"
+
+// AppName is the reference stack deployed into the sandbox.
+const AppName = "three-tier"
+
+// app returns the reference stack.
+func app() apps.App {
+	return *apps.Get(AppName)
+}
+
+// Breakage is a fault users can inject into the reference stack.
+type Breakage struct {
+	ID          string
+	Name        string
+	Component   string // Deployment or Service the fault targets
+	Description string
+	Fault       scenario.Fault
+}
+
+// Breakages returns the menu of faults, in display order.
+func Breakages() []Breakage {
+	return []Breakage{
+		{
+			ID: "bad-image", Name: "Unpullable image", Component: "frontend",
+			Description: "The frontend is rolled out with an image tag that doesn't exist.",
+			Fault:       scenario.BadImage("frontend", "nginx:does-not-exist"),
+		},
+		{
+			ID: "selector-mismatch", Name: "Service selector typo", Component: "backend",
+			Description: "The backend Service no longer selects any pods.",
+			Fault:       scenario.SelectorMismatch("backend", "app", "backnd"),
+		},
+		{
+			ID: "wrong-target-port", Name: "Wrong target port", Component: "backend",
+			Description: "The backend Service forwards to a port nothing listens on.",
+			Fault:       scenario.WrongTargetPort("backend", 8081),
+		},
+		{
+			ID: "liveness-probe", Name: "Failing liveness probe", Component: "backend",
+			Description: "The backend gets a liveness probe on a missing path and keeps restarting.",
+			Fault:       scenario.FailingLivenessProbe("backend", "/healthz-missing", 8080),
+		},
+		{
+			ID: "oom", Name: "Memory limit too low", Component: "db",
+			Description: "The database is capped at a few megabytes of memory.",
+			Fault:       scenario.TinyMemoryLimit("db", "8Mi"),
+		},
+		{
+			ID: "missing-env", Name: "Missing database password", Component: "db",
+			Description: "The database loses the environment variable it needs to start.",
+			Fault:       scenario.DropEnv("db", "POSTGRES_PASSWORD"),
+		},
+		{
+			ID: "scaled-down", Name: "Scaled to zero", Component: "backend",
+			Description: "Someone scaled the backend down to zero replicas.",
+			Fault:       scenario.ScaleToZero("backend"),
+		},
+		{
+			ID: "deny-traffic", Name: "Deny-all NetworkPolicy", Component: "frontend",
+			Description: "A NetworkPolicy blocks all traffic to the frontend. Only visible on CNIs that enforce policies.",
+			Fault:       scenario.DenyIngress("frontend"),
+		},
+		{
+			ID: "cascade", Name: "Cascading failure", Component: "backend",
+			Description: "The database is starved of memory and the backend Service points at the wrong port.",
+			Fault: scenario.ComposeFaults(
+				scenario.TinyMemoryLimit("db", "8Mi"),
+				scenario.WrongTargetPort("backend", 8081),
+			),
+		},
+	}
+}
+
+// GetBreakage returns the breakage with the given ID, or nil.
+func GetBreakage(id string) *Breakage {
+	for _, b := range Breakages() {
+		if b.ID == id {
+			return &b
+		}
+	}
+	return nil
+}
+
+// Sandbox deploys and breaks the reference stack.
+type Sandbox struct {
+	client *k8s.Client
+}
+
+// New creates a sandbox on the given cluster.
+func New(client *k8s.Client) *Sandbox {
+	return &Sandbox{client: client}
+}
+
+// Deploy creates the namespace and (re)applies the healthy reference stack.
+func (s *Sandbox) Deploy(ctx context.Context) error {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   Namespace,
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "k8s-dojo"},
+		},
+	}
+	_, err := s.client.Clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace: %w", err)
+	}
+	return app().Deploy(ctx, s.client, Namespace)
+}
+
+// WaitHealthy blocks until every component is healthy.
+func (s *Sandbox) WaitHealthy(ctx context.Context) error {
+	return app().WaitReady(ctx, s.client.Clientset, Namespace)
+}
+
+// Health reports whether each component is available and reachable through its Service.
+func (s *Sandbox) Health(ctx context.Context) []check.Check {
+	return app().Health(ctx, s.client.Clientset, Namespace)
+}
+
+// Break injects a breakage into the running stack.
+func (s *Sandbox) Break(ctx context.Context, b Breakage) error {
+	if err := b.Fault(ctx, s.client.Clientset, Namespace); err != nil {
+		return fmt.Errorf("failed to inject %s: %w", b.ID, err)
+	}
+	return nil
+}
+
+// Reset replaces the stack with a freshly deployed healthy one, undoing every breakage.
+func (s *Sandbox) Reset(ctx context.Context) error {
+	if err := s.Teardown(ctx); err != nil {
+		return err
+	}
+	err := wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		_, err := s.client.Clientset.CoreV1().Namespaces().Get(ctx, Namespace, metav1.GetOptions{})
+		return apierrors.IsNotFound(err), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for namespace %s to be deleted: %w", Namespace, err)
+	}
+	return s.Deploy(ctx)
+}
+
+// Teardown deletes the sandbox namespace and everything in it.
+func (s *Sandbox) Teardown(ctx context.Context) error {
+	err := s.client.Clientset.CoreV1().Namespaces().Delete(ctx, Namespace, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete namespace: %w", err)
+	}
+	return nil
+}
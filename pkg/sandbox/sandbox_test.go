@@ -0,0 +1,33 @@
+package sandbox
+
+import (
+	"testing"
+)
+
+func TestBreakages(t *testing.T) {
+	components := map[string]bool{}
+	for _, c := range app().Components {
+		components[c] = true
+	}
+
+	seen := map[string]bool{}
+	for _, b := range Breakages() {
+		if seen[b.ID] {
+			t.Errorf("Duplicate breakage ID %s", b.ID)
+		}
+		seen[b.ID] = true
+		if b.Fault == nil || b.Name == "" || b.Description == "" {
+			t.Errorf("Breakage %s is incomplete", b.ID)
+		}
+		if !components[b.Component] {
+			t.Errorf("Breakage %s targets unknown component %s", b.ID, b.Component)
+		}
+	}
+
+	if GetBreakage("bad-image") == nil {
+		t.Error("Expected to find bad-image")
+	}
+	if GetBreakage("nope") != nil {
+		t.Error("Expected nil for unknown breakage")
+	}
+}
@@ -0,0 +1,304 @@
+// Package scaffold generates skeletons for new scenarios.
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// Output formats for new scenarios.
+const (
+	FormatGo   = "go"
+	FormatYAML = "yaml"
+)
+
+// Options describes the scenario to generate.
+type Options struct {
+	ID         string
+	Category   string
+	Name       string
+	Difficulty string // easy, medium or hard
+	Format     string
+}
+
+var idPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Validate checks the options and fills in defaults.
+func (o *Options) Validate() error {
+	if !idPattern.MatchString(o.ID) || len(o.ID) > 50 {
+		return fmt.Errorf("invalid id %q: use lowercase letters, digits and dashes", o.ID)
+	}
+	if o.Category == "" {
+		return fmt.Errorf("category is required")
+	}
+	if o.Name == "" {
+		o.Name = o.Category + ": " + strings.ReplaceAll(o.ID, "-", " ")
+	}
+	o.Difficulty = strings.ToLower(o.Difficulty)
+	switch o.Difficulty {
+	case "":
+		o.Difficulty = "easy"
+	case "easy", "medium", "hard":
+	default:
+		return fmt.Errorf("invalid difficulty %q", o.Difficulty)
+	}
+	switch o.Format {
+	case "":
+		o.Format = FormatGo
+	case FormatGo, FormatYAML:
+	default:
+		return fmt.Errorf("invalid format %q (go or yaml)", o.Format)
+	}
+	return nil
+}
+
+// TypeName returns the Go type for the scenario, e.g. "MyScenario" for "my-scenario".
+func (o Options) TypeName() string {
+	var b strings.Builder
+	for _, part := range strings.Split(o.ID, "-") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// MetadataVar returns the variable holding the scenario's metadata, e.g.
+// "myScenarioMetadata".
+func (o Options) MetadataVar() string {
+	name := o.TypeName()
+	return strings.ToLower(name[:1]) + name[1:] + "Metadata"
+}
+
+// DifficultyLabel returns the difficulty as shown in metadata, e.g. "Easy".
+func (o Options) DifficultyLabel() string {
+	return strings.ToUpper(o.Difficulty[:1]) + o.Difficulty[1:]
+}
+
+// DifficultyConst returns the scenario package constant for the difficulty.
+func (o Options) DifficultyConst() string {
+	return "Difficulty" + o.DifficultyLabel()
+}
+
+// Generate writes the scenario skeleton and returns the files it created or changed.
+// Go scenarios are written under repoRoot and registered in the registry;
+// YAML scenarios are written to yamlDir.
+func Generate(opts Options, repoRoot, yamlDir string) ([]string, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Format == FormatYAML {
+		path, err := writeYAML(opts, yamlDir)
+		if err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}
+	return writeGo(opts, repoRoot)
+}
+
+func writeYAML(opts Options, dir string) (string, error) {
+	path := filepath.Join(dir, opts.ID+".yaml")
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("%s already exists", path)
+	}
+
+	var buf bytes.Buffer
+	if err := yamlTemplate.Execute(&buf, opts); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("failed to write scenario: %w", err)
+	}
+	return path, nil
+}
+
+func writeGo(opts Options, repoRoot string) ([]string, error) {
+	scenarioDir := filepath.Join(repoRoot, "pkg", "scenario")
+	path := filepath.Join(scenarioDir, strings.ReplaceAll(opts.ID, "-", "_")+".go")
+	registryPath := filepath.Join(scenarioDir, "registry.go")
+
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%s already exists", path)
+	}
+	registry, err := os.ReadFile(registryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry (run from the repository root): %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := goTemplate.Execute(&buf, opts); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format scenario: %w", err)
+	}
+
+	updated, err := register(registry, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write scenario: %w", err)
+	}
+	if err := os.WriteFile(registryPath, updated, 0644); err != nil {
+		return nil, fmt.Errorf("failed to update registry: %w", err)
+	}
+	return []string{path, registryPath}, nil
+}
+
+// builtinList matches the list of Go scenarios in registry.go.
+var builtinList = regexp.MustCompile(`(?s)\nvar builtins = \[\]builtin\{\n.*?\n}\n`)
+
+// register appends the scenario's metadata and factory to the list of Go
+// scenarios in registry.go.
+func register(registry []byte, opts Options) ([]byte, error) {
+	loc := builtinList.FindIndex(registry)
+	if loc == nil {
+		return nil, fmt.Errorf("could not find the scenario list in registry.go")
+	}
+
+	end := loc[1] - len("}\n")
+	entry := fmt.Sprintf("\n\t// %s\n\t{%s, func(d Deps) Scenario { return New%s(d.Clientset) }},\n", opts.Category, opts.MetadataVar(), opts.TypeName())
+	out := append([]byte{}, registry[:end]...)
+	out = append(out, entry...)
+	out = append(out, registry[end:]...)
+
+	formatted, err := format.Source(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format registry: %w", err)
+	}
+	return formatted, nil
+}
+
+var goTemplate = template.Must(template.New("go").Parse(`package scenario
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s-dojo/pkg/scenario/check"
+)
+
+// {{.TypeName}} scenario: TODO describe the fault.
+type {{.TypeName}} struct {
+	BaseScenario
+	clientset kubernetes.Interface
+}
+
+func New{{.TypeName}}(clientset kubernetes.Interface) *{{.TypeName}} {
+	return &{{.TypeName}}{
+		BaseScenario: BaseScenario{Namespace: "{{.ID}}"},
+		clientset:    clientset,
+	}
+}
+
+var {{.MetadataVar}} = Metadata{
+	ID:          "{{.ID}}",
+	Name:        "{{.Name}}",
+	Description: "TODO: what the learner observes.",
+	Difficulty:  {{.DifficultyConst}},
+	Category:    "{{.Category}}",
+	Tags:        []string{"TODO: topic"},
+	Hints:       []string{"TODO: first hint", "TODO: second hint"},
+}
+
+func (s *{{.TypeName}}) GetMetadata() Metadata {
+	return {{.MetadataVar}}
+}
+
+func (s *{{.TypeName}}) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// TODO: create the broken resources
+	_, err = s.clientset.CoreV1().Pods(s.Namespace).Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Image: "nginx:wrongtag"},
+			},
+		},
+	}, metav1.CreateOptions{})
+	return err
+}
+
+func (s *{{.TypeName}}) Validate(ctx context.Context) Result {
+	return NewResult("Success! TODO",
+		check.PodRunning(ctx, s.clientset, s.Namespace, "app"),
+	)
+}
+
+func (s *{{.TypeName}}) Solve(ctx context.Context) error {
+	// TODO: apply the reference fix
+	return recreatePod(ctx, s.clientset, s.Namespace, "app", func(pod *corev1.Pod) {
+		pod.Spec.Containers[0].Image = "nginx:alpine"
+	})
+}
+
+func (s *{{.TypeName}}) Cleanup(ctx context.Context) error {
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
+`))
+
+var yamlTemplate = template.Must(template.New("yaml").Parse(`id: {{.ID}}
+name: "{{.Name}}"
+description: "TODO: what the learner observes."
+difficulty: {{.DifficultyLabel}}
+category: {{.Category}}
+tags: ["TODO: topic"]
+hints:
+  - "TODO: first hint"
+  - "TODO: second hint"
+
+# Broken resources, applied into the scenario namespace
+setup: |
+  apiVersion: v1
+  kind: Pod
+  metadata:
+    name: app
+  spec:
+    containers:
+    - name: app
+      image: nginx:wrongtag
+
+# Conditions that must all pass
+checks:
+  - type: fieldEquals
+    name: Image fixed
+    kind: Pod
+    object: app
+    path: "{.spec.containers[0].image}"
+    value: nginx:alpine
+    failure: "The pod still uses a broken image."
+
+# Reference fix used by 'k8s-dojo dev test'
+solve: |
+  apiVersion: v1
+  kind: Pod
+  metadata:
+    name: app
+  spec:
+    containers:
+    - name: app
+      image: nginx:alpine
+`))
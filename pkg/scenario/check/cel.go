@@ -0,0 +1,57 @@
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/policy"
+)
+
+// CompileCEL compiles a CEL condition for CEL. A single object is bound to
+// object and to its lowercased kind, e.g. deployment; with list set, the
+// matching objects are bound to objects instead.
+func CompileCEL(expr, kind string, list bool) (*policy.Expression, error) {
+	if list {
+		return policy.Compile(expr, "objects")
+	}
+	return policy.Compile(expr, "object", policy.KindVariable(kind))
+}
+
+// CEL passes when expr, compiled by CompileCEL, holds for the named object
+// of kind or, with name empty, for the list of objects matching selector.
+func CEL(ctx context.Context, client *k8s.Client, apiVersion, kind, namespace, name, selector string, expr *policy.Expression) Check {
+	if name == "" {
+		title := fmt.Sprintf("%s objects satisfy the policy", kind)
+		if selector != "" {
+			title = fmt.Sprintf("%s objects %s satisfy the policy", kind, selector)
+		}
+		objs, err := client.List(ctx, apiVersion, kind, namespace, selector)
+		if err != nil {
+			return fail(title, "Failed to list %s objects: %v", kind, err)
+		}
+		items := make([]interface{}, len(objs))
+		for i := range objs {
+			items[i] = objs[i].Object
+		}
+		return celResult(title, expr, map[string]interface{}{"objects": items})
+	}
+
+	title := fmt.Sprintf("%s %s satisfies the policy", kind, name)
+	obj, err := client.Get(ctx, apiVersion, kind, namespace, name)
+	if err != nil {
+		return fail(title, "%s %s not found: %v", kind, name, err)
+	}
+	return celResult(title, expr, map[string]interface{}{"object": obj.Object, policy.KindVariable(kind): obj.Object})
+}
+
+func celResult(title string, expr *policy.Expression, vars map[string]interface{}) Check {
+	ok, err := expr.Eval(vars)
+	if err != nil {
+		return fail(title, "%v", err)
+	}
+	if !ok {
+		return fail(title, "%s is false.", expr)
+	}
+	return pass(title)
+}
@@ -0,0 +1,62 @@
+package check
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCEL(t *testing.T) {
+	ctx := context.Background()
+	configMap := func(name, mode string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: map[string]string{"app": "web"}},
+			Data:       map[string]string{"mode": mode},
+		}
+	}
+	client := fakeClient(testDeployment(3), configMap("web-a", "release"), configMap("web-b", "debug"))
+
+	tests := []struct {
+		name             string
+		apiVersion, kind string
+		object, selector string
+		expr             string
+		want             string // Substring of the failure; "" means passed
+	}{
+		{"object", "apps/v1", "Deployment", "web", "", "object.spec.replicas == 3", ""},
+		{"kind variable", "apps/v1", "Deployment", "web", "", "deployment.status.availableReplicas >= 2", ""},
+		{"object false", "apps/v1", "Deployment", "web", "", "object.spec.replicas > 5", "object.spec.replicas > 5 is false."},
+		{"missing object", "apps/v1", "Deployment", "api", "", "object.spec.replicas == 3", "Deployment api not found"},
+		{"all listed", "v1", "ConfigMap", "", "app=web", "objects.all(o, has(o.data.mode))", ""},
+		{"list false", "v1", "ConfigMap", "", "app=web", "objects.all(o, o.data.mode == 'release')", "is false."},
+		{"list empty", "v1", "ConfigMap", "", "app=api", "size(objects) > 0", "is false."},
+		{"runtime error", "apps/v1", "Deployment", "web", "", "object.spec.missing == 1", "no such key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := CompileCEL(tt.expr, tt.kind, tt.object == "")
+			if err != nil {
+				t.Fatalf("CompileCEL failed: %v", err)
+			}
+			got := CEL(ctx, client, tt.apiVersion, tt.kind, testNamespace, tt.object, tt.selector, expr)
+			if got.Passed != (tt.want == "") || !strings.Contains(got.Details, tt.want) {
+				t.Errorf("CEL() = %+v, want failure %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileCEL(t *testing.T) {
+	if _, err := CompileCEL("deployment.spec.replicas == 3", "Deployment", false); err != nil {
+		t.Errorf("Expected the kind variable for a single object: %v", err)
+	}
+	if _, err := CompileCEL("deployment.spec.replicas == 3", "Deployment", true); err == nil {
+		t.Error("Expected only objects to be bound for a list")
+	}
+	if _, err := CompileCEL("object.spec.replicas ==", "Deployment", false); err == nil {
+		t.Error("Expected a syntax error")
+	}
+}
@@ -0,0 +1,336 @@
+// Package check provides reusable assertions for scenario validation.
+package check
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"k8s-dojo/pkg/k8s"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Severity is how much a failing check matters.
+type Severity string
+
+const (
+	SeverityBlocker Severity = "blocker" // Must pass for the scenario to be solved
+	SeverityWarn    Severity = "warn"    // Worth fixing, but doesn't block solving
+	SeverityInfo    Severity = "info"    // Advice only
+)
+
+// ParseSeverity returns the named severity; "" means SeverityBlocker.
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(s); sev {
+	case "":
+		return SeverityBlocker, nil
+	case SeverityBlocker, SeverityWarn, SeverityInfo:
+		return sev, nil
+	}
+	return "", fmt.Errorf("invalid severity %q, want blocker, warn or info", s)
+}
+
+// Kubernetes documentation the built-in checks link to.
+const (
+	DocPodLifecycle = "https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/"
+	DocDeployments  = "https://kubernetes.io/docs/concepts/workloads/controllers/deployment/"
+	DocServices     = "https://kubernetes.io/docs/concepts/services-networking/service/"
+)
+
+// Check is a single named condition evaluated during validation.
+type Check struct {
+	Name    string
+	Passed  bool
+	Details string
+
+	Severity Severity // Of a failure; "" means SeverityBlocker
+	DocURL   string   // Documentation about the condition, shown when it fails
+}
+
+// Blocking reports whether the check must pass for the scenario to be solved.
+func (c Check) Blocking() bool {
+	return c.Severity == "" || c.Severity == SeverityBlocker
+}
+
+// WithSeverity returns a copy of the check with a different severity.
+func (c Check) WithSeverity(severity Severity) Check {
+	c.Severity = severity
+	return c
+}
+
+// WithDoc returns a copy of the check linking to url.
+func (c Check) WithDoc(url string) Check {
+	c.DocURL = url
+	return c
+}
+
+// Named returns a copy of the check with a different name.
+func (c Check) Named(name string) Check {
+	c.Name = name
+	return c
+}
+
+// OrElse returns a copy of the check whose failure details are replaced.
+// Passing checks are returned unchanged.
+func (c Check) OrElse(details string) Check {
+	if !c.Passed {
+		c.Details = details
+	}
+	return c
+}
+
+// pass and fail build checks with consistent details.
+func pass(name string) Check {
+	return Check{Name: name, Passed: true}
+}
+
+func fail(name, format string, args ...interface{}) Check {
+	return Check{Name: name, Passed: false, Details: fmt.Sprintf(format, args...)}
+}
+
+// FieldEquals passes when got deeply equals want.
+func FieldEquals(name string, got, want interface{}) Check {
+	if reflect.DeepEqual(got, want) {
+		return pass(name)
+	}
+	return fail(name, "%s is %v, expected %v.", name, got, want)
+}
+
+// PodRunning passes when the named pod is in the Running phase.
+func PodRunning(ctx context.Context, clientset kubernetes.Interface, namespace, name string) Check {
+	title := fmt.Sprintf("Pod %s running", name)
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fail(title, "Pod %s not found: %v", name, err).WithDoc(DocPodLifecycle)
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return fail(title, "Pod %s is %s.", name, pod.Status.Phase).WithDoc(DocPodLifecycle)
+	}
+	return pass(title).WithDoc(DocPodLifecycle)
+}
+
+// PodsRunning passes when any pod matching the label selector is Running.
+func PodsRunning(ctx context.Context, clientset kubernetes.Interface, namespace, selector string) Check {
+	title := fmt.Sprintf("Pod %s running", selector)
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fail(title, "Failed to list pods: %v", err).WithDoc(DocPodLifecycle)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning {
+			return pass(title).WithDoc(DocPodLifecycle)
+		}
+	}
+	return fail(title, "No running pods match %s.", selector).WithDoc(DocPodLifecycle)
+}
+
+// PodDeleted passes when the named pod no longer exists.
+func PodDeleted(ctx context.Context, clientset kubernetes.Interface, namespace, name string) Check {
+	title := fmt.Sprintf("Pod %s deleted", name)
+	_, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return pass(title)
+	}
+	if err != nil {
+		return fail(title, "Failed to get pod %s: %v", name, err)
+	}
+	return fail(title, "Pod %s still exists.", name)
+}
+
+// DeploymentAvailable passes when the deployment controller has observed the
+// latest spec and reports the deployment Available with at least one available
+// replica. Status from before the learner's last change doesn't count.
+func DeploymentAvailable(ctx context.Context, clientset kubernetes.Interface, namespace, name string) Check {
+	title := fmt.Sprintf("Deployment %s available", name)
+	dep, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fail(title, "Deployment %s not found: %v", name, err).WithDoc(DocDeployments)
+	}
+	if dep.Status.ObservedGeneration < dep.Generation {
+		return fail(title, "Deployment %s hasn't picked up its latest change yet.", name).WithDoc(DocDeployments)
+	}
+	if dep.Status.AvailableReplicas == 0 {
+		return fail(title, "Deployment %s has 0 available replicas.", name).WithDoc(DocDeployments)
+	}
+	if c := deploymentCondition(dep, appsv1.DeploymentAvailable); c != nil && c.Status != corev1.ConditionTrue {
+		return fail(title, "Deployment %s is not available: %s", name, c.Message).WithDoc(DocDeployments)
+	}
+	return pass(title).WithDoc(DocDeployments)
+}
+
+// DeploymentRolledOut passes when the deployment's latest spec is fully rolled
+// out, as `kubectl rollout status` would report it: every replica updated and
+// available, no old replicas left, and the rollout not stuck past its deadline.
+func DeploymentRolledOut(ctx context.Context, clientset kubernetes.Interface, namespace, name string) Check {
+	title := fmt.Sprintf("Deployment %s rolled out", name)
+	dep, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fail(title, "Deployment %s not found: %v", name, err).WithDoc(DocDeployments)
+	}
+	if reason := rolloutPending(dep); reason != "" {
+		return fail(title, "%s", reason).WithDoc(DocDeployments)
+	}
+	return pass(title).WithDoc(DocDeployments)
+}
+
+// rolloutPending explains why the deployment's rollout isn't complete, or returns "" if it is.
+func rolloutPending(dep *appsv1.Deployment) string {
+	st := dep.Status
+	replicas := int32(1)
+	if dep.Spec.Replicas != nil {
+		replicas = *dep.Spec.Replicas
+	}
+	if st.ObservedGeneration < dep.Generation {
+		return fmt.Sprintf("Deployment %s hasn't picked up its latest change yet.", dep.Name)
+	}
+	if c := deploymentCondition(dep, appsv1.DeploymentProgressing); c != nil && c.Reason == "ProgressDeadlineExceeded" {
+		return fmt.Sprintf("Deployment %s exceeded its progress deadline: %s", dep.Name, c.Message)
+	}
+	switch {
+	case st.UpdatedReplicas < replicas:
+		return fmt.Sprintf("Deployment %s has %d of %d replicas updated.", dep.Name, st.UpdatedReplicas, replicas)
+	case st.Replicas > st.UpdatedReplicas:
+		return fmt.Sprintf("Deployment %s still runs %d old replica(s).", dep.Name, st.Replicas-st.UpdatedReplicas)
+	case st.AvailableReplicas < st.UpdatedReplicas:
+		return fmt.Sprintf("Deployment %s has %d of %d updated replicas available.", dep.Name, st.AvailableReplicas, st.UpdatedReplicas)
+	}
+	return ""
+}
+
+// deploymentCondition returns the deployment's condition of type t, or nil if it has none.
+func deploymentCondition(dep *appsv1.Deployment, t appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range dep.Status.Conditions {
+		if dep.Status.Conditions[i].Type == t {
+			return &dep.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// EndpointsNonEmpty passes when the service has at least one ready endpoint address.
+func EndpointsNonEmpty(ctx context.Context, clientset kubernetes.Interface, namespace, service string) Check {
+	title := fmt.Sprintf("Service %s has endpoints", service)
+	ep, err := clientset.CoreV1().Endpoints(namespace).Get(ctx, service, metav1.GetOptions{})
+	if err != nil {
+		return fail(title, "Endpoints for %s not found: %v", service, err).WithDoc(DocServices)
+	}
+	for _, subset := range ep.Subsets {
+		if len(subset.Addresses) > 0 {
+			return pass(title).WithDoc(DocServices)
+		}
+	}
+	return fail(title, "Service %s has no endpoints.", service).WithDoc(DocServices)
+}
+
+// EventuallyExec passes when the command succeeds in the pod within the timeout.
+// Failed attempts are retried every second.
+func EventuallyExec(ctx context.Context, clientset kubernetes.Interface, config *rest.Config, namespace, pod, container string, command []string, timeout time.Duration) Check {
+	title := fmt.Sprintf("Command succeeds in %s", pod)
+
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		_, lastErr = k8s.Exec(ctx, clientset, config, namespace, pod, container, command)
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		if lastErr == nil {
+			lastErr = err
+		}
+		return fail(title, "Command %v failed in %s: %v", command, pod, lastErr)
+	}
+	return pass(title)
+}
+
+// ObjectFieldEquals passes when a JSONPath expression on any object evaluates to want.
+// The path uses kubectl syntax, e.g. "{.spec.replicas}".
+func ObjectFieldEquals(ctx context.Context, client *k8s.Client, apiVersion, kind, namespace, name, path, want string) Check {
+	title := fmt.Sprintf("%s %s %s", kind, name, path)
+
+	got, err := client.GetField(ctx, apiVersion, kind, namespace, name, path)
+	if err != nil {
+		return fail(title, "%v", err)
+	}
+	if got != want {
+		return fail(title, "%s %s has %s = %q, expected %q.", kind, name, path, got, want)
+	}
+	return pass(title)
+}
+
+// DefaultStableWindow is how long containers must stay up to count as stable.
+// It outlasts a liveness probe with the default period and failure threshold.
+const DefaultStableWindow = 30 * time.Second
+
+// PodStable passes when the named pod is Running and every container is ready
+// and has stayed up for at least window. A crash-looping pod caught between
+// restarts, or a container about to be killed by its liveness probe, fails.
+func PodStable(ctx context.Context, clientset kubernetes.Interface, namespace, name string, window time.Duration) Check {
+	title := fmt.Sprintf("Pod %s stable", name)
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fail(title, "Pod %s not found: %v", name, err).WithDoc(DocPodLifecycle)
+	}
+	if reason := unstable(pod, window, time.Now()); reason != "" {
+		return fail(title, "%s", reason).WithDoc(DocPodLifecycle)
+	}
+	return pass(title).WithDoc(DocPodLifecycle)
+}
+
+// PodsStable passes when at least one pod matches the label selector and
+// every matching pod is stable as defined by PodStable. An empty selector,
+// which would match every pod in the namespace, fails.
+func PodsStable(ctx context.Context, clientset kubernetes.Interface, namespace, selector string, window time.Duration) Check {
+	title := fmt.Sprintf("Pods %s stable", selector)
+	if selector == "" {
+		return fail(title, "No pod selector given.").WithDoc(DocPodLifecycle)
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fail(title, "Failed to list pods: %v", err).WithDoc(DocPodLifecycle)
+	}
+	if len(pods.Items) == 0 {
+		return fail(title, "No pods match %s.", selector).WithDoc(DocPodLifecycle)
+	}
+	now := time.Now()
+	for i := range pods.Items {
+		if reason := unstable(&pods.Items[i], window, now); reason != "" {
+			return fail(title, "%s", reason).WithDoc(DocPodLifecycle)
+		}
+	}
+	return pass(title).WithDoc(DocPodLifecycle)
+}
+
+// unstable explains why the pod isn't stable at now, or returns "" if it is.
+func unstable(pod *corev1.Pod, window time.Duration, now time.Time) string {
+	if pod.Status.Phase != corev1.PodRunning {
+		return fmt.Sprintf("Pod %s is %s.", pod.Name, pod.Status.Phase)
+	}
+	if len(pod.Status.ContainerStatuses) < len(pod.Spec.Containers) {
+		return fmt.Sprintf("Pod %s hasn't reported all its containers yet.", pod.Name)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		switch {
+		case cs.State.Waiting != nil:
+			return fmt.Sprintf("Container %s is waiting: %s (%d restarts).", cs.Name, cs.State.Waiting.Reason, cs.RestartCount)
+		case cs.State.Running == nil:
+			return fmt.Sprintf("Container %s is not running (%d restarts).", cs.Name, cs.RestartCount)
+		case !cs.Ready:
+			return fmt.Sprintf("Container %s is running but not ready.", cs.Name)
+		}
+		up := now.Sub(cs.State.Running.StartedAt.Time)
+		if up < window {
+			if cs.RestartCount > 0 {
+				return fmt.Sprintf("Container %s restarted %s ago (%d restarts). Waiting to see it stay up for %s.", cs.Name, up.Round(time.Second), cs.RestartCount, window)
+			}
+			return fmt.Sprintf("Container %s has been up for %s. Waiting to see it stay up for %s.", cs.Name, up.Round(time.Second), window)
+		}
+	}
+	return ""
+}
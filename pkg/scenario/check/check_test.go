@@ -0,0 +1,430 @@
+package check
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-dojo/pkg/k8s"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery/cached/memory"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/restmapper"
+)
+
+const testNamespace = "dojo"
+
+// testPod returns a pod of one container that started at started and is
+// ready as given.
+func testPod(name string, started time.Time, restarts int32, ready bool) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "nginx"}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "web",
+				Ready:        ready,
+				RestartCount: restarts,
+				State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(started)}},
+			}},
+		},
+	}
+}
+
+func TestUnstable(t *testing.T) {
+	now := time.Now()
+	crashing := testPod("web", now, 4, false)
+	crashing.Status.ContainerStatuses[0].State = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+	pending := testPod("web", now, 0, false)
+	pending.Status.Phase = corev1.PodPending
+	unreported := testPod("web", now.Add(-time.Hour), 0, true)
+	unreported.Spec.Containers = append(unreported.Spec.Containers, corev1.Container{Name: "sidecar", Image: "busybox"})
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want string // Substring of the reason; "" means stable
+	}{
+		{"up past the window", testPod("web", now.Add(-time.Minute), 0, true), ""},
+		{"restarted but up past the window", testPod("web", now.Add(-time.Minute), 3, true), ""},
+		{"restarted within the window", testPod("web", now.Add(-10*time.Second), 2, true), "restarted 10s ago (2 restarts)"},
+		{"new within the window", testPod("web", now.Add(-10*time.Second), 0, true), "has been up for 10s"},
+		{"running but not ready", testPod("web", now.Add(-time.Minute), 0, false), "not ready"},
+		{"crash looping", crashing, "waiting: CrashLoopBackOff (4 restarts)"},
+		{"pending", pending, "is Pending"},
+		{"missing container status", unreported, "hasn't reported all its containers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unstable(tt.pod, DefaultStableWindow, now)
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("Expected stable, got %q", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("unstable() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodStable(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{"stable", testPod("web", now.Add(-time.Minute), 1, true), true},
+		{"restarted within the window", testPod("web", now.Add(-5*time.Second), 1, true), false},
+		{"not ready", testPod("web", now.Add(-time.Minute), 0, false), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(tt.pod)
+			got := PodStable(ctx, clientset, testNamespace, "web", DefaultStableWindow)
+			if got.Passed != tt.want {
+				t.Errorf("Passed = %v, want %v (%s)", got.Passed, tt.want, got.Details)
+			}
+			if got.DocURL != DocPodLifecycle {
+				t.Errorf("DocURL = %q, want %q", got.DocURL, DocPodLifecycle)
+			}
+		})
+	}
+
+	missing := PodStable(ctx, fake.NewSimpleClientset(), testNamespace, "web", DefaultStableWindow)
+	if missing.Passed || !strings.Contains(missing.Details, "not found") {
+		t.Errorf("Expected a missing pod to fail, got %+v", missing)
+	}
+}
+
+func TestPodsStable(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	tests := []struct {
+		name     string
+		pods     []runtime.Object
+		selector string
+		want     bool
+	}{
+		{"all stable", []runtime.Object{
+			testPod("web-1", now.Add(-time.Minute), 0, true),
+			testPod("web-2", now.Add(-time.Hour), 2, true),
+		}, "app=web", true},
+		{"one restarted within the window", []runtime.Object{
+			testPod("web-1", now.Add(-time.Minute), 0, true),
+			testPod("web-2", now.Add(-3*time.Second), 5, true),
+		}, "app=web", false},
+		{"one not ready", []runtime.Object{
+			testPod("web-1", now.Add(-time.Minute), 0, true),
+			testPod("web-2", now.Add(-time.Minute), 0, false),
+		}, "app=web", false},
+		{"nothing matches", []runtime.Object{testPod("web-1", now.Add(-time.Minute), 0, true)}, "app=api", false},
+		{"no pods", nil, "app=web", false},
+		{"empty selector", []runtime.Object{testPod("web-1", now.Add(-time.Minute), 0, true)}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(tt.pods...)
+			got := PodsStable(ctx, clientset, testNamespace, tt.selector, DefaultStableWindow)
+			if got.Passed != tt.want {
+				t.Errorf("Passed = %v, want %v (%s)", got.Passed, tt.want, got.Details)
+			}
+		})
+	}
+}
+
+// testDeployment returns a fully rolled-out deployment of replicas pods at generation 2.
+func testDeployment(replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace, Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			ReadyReplicas:      replicas,
+			AvailableReplicas:  replicas,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+			},
+		},
+	}
+}
+
+func TestDeploymentRollout(t *testing.T) {
+	stale := testDeployment(3)
+	stale.Status.ObservedGeneration = 1
+	stuck := testDeployment(3)
+	stuck.Status.Conditions[1] = appsv1.DeploymentCondition{
+		Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded",
+		Message: `ReplicaSet "web-5d8f" has timed out progressing.`,
+	}
+	old := testDeployment(3)
+	old.Status.Replicas = 4
+	partial := testDeployment(3)
+	partial.Status.UpdatedReplicas = 1
+	unavailable := testDeployment(3)
+	unavailable.Status.AvailableReplicas = 2
+	down := testDeployment(3)
+	down.Status.AvailableReplicas = 0
+	down.Status.Conditions[0] = appsv1.DeploymentCondition{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, Message: "Deployment does not have minimum availability."}
+	unavailableCondition := testDeployment(3)
+	unavailableCondition.Status.Conditions[0] = down.Status.Conditions[0]
+
+	tests := []struct {
+		name          string
+		dep           *appsv1.Deployment
+		wantRolledOut string // Substring of the failure; "" means passed
+		wantAvailable string
+	}{
+		{"rolled out", testDeployment(3), "", ""},
+		{"latest spec not observed", stale, "hasn't picked up its latest change", "hasn't picked up its latest change"},
+		{"progress deadline exceeded", stuck, "exceeded its progress deadline", ""},
+		{"old replicas remain", old, "still runs 1 old replica(s)", ""},
+		{"replicas not updated", partial, "1 of 3 replicas updated", ""},
+		{"updated replicas unavailable", unavailable, "2 of 3 updated replicas available", ""},
+		{"no replicas available", down, "0 of 3 updated replicas available", "0 available replicas"},
+		{"not available", unavailableCondition, "", "is not available: Deployment does not have minimum availability."},
+	}
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(tt.dep)
+			for _, c := range []struct {
+				got  Check
+				want string
+			}{
+				{DeploymentRolledOut(ctx, clientset, testNamespace, "web"), tt.wantRolledOut},
+				{DeploymentAvailable(ctx, clientset, testNamespace, "web"), tt.wantAvailable},
+			} {
+				if c.got.Passed != (c.want == "") || !strings.Contains(c.got.Details, c.want) {
+					t.Errorf("%s: Passed = %v (%q), want failure %q", c.got.Name, c.got.Passed, c.got.Details, c.want)
+				}
+				if c.got.DocURL != DocDeployments {
+					t.Errorf("%s: DocURL = %q, want %q", c.got.Name, c.got.DocURL, DocDeployments)
+				}
+			}
+		})
+	}
+
+	missing := DeploymentRolledOut(ctx, fake.NewSimpleClientset(), testNamespace, "web")
+	if missing.Passed || !strings.Contains(missing.Details, "not found") {
+		t.Errorf("Expected a missing deployment to fail, got %+v", missing)
+	}
+}
+
+func TestRolloutPendingDefaultReplicas(t *testing.T) {
+	dep := testDeployment(1)
+	dep.Spec.Replicas = nil
+	if reason := rolloutPending(dep); reason != "" {
+		t.Errorf("Expected replicas to default to 1, got %q", reason)
+	}
+	dep.Status.UpdatedReplicas = 0
+	if reason := rolloutPending(dep); !strings.Contains(reason, "0 of 1 replicas updated") {
+		t.Errorf("rolloutPending() = %q", reason)
+	}
+}
+
+// fakeClient returns a client whose typed, dynamic and discovery clients are
+// all backed by objs.
+func fakeClient(objs ...runtime.Object) *k8s.Client {
+	clientset := fake.NewSimpleClientset(objs...)
+	clientset.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true},
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			{Name: "namespaces", Kind: "Namespace"},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+		}},
+	}
+	return &k8s.Client{
+		Clientset: clientset,
+		Dynamic:   dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objs...),
+		Mapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery())),
+	}
+}
+
+func TestCheckModifiers(t *testing.T) {
+	failed := fail("Pod web running", "Pod web is %s.", corev1.PodPending)
+	if failed.Passed || failed.Details != "Pod web is Pending." {
+		t.Errorf("fail() = %+v", failed)
+	}
+	if !failed.Blocking() || !failed.WithSeverity(SeverityBlocker).Blocking() {
+		t.Error("Expected checks to block by default")
+	}
+	if failed.WithSeverity(SeverityWarn).Blocking() {
+		t.Error("Expected a warning not to block")
+	}
+
+	if got := failed.OrElse("Check the image tag."); got.Details != "Check the image tag." || got.Name != failed.Name {
+		t.Errorf("OrElse on a failure = %+v", got)
+	}
+	if got := pass("ok").OrElse("Check the image tag."); got.Details != "" || !got.Passed {
+		t.Errorf("OrElse on a pass = %+v", got)
+	}
+	if got := failed.Named("Web is up"); got.Name != "Web is up" || got.Details != failed.Details || got.Passed {
+		t.Errorf("Named() = %+v", got)
+	}
+	if got := failed.WithDoc(DocServices); got.DocURL != DocServices || failed.DocURL != "" {
+		t.Errorf("WithDoc() = %+v, and changed the original to %q", got, failed.DocURL)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for in, want := range map[string]Severity{"": SeverityBlocker, "blocker": SeverityBlocker, "warn": SeverityWarn, "info": SeverityInfo} {
+		if got, err := ParseSeverity(in); err != nil || got != want {
+			t.Errorf("ParseSeverity(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("Expected an unknown severity to be rejected")
+	}
+}
+
+func TestFieldEquals(t *testing.T) {
+	if c := FieldEquals("replicas", int32(3), int32(3)); !c.Passed {
+		t.Errorf("Expected equal values to pass: %+v", c)
+	}
+	if c := FieldEquals("labels", map[string]string{"app": "web"}, map[string]string{"app": "web"}); !c.Passed {
+		t.Errorf("Expected deeply equal values to pass: %+v", c)
+	}
+	c := FieldEquals("replicas", int32(1), int32(3))
+	if c.Passed || c.Details != "replicas is 1, expected 3." {
+		t.Errorf("FieldEquals mismatch = %+v", c)
+	}
+	if c := FieldEquals("replicas", 3, int32(3)); c.Passed {
+		t.Error("Expected values of different types to differ")
+	}
+}
+
+func TestPodRunning(t *testing.T) {
+	ctx := context.Background()
+	running := testPod("web", time.Now(), 0, true)
+	pending := testPod("web", time.Now(), 0, false)
+	pending.Status.Phase = corev1.PodPending
+
+	tests := []struct {
+		name string
+		pods []runtime.Object
+		want string // Substring of the failure; "" means passed
+	}{
+		{"running", []runtime.Object{running}, ""},
+		{"pending", []runtime.Object{pending}, "Pod web is Pending."},
+		{"missing", nil, "Pod web not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PodRunning(ctx, fake.NewSimpleClientset(tt.pods...), testNamespace, "web")
+			if got.Passed != (tt.want == "") || !strings.Contains(got.Details, tt.want) {
+				t.Errorf("PodRunning() = %+v, want failure %q", got, tt.want)
+			}
+			if got.Name != "Pod web running" || got.DocURL != DocPodLifecycle {
+				t.Errorf("Unexpected name or doc: %+v", got)
+			}
+		})
+	}
+}
+
+func TestPodsRunning(t *testing.T) {
+	ctx := context.Background()
+	pending := testPod("web-1", time.Now(), 0, false)
+	pending.Status.Phase = corev1.PodPending
+	clientset := fake.NewSimpleClientset(pending, testPod("web-2", time.Now(), 0, true))
+
+	if got := PodsRunning(ctx, clientset, testNamespace, "app=web"); !got.Passed {
+		t.Errorf("Expected one running pod to be enough: %+v", got)
+	}
+	if got := PodsRunning(ctx, fake.NewSimpleClientset(pending), testNamespace, "app=web"); got.Passed || got.Details != "No running pods match app=web." {
+		t.Errorf("Expected only pending pods to fail: %+v", got)
+	}
+	if got := PodsRunning(ctx, clientset, testNamespace, "app=api"); got.Passed {
+		t.Errorf("Expected an unmatched selector to fail: %+v", got)
+	}
+}
+
+func TestPodDeleted(t *testing.T) {
+	ctx := context.Background()
+	if got := PodDeleted(ctx, fake.NewSimpleClientset(), testNamespace, "web"); !got.Passed {
+		t.Errorf("Expected a missing pod to count as deleted: %+v", got)
+	}
+	if got := PodDeleted(ctx, fake.NewSimpleClientset(testPod("web", time.Now(), 0, true)), testNamespace, "web"); got.Passed || got.Details != "Pod web still exists." {
+		t.Errorf("Expected an existing pod to fail: %+v", got)
+	}
+}
+
+func TestEndpointsNonEmpty(t *testing.T) {
+	ctx := context.Background()
+	endpoints := func(subsets ...corev1.EndpointSubset) *corev1.Endpoints {
+		return &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace}, Subsets: subsets}
+	}
+	tests := []struct {
+		name string
+		objs []runtime.Object
+		want string
+	}{
+		{"ready address", []runtime.Object{endpoints(
+			corev1.EndpointSubset{NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}},
+			corev1.EndpointSubset{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.2"}}},
+		)}, ""},
+		{"only not-ready addresses", []runtime.Object{endpoints(
+			corev1.EndpointSubset{NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}},
+		)}, "Service web has no endpoints."},
+		{"no subsets", []runtime.Object{endpoints()}, "Service web has no endpoints."},
+		{"missing", nil, "Endpoints for web not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EndpointsNonEmpty(ctx, fake.NewSimpleClientset(tt.objs...), testNamespace, "web")
+			if got.Passed != (tt.want == "") || !strings.Contains(got.Details, tt.want) {
+				t.Errorf("EndpointsNonEmpty() = %+v, want failure %q", got, tt.want)
+			}
+			if got.DocURL != DocServices {
+				t.Errorf("DocURL = %q, want %q", got.DocURL, DocServices)
+			}
+		})
+	}
+}
+
+func TestObjectFieldEquals(t *testing.T) {
+	ctx := context.Background()
+	client := fakeClient(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: testNamespace}, Data: map[string]string{"mode": "debug"}},
+		testDeployment(3),
+	)
+
+	tests := []struct {
+		name                    string
+		apiVersion, kind, obj   string
+		path, want, wantFailure string
+	}{
+		{"configmap field", "v1", "ConfigMap", "settings", "{.data.mode}", "debug", ""},
+		{"deployment replicas", "apps/v1", "Deployment", "web", "{.spec.replicas}", "3", ""},
+		{"wrong value", "v1", "ConfigMap", "settings", "{.data.mode}", "release", `has {.data.mode} = "debug", expected "release"`},
+		{"missing key", "v1", "ConfigMap", "settings", "{.data.level}", "info", `= "", expected "info"`},
+		{"missing object", "v1", "ConfigMap", "other", "{.data.mode}", "debug", "failed to get ConfigMap other"},
+		{"bad path", "v1", "ConfigMap", "settings", "{.data[", "debug", "invalid path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ObjectFieldEquals(ctx, client, tt.apiVersion, tt.kind, testNamespace, tt.obj, tt.path, tt.want)
+			if got.Passed != (tt.wantFailure == "") || !strings.Contains(got.Details, tt.wantFailure) {
+				t.Errorf("ObjectFieldEquals() = %+v, want failure %q", got, tt.wantFailure)
+			}
+		})
+	}
+}
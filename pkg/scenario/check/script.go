@@ -0,0 +1,211 @@
+package check
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s-dojo/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// ScriptTimeout bounds one run of a local verification script.
+	ScriptTimeout = 30 * time.Second
+
+	// PodScriptTimeout bounds a script run in a validator pod, which may
+	// have to pull its image first.
+	PodScriptTimeout = 2 * time.Minute
+
+	// DefaultValidatorImage runs scripts in validator pods. It needs sh and kubectl.
+	DefaultValidatorImage = "bitnami/kubectl"
+
+	// validatorAccount is the ServiceAccount validator pods run as, with
+	// read access to the scenario namespace.
+	validatorAccount = "dojo-validator"
+)
+
+// ScriptOptions tune how Script runs its script and reads the outcome.
+type ScriptOptions struct {
+	// InPod runs the script in a disposable pod in the namespace instead of
+	// on this machine, so it doesn't depend on the tools installed here.
+	InPod bool
+	Image string // Validator pod image (default: DefaultValidatorImage)
+
+	// Expect, when set, must match the script's output as well.
+	Expect *regexp.Regexp
+
+	Timeout time.Duration // Default: ScriptTimeout, or PodScriptTimeout in a pod
+}
+
+// Script passes when the shell script exits with status 0 and, with
+// opts.Expect set, its output matches. Locally it runs under sh with
+// KUBECONFIG pointing at the cluster, defaulting to namespace; in a pod,
+// kubectl uses the pod's ServiceAccount. NAMESPACE is set either way. The
+// last line the script prints explains a failure.
+func Script(ctx context.Context, client *k8s.Client, namespace, script string, opts ScriptOptions) Check {
+	const name = "Verification script"
+
+	run := RunScript
+	timeout := ScriptTimeout
+	if opts.InPod {
+		image := opts.Image
+		if image == "" {
+			image = DefaultValidatorImage
+		}
+		run = func(ctx context.Context, client *k8s.Client, namespace, script string, timeout time.Duration) (string, error) {
+			return RunScriptInPod(ctx, client, namespace, image, script, timeout)
+		}
+		timeout = PodScriptTimeout
+	}
+	if opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+
+	out, err := run(ctx, client, namespace, script, timeout)
+	if err != nil {
+		return fail(name, "%s", err.Error())
+	}
+	if opts.Expect != nil && !opts.Expect.MatchString(out) {
+		if line := lastLine(out); line != "" {
+			return fail(name, "The script printed %q, which doesn't match %q.", line, opts.Expect.String())
+		}
+		return fail(name, "The script printed nothing, expected output matching %q.", opts.Expect.String())
+	}
+	return pass(name)
+}
+
+// RunScript runs a shell script on this machine against the cluster, for at
+// most timeout, and returns its output. A failure's error carries the
+// script's last line of output.
+func RunScript(ctx context.Context, client *k8s.Client, namespace, script string, timeout time.Duration) (string, error) {
+	kubeconfig, err := client.KubeconfigFor(namespace)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp("", "k8s-dojo-kubeconfig-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a kubeconfig for the script: %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(kubeconfig)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write a kubeconfig for the script: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", script)
+	cmd.Env = append(os.Environ(), "KUBECONFIG="+f.Name(), "NAMESPACE="+namespace)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return out.String(), fmt.Errorf("the script did not finish within %s", timeout)
+		}
+		if line := lastLine(out.String()); line != "" {
+			return out.String(), errors.New(line)
+		}
+		return out.String(), fmt.Errorf("the script failed: %w", err)
+	}
+	return out.String(), nil
+}
+
+// RunScriptInPod runs a shell script in a short-lived pod of image in
+// namespace, for at most timeout, and returns its output. The pod runs as a
+// ServiceAccount that can read the namespace, and is deleted afterwards.
+func RunScriptInPod(ctx context.Context, client *k8s.Client, namespace, image, script string, timeout time.Duration) (string, error) {
+	if err := ensureValidatorAccount(ctx, client, namespace); err != nil {
+		return "", err
+	}
+
+	pods := client.Clientset.CoreV1().Pods(namespace)
+	pod, err := pods.Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "dojo-validator-",
+			Labels:       map[string]string{"app.kubernetes.io/managed-by": "k8s-dojo"},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: validatorAccount,
+			RestartPolicy:      corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:    "validator",
+				Image:   image,
+				Command: []string{"sh", "-c", script},
+				Env:     []corev1.EnvVar{{Name: "NAMESPACE", Value: namespace}},
+			}},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to create validator pod: %w", err)
+	}
+	defer func() {
+		_ = pods.Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	var phase corev1.PodPhase
+	err = wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		p, err := pods.Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		phase = p.Status.Phase
+		return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("the validator pod did not finish within %s", timeout)
+	}
+
+	out, err := pods.GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read validator output: %w", err)
+	}
+	if phase == corev1.PodFailed {
+		if line := lastLine(string(out)); line != "" {
+			return string(out), errors.New(line)
+		}
+		return string(out), fmt.Errorf("the script failed")
+	}
+	return string(out), nil
+}
+
+// ensureValidatorAccount creates the validator ServiceAccount in namespace,
+// bound to the built-in view role there, unless it exists.
+func ensureValidatorAccount(ctx context.Context, client *k8s.Client, namespace string) error {
+	_, err := client.Clientset.CoreV1().ServiceAccounts(namespace).Create(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: validatorAccount},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create validator service account: %w", err)
+	}
+	_, err = client.Clientset.RbacV1().RoleBindings(namespace).Create(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: validatorAccount},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: validatorAccount, Namespace: namespace}},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to bind validator service account: %w", err)
+	}
+	return nil
+}
+
+// lastLine returns the last non-blank line of output.
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,185 @@
+package check
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-dojo/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: kind-dojo
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: kind-dojo
+  context:
+    cluster: kind-dojo
+    user: admin
+current-context: kind-dojo
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+func TestScriptLocal(t *testing.T) {
+	client, err := k8s.NewClientFromKubeconfig(testKubeconfig)
+	if err != nil {
+		t.Fatalf("NewClientFromKubeconfig failed: %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		script string
+		opts   ScriptOptions
+		want   string // Failure details; "" means passed
+	}{
+		{"exit 0", "true", ScriptOptions{}, ""},
+		{"namespace set", `test "$NAMESPACE" = dojo && grep -q "namespace: dojo" "$KUBECONFIG"`, ScriptOptions{}, ""},
+		{"last line explains a failure", "echo checking\necho 'Service web has no endpoints'\nexit 1", ScriptOptions{}, "Service web has no endpoints"},
+		{"silent failure", "exit 3", ScriptOptions{}, "the script failed: exit status 3"},
+		{"expected output", "echo replicas=3", ScriptOptions{Expect: regexp.MustCompile(`replicas=3`)}, ""},
+		{"unexpected output", "echo replicas=1", ScriptOptions{Expect: regexp.MustCompile(`replicas=3`)}, `The script printed "replicas=1", which doesn't match "replicas=3".`},
+		{"no output", "true", ScriptOptions{Expect: regexp.MustCompile(`ok`)}, `The script printed nothing, expected output matching "ok".`},
+		{"timeout", "exec sleep 5", ScriptOptions{Timeout: 100 * time.Millisecond}, "the script did not finish within 100ms"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Script(ctx, client, testNamespace, tt.script, tt.opts)
+			if got.Passed != (tt.want == "") || got.Details != tt.want {
+				t.Errorf("Script() = %+v, want failure %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := RunScript(ctx, &k8s.Client{}, testNamespace, "true", time.Second); err == nil {
+		t.Error("Expected a client without a kubeconfig to be refused")
+	}
+}
+
+// finishValidator makes the fake API server report validator pods in phase.
+func finishValidator(clientset *fake.Clientset, phase corev1.PodPhase, created *[]*corev1.Pod) {
+	clientset.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get, ok := action.(k8stesting.GetAction)
+		if !ok || get.GetSubresource() != "" {
+			return false, nil, nil
+		}
+		obj, err := clientset.Tracker().Get(corev1.SchemeGroupVersion.WithResource("pods"), get.GetNamespace(), get.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		pod := obj.(*corev1.Pod).DeepCopy()
+		*created = append(*created, pod)
+		pod.Status.Phase = phase
+		return true, pod, nil
+	})
+	// The fake clientset doesn't implement generateName
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+		if pod.Name == "" {
+			pod.Name = pod.GenerateName + "test"
+		}
+		return false, nil, nil
+	})
+}
+
+func TestRunScriptInPod(t *testing.T) {
+	ctx := context.Background()
+
+	for _, phase := range []corev1.PodPhase{corev1.PodSucceeded, corev1.PodFailed} {
+		t.Run(string(phase), func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			var seen []*corev1.Pod
+			finishValidator(clientset, phase, &seen)
+			client := &k8s.Client{Clientset: clientset}
+
+			out, err := RunScriptInPod(ctx, client, testNamespace, DefaultValidatorImage, "kubectl get pods", time.Minute)
+			if phase == corev1.PodSucceeded && err != nil {
+				t.Fatalf("RunScriptInPod failed: %v", err)
+			}
+			if phase == corev1.PodFailed && (err == nil || err.Error() != strings.TrimSpace(out)) {
+				t.Fatalf("Expected the pod's last line as the error, got %v (output %q)", err, out)
+			}
+			if out != "fake logs" {
+				t.Errorf("Expected the pod's logs as output, got %q", out)
+			}
+
+			if len(seen) == 0 {
+				t.Fatal("Expected the validator pod to be polled")
+			}
+			pod := seen[0]
+			c := pod.Spec.Containers[0]
+			if pod.Spec.ServiceAccountName != validatorAccount || pod.Spec.RestartPolicy != corev1.RestartPolicyNever {
+				t.Errorf("Unexpected validator pod spec: %+v", pod.Spec)
+			}
+			if c.Image != DefaultValidatorImage || strings.Join(c.Command, " ") != "sh -c kubectl get pods" {
+				t.Errorf("Unexpected validator container: %+v", c)
+			}
+			if len(c.Env) != 1 || c.Env[0] != (corev1.EnvVar{Name: "NAMESPACE", Value: testNamespace}) {
+				t.Errorf("Expected NAMESPACE in the environment, got %v", c.Env)
+			}
+
+			pods, _ := clientset.CoreV1().Pods(testNamespace).List(ctx, metav1.ListOptions{})
+			if len(pods.Items) != 0 {
+				t.Errorf("Expected the validator pod to be deleted, %d left", len(pods.Items))
+			}
+			if _, err := clientset.CoreV1().ServiceAccounts(testNamespace).Get(ctx, validatorAccount, metav1.GetOptions{}); err != nil {
+				t.Errorf("Expected the validator service account: %v", err)
+			}
+			rb, err := clientset.RbacV1().RoleBindings(testNamespace).Get(ctx, validatorAccount, metav1.GetOptions{})
+			if err != nil || rb.RoleRef.Name != "view" {
+				t.Errorf("Expected the validator bound to view, got %v, %v", rb, err)
+			}
+		})
+	}
+}
+
+func TestScriptInPod(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		// Left from an earlier run
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: validatorAccount, Namespace: testNamespace}},
+	)
+	var seen []*corev1.Pod
+	finishValidator(clientset, corev1.PodSucceeded, &seen)
+	client := &k8s.Client{Clientset: clientset}
+
+	got := Script(ctx, client, testNamespace, "kubectl get pods", ScriptOptions{InPod: true, Image: "example.com/kubectl:1.31", Expect: regexp.MustCompile("logs")})
+	if !got.Passed {
+		t.Fatalf("Script() = %+v", got)
+	}
+	if image := seen[0].Spec.Containers[0].Image; image != "example.com/kubectl:1.31" {
+		t.Errorf("Expected the custom image, got %s", image)
+	}
+
+	got = Script(ctx, client, testNamespace, "kubectl get pods", ScriptOptions{InPod: true, Expect: regexp.MustCompile("^ok$")})
+	if got.Passed || !strings.Contains(got.Details, `printed "fake logs"`) {
+		t.Errorf("Expected the output to be matched, got %+v", got)
+	}
+}
+
+func TestLastLine(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                         "",
+		"one":                      "one",
+		"one\ntwo\n\n":             "two",
+		"  first\n  last line  \n": "last line",
+	} {
+		if got := lastLine(in); got != want {
+			t.Errorf("lastLine(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Quick command groups, in the order the content panel lists them.
+const (
+	CommandInspect = "inspect"
+	CommandEvents  = "events"
+	CommandLogs    = "logs"
+)
+
+// CommandGroups lists the quick command groups in display order.
+var CommandGroups = []string{CommandInspect, CommandEvents, CommandLogs}
+
+// Command is a quick command the learner can run with one keystroke. Like
+// hints, it is a template filled in with HintValues when shown, e.g.
+// "kubectl logs -n {{ .Namespace }} deploy/web".
+type Command struct {
+	Group   string `json:"group"` // One of CommandGroups
+	Command string `json:"command"`
+}
+
+// shellMeta are the characters that chain, substitute or redirect commands
+// in a shell, none of which a plain kubectl invocation needs.
+const shellMeta = ";&|$`<>"
+
+// DefaultCommands are shown for scenarios that don't declare their own.
+var DefaultCommands = []Command{
+	{Group: CommandInspect, Command: "kubectl get pods -n {{ .Namespace }}"},
+	{Group: CommandEvents, Command: "kubectl get events -n {{ .Namespace }} --sort-by=.lastTimestamp"},
+}
+
+// QuickCommands returns the scenario's quick commands, or DefaultCommands.
+func (m Metadata) QuickCommands() []Command {
+	if len(m.Commands) == 0 {
+		return DefaultCommands
+	}
+	return m.Commands
+}
+
+func (c Command) validate() error {
+	known := false
+	for _, g := range CommandGroups {
+		known = known || c.Group == g
+	}
+	if !known {
+		return fmt.Errorf("unknown group %q", c.Group)
+	}
+	if c.Command == "" {
+		return fmt.Errorf("command is empty")
+	}
+	if err := ValidateHint(c.Command); err != nil {
+		return err
+	}
+	// The command is typed into the terminal and run, so it must stay one line
+	if hasControl(c.Command) || hasControl(RenderHint(c.Command, HintValues{})) {
+		return fmt.Errorf("command %q contains a newline or control character", c.Command)
+	}
+	return nil
+}
+
+// plainKubectl reports whether the command, once filled in, runs kubectl
+// and nothing else.
+func (c Command) plainKubectl() bool {
+	typed := RenderHint(c.Command, HintValues{Namespace: "dojo", ClusterContext: "kind-dojo"})
+	return strings.HasPrefix(typed, "kubectl ") && !strings.ContainsAny(typed, shellMeta) && !hasControl(typed)
+}
+
+func hasControl(s string) bool {
+	return strings.IndexFunc(s, unicode.IsControl) >= 0
+}
@@ -0,0 +1,97 @@
+package scenario
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+// crdGVR is the resource for CustomResourceDefinitions, accessed via the dynamic client.
+var crdGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// getCustomResource fetches a namespaced custom resource, returning nil if it doesn't exist.
+func getCustomResource(ctx context.Context, dyn dynamic.Interface, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	obj, err := dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return obj, err
+}
+
+// crdEstablished reports whether a CRD exists and is serving its API.
+func crdEstablished(ctx context.Context, dyn dynamic.Interface, name string) (bool, error) {
+	crd, err := dyn.Resource(crdGVR).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if ok && cond["type"] == "Established" && cond["status"] == "True" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// waitForCRD polls until the CRD is established.
+func waitForCRD(ctx context.Context, dyn dynamic.Interface, name string) error {
+	return wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+		ok, err := crdEstablished(ctx, dyn, name)
+		return ok && err == nil, nil
+	})
+}
+
+// deleteCRD removes a CRD and, through cascading deletion, all of its custom resources.
+func deleteCRD(ctx context.Context, dyn dynamic.Interface, name string) error {
+	err := dyn.Resource(crdGVR).Delete(ctx, name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// crdOwnerLabel marks the CRDs k8s-dojo installs, so cleanup never deletes one
+// the cluster already had.
+const crdOwnerLabel = "app.kubernetes.io/managed-by"
+
+// deleteUnusedCRD deletes a CRD installed by k8s-dojo once no custom resources
+// of gvr remain outside namespace, the one being cleaned up. CRDs are
+// cluster-scoped, so one deleted while another session still uses it would
+// take that session's resources with it.
+func deleteUnusedCRD(ctx context.Context, dyn dynamic.Interface, name string, gvr schema.GroupVersionResource, namespace string) error {
+	crd, err := dyn.Resource(crdGVR).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if crd.GetLabels()[crdOwnerLabel] != "k8s-dojo" {
+		return nil
+	}
+
+	list, err := dyn.Resource(gvr).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, item := range list.Items {
+		if item.GetNamespace() != namespace {
+			return nil
+		}
+	}
+	return deleteCRD(ctx, dyn, name)
+}
@@ -0,0 +1,32 @@
+package scenario
+
+// DemoStep is one captioned command of a scripted demo.
+type DemoStep struct {
+	Caption string
+	Command string
+}
+
+// Demonstrable is implemented by scenarios with a scripted canonical solution.
+// Scenarios without one fall back to the commands of their guide.
+type Demonstrable interface {
+	DemoScript() []DemoStep
+}
+
+// DemoScript returns the commands that solve the scenario, captioned for `k8s-dojo demo`.
+// Call it after the scenario has started so the commands target its namespace.
+func DemoScript(s Scenario) []DemoStep {
+	if d, ok := s.(Demonstrable); ok {
+		return d.DemoScript()
+	}
+	g, ok := s.(Guided)
+	if !ok {
+		return nil
+	}
+	var steps []DemoStep
+	for _, step := range g.Guide() {
+		if step.Command != "" {
+			steps = append(steps, DemoStep{Caption: step.Title, Command: step.Command})
+		}
+	}
+	return steps
+}
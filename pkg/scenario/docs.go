@@ -0,0 +1,30 @@
+package scenario
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// DocLink is a documentation page about what a scenario teaches, e.g. on
+// kubernetes.io.
+type DocLink struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func (d DocLink) validate() error {
+	if d.Title == "" {
+		return fmt.Errorf("title is empty")
+	}
+	return validateDocURL(d.URL)
+}
+
+// validateDocURL accepts only http and https links, so pack content can't
+// link to local files or put arbitrary text where a link is shown.
+func validateDocURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url %q", raw)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Fault injects one breakage into healthy resources in a namespace.
+// Faults are the building blocks of scenario setups and compose with ComposeFaults.
+type Fault func(ctx context.Context, clientset kubernetes.Interface, namespace string) error
+
+// ComposeFaults returns a fault that injects each fault in order.
+func ComposeFaults(faults ...Fault) Fault {
+	return func(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+		for _, f := range faults {
+			if err := f(ctx, clientset, namespace); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// mutateContainer applies a change to the first container of a deployment.
+func mutateContainer(deployment string, mutate func(*corev1.Container)) Fault {
+	return func(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+		err := updateDeployment(ctx, clientset, namespace, deployment, func(dep *appsv1.Deployment) {
+			mutate(&dep.Spec.Template.Spec.Containers[0])
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update deployment %s: %w", deployment, err)
+		}
+		return nil
+	}
+}
+
+// BadImage points a deployment at an image that can't be pulled.
+func BadImage(deployment, image string) Fault {
+	return mutateContainer(deployment, func(c *corev1.Container) {
+		c.Image = image
+	})
+}
+
+// FailingLivenessProbe adds a liveness probe on a path that doesn't exist, so the container restarts.
+func FailingLivenessProbe(deployment, path string, port int) Fault {
+	return mutateContainer(deployment, func(c *corev1.Container) {
+		c.LivenessProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: path, Port: intstr.FromInt(port)},
+			},
+			InitialDelaySeconds: 3,
+			PeriodSeconds:       3,
+			FailureThreshold:    2,
+		}
+	})
+}
+
+// TinyMemoryLimit caps a deployment's memory so its container is OOM killed.
+func TinyMemoryLimit(deployment, limit string) Fault {
+	return mutateContainer(deployment, func(c *corev1.Container) {
+		if c.Resources.Limits == nil {
+			c.Resources.Limits = corev1.ResourceList{}
+		}
+		c.Resources.Limits[corev1.ResourceMemory] = mustParse(limit)
+		delete(c.Resources.Requests, corev1.ResourceMemory)
+	})
+}
+
+// DropEnv removes an environment variable the container needs to start.
+func DropEnv(deployment, name string) Fault {
+	return mutateContainer(deployment, func(c *corev1.Container) {
+		var env []corev1.EnvVar
+		for _, e := range c.Env {
+			if e.Name != name {
+				env = append(env, e)
+			}
+		}
+		c.Env = env
+	})
+}
+
+// ScaleToZero scales a deployment down so its Service has no endpoints.
+func ScaleToZero(deployment string) Fault {
+	return func(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+		err := updateDeployment(ctx, clientset, namespace, deployment, func(dep *appsv1.Deployment) {
+			replicas := int32(0)
+			dep.Spec.Replicas = &replicas
+		})
+		if err != nil {
+			return fmt.Errorf("failed to scale deployment %s: %w", deployment, err)
+		}
+		return nil
+	}
+}
+
+// SelectorMismatch changes a service selector so it matches no pods.
+func SelectorMismatch(service, key, value string) Fault {
+	return func(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+		err := updateService(ctx, clientset, namespace, service, func(svc *corev1.Service) {
+			if svc.Spec.Selector == nil {
+				svc.Spec.Selector = map[string]string{}
+			}
+			svc.Spec.Selector[key] = value
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update service %s: %w", service, err)
+		}
+		return nil
+	}
+}
+
+// WrongTargetPort points a service at a port its pods don't listen on.
+func WrongTargetPort(service string, port int) Fault {
+	return func(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+		err := updateService(ctx, clientset, namespace, service, func(svc *corev1.Service) {
+			svc.Spec.Ports[0].TargetPort = intstr.FromInt(port)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update service %s: %w", service, err)
+		}
+		return nil
+	}
+}
+
+// DenyIngress adds a NetworkPolicy that blocks all traffic to pods with the given app label.
+func DenyIngress(app string) Fault {
+	return func(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+		policy := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "deny-" + app},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		}
+		if _, err := clientset.NetworkingV1().NetworkPolicies(namespace).Create(ctx, policy, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create network policy: %w", err)
+		}
+		return nil
+	}
+}
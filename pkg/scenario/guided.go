@@ -0,0 +1,20 @@
+package scenario
+
+import "context"
+
+// GuideStep is one instruction card of a guided walkthrough.
+type GuideStep struct {
+	Title       string
+	Instruction string
+	Command     string // The command the learner is expected to run
+
+	// Verify reports whether the step is complete. Steps without Verify
+	// (e.g. reading output) are advanced by the learner.
+	Verify func(ctx context.Context) Check
+}
+
+// Guided is implemented by scenarios that offer a step-by-step beginner mode.
+// In guided mode the hints are replaced by the steps, revealed one at a time.
+type Guided interface {
+	Guide() []GuideStep
+}
@@ -0,0 +1,52 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// HintValues are what hint templates can refer to, filled in when a hint is
+// shown so they match the running attempt, e.g.
+// "kubectl get pods -n {{ .Namespace }}".
+type HintValues struct {
+	Namespace      string // The attempt's namespace
+	ClusterContext string // The kubeconfig context of the dojo cluster
+}
+
+// RenderHint fills in a hint's template. A hint that isn't a valid template
+// is shown as written.
+func RenderHint(hint string, values HintValues) string {
+	if !strings.Contains(hint, "{{") {
+		return hint
+	}
+	t, err := parseHint(hint)
+	if err != nil {
+		return hint
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, values); err != nil {
+		return hint
+	}
+	return b.String()
+}
+
+// ValidateHint reports whether a hint is a template RenderHint can fill in.
+func ValidateHint(hint string) error {
+	t, err := parseHint(hint)
+	if err != nil {
+		return err
+	}
+	if err := t.Execute(&strings.Builder{}, HintValues{}); err != nil {
+		return fmt.Errorf("invalid hint template: %w", err)
+	}
+	return nil
+}
+
+func parseHint(hint string) (*template.Template, error) {
+	t, err := template.New("hint").Option("missingkey=error").Parse(hint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hint template: %w", err)
+	}
+	return t, nil
+}
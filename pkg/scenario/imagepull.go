@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,11 +17,11 @@ import (
 // ImagePullBackOff is a scenario where a deployment has an invalid image tag.
 type ImagePullBackOff struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
 // NewImagePullBackOff creates a new ImagePullBackOff scenario.
-func NewImagePullBackOff(clientset *kubernetes.Clientset) *ImagePullBackOff {
+func NewImagePullBackOff(clientset kubernetes.Interface) *ImagePullBackOff {
 	return &ImagePullBackOff{
 		BaseScenario: BaseScenario{
 			Namespace: "dojo-level-1",
@@ -28,21 +30,31 @@ func NewImagePullBackOff(clientset *kubernetes.Clientset) *ImagePullBackOff {
 	}
 }
 
+var imagePullBackOffMetadata = Metadata{
+	ID:          "image-pull-backoff",
+	Name:        "Level 1: Image Pull Error",
+	Description: "The web-server Deployment is failing to start. Investigate and fix the issue.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Pods & Containers",
+	Tags:        []string{"images", "registry", "pods", "cka"},
+	Hints: []string{
+		"Check the Pod status using: kubectl get pods -n {{ .Namespace }}",
+		"Look at the Pod events: kubectl describe pod -n {{ .Namespace }}",
+		"The image tag might be incorrect...",
+	},
+	Commands: []Command{
+		{Group: CommandInspect, Command: "kubectl get deploy,pods -n {{ .Namespace }} -o wide"},
+		{Group: CommandEvents, Command: "kubectl describe pod -l app=web-server -n {{ .Namespace }}"},
+	},
+	TimeLimit: 10 * time.Minute,
+	DocsLinks: []DocLink{
+		{Title: "Images", URL: "https://kubernetes.io/docs/concepts/containers/images/"},
+	},
+}
+
 // GetMetadata returns the scenario's metadata.
 func (s *ImagePullBackOff) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "image-pull-backoff",
-		Name:        "Level 1: Image Pull Error",
-		Description: "The web-server Deployment is failing to start. Investigate and fix the issue.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Pods & Containers",
-		Hints: []string{
-			"Check the Pod status using: kubectl get pods -n " + s.Namespace,
-			"Look at the Pod events: kubectl describe pod -n " + s.Namespace,
-			"The image tag might be incorrect...",
-		},
-		TimeLimit: 10 * time.Minute,
-	}
+	return imagePullBackOffMetadata
 }
 
 // Setup creates the faulty deployment in the cluster.
@@ -107,6 +119,11 @@ func (s *ImagePullBackOff) Setup(ctx context.Context) error {
 	return nil
 }
 
+// WaitReady blocks until the pod has failed to pull its image at least once.
+func (s *ImagePullBackOff) WaitReady(ctx context.Context) error {
+	return waitForPods(ctx, s.clientset, s.Namespace, "app=web-server", containerWaiting("ErrImagePull", "ImagePullBackOff"))
+}
+
 // Validate checks if the user has fixed the deployment.
 func (s *ImagePullBackOff) Validate(ctx context.Context) Result {
 	// Get pods in the namespace
@@ -117,14 +134,16 @@ func (s *ImagePullBackOff) Validate(ctx context.Context) Result {
 		return Result{Solved: false, Message: fmt.Sprintf("Error checking pods: %v", err)}
 	}
 
-	if len(pods.Items) == 0 {
-		return Result{Solved: false, Message: "No pods found. Deployment may have been deleted."}
+	podsExist := Check{
+		Name:    "Pods exist",
+		Passed:  len(pods.Items) > 0,
+		Details: "No pods found. Deployment may have been deleted.",
 	}
 
-	// Check if any pod is running
+	// Check if any pod is running with all containers ready
+	running := Check{Name: "Pod running and ready", Details: "Pod is not yet running. Keep trying!"}
 	for _, pod := range pods.Items {
 		if pod.Status.Phase == corev1.PodRunning {
-			// Verify all containers are ready
 			allReady := true
 			for _, cs := range pod.Status.ContainerStatuses {
 				if !cs.Ready {
@@ -133,24 +152,74 @@ func (s *ImagePullBackOff) Validate(ctx context.Context) Result {
 				}
 			}
 			if allReady {
-				return Result{Solved: true, Message: "🎉 Congratulations! The web-server is now running!"}
+				running.Passed = true
+				break
 			}
 		}
 	}
 
-	// Check for ImagePullBackOff status
-	for _, pod := range pods.Items {
-		for _, cs := range pod.Status.ContainerStatuses {
-			if cs.State.Waiting != nil {
-				reason := cs.State.Waiting.Reason
-				if reason == "ImagePullBackOff" || reason == "ErrImagePull" {
-					return Result{Solved: false, Message: "Pod is stuck in " + reason + ". Keep investigating!"}
+	// Surface ImagePullBackOff status when still broken
+	if !running.Passed {
+		for _, pod := range pods.Items {
+			for _, cs := range pod.Status.ContainerStatuses {
+				if cs.State.Waiting != nil {
+					reason := cs.State.Waiting.Reason
+					if reason == "ImagePullBackOff" || reason == "ErrImagePull" {
+						running.Details = "Pod is stuck in " + reason + ". Keep investigating!"
+					}
 				}
 			}
 		}
 	}
 
-	return Result{Solved: false, Message: "Pod is not yet running. Keep trying!"}
+	return NewResult("🎉 Congratulations! The web-server is now running!", podsExist, running)
+}
+
+// Guide walks beginners through diagnosing and fixing the image tag.
+func (s *ImagePullBackOff) Guide() []GuideStep {
+	return []GuideStep{
+		{
+			Title:       "Find the failing Pod",
+			Instruction: "List the Pods in the scenario namespace. The STATUS column shows why the web-server Pod is not running.",
+			Command:     "kubectl get pods -n " + s.Namespace,
+		},
+		{
+			Title:       "Read the events",
+			Instruction: "Describe the Pod and scroll to Events at the bottom. Look for the image the kubelet failed to pull.",
+			Command:     "kubectl describe pod -l app=web-server -n " + s.Namespace,
+		},
+		{
+			Title:       "Fix the image tag",
+			Instruction: "The tag 'wrongtag' does not exist. Point the Deployment at a real nginx image.",
+			Command:     "kubectl set image deployment/web-server nginx=nginx:alpine -n " + s.Namespace,
+			Verify: func(ctx context.Context) Check {
+				dep, err := s.clientset.AppsV1().Deployments(s.Namespace).Get(ctx, "web-server", metav1.GetOptions{})
+				if err != nil {
+					return Check{Name: "Image updated", Details: err.Error()}
+				}
+				return Check{
+					Name:    "Image updated",
+					Passed:  dep.Spec.Template.Spec.Containers[0].Image != "nginx:wrongtag",
+					Details: "The Deployment still uses nginx:wrongtag.",
+				}
+			},
+		},
+		{
+			Title:       "Watch the rollout",
+			Instruction: "Kubernetes replaces the broken Pod with one using the new image. Wait for the rollout to finish.",
+			Command:     "kubectl rollout status deployment/web-server -n " + s.Namespace,
+			Verify: func(ctx context.Context) Check {
+				return check.DeploymentAvailable(ctx, s.clientset, s.Namespace, "web-server")
+			},
+		},
+	}
+}
+
+// Solve points the deployment at an image that exists.
+func (s *ImagePullBackOff) Solve(ctx context.Context) error {
+	return updateDeployment(ctx, s.clientset, s.Namespace, "web-server", func(dep *appsv1.Deployment) {
+		dep.Spec.Template.Spec.Containers[0].Image = "nginx:alpine"
+	})
 }
 
 // Cleanup removes all resources created by this scenario.
@@ -2,6 +2,8 @@ package scenario
 
 import (
 	"context"
+	"regexp"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -12,25 +14,28 @@ import (
 // IngressPathError scenario: Mismatched Ingress path.
 type IngressPathError struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewIngressPathError(clientset *kubernetes.Clientset) *IngressPathError {
+func NewIngressPathError(clientset kubernetes.Interface) *IngressPathError {
 	return &IngressPathError{
 		BaseScenario: BaseScenario{Namespace: "ingress-path"},
 		clientset:    clientset,
 	}
 }
 
+var ingressPathErrorMetadata = Metadata{
+	ID:          "ingress-path-error",
+	Name:        "Ingress: 404 Not Found",
+	Description: "Requests to /app return 404. Verify the Ingress path configuration.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Networking",
+	Tags:        []string{"ingress", "http", "services", "cka", "ckad"},
+	Hints:       []string{"Check the Ingress `path`", "Ensure the application handles that path or use Rewrite"},
+}
+
 func (s *IngressPathError) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "ingress-path-error",
-		Name:        "Ingress: 404 Not Found",
-		Description: "Requests to /app return 404. Verify the Ingress path configuration.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Networking",
-		Hints:       []string{"Check the Ingress `path`", "Ensure the application handles that path or use Rewrite"},
-	}
+	return ingressPathErrorMetadata
 }
 
 func (s *IngressPathError) Setup(ctx context.Context) error {
@@ -84,14 +89,92 @@ func (s *IngressPathError) Validate(ctx context.Context) Result {
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
+	return s.validate(ing)
+}
+
+// ValidateManifests implements ManifestValidator.
+func (s *IngressPathError) ValidateManifests(objs Manifests) Result {
+	var ing networkingv1.Ingress
+	if res, ok := objs.Find("Ingress", "app-ingress", &ing); !ok {
+		return res
+	}
+	return s.validate(&ing)
+}
+
+// validate checks the ingress spec, live or from a manifest.
+func (s *IngressPathError) validate(ing *networkingv1.Ingress) Result {
+	// Any configuration that routes /app to the service is a valid fix
+	routed := AnyOf("Route /app to app-svc", "Ingress path is still incorrect (Target: /app).",
+		Outcome{Name: "Exact or prefix path /app", Match: func() bool {
+			return ingressRoutes(ing, targetPath, matchPath)
+		}},
+		Outcome{Name: "Regex path with rewrite", Match: func() bool {
+			if ing.Annotations["nginx.ingress.kubernetes.io/use-regex"] != "true" &&
+				ing.Annotations["nginx.ingress.kubernetes.io/rewrite-target"] == "" {
+				return false
+			}
+			return ingressRoutes(ing, targetPath, matchRegex)
+		}},
+		Outcome{Name: "Default backend", Match: func() bool {
+			return isAppBackend(ing.Spec.DefaultBackend)
+		}},
+	)
 
-	if len(ing.Spec.Rules) > 0 {
-		paths := ing.Spec.Rules[0].HTTP.Paths
-		if len(paths) > 0 && paths[0].Path == "/app" {
-			return Result{Solved: true, Message: "Success! Ingress path updated to /app."}
+	return NewResult("Success! Ingress now routes /app to the application.", routed)
+}
+
+const targetPath = "/app"
+
+// ingressRoutes reports whether any rule path matching target points at app-svc.
+func ingressRoutes(ing *networkingv1.Ingress, target string, match func(networkingv1.HTTPIngressPath, string) bool) bool {
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			backend := p.Backend
+			if match(p, target) && isAppBackend(&backend) {
+				return true
+			}
 		}
 	}
-	return Result{Solved: false, Message: "Ingress path is still incorrect (Target: /app)."}
+	return false
+}
+
+// matchPath applies Exact and Prefix semantics from the Ingress spec.
+func matchPath(p networkingv1.HTTPIngressPath, target string) bool {
+	if p.PathType != nil && *p.PathType == networkingv1.PathTypeExact {
+		return p.Path == target
+	}
+	// Prefix and ImplementationSpecific match on path elements
+	prefix := strings.TrimSuffix(p.Path, "/")
+	return prefix == "" || target == prefix || strings.HasPrefix(target, prefix+"/")
+}
+
+// matchRegex treats the path as a regular expression, as ingress-nginx does with use-regex.
+func matchRegex(p networkingv1.HTTPIngressPath, target string) bool {
+	re, err := regexp.Compile("^" + p.Path)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(target)
+}
+
+func isAppBackend(b *networkingv1.IngressBackend) bool {
+	if b == nil || b.Service == nil || b.Service.Name != "app-svc" {
+		return false
+	}
+	return b.Service.Port.Number == 80
+}
+
+func (s *IngressPathError) Solve(ctx context.Context) error {
+	ing, err := s.clientset.NetworkingV1().Ingresses(s.Namespace).Get(ctx, "app-ingress", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	ing.Spec.Rules[0].HTTP.Paths[0].Path = targetPath
+	_, err = s.clientset.NetworkingV1().Ingresses(s.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+	return err
 }
 
 func (s *IngressPathError) Cleanup(ctx context.Context) error {
@@ -12,25 +12,28 @@ import (
 // IngressTLSMismatch scenario: Ingress references missing Secret.
 type IngressTLSMismatch struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewIngressTLSMismatch(clientset *kubernetes.Clientset) *IngressTLSMismatch {
+func NewIngressTLSMismatch(clientset kubernetes.Interface) *IngressTLSMismatch {
 	return &IngressTLSMismatch{
 		BaseScenario: BaseScenario{Namespace: "ingress-tls"},
 		clientset:    clientset,
 	}
 }
 
+var ingressTLSMismatchMetadata = Metadata{
+	ID:          "ingress-tls-mismatch",
+	Name:        "Ingress: TLS Secret Missing",
+	Description: "Ingress is crashing or not loading certificate. Check the Secret reference.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Networking",
+	Tags:        []string{"ingress", "tls", "certificates", "cks"},
+	Hints:       []string{"Check `kubectl get secret`", "Compare with Ingress `tls` section", "Create the secret or fix the name"},
+}
+
 func (s *IngressTLSMismatch) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "ingress-tls-mismatch",
-		Name:        "Ingress: TLS Secret Missing",
-		Description: "Ingress is crashing or not loading certificate. Check the Secret reference.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Networking",
-		Hints:       []string{"Check `kubectl get secret`", "Compare with Ingress `tls` section", "Create the secret or fix the name"},
-	}
+	return ingressTLSMismatchMetadata
 }
 
 func (s *IngressTLSMismatch) Setup(ctx context.Context) error {
@@ -90,12 +93,32 @@ func (s *IngressTLSMismatch) Validate(ctx context.Context) Result {
 		secretName = ing.Spec.TLS[0].SecretName
 	}
 
-	_, err = s.clientset.CoreV1().Secrets(s.Namespace).Get(ctx, secretName, metav1.GetOptions{})
-	if err == nil {
-		return Result{Solved: true, Message: "Success! Ingress TLS secret found."}
+	tlsConfigured := Check{
+		Name:    "Ingress has TLS",
+		Passed:  secretName != "",
+		Details: "Ingress has no TLS secret configured.",
 	}
 
-	return Result{Solved: false, Message: "Referenced TLS secret '" + secretName + "' not found."}
+	secretFound := Check{
+		Name:    "TLS secret exists",
+		Details: "Referenced TLS secret '" + secretName + "' not found.",
+	}
+	if secretName != "" {
+		_, err = s.clientset.CoreV1().Secrets(s.Namespace).Get(ctx, secretName, metav1.GetOptions{})
+		secretFound.Passed = err == nil
+	}
+
+	return NewResult("Success! Ingress TLS secret found.", tlsConfigured, secretFound)
+}
+
+func (s *IngressTLSMismatch) Solve(ctx context.Context) error {
+	ing, err := s.clientset.NetworkingV1().Ingresses(s.Namespace).Get(ctx, "secure-ingress", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	ing.Spec.TLS[0].SecretName = "connection-secure"
+	_, err = s.clientset.NetworkingV1().Ingresses(s.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+	return err
 }
 
 func (s *IngressTLSMismatch) Cleanup(ctx context.Context) error {
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -11,25 +13,36 @@ import (
 // InitContainerCrash scenario: InitContainer fails to complete.
 type InitContainerCrash struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewInitContainerCrash(clientset *kubernetes.Clientset) *InitContainerCrash {
+func NewInitContainerCrash(clientset kubernetes.Interface) *InitContainerCrash {
 	return &InitContainerCrash{
 		BaseScenario: BaseScenario{Namespace: "init-crash"},
 		clientset:    clientset,
 	}
 }
 
+var initContainerCrashMetadata = Metadata{
+	ID:          "init-container-crash",
+	Name:        "Lifecycle: Stuck Initializing",
+	Description: "Pod Status says 'Init:CrashLoopBackOff'. The main container never starts.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Lifecycle",
+	Tags:        []string{"init-containers", "pods", "logs", "ckad"},
+	Hints:       []string{"Use `kubectl logs -c init-myservice`", "The init container command is failing"},
+	Commands: []Command{
+		{Group: CommandInspect, Command: "kubectl get pod app -n {{ .Namespace }}"},
+		{Group: CommandEvents, Command: "kubectl describe pod app -n {{ .Namespace }}"},
+		{Group: CommandLogs, Command: "kubectl logs app -c init-check -n {{ .Namespace }}"},
+	},
+	DocsLinks: []DocLink{
+		{Title: "Init Containers", URL: "https://kubernetes.io/docs/concepts/workloads/pods/init-containers/"},
+	},
+}
+
 func (s *InitContainerCrash) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "init-container-crash",
-		Name:        "Lifecycle: Stuck Initializing",
-		Description: "Pod Status says 'Init:CrashLoopBackOff'. The main container never starts.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Lifecycle",
-		Hints:       []string{"Use `kubectl logs -c init-myservice`", "The init container command is failing"},
-	}
+	return initContainerCrashMetadata
 }
 
 func (s *InitContainerCrash) Setup(ctx context.Context) error {
@@ -58,16 +71,38 @@ func (s *InitContainerCrash) Setup(ctx context.Context) error {
 	return err
 }
 
+// WaitReady blocks until the init container has failed at least once.
+func (s *InitContainerCrash) WaitReady(ctx context.Context) error {
+	return waitForPods(ctx, s.clientset, s.Namespace, "", initContainerFailed)
+}
+
 func (s *InitContainerCrash) Validate(ctx context.Context) Result {
 	pod, err := s.clientset.CoreV1().Pods(s.Namespace).Get(ctx, "app", metav1.GetOptions{})
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
 
-	if pod.Status.Phase == corev1.PodRunning {
-		return Result{Solved: true, Message: "Success! Pod is running."}
+	initDone := Check{
+		Name:    "Init containers completed",
+		Passed:  len(pod.Status.InitContainerStatuses) > 0,
+		Details: "Init container has not completed successfully.",
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.State.Terminated == nil || cs.State.Terminated.ExitCode != 0 {
+			initDone.Passed = false
+		}
 	}
-	return Result{Solved: false, Message: "Pod is not Running."}
+
+	return NewResult("Success! Pod is running.",
+		initDone,
+		check.PodRunning(ctx, s.clientset, s.Namespace, "app"),
+	)
+}
+
+func (s *InitContainerCrash) Solve(ctx context.Context) error {
+	return recreatePod(ctx, s.clientset, s.Namespace, "app", func(pod *corev1.Pod) {
+		pod.Spec.InitContainers[0].Command = []string{"sh", "-c", "exit 0"}
+	})
 }
 
 func (s *InitContainerCrash) Cleanup(ctx context.Context) error {
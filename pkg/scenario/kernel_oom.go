@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -11,25 +13,28 @@ import (
 // KernelOOMDisable scenario: Ensure QoS Guaranteed.
 type KernelOOMDisable struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewKernelOOMDisable(clientset *kubernetes.Clientset) *KernelOOMDisable {
+func NewKernelOOMDisable(clientset kubernetes.Interface) *KernelOOMDisable {
 	return &KernelOOMDisable{
 		BaseScenario: BaseScenario{Namespace: "kernel-oom"},
 		clientset:    clientset,
 	}
 }
 
+var kernelOOMDisableMetadata = Metadata{
+	ID:          "kernel-oom-disable",
+	Name:        "Kernel: OOM Survival",
+	Description: "This critical pod must not be OOM Killed. Configure it as QoS Guaranteed (or simulate OOM prevention).",
+	Difficulty:  DifficultyHard,
+	Category:    "Kernel",
+	Tags:        []string{"oom", "memory", "limits"},
+	Hints:       []string{"Set Limits == Requests", "Look for QoS Class 'Guaranteed'"},
+}
+
 func (s *KernelOOMDisable) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "kernel-oom-disable",
-		Name:        "Kernel: OOM Survival",
-		Description: "This critical pod must not be OOM Killed. Configure it as QoS Guaranteed (or simulate OOM prevention).",
-		Difficulty:  DifficultyHard,
-		Category:    "Kernel",
-		Hints:       []string{"Set Limits == Requests", "Look for QoS Class 'Guaranteed'"},
-	}
+	return kernelOOMDisableMetadata
 }
 
 func (s *KernelOOMDisable) Setup(ctx context.Context) error {
@@ -64,10 +69,22 @@ func (s *KernelOOMDisable) Validate(ctx context.Context) Result {
 		return Result{Solved: false, Message: err.Error()}
 	}
 
-	if pod.Status.QOSClass == corev1.PodQOSGuaranteed {
-		return Result{Solved: true, Message: "Success! Pod is QoS Guaranteed."}
-	}
-	return Result{Solved: false, Message: "Pod QoS is " + string(pod.Status.QOSClass) + ", expected Guaranteed."}
+	return NewResult("Success! Pod is QoS Guaranteed.",
+		check.FieldEquals("Pod QoS", pod.Status.QOSClass, corev1.PodQOSGuaranteed),
+	)
+}
+
+func (s *KernelOOMDisable) Solve(ctx context.Context) error {
+	return recreatePod(ctx, s.clientset, s.Namespace, "critical-pod", func(pod *corev1.Pod) {
+		resources := corev1.ResourceList{
+			corev1.ResourceCPU:    mustParse("100m"),
+			corev1.ResourceMemory: mustParse("64Mi"),
+		}
+		pod.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+			Requests: resources,
+			Limits:   resources,
+		}
+	})
 }
 
 func (s *KernelOOMDisable) Cleanup(ctx context.Context) error {
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,25 +14,37 @@ import (
 // LifeCrashConfig scenario: CrashLoop due to missing ConfigMap.
 type LifeCrashConfig struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewLifeCrashConfig(clientset *kubernetes.Clientset) *LifeCrashConfig {
+func NewLifeCrashConfig(clientset kubernetes.Interface) *LifeCrashConfig {
 	return &LifeCrashConfig{
 		BaseScenario: BaseScenario{Namespace: "life-crash-config"},
 		clientset:    clientset,
 	}
 }
 
+var lifeCrashConfigMetadata = Metadata{
+	ID:          "crashloop-missing-config",
+	Name:        "Lifecycle: The CrashLoop Mystery",
+	Description: "Pod is crash-looping. The logs mention a missing configuration.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Lifecycle",
+	Tags:        []string{"crashloop", "configmaps", "logs", "ckad"},
+	Hints:       []string{"Use `kubectl logs`", "Check envFrom or volumeMounts", "The ConfigMap 'app-config' is missing"},
+	Commands: []Command{
+		{Group: CommandInspect, Command: "kubectl get pods -n {{ .Namespace }}"},
+		{Group: CommandEvents, Command: "kubectl get events -n {{ .Namespace }} --sort-by=.lastTimestamp"},
+		{Group: CommandLogs, Command: "kubectl logs deploy/app -n {{ .Namespace }} --previous"},
+	},
+	DocsLinks: []DocLink{
+		{Title: "Debug Running Pods", URL: "https://kubernetes.io/docs/tasks/debug/debug-application/debug-running-pod/"},
+		{Title: "ConfigMaps", URL: "https://kubernetes.io/docs/concepts/configuration/configmap/"},
+	},
+}
+
 func (s *LifeCrashConfig) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "crashloop-missing-config",
-		Name:        "Lifecycle: The CrashLoop Mystery",
-		Description: "Pod is crash-looping. The logs mention a missing configuration.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Lifecycle",
-		Hints:       []string{"Use `kubectl logs`", "Check envFrom or volumeMounts", "The ConfigMap 'app-config' is missing"},
-	}
+	return lifeCrashConfigMetadata
 }
 
 func (s *LifeCrashConfig) Setup(ctx context.Context) error {
@@ -75,18 +89,23 @@ func (s *LifeCrashConfig) Setup(ctx context.Context) error {
 	return err
 }
 
+// WaitReady blocks until the missing ConfigMap has blocked the volume mount.
+func (s *LifeCrashConfig) WaitReady(ctx context.Context) error {
+	return waitForEvent(ctx, s.clientset, s.Namespace, "FailedMount")
+}
+
 func (s *LifeCrashConfig) Validate(ctx context.Context) Result {
-	pods, err := s.clientset.CoreV1().Pods(s.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app=crash"})
-	if err != nil {
-		return Result{Solved: false, Message: err.Error()}
-	}
+	return NewResult("Success! Application is running.",
+		check.PodsRunning(ctx, s.clientset, s.Namespace, "app=crash"),
+	)
+}
 
-	for _, pod := range pods.Items {
-		if pod.Status.Phase == corev1.PodRunning {
-			return Result{Solved: true, Message: "Success! Application is running."}
-		}
-	}
-	return Result{Solved: false, Message: "Pod is not running yet."}
+func (s *LifeCrashConfig) Solve(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().ConfigMaps(s.Namespace).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config"},
+		Data:       map[string]string{"settings.properties": "mode=production\n"},
+	}, metav1.CreateOptions{})
+	return err
 }
 
 func (s *LifeCrashConfig) Cleanup(ctx context.Context) error {
@@ -12,25 +12,28 @@ import (
 // LifeGracefulShutdown scenario: Missing preStop hook.
 type LifeGracefulShutdown struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewLifeGracefulShutdown(clientset *kubernetes.Clientset) *LifeGracefulShutdown {
+func NewLifeGracefulShutdown(clientset kubernetes.Interface) *LifeGracefulShutdown {
 	return &LifeGracefulShutdown{
 		BaseScenario: BaseScenario{Namespace: "life-graceful"},
 		clientset:    clientset,
 	}
 }
 
+var lifeGracefulShutdownMetadata = Metadata{
+	ID:          "life-graceful-shutdown",
+	Name:        "Lifecycle: Zero Downtime",
+	Description: "Requests fail during rollout. Configure a graceful shutdown strategy.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Lifecycle",
+	Tags:        []string{"signals", "termination", "pods"},
+	Hints:       []string{"Add a preStop hook", "Sleep for a few seconds to allow traffic to drain"},
+}
+
 func (s *LifeGracefulShutdown) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "life-graceful-shutdown",
-		Name:        "Lifecycle: Zero Downtime",
-		Description: "Requests fail during rollout. Configure a graceful shutdown strategy.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Lifecycle",
-		Hints:       []string{"Add a preStop hook", "Sleep for a few seconds to allow traffic to drain"},
-	}
+	return lifeGracefulShutdownMetadata
 }
 
 func (s *LifeGracefulShutdown) Setup(ctx context.Context) error {
@@ -68,7 +71,20 @@ func (s *LifeGracefulShutdown) Validate(ctx context.Context) Result {
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
+	return s.validate(dep)
+}
 
+// ValidateManifests implements ManifestValidator.
+func (s *LifeGracefulShutdown) ValidateManifests(objs Manifests) Result {
+	var dep appsv1.Deployment
+	if res, ok := objs.Find("Deployment", "web", &dep); !ok {
+		return res
+	}
+	return s.validate(&dep)
+}
+
+// validate checks the deployment spec, live or from a manifest.
+func (s *LifeGracefulShutdown) validate(dep *appsv1.Deployment) Result {
 	if len(dep.Spec.Template.Spec.Containers) > 0 {
 		c := dep.Spec.Template.Spec.Containers[0]
 		if c.Lifecycle != nil && c.Lifecycle.PreStop != nil {
@@ -78,6 +94,16 @@ func (s *LifeGracefulShutdown) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "No preStop hook found in container spec."}
 }
 
+func (s *LifeGracefulShutdown) Solve(ctx context.Context) error {
+	return updateDeployment(ctx, s.clientset, s.Namespace, "web", func(dep *appsv1.Deployment) {
+		dep.Spec.Template.Spec.Containers[0].Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "sleep 5 && nginx -s quit"}},
+			},
+		}
+	})
+}
+
 func (s *LifeGracefulShutdown) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
@@ -0,0 +1,188 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s-dojo/pkg/scenario/check"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// revisionAnnotation and revisionHistoryAnnotation are set by the deployment
+	// controller. An undo renumbers the restored ReplicaSet and records its old
+	// revisions in the history annotation.
+	revisionAnnotation        = "deployment.kubernetes.io/revision"
+	revisionHistoryAnnotation = "deployment.kubernetes.io/revision-history"
+
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+
+	// healthyRevisionTimeout bounds how long Setup waits for revision 1 to come up.
+	healthyRevisionTimeout = 2 * time.Minute
+)
+
+// LifeRolloutUndo scenario: a release with a typo in its image tag was just
+// rolled out. The fix is `kubectl rollout undo`, so validation checks that the
+// Deployment runs the template of revision 1 again, not merely that pods run.
+type LifeRolloutUndo struct {
+	BaseScenario
+	clientset kubernetes.Interface
+}
+
+func NewLifeRolloutUndo(clientset kubernetes.Interface) *LifeRolloutUndo {
+	return &LifeRolloutUndo{
+		BaseScenario: BaseScenario{Namespace: "life-rollout-undo"},
+		clientset:    clientset,
+	}
+}
+
+var lifeRolloutUndoMetadata = Metadata{
+	ID:          "life-rollout-undo",
+	Name:        "Lifecycle: Roll It Back",
+	Description: "The v1.5 release of Deployment 'api' just went out and its new pods can't start. The release also changed the configuration, so don't patch it up by hand: return the Deployment to exactly what ran before.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Lifecycle",
+	Tags:        []string{"deployments", "rollouts", "ckad"},
+	Hints: []string{
+		"`kubectl rollout status deployment api` shows the rollout is stuck",
+		"`kubectl rollout history deployment api` lists the revisions and why they were made",
+		"`kubectl rollout undo deployment api` restores the previous revision's pod template",
+	},
+}
+
+func (s *LifeRolloutUndo) GetMetadata() Metadata {
+	return lifeRolloutUndoMetadata
+}
+
+func (s *LifeRolloutUndo) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	replicas := int32(2)
+	labels := map[string]string{"app": "api"}
+	_, err = s.clientset.AppsV1().Deployments(s.Namespace).Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "api",
+			Annotations: map[string]string{changeCauseAnnotation: "release v1.4"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "api",
+						Image: "nginx:1.27-alpine",
+						Env: []corev1.EnvVar{
+							{Name: "API_VERSION", Value: "1.4"},
+							{Name: "LOG_LEVEL", Value: "info"},
+						},
+					}},
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Let revision 1 come up, so the bad release replaces a healthy one
+	err = wait.PollUntilContextTimeout(ctx, readyPollInterval, healthyRevisionTimeout, true, func(ctx context.Context) (bool, error) {
+		dep, err := s.clientset.AppsV1().Deployments(s.Namespace).Get(ctx, "api", metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		return dep.Status.AvailableReplicas == replicas, nil
+	})
+	if err != nil {
+		return fmt.Errorf("revision 1 did not become available: %w", err)
+	}
+
+	return updateDeployment(ctx, s.clientset, s.Namespace, "api", func(dep *appsv1.Deployment) {
+		dep.Annotations[changeCauseAnnotation] = "release v1.5"
+		c := &dep.Spec.Template.Spec.Containers[0]
+		c.Image = "nginx:1.27-alpina" // Typo in the tag
+		c.Env = []corev1.EnvVar{
+			{Name: "API_VERSION", Value: "1.5"},
+			{Name: "LOG_LEVEL", Value: "debug"},
+		}
+	})
+}
+
+// WaitReady blocks until the new revision's pods fail to pull their image.
+func (s *LifeRolloutUndo) WaitReady(ctx context.Context) error {
+	return waitForPods(ctx, s.clientset, s.Namespace, "app=api", containerWaiting("ErrImagePull", "ImagePullBackOff"))
+}
+
+func (s *LifeRolloutUndo) Validate(ctx context.Context) Result {
+	dep, err := s.clientset.AppsV1().Deployments(s.Namespace).Get(ctx, "api", metav1.GetOptions{})
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+	rsList, err := s.clientset.AppsV1().ReplicaSets(s.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app=api"})
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+
+	// The ReplicaSet carrying the Deployment's revision runs its current template
+	var current *appsv1.ReplicaSet
+	for i, rs := range rsList.Items {
+		if rs.Annotations[revisionAnnotation] == dep.Annotations[revisionAnnotation] {
+			current = &rsList.Items[i]
+		}
+	}
+	restored := Check{Name: "Revision 1 restored", Details: "The Deployment doesn't run revision 1's pod template. Compare the revisions with `kubectl rollout history deployment api --revision=1`."}
+	if current != nil {
+		restored.Passed = current.Annotations[revisionAnnotation] == "1" || hasRevision(current.Annotations[revisionHistoryAnnotation], "1")
+	}
+
+	return NewResult("Success! v1.4 is back and fully rolled out.",
+		restored,
+		check.DeploymentRolledOut(ctx, s.clientset, s.Namespace, "api"),
+	)
+}
+
+func (s *LifeRolloutUndo) Solve(ctx context.Context) error {
+	// What `kubectl rollout undo` does: copy revision 1's template back
+	rsList, err := s.clientset.AppsV1().ReplicaSets(s.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app=api"})
+	if err != nil {
+		return err
+	}
+	for _, rs := range rsList.Items {
+		if rs.Annotations[revisionAnnotation] != "1" {
+			continue
+		}
+		template := rs.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		return updateDeployment(ctx, s.clientset, s.Namespace, "api", func(dep *appsv1.Deployment) {
+			dep.Spec.Template = *template
+		})
+	}
+	return fmt.Errorf("revision 1 of deployment api not found")
+}
+
+func (s *LifeRolloutUndo) Cleanup(ctx context.Context) error {
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
+
+// hasRevision reports whether a comma-separated revision history lists revision.
+func hasRevision(history, revision string) bool {
+	for _, r := range strings.Split(history, ",") {
+		if r == revision {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package scenario
+
+import (
+	"fmt"
+
+	"k8s-dojo/pkg/k8s"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ManifestValidator is implemented by scenarios whose validation only reads
+// object specs, so a fix can be checked against local manifests without a cluster.
+type ManifestValidator interface {
+	ValidateManifests(objs Manifests) Result
+}
+
+// Manifests are the learner's objects, decoded from local YAML.
+type Manifests []*unstructured.Unstructured
+
+// Find converts the object with the given kind and name into out. When it is
+// missing or malformed, the returned Result explains why and ok is false.
+func (m Manifests) Find(kind, name string, out any) (res Result, ok bool) {
+	for _, obj := range m {
+		if obj.GetKind() != kind || obj.GetName() != name {
+			continue
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, out); err != nil {
+			return Result{Message: fmt.Sprintf("%s '%s' is invalid: %v", kind, name, err)}, false
+		}
+		return Result{}, true
+	}
+	return Result{Message: fmt.Sprintf("%s '%s' not found in the manifests.", kind, name)}, false
+}
+
+// ValidateManifest checks a fix written as a YAML or JSON manifest, offline.
+func ValidateManifest(s Scenario, manifest string) (Result, error) {
+	v, ok := s.(ManifestValidator)
+	if !ok {
+		return Result{}, fmt.Errorf("scenario %s needs a cluster to validate", s.GetMetadata().ID)
+	}
+	objs, err := k8s.DecodeManifest(manifest)
+	if err != nil {
+		return Result{}, err
+	}
+	return v.ValidateManifests(objs), nil
+}
@@ -2,6 +2,7 @@ package scenario
 
 import (
 	"context"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,25 +12,31 @@ import (
 // NetDNSNdots scenario: DNS latency due to ndots.
 type NetDNSNdots struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewNetDNSNdots(clientset *kubernetes.Clientset) *NetDNSNdots {
+func NewNetDNSNdots(clientset kubernetes.Interface) *NetDNSNdots {
 	return &NetDNSNdots{
 		BaseScenario: BaseScenario{Namespace: "net-dns-ndots"},
 		clientset:    clientset,
 	}
 }
 
+var netDNSNdotsMetadata = Metadata{
+	ID:          "net-dns-ndots",
+	Name:        "Network: DNS 5s Latency",
+	Description: "External domain lookups have high latency. Optimize the DNS configuration for a Pod that mostly accesses external FQDNs.",
+	Difficulty:  DifficultyHard,
+	Category:    "Networking",
+	Tags:        []string{"dns", "resolv.conf", "cka"},
+	Hints:       []string{"Default ndots is 5", "Check /etc/resolv.conf inside pod", "Set dnsConfig in Pod spec"},
+	DocsLinks: []DocLink{
+		{Title: "DNS for Services and Pods", URL: "https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/"},
+	},
+}
+
 func (s *NetDNSNdots) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "net-dns-ndots",
-		Name:        "Network: DNS 5s Latency",
-		Description: "External domain lookups have high latency. Optimize the DNS configuration for a Pod that mostly accesses external FQDNs.",
-		Difficulty:  DifficultyHard,
-		Category:    "Networking",
-		Hints:       []string{"Default ndots is 5", "Check /etc/resolv.conf inside pod", "Set dnsConfig in Pod spec"},
-	}
+	return netDNSNdotsMetadata
 }
 
 func (s *NetDNSNdots) Setup(ctx context.Context) error {
@@ -56,19 +63,43 @@ func (s *NetDNSNdots) Validate(ctx context.Context) Result {
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
+	return s.validate(pod)
+}
+
+// ValidateManifests implements ManifestValidator.
+func (s *NetDNSNdots) ValidateManifests(objs Manifests) Result {
+	var pod corev1.Pod
+	if res, ok := objs.Find("Pod", "legacy-app", &pod); !ok {
+		return res
+	}
+	return s.validate(&pod)
+}
 
+// validate checks the pod spec, live or from a manifest.
+func (s *NetDNSNdots) validate(pod *corev1.Pod) Result {
+	configured := Check{Name: "ndots configured", Details: "ndots configuration not found or value too high."}
+	optimized := Check{Name: "ndots below 3", Details: "ndots configuration not found or value too high."}
 	if pod.Spec.DNSConfig != nil {
 		for _, opt := range pod.Spec.DNSConfig.Options {
 			if opt.Name == "ndots" && opt.Value != nil {
-				val := *opt.Value
-				if val < "3" {
-					return Result{Solved: true, Message: "Success! ndots reduced to optimized level."}
+				configured.Passed = true
+				if val, err := strconv.Atoi(*opt.Value); err == nil && val < 3 {
+					optimized.Passed = true
 				}
 			}
 		}
 	}
 
-	return Result{Solved: false, Message: "ndots configuration not found or value too high."}
+	return NewResult("Success! ndots reduced to optimized level.", configured, optimized)
+}
+
+func (s *NetDNSNdots) Solve(ctx context.Context) error {
+	ndots := "2"
+	return recreatePod(ctx, s.clientset, s.Namespace, "legacy-app", func(pod *corev1.Pod) {
+		pod.Spec.DNSConfig = &corev1.PodDNSConfig{
+			Options: []corev1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}},
+		}
+	})
 }
 
 func (s *NetDNSNdots) Cleanup(ctx context.Context) error {
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -11,25 +13,28 @@ import (
 // NetGrpcBalance scenario: Standard Service for gRPC (needs Headless).
 type NetGrpcBalance struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewNetGrpcBalance(clientset *kubernetes.Clientset) *NetGrpcBalance {
+func NewNetGrpcBalance(clientset kubernetes.Interface) *NetGrpcBalance {
 	return &NetGrpcBalance{
 		BaseScenario: BaseScenario{Namespace: "net-grpc-balance"},
 		clientset:    clientset,
 	}
 }
 
+var netGrpcBalanceMetadata = Metadata{
+	ID:          "net-grpc-balance",
+	Name:        "Network: gRPC Load Balancing",
+	Description: "gRPC traffic is unevenly distributed. Implement Client-side Load Balancing by converting the Service to Headless.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Networking",
+	Tags:        []string{"grpc", "load-balancing", "services"},
+	Hints:       []string{"gRPC over HTTP/2 reuses connections", "Standard ClusterIP does L4 balancing", "Set clusterIP to None"},
+}
+
 func (s *NetGrpcBalance) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "net-grpc-balance",
-		Name:        "Network: gRPC Load Balancing",
-		Description: "gRPC traffic is unevenly distributed. Implement Client-side Load Balancing by converting the Service to Headless.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Networking",
-		Hints:       []string{"gRPC over HTTP/2 reuses connections", "Standard ClusterIP does L4 balancing", "Set clusterIP to None"},
-	}
+	return netGrpcBalanceMetadata
 }
 
 func (s *NetGrpcBalance) Setup(ctx context.Context) error {
@@ -58,12 +63,44 @@ func (s *NetGrpcBalance) Validate(ctx context.Context) Result {
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
+	return s.validate(svc)
+}
 
-	if svc.Spec.ClusterIP == "None" {
-		return Result{Solved: true, Message: "Success! Service is now Headless (ClusterIP: None)."}
+// ValidateManifests implements ManifestValidator.
+func (s *NetGrpcBalance) ValidateManifests(objs Manifests) Result {
+	var svc corev1.Service
+	if res, ok := objs.Find("Service", "grpc-service", &svc); !ok {
+		return res
 	}
+	return s.validate(&svc)
+}
+
+// validate checks the service spec, live or from a manifest.
+func (s *NetGrpcBalance) validate(svc *corev1.Service) Result {
+	return NewResult("Success! Service is now Headless (ClusterIP: None).",
+		check.FieldEquals("ClusterIP", svc.Spec.ClusterIP, corev1.ClusterIPNone).
+			OrElse("Service is still using a Virtual IP (ClusterIP)."),
+	)
+}
 
-	return Result{Solved: false, Message: "Service is still using a Virtual IP (ClusterIP)."}
+func (s *NetGrpcBalance) Solve(ctx context.Context) error {
+	// ClusterIP is immutable, so the Service has to be recreated
+	svc, err := s.clientset.CoreV1().Services(s.Namespace).Get(ctx, "grpc-service", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := s.clientset.CoreV1().Services(s.Namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+	_, err = s.clientset.CoreV1().Services(s.Namespace).Create(ctx, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: svc.Name, Labels: svc.Labels},
+		Spec: corev1.ServiceSpec{
+			Selector:  svc.Spec.Selector,
+			Ports:     svc.Spec.Ports,
+			ClusterIP: corev1.ClusterIPNone,
+		},
+	}, metav1.CreateOptions{})
+	return err
 }
 
 func (s *NetGrpcBalance) Cleanup(ctx context.Context) error {
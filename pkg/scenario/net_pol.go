@@ -2,39 +2,63 @@ package scenario
 
 import (
 	"context"
+	"time"
+
+	"k8s-dojo/pkg/scenario/check"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 // NetPolDNSBlock scenario: NetworkPolicy blocking DNS.
 type NetPolDNSBlock struct {
 	BaseScenario
-	clientset  *kubernetes.Clientset
-	restConfig interface{} // Needed for exec (TODO: refactor to pass config)
+	clientset  kubernetes.Interface
+	restConfig *rest.Config // Needed for exec; nil disables the connectivity test
+	enforcing  *bool        // Cached result of CNI detection
 }
 
-// Note: We need rest.Config for Exec. For now, we'll verify via Policy check to avoid complexity of Exec in validation loop.
-// Exec is expensive and slow.
+// enforcingCNIs lists kube-system DaemonSets of CNIs known to enforce NetworkPolicy.
+// kindnet enforces policies since kind v0.24 via kube-network-policies.
+var enforcingCNIs = []string{
+	"calico-node",
+	"cilium",
+	"kube-router",
+	"weave-net",
+	"antrea-agent",
+	"kube-network-policies",
+	"kindnet",
+}
 
-func NewNetPolDNSBlock(clientset *kubernetes.Clientset) *NetPolDNSBlock {
+func NewNetPolDNSBlock(clientset kubernetes.Interface, restConfig *rest.Config) *NetPolDNSBlock {
 	return &NetPolDNSBlock{
 		BaseScenario: BaseScenario{Namespace: "netpol-dns-block"},
 		clientset:    clientset,
+		restConfig:   restConfig,
 	}
 }
 
+var netPolDNSBlockMetadata = Metadata{
+	ID:          "netpol-dns-block",
+	Name:        "Network Security: The Silent Block",
+	Description: "The app cannot resolve any domains. A restrictive NetworkPolicy is in place.",
+	Difficulty:  DifficultyHard,
+	Category:    "Networking",
+	Tags:        []string{"networkpolicy", "dns", "cka", "cks"},
+	Hints:       []string{"Review the NetworkPolicy 'default-deny'", "DNS runs on UDP/TCP port 53", "CoreDNS is in kube-system"},
+	Version:     2, // v2 validates with a live DNS lookup instead of inspecting the policies
+	DocsLinks: []DocLink{
+		{Title: "Network Policies", URL: "https://kubernetes.io/docs/concepts/services-networking/network-policies/"},
+	},
+}
+
 func (s *NetPolDNSBlock) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "netpol-dns-block",
-		Name:        "Network Security: The Silent Block",
-		Description: "The app cannot resolve any domains. A restrictive NetworkPolicy is in place.",
-		Difficulty:  DifficultyHard,
-		Category:    "Networking",
-		Hints:       []string{"Review the NetworkPolicy 'default-deny'", "DNS runs on UDP/TCP port 53", "CoreDNS is in kube-system"},
-	}
+	return netPolDNSBlockMetadata
 }
 
 func (s *NetPolDNSBlock) Setup(ctx context.Context) error {
@@ -76,34 +100,142 @@ func (s *NetPolDNSBlock) Setup(ctx context.Context) error {
 	return err
 }
 
+// WaitReady blocks until the test pod is running.
+func (s *NetPolDNSBlock) WaitReady(ctx context.Context) error {
+	return waitForPods(ctx, s.clientset, s.Namespace, "app=secure", podRunning)
+}
+
 func (s *NetPolDNSBlock) Validate(ctx context.Context) Result {
-	// Check if any NetworkPolicy allows UDP 53
+	pod, err := s.clientset.CoreV1().Pods(s.Namespace).Get(ctx, "blocked-pod", metav1.GetOptions{})
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return Result{Solved: false, Message: "Pod 'blocked-pod' is not running."}
+	}
+
+	// Prefer an actual DNS lookup when policies are enforced by the CNI
+	if s.restConfig != nil && s.policiesEnforced(ctx) {
+		return s.validateConnectivity(ctx)
+	}
+
+	return s.validatePolicies(ctx, pod)
+}
+
+// validateConnectivity resolves a cluster name from inside the blocked pod.
+func (s *NetPolDNSBlock) validateConnectivity(ctx context.Context) Result {
+	lookup := []string{"timeout", "5", "nslookup", "kubernetes.default.svc.cluster.local"}
+	return NewResult("Success! The pod can resolve DNS names again.",
+		check.EventuallyExec(ctx, s.clientset, s.restConfig, s.Namespace, "blocked-pod", "app", lookup, 10*time.Second).
+			Named("DNS lookup succeeds").
+			OrElse("DNS lookup from 'blocked-pod' still fails."),
+	)
+}
+
+// validatePolicies is the fallback used when NetworkPolicy is not enforced.
+// It passes when no policy restricts the pod's egress, e.g. because the
+// deny-all policy was deleted, or when an egress rule that selects the pod
+// permits port 53.
+func (s *NetPolDNSBlock) validatePolicies(ctx context.Context, pod *corev1.Pod) Result {
 	pols, err := s.clientset.NetworkingV1().NetworkPolicies(s.Namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
 
-	allowsDNS := false
+	isolated := false
 	for _, pol := range pols.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&pol.Spec.PodSelector)
+		if err != nil || !selector.Matches(labels.Set(pod.Labels)) || !restrictsEgress(pol) {
+			continue
+		}
+		isolated = true
 		for _, egress := range pol.Spec.Egress {
-			for _, port := range egress.Ports {
-				if port.Port != nil && port.Port.IntVal == 53 {
-					allowsDNS = true
-				}
-			}
-			// Or check if it allows all (empty ports)
-			if len(egress.Ports) == 0 && len(egress.To) > 0 {
-				// Potentially allows all ports to some destination
-				allowsDNS = true // Simplified check
+			if egressAllowsDNS(egress) {
+				return Result{Solved: true, Message: "Success! NetworkPolicy now allows DNS traffic."}
 			}
 		}
 	}
+	if !isolated {
+		return Result{Solved: true, Message: "Success! No NetworkPolicy restricts the pod's egress any more."}
+	}
+
+	return Result{Solved: false, Message: "No NetworkPolicy rule found allowing DNS (port 53) for 'blocked-pod'."}
+}
+
+// restrictsEgress reports whether the policy isolates the pods it selects for
+// egress. Without policyTypes, only policies with egress rules do.
+func restrictsEgress(pol networkingv1.NetworkPolicy) bool {
+	if len(pol.Spec.PolicyTypes) == 0 {
+		return len(pol.Spec.Egress) > 0
+	}
+	for _, t := range pol.Spec.PolicyTypes {
+		if t == networkingv1.PolicyTypeEgress {
+			return true
+		}
+	}
+	return false
+}
 
-	if allowsDNS {
-		return Result{Solved: true, Message: "Success! NetworkPolicy now allows DNS traffic."}
+// egressAllowsDNS reports whether a rule permits traffic to port 53.
+func egressAllowsDNS(rule networkingv1.NetworkPolicyEgressRule) bool {
+	// No ports means all ports to the listed destinations
+	if len(rule.Ports) == 0 {
+		return true
+	}
+	for _, port := range rule.Ports {
+		if port.Port == nil {
+			return true // All ports for this protocol
+		}
+		if port.Port.IntVal == 53 || port.Port.StrVal == "dns" || port.Port.StrVal == "dns-tcp" {
+			return true
+		}
+		if port.EndPort != nil && port.Port.IntVal <= 53 && *port.EndPort >= 53 {
+			return true
+		}
+	}
+	return false
+}
+
+// policiesEnforced reports whether the cluster runs a CNI that enforces NetworkPolicy.
+func (s *NetPolDNSBlock) policiesEnforced(ctx context.Context) bool {
+	if s.enforcing != nil {
+		return *s.enforcing
+	}
+
+	dss, err := s.clientset.AppsV1().DaemonSets("kube-system").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false // Retry detection on the next check
 	}
 
-	return Result{Solved: false, Message: "No NetworkPolicy rule found explicitly allowing Port 53."}
+	enforcing := false
+	for _, ds := range dss.Items {
+		for _, name := range enforcingCNIs {
+			if ds.Name == name {
+				enforcing = true
+			}
+		}
+	}
+	s.enforcing = &enforcing
+	return enforcing
+}
+
+func (s *NetPolDNSBlock) Solve(ctx context.Context) error {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	dns := intstr.FromInt(53)
+	_, err := s.clientset.NetworkingV1().NetworkPolicies(s.Namespace).Create(ctx, &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-dns"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress: []networkingv1.NetworkPolicyEgressRule{{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &udp, Port: &dns},
+					{Protocol: &tcp, Port: &dns},
+				},
+			}},
+		},
+	}, metav1.CreateOptions{})
+	return err
 }
 
 func (s *NetPolDNSBlock) Cleanup(ctx context.Context) error {
@@ -2,6 +2,9 @@ package scenario
 
 import (
 	"context"
+	"fmt"
+
+	"k8s-dojo/pkg/scenario/check"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,25 +14,37 @@ import (
 // NetServiceSelector scenario: Service selector typo.
 type NetServiceSelector struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewNetServiceSelector(clientset *kubernetes.Clientset) *NetServiceSelector {
+func NewNetServiceSelector(clientset kubernetes.Interface) *NetServiceSelector {
 	return &NetServiceSelector{
 		BaseScenario: BaseScenario{Namespace: "net-service-selector"},
 		clientset:    clientset,
 	}
 }
 
+var netServiceSelectorMetadata = Metadata{
+	ID:          "net-service-selector",
+	Name:        "Network 101: Service Discovery Failure",
+	Description: "A Service is deployed but cannot find its Pods. Fix the connection.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Networking",
+	Tags:        []string{"services", "selectors", "endpoints", "cka", "ckad"},
+	Hints:       []string{"Check the Service selector and Pod labels", "Use `kubectl get endpoints`"},
+	Commands: []Command{
+		{Group: CommandInspect, Command: "kubectl get svc web-service -n {{ .Namespace }} -o wide"},
+		{Group: CommandInspect, Command: "kubectl get pods -n {{ .Namespace }} --show-labels"},
+		{Group: CommandInspect, Command: "kubectl get endpoints web-service -n {{ .Namespace }}"},
+	},
+	DocsLinks: []DocLink{
+		{Title: "Service", URL: "https://kubernetes.io/docs/concepts/services-networking/service/"},
+		{Title: "Debug Services", URL: "https://kubernetes.io/docs/tasks/debug/debug-application/debug-service/"},
+	},
+}
+
 func (s *NetServiceSelector) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "net-service-selector",
-		Name:        "Network 101: Service Discovery Failure",
-		Description: "A Service is deployed but cannot find its Pods. Fix the connection.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Networking",
-		Hints:       []string{"Check the Service selector and Pod labels", "Use `kubectl get endpoints`"},
-	}
+	return netServiceSelectorMetadata
 }
 
 func (s *NetServiceSelector) Setup(ctx context.Context) error {
@@ -68,16 +83,39 @@ func (s *NetServiceSelector) Setup(ctx context.Context) error {
 }
 
 func (s *NetServiceSelector) Validate(ctx context.Context) Result {
-	ep, err := s.clientset.CoreV1().Endpoints(s.Namespace).Get(ctx, "web-service", metav1.GetOptions{})
-	if err != nil {
-		return Result{Solved: false, Message: err.Error()}
-	}
+	return NewResult("Success! Service found the Pods.",
+		check.EndpointsNonEmpty(ctx, s.clientset, s.Namespace, "web-service"),
+	)
+}
 
-	if len(ep.Subsets) > 0 && len(ep.Subsets[0].Addresses) > 0 {
-		return Result{Solved: true, Message: "Success! Service found the Pods."}
+// Guide walks beginners from empty endpoints to the selector typo.
+func (s *NetServiceSelector) Guide() []GuideStep {
+	return []GuideStep{
+		{
+			Title:       "Check the endpoints",
+			Instruction: "A Service routes traffic to the Pods its selector matches. An empty ENDPOINTS column means it matches none.",
+			Command:     "kubectl get endpoints web-service -n " + s.Namespace,
+		},
+		{
+			Title:       "Compare selector and labels",
+			Instruction: "Look at the Service's SELECTOR and compare it with the labels on the Pod.",
+			Command:     fmt.Sprintf("kubectl get svc web-service -o wide -n %s && kubectl get pods --show-labels -n %s", s.Namespace, s.Namespace),
+		},
+		{
+			Title:       "Fix the selector",
+			Instruction: "Change the Service selector to match the Pod's app=web label.",
+			Command:     `kubectl patch svc web-service -n ` + s.Namespace + ` -p '{"spec":{"selector":{"app":"web"}}}'`,
+			Verify: func(ctx context.Context) Check {
+				return check.EndpointsNonEmpty(ctx, s.clientset, s.Namespace, "web-service")
+			},
+		},
 	}
+}
 
-	return Result{Solved: false, Message: "Service has no endpoints."}
+func (s *NetServiceSelector) Solve(ctx context.Context) error {
+	return updateService(ctx, s.clientset, s.Namespace, "web-service", func(svc *corev1.Service) {
+		svc.Spec.Selector = map[string]string{"app": "web"}
+	})
 }
 
 func (s *NetServiceSelector) Cleanup(ctx context.Context) error {
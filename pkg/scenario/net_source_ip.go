@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -11,25 +13,28 @@ import (
 // NetSourceIP scenario: Preserve client source IP (externalTrafficPolicy: Local).
 type NetSourceIP struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewNetSourceIP(clientset *kubernetes.Clientset) *NetSourceIP {
+func NewNetSourceIP(clientset kubernetes.Interface) *NetSourceIP {
 	return &NetSourceIP{
 		BaseScenario: BaseScenario{Namespace: "net-source-ip"},
 		clientset:    clientset,
 	}
 }
 
+var netSourceIPMetadata = Metadata{
+	ID:          "net-source-ip",
+	Name:        "Network: The Vanishing Source IP",
+	Description: "The backend sees all traffic coming from Node IPs instead of real client IPs. Fix it.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Networking",
+	Tags:        []string{"services", "externalTrafficPolicy", "nodeport"},
+	Hints:       []string{"Traffic is being SNATed", "Check externalTrafficPolicy in Service spec"},
+}
+
 func (s *NetSourceIP) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "net-source-ip",
-		Name:        "Network: The Vanishing Source IP",
-		Description: "The backend sees all traffic coming from Node IPs instead of real client IPs. Fix it.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Networking",
-		Hints:       []string{"Traffic is being SNATed", "Check externalTrafficPolicy in Service spec"},
-	}
+	return netSourceIPMetadata
 }
 
 func (s *NetSourceIP) Setup(ctx context.Context) error {
@@ -59,12 +64,30 @@ func (s *NetSourceIP) Validate(ctx context.Context) Result {
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
+	return s.validate(svc)
+}
 
-	if svc.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal {
-		return Result{Solved: true, Message: "Success! ExternalTrafficPolicy is set to Local."}
+// ValidateManifests implements ManifestValidator.
+func (s *NetSourceIP) ValidateManifests(objs Manifests) Result {
+	var svc corev1.Service
+	if res, ok := objs.Find("Service", "public-service", &svc); !ok {
+		return res
 	}
+	return s.validate(&svc)
+}
+
+// validate checks the service spec, live or from a manifest.
+func (s *NetSourceIP) validate(svc *corev1.Service) Result {
+	return NewResult("Success! ExternalTrafficPolicy is set to Local.",
+		check.FieldEquals("ExternalTrafficPolicy", svc.Spec.ExternalTrafficPolicy, corev1.ServiceExternalTrafficPolicyTypeLocal).
+			OrElse("Policy is still set to Cluster (SNAT enabled)."),
+	)
+}
 
-	return Result{Solved: false, Message: "Policy is still set to Cluster (SNAT enabled)."}
+func (s *NetSourceIP) Solve(ctx context.Context) error {
+	return updateService(ctx, s.clientset, s.Namespace, "public-service", func(svc *corev1.Service) {
+		svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeLocal
+	})
 }
 
 func (s *NetSourceIP) Cleanup(ctx context.Context) error {
@@ -2,35 +2,44 @@ package scenario
 
 import (
 	"context"
+	"fmt"
+
+	"k8s-dojo/pkg/k8s"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 // NetTargetPortMismatch scenario: Service targetPort doesn't match container port.
 type NetTargetPortMismatch struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset  kubernetes.Interface
+	restConfig *rest.Config
 }
 
-func NewNetTargetPortMismatch(clientset *kubernetes.Clientset) *NetTargetPortMismatch {
+func NewNetTargetPortMismatch(clientset kubernetes.Interface, restConfig *rest.Config) *NetTargetPortMismatch {
 	return &NetTargetPortMismatch{
 		BaseScenario: BaseScenario{Namespace: "net-target-port"},
 		clientset:    clientset,
+		restConfig:   restConfig,
 	}
 }
 
+var netTargetPortMismatchMetadata = Metadata{
+	ID:          "net-target-port-mismatch",
+	Name:        "Network: The Unreachable Port",
+	Description: "Service is refusing connections. Check the port mapping.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Networking",
+	Tags:        []string{"services", "ports", "endpoints", "cka"},
+	Hints:       []string{"Check the Service `targetPort`", "Check the Container `ports`", "They must match"},
+}
+
 func (s *NetTargetPortMismatch) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "net-target-port-mismatch",
-		Name:        "Network: The Unreachable Port",
-		Description: "Service is refusing connections. Check the port mapping.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Networking",
-		Hints:       []string{"Check the Service `targetPort`", "Check the Container `ports`", "They must match"},
-	}
+	return netTargetPortMismatchMetadata
 }
 
 func (s *NetTargetPortMismatch) Setup(ctx context.Context) error {
@@ -75,19 +84,21 @@ func (s *NetTargetPortMismatch) Setup(ctx context.Context) error {
 }
 
 func (s *NetTargetPortMismatch) Validate(ctx context.Context) Result {
-	svc, err := s.clientset.CoreV1().Services(s.Namespace).Get(ctx, "web-service", metav1.GetOptions{})
+	// Hit the app through the Service, like a client would
+	status, err := k8s.ServiceHTTPGet(ctx, s.clientset, s.restConfig, s.Namespace, "web-service", 80, "/")
 	if err != nil {
-		return Result{Solved: false, Message: err.Error()}
+		return Result{Solved: false, Message: "Service is still refusing connections: " + err.Error()}
 	}
-
-	if len(svc.Spec.Ports) > 0 {
-		tgt := svc.Spec.Ports[0].TargetPort
-		if tgt.IntVal == 80 || tgt.StrVal == "80" {
-			return Result{Solved: true, Message: "Success! TargetPort matches container port."}
-		}
+	if status != 200 {
+		return Result{Solved: false, Message: fmt.Sprintf("Service responded with HTTP %d.", status)}
 	}
+	return Result{Solved: true, Message: "Success! The Service now returns HTTP 200."}
+}
 
-	return Result{Solved: false, Message: "Service targetPort is still incorrect."}
+func (s *NetTargetPortMismatch) Solve(ctx context.Context) error {
+	return updateService(ctx, s.clientset, s.Namespace, "web-service", func(svc *corev1.Service) {
+		svc.Spec.Ports[0].TargetPort = intstr.FromInt(80)
+	})
 }
 
 func (s *NetTargetPortMismatch) Cleanup(ctx context.Context) error {
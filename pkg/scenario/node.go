@@ -0,0 +1,89 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// nodeExecTimeout bounds how long runOnNode waits for its pod to finish.
+const nodeExecTimeout = time.Minute
+
+// runOnNode runs command in a short-lived pod on node with each of hostPaths
+// mounted at the same path, waits for it to succeed and returns its output.
+// Only hostPaths of the node are exposed, so scenarios can't reach anything
+// else on it by mistake.
+func runOnNode(ctx context.Context, clientset kubernetes.Interface, namespace, node string, hostPaths []string, command []string) (string, error) {
+	var mounts []corev1.VolumeMount
+	var volumes []corev1.Volume
+	for i, path := range hostPaths {
+		name := fmt.Sprintf("host-%d", i)
+		mounts = append(mounts, corev1.VolumeMount{Name: name, MountPath: path})
+		volumes = append(volumes, corev1.Volume{
+			Name:         name,
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: path}},
+		})
+	}
+
+	pod, err := clientset.CoreV1().Pods(namespace).Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "node-exec-"},
+		Spec: corev1.PodSpec{
+			NodeName:      node,
+			RestartPolicy: corev1.RestartPolicyNever,
+			Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Containers: []corev1.Container{{
+				Name:         "exec",
+				Image:        "busybox",
+				Command:      command,
+				VolumeMounts: mounts,
+			}},
+			Volumes: volumes,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to create node exec pod: %w", err)
+	}
+	defer func() {
+		_ = clientset.CoreV1().Pods(namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	var phase corev1.PodPhase
+	err = wait.PollUntilContextTimeout(ctx, readyPollInterval, nodeExecTimeout, true, func(ctx context.Context) (bool, error) {
+		p, err := clientset.CoreV1().Pods(namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		phase = p.Status.Phase
+		return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to run %v on node %s: %w", command, node, err)
+	}
+
+	out, err := clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read node exec output: %w", err)
+	}
+	if phase == corev1.PodFailed {
+		return string(out), fmt.Errorf("command %v failed on node %s: %s", command, node, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// controlPlaneNode returns the name of the cluster's control-plane node.
+func controlPlaneNode(ctx context.Context, clientset kubernetes.Interface) (string, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: "node-role.kubernetes.io/control-plane"})
+	if err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+	if len(nodes.Items) == 0 {
+		return "", fmt.Errorf("no control-plane node found")
+	}
+	return nodes.Items[0].Name, nil
+}
@@ -0,0 +1,156 @@
+package scenario
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// OpCRDMissing scenario: Custom resource can't be created because its CRD is not installed.
+type OpCRDMissing struct {
+	BaseScenario
+	clientset kubernetes.Interface
+	dynamic   dynamic.Interface
+	applier   Applier
+}
+
+const widgetCRDName = "widgets.dojo.example.com"
+
+var widgetGVR = schema.GroupVersionResource{Group: "dojo.example.com", Version: "v1", Resource: "widgets"}
+
+// The CRD is shipped in a ConfigMap, as an operator's install bundle would be.
+const widgetCRDManifest = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.dojo.example.com
+  labels:
+    app.kubernetes.io/managed-by: k8s-dojo
+spec:
+  group: dojo.example.com
+  scope: Namespaced
+  names:
+    plural: widgets
+    singular: widget
+    kind: Widget
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              size:
+                type: integer
+`
+
+const widgetCRManifest = `apiVersion: dojo.example.com/v1
+kind: Widget
+metadata:
+  name: my-widget
+spec:
+  size: 3
+`
+
+func NewOpCRDMissing(clientset kubernetes.Interface, dyn dynamic.Interface, applier Applier) *OpCRDMissing {
+	return &OpCRDMissing{
+		BaseScenario: BaseScenario{Namespace: "op-crd-missing"},
+		clientset:    clientset,
+		dynamic:      dyn,
+		applier:      applier,
+	}
+}
+
+var opCRDMissingMetadata = Metadata{
+	ID:          "crd-missing",
+	Name:        "Operators: No Matches for Kind",
+	Description: "Applying the Widget 'my-widget' (ConfigMap 'widget-bundle') fails with \"no matches for kind\". Get the custom resource created.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Operators",
+	Tags:        []string{"crd", "operators"},
+	Hints: []string{
+		"Try applying the `widget.yaml` key of ConfigMap 'widget-bundle'",
+		"`kubectl get crd` lists the installed CustomResourceDefinitions",
+		"The bundle also ships the CRD in `crd.yaml`; install it first",
+	},
+	ClusterAccess: true,
+}
+
+func (s *OpCRDMissing) GetMetadata() Metadata {
+	return opCRDMissingMetadata
+}
+
+func (s *OpCRDMissing) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Make sure a previous attempt didn't leave the CRD behind, unless
+	// another session's Widgets still use it
+	if err := deleteUnusedCRD(ctx, s.dynamic, widgetCRDName, widgetGVR, s.Namespace); err != nil {
+		return err
+	}
+
+	_, err = s.clientset.CoreV1().ConfigMaps(s.Namespace).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "widget-bundle"},
+		Data: map[string]string{
+			"crd.yaml":    widgetCRDManifest,
+			"widget.yaml": widgetCRManifest,
+		},
+	}, metav1.CreateOptions{})
+	return err
+}
+
+func (s *OpCRDMissing) Validate(ctx context.Context) Result {
+	established, err := crdEstablished(ctx, s.dynamic, widgetCRDName)
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+
+	crInstalled := Check{
+		Name:    "Widget created",
+		Details: "Widget 'my-widget' does not exist yet.",
+	}
+	if established {
+		cr, err := getCustomResource(ctx, s.dynamic, widgetGVR, s.Namespace, "my-widget")
+		if err != nil {
+			return Result{Solved: false, Message: err.Error()}
+		}
+		crInstalled.Passed = cr != nil
+	}
+
+	return NewResult("Success! The CRD is installed and the Widget exists.",
+		Check{Name: "CRD established", Passed: established, Details: "CRD " + widgetCRDName + " is not installed."},
+		crInstalled,
+	)
+}
+
+func (s *OpCRDMissing) Solve(ctx context.Context) error {
+	if err := s.applier.ApplyYAML(ctx, widgetCRDManifest, ""); err != nil {
+		return err
+	}
+	if err := waitForCRD(ctx, s.dynamic, widgetCRDName); err != nil {
+		return err
+	}
+	return s.applier.ApplyYAML(ctx, widgetCRManifest, s.Namespace)
+}
+
+// CleanupSteps implements CleanupDescriber.
+func (s *OpCRDMissing) CleanupSteps() []CleanupStep {
+	return []CleanupStep{{Description: "CRD " + widgetCRDName + ", unless other namespaces have Widgets", Command: "kubectl delete crd " + widgetCRDName}}
+}
+
+func (s *OpCRDMissing) Cleanup(ctx context.Context) error {
+	_ = deleteUnusedCRD(ctx, s.dynamic, widgetCRDName, widgetGVR, s.Namespace)
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
@@ -0,0 +1,156 @@
+package scenario
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// OpCRDSchemaReject scenario: Custom resource rejected by the CRD's validation schema.
+type OpCRDSchemaReject struct {
+	BaseScenario
+	clientset kubernetes.Interface
+	dynamic   dynamic.Interface
+	applier   Applier
+}
+
+const databaseCRDName = "databases.dojo.example.com"
+
+var databaseGVR = schema.GroupVersionResource{Group: "dojo.example.com", Version: "v1", Resource: "databases"}
+
+const databaseCRDManifest = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: databases.dojo.example.com
+  labels:
+    app.kubernetes.io/managed-by: k8s-dojo
+spec:
+  group: dojo.example.com
+  scope: Namespaced
+  names:
+    plural: databases
+    singular: database
+    kind: Database
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: ["engine", "replicas"]
+            properties:
+              engine:
+                type: string
+                enum: ["postgres", "mysql"]
+              replicas:
+                type: integer
+                minimum: 1
+                maximum: 5
+`
+
+// The manifest the "team" tried to apply; both fields violate the schema.
+const databaseCRManifest = `apiVersion: dojo.example.com/v1
+kind: Database
+metadata:
+  name: orders-db
+spec:
+  engine: postgresql
+  replicas: "3"
+`
+
+func NewOpCRDSchemaReject(clientset kubernetes.Interface, dyn dynamic.Interface, applier Applier) *OpCRDSchemaReject {
+	return &OpCRDSchemaReject{
+		BaseScenario: BaseScenario{Namespace: "op-crd-schema"},
+		clientset:    clientset,
+		dynamic:      dyn,
+		applier:      applier,
+	}
+}
+
+var opCRDSchemaRejectMetadata = Metadata{
+	ID:          "crd-schema-reject",
+	Name:        "Operators: Rejected by Schema",
+	Description: "The Database 'orders-db' in ConfigMap 'orders-db' is rejected by the API server. Fix the manifest so it passes validation and create a 3-replica postgres Database.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Operators",
+	Tags:        []string{"crd", "validation", "operators"},
+	Hints: []string{
+		"Apply the `database.yaml` key of ConfigMap 'orders-db' and read the error",
+		"`kubectl explain database.spec` shows the schema",
+		"Check the allowed `engine` values and the type of `replicas`",
+	},
+}
+
+func (s *OpCRDSchemaReject) GetMetadata() Metadata {
+	return opCRDSchemaRejectMetadata
+}
+
+func (s *OpCRDSchemaReject) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	if err := s.applier.ApplyYAML(ctx, databaseCRDManifest, ""); err != nil {
+		return err
+	}
+
+	_, err = s.clientset.CoreV1().ConfigMaps(s.Namespace).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders-db"},
+		Data:       map[string]string{"database.yaml": databaseCRManifest},
+	}, metav1.CreateOptions{})
+	return err
+}
+
+// WaitReady blocks until the CRD is serving so the rejection is reproducible.
+func (s *OpCRDSchemaReject) WaitReady(ctx context.Context) error {
+	return waitForCRD(ctx, s.dynamic, databaseCRDName)
+}
+
+func (s *OpCRDSchemaReject) Validate(ctx context.Context) Result {
+	cr, err := getCustomResource(ctx, s.dynamic, databaseGVR, s.Namespace, "orders-db")
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+
+	engine := ""
+	var replicas int64
+	if cr != nil {
+		engine, _, _ = unstructured.NestedString(cr.Object, "spec", "engine")
+		replicas, _, _ = unstructured.NestedInt64(cr.Object, "spec", "replicas")
+	}
+
+	return NewResult("Success! The Database passes schema validation.",
+		Check{Name: "Database created", Passed: cr != nil, Details: "Database 'orders-db' does not exist yet."},
+		Check{Name: "Engine is postgres", Passed: engine == "postgres", Details: "The team needs a postgres database."},
+		Check{Name: "Three replicas", Passed: replicas == 3, Details: "The team needs 3 replicas."},
+	)
+}
+
+func (s *OpCRDSchemaReject) Solve(ctx context.Context) error {
+	fixed := strings.NewReplacer("engine: postgresql", "engine: postgres", `replicas: "3"`, "replicas: 3").
+		Replace(databaseCRManifest)
+	return s.applier.ApplyYAML(ctx, fixed, s.Namespace)
+}
+
+// CleanupSteps implements CleanupDescriber.
+func (s *OpCRDSchemaReject) CleanupSteps() []CleanupStep {
+	return []CleanupStep{{Description: "CRD " + databaseCRDName + ", unless other namespaces have Databases", Command: "kubectl delete crd " + databaseCRDName}}
+}
+
+func (s *OpCRDSchemaReject) Cleanup(ctx context.Context) error {
+	_ = deleteUnusedCRD(ctx, s.dynamic, databaseCRDName, databaseGVR, s.Namespace)
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
@@ -0,0 +1,249 @@
+package scenario
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// dojoCertDir holds the drill's PKI on the control-plane node, beside the
+	// cluster's own /etc/kubernetes/pki, which the drill never touches.
+	dojoCertDir = "/etc/kubernetes/dojo-pki"
+
+	// kubeadmPath is where Kind nodes ship the kubeadm binary.
+	kubeadmPath = "/usr/bin/kubeadm"
+
+	// expiringCert is the certificate Setup issues with only days left.
+	expiringCert = "apiserver-kubelet-client"
+
+	// certRenewThreshold is how long every certificate must stay valid after the fix.
+	certRenewThreshold = 30 * 24 * time.Hour
+)
+
+// OpsCertExpiry scenario: one certificate in a kubeadm PKI is about to expire.
+// Renewing the cluster's real certificates would restart its control plane, so
+// the drill issues its own CA and certificates into dojoCertDir and kubeadm is
+// pointed at them with --cert-dir.
+type OpsCertExpiry struct {
+	BaseScenario
+	clientset kubernetes.Interface
+}
+
+func NewOpsCertExpiry(clientset kubernetes.Interface) *OpsCertExpiry {
+	return &OpsCertExpiry{
+		BaseScenario: BaseScenario{Namespace: "ops-certs"},
+		clientset:    clientset,
+	}
+}
+
+var opsCertExpiryMetadata = Metadata{
+	ID:          "ops-cert-expiry",
+	Name:        "Ops: Certificate Countdown",
+	Description: "An audit flagged the kubeadm PKI in " + dojoCertDir + " on the control-plane node: one certificate expires within days. Find it and renew it so every certificate stays valid for at least 30 more days.",
+	Difficulty:  DifficultyHard,
+	Category:    "Operations",
+	Tags:        []string{"certificates", "tls", "control-plane", "cka"},
+	Hints: []string{
+		"Open a shell on the node: `docker exec -it k8s-dojo-control-plane bash`",
+		"`kubeadm certs check-expiration --cert-dir " + dojoCertDir + "` lists every certificate with its RESIDUAL TIME",
+		"`kubeadm certs renew <name> --cert-dir " + dojoCertDir + "` re-issues a certificate from the same CA",
+	},
+}
+
+func (s *OpsCertExpiry) GetMetadata() Metadata {
+	return opsCertExpiryMetadata
+}
+
+func (s *OpsCertExpiry) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	files, err := dojoPKI(time.Now())
+	if err != nil {
+		return err
+	}
+
+	// PEM data never contains single quotes, so it can be inlined safely
+	script := []string{"set -e", "rm -rf " + dojoCertDir, "mkdir -p " + dojoCertDir}
+	for name, data := range files {
+		script = append(script, fmt.Sprintf("printf '%%s' '%s' > %s/%s", data, dojoCertDir, name))
+	}
+	_, err = s.onControlPlane(ctx, "sh", "-c", strings.Join(script, "\n"))
+	return err
+}
+
+func (s *OpsCertExpiry) Validate(ctx context.Context) Result {
+	out, err := s.onControlPlane(ctx, kubeadmPath, "certs", "check-expiration", "--cert-dir", dojoCertDir)
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+	residual := parseCertExpiration(out)
+
+	var checks []Check
+	for _, name := range []string{"ca", "apiserver", expiringCert} {
+		c := Check{Name: fmt.Sprintf("%s valid for 30+ days", name)}
+		left, ok := residual[name]
+		switch {
+		case !ok:
+			c.Details = fmt.Sprintf("Certificate '%s' is missing from %s.", name, dojoCertDir)
+		case left < certRenewThreshold:
+			c.Details = fmt.Sprintf("Certificate '%s' expires in %s.", name, left.Round(time.Hour))
+		default:
+			c.Passed = true
+		}
+		checks = append(checks, c)
+	}
+	return NewResult("Success! Every certificate is valid for at least 30 more days.", checks...)
+}
+
+func (s *OpsCertExpiry) Solve(ctx context.Context) error {
+	_, err := s.onControlPlane(ctx, kubeadmPath, "certs", "renew", expiringCert, "--cert-dir", dojoCertDir)
+	return err
+}
+
+// CleanupSteps implements CleanupDescriber.
+func (s *OpsCertExpiry) CleanupSteps() []CleanupStep {
+	return []CleanupStep{{
+		Description: "drill certificates on the control-plane node",
+		Command:     "docker exec k8s-dojo-control-plane rm -rf " + dojoCertDir,
+	}}
+}
+
+func (s *OpsCertExpiry) Cleanup(ctx context.Context) error {
+	_, _ = s.onControlPlane(ctx, "rm", "-rf", dojoCertDir)
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
+
+// onControlPlane runs command on the control-plane node with /etc/kubernetes
+// and the node's kubeadm binary mounted.
+func (s *OpsCertExpiry) onControlPlane(ctx context.Context, command ...string) (string, error) {
+	node, err := controlPlaneNode(ctx, s.clientset)
+	if err != nil {
+		return "", err
+	}
+	return runOnNode(ctx, s.clientset, s.Namespace, node, []string{"/etc/kubernetes", kubeadmPath}, command)
+}
+
+// dojoPKI issues a CA, a healthy apiserver certificate and an expiringCert
+// with three days left, keyed by their kubeadm file names.
+func dojoPKI(now time.Time) (map[string]string, error) {
+	files := map[string]string{}
+
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubernetes"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if err := issueCert(files, "ca", ca, ca, caKey, caKey); err != nil {
+		return nil, err
+	}
+
+	leaves := []struct {
+		name     string
+		template *x509.Certificate
+	}{
+		{"apiserver", &x509.Certificate{
+			Subject:     pkix.Name{CommonName: "kube-apiserver"},
+			DNSNames:    []string{"kubernetes", "kubernetes.default", "kubernetes.default.svc"},
+			NotAfter:    now.AddDate(1, 0, 0),
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}},
+		{expiringCert, &x509.Certificate{
+			Subject:     pkix.Name{CommonName: "kube-apiserver-kubelet-client", Organization: []string{"kubeadm:cluster-admins"}},
+			NotAfter:    now.AddDate(0, 0, 3),
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}},
+	}
+	for i, leaf := range leaves {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+		leaf.template.SerialNumber = big.NewInt(int64(i + 2))
+		leaf.template.NotBefore = now.Add(-time.Hour)
+		leaf.template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+		if err := issueCert(files, leaf.name, leaf.template, ca, key, caKey); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// issueCert signs template with parent and adds the PEM-encoded certificate and key to files.
+func issueCert(files map[string]string, name string, template, parent *x509.Certificate, key, parentKey *rsa.PrivateKey) error {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return fmt.Errorf("failed to issue certificate %s: %w", name, err)
+	}
+	files[name+".crt"] = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	files[name+".key"] = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	return nil
+}
+
+// parseCertExpiration reads the RESIDUAL TIME column of `kubeadm certs
+// check-expiration` per certificate. Expired certificates map to a negative duration.
+func parseCertExpiration(out string) map[string]time.Duration {
+	residual := map[string]time.Duration{}
+	for _, line := range strings.Split(out, "\n") {
+		// NAME, five EXPIRES fields ("Oct 14, 2027 10:00 UTC"), RESIDUAL TIME, ...
+		fields := strings.Fields(line)
+		if len(fields) < 7 {
+			continue
+		}
+		if d, ok := parseResidualTime(fields[6]); ok {
+			residual[fields[0]] = d
+		}
+	}
+	return residual
+}
+
+// parseResidualTime parses kubeadm's short durations such as 364d, 9y or <invalid>.
+func parseResidualTime(s string) (time.Duration, bool) {
+	if s == "<invalid>" {
+		return -1, true
+	}
+	units := map[byte]time.Duration{
+		's': time.Second,
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'y': 365 * 24 * time.Hour,
+	}
+	if len(s) < 2 {
+		return 0, false
+	}
+	unit, ok := units[s[len(s)-1]]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
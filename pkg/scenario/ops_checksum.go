@@ -12,25 +12,28 @@ import (
 // OpsConfigChecksum scenario: Config checksum annotation.
 type OpsConfigChecksum struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewOpsConfigChecksum(clientset *kubernetes.Clientset) *OpsConfigChecksum {
+func NewOpsConfigChecksum(clientset kubernetes.Interface) *OpsConfigChecksum {
 	return &OpsConfigChecksum{
 		BaseScenario: BaseScenario{Namespace: "ops-checksum"},
 		clientset:    clientset,
 	}
 }
 
+var opsConfigChecksumMetadata = Metadata{
+	ID:          "ops-config-checksum",
+	Name:        "Ops: GitOps Trigger",
+	Description: "The Deployment must restart when ConfigMap changes. Add a checksum annotation.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Operations",
+	Tags:        []string{"configmaps", "rollouts", "deployments"},
+	Hints:       []string{"Add an annotation to the Pod template", "Key typically contains 'checksum' or 'sha256'"},
+}
+
 func (s *OpsConfigChecksum) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "ops-config-checksum",
-		Name:        "Ops: GitOps Trigger",
-		Description: "The Deployment must restart when ConfigMap changes. Add a checksum annotation.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Operations",
-		Hints:       []string{"Add an annotation to the Pod template", "Key typically contains 'checksum' or 'sha256'"},
-	}
+	return opsConfigChecksumMetadata
 }
 
 func (s *OpsConfigChecksum) Setup(ctx context.Context) error {
@@ -64,7 +67,20 @@ func (s *OpsConfigChecksum) Validate(ctx context.Context) Result {
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
+	return s.validate(dep)
+}
+
+// ValidateManifests implements ManifestValidator.
+func (s *OpsConfigChecksum) ValidateManifests(objs Manifests) Result {
+	var dep appsv1.Deployment
+	if res, ok := objs.Find("Deployment", "gitops-app", &dep); !ok {
+		return res
+	}
+	return s.validate(&dep)
+}
 
+// validate checks the deployment spec, live or from a manifest.
+func (s *OpsConfigChecksum) validate(dep *appsv1.Deployment) Result {
 	for k := range dep.Spec.Template.Annotations {
 		if k == "checksum/config" || (len(k) > 8 && k[:8] == "checksum") {
 			return Result{Solved: true, Message: "Success! Checksum annotation found."}
@@ -73,6 +89,15 @@ func (s *OpsConfigChecksum) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "No checksum annotation found in Pod template."}
 }
 
+func (s *OpsConfigChecksum) Solve(ctx context.Context) error {
+	return updateDeployment(ctx, s.clientset, s.Namespace, "gitops-app", func(dep *appsv1.Deployment) {
+		if dep.Spec.Template.Annotations == nil {
+			dep.Spec.Template.Annotations = map[string]string{}
+		}
+		dep.Spec.Template.Annotations["checksum/config"] = "dojo"
+	})
+}
+
 func (s *OpsConfigChecksum) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
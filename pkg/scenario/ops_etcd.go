@@ -0,0 +1,203 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s-dojo/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// etcdDataDir is the control-plane node's etcd data directory, mounted into the etcd pod.
+	etcdDataDir = "/var/lib/etcd"
+
+	// etcdBackupPath and etcdRestoreDir sit beside the live data, which the drill never touches.
+	etcdBackupPath = etcdDataDir + "/dojo-backup.db"
+	etcdRestoreDir = etcdDataDir + "/dojo-restore"
+)
+
+// etcdctlFlags connect etcdctl inside the etcd pod to its own member.
+var etcdctlFlags = []string{
+	"--endpoints=https://127.0.0.1:2379",
+	"--cacert=/etc/kubernetes/pki/etcd/ca.crt",
+	"--cert=/etc/kubernetes/pki/etcd/server.crt",
+	"--key=/etc/kubernetes/pki/etcd/server.key",
+}
+
+// etcdPodRef finds the etcd pod in kubectl commands shown to the learner.
+const etcdPodRef = "$(kubectl -n kube-system get pod -l component=etcd -o name)"
+
+// OpsEtcdBackup scenario: take an etcd snapshot before a risky change and
+// practise restoring it. The restore goes into a separate data directory;
+// swapping it in would roll back the whole dojo cluster, so the drill stops
+// short of the cut-over and explains it instead.
+type OpsEtcdBackup struct {
+	BaseScenario
+	clientset  kubernetes.Interface
+	restConfig *rest.Config // Needed for exec into the etcd pod
+}
+
+func NewOpsEtcdBackup(clientset kubernetes.Interface, restConfig *rest.Config) *OpsEtcdBackup {
+	return &OpsEtcdBackup{
+		BaseScenario: BaseScenario{Namespace: "ops-etcd"},
+		clientset:    clientset,
+		restConfig:   restConfig,
+	}
+}
+
+var opsEtcdBackupMetadata = Metadata{
+	ID:          "ops-etcd-backup",
+	Name:        "Ops: Backup Before You Break It",
+	Description: "A migration is about to delete ConfigMap 'payments-config'. Snapshot etcd to " + etcdBackupPath + " first, then restore the snapshot into " + etcdRestoreDir + " to prove the backup works.",
+	Difficulty:  DifficultyHard,
+	Category:    "Operations",
+	Tags:        []string{"etcd", "backup", "control-plane", "cka"},
+	Hints: []string{
+		"etcd runs as a static pod in kube-system: `kubectl -n kube-system get pods -l component=etcd`",
+		"The etcd image ships `etcdctl` and `etcdutl`; the TLS files are under /etc/kubernetes/pki/etcd",
+		"`etcdctl snapshot save` takes the backup, `etcdutl snapshot restore --data-dir` restores it",
+	},
+	ClusterAccess: true,
+}
+
+func (s *OpsEtcdBackup) GetMetadata() Metadata {
+	return opsEtcdBackupMetadata
+}
+
+func (s *OpsEtcdBackup) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Leftovers of an earlier attempt would make the restore fail
+	if err := s.removeBackups(ctx); err != nil {
+		return err
+	}
+
+	_, err = s.clientset.CoreV1().ConfigMaps(s.Namespace).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-config"},
+		Data:       map[string]string{"gateway": "https://payments.internal", "retries": "3"},
+	}, metav1.CreateOptions{})
+	return err
+}
+
+func (s *OpsEtcdBackup) Validate(ctx context.Context) Result {
+	return NewResult("Success! The snapshot is saved and restores cleanly.",
+		s.snapshotValid(ctx, "Snapshot saved", etcdBackupPath),
+		s.snapshotValid(ctx, "Snapshot restored", etcdRestoreDir+"/member/snap/db"),
+	)
+}
+
+func (s *OpsEtcdBackup) Guide() []GuideStep {
+	etcdctl := "etcdctl " + strings.Join(etcdctlFlags, " ")
+	return []GuideStep{
+		{
+			Title:       "Find etcd",
+			Instruction: "Kubernetes keeps all cluster state in etcd, which runs as a static pod on the control-plane node.",
+			Command:     "kubectl -n kube-system get pods -l component=etcd",
+		},
+		{
+			Title:       "Take a snapshot",
+			Instruction: "Save a snapshot inside the etcd pod. " + etcdDataDir + " is a directory on the node, so the file outlives the pod.",
+			Command:     fmt.Sprintf("kubectl -n kube-system exec %s -- %s snapshot save %s", etcdPodRef, etcdctl, etcdBackupPath),
+			Verify: func(ctx context.Context) Check {
+				return s.snapshotValid(ctx, "Snapshot saved", etcdBackupPath)
+			},
+		},
+		{
+			Title:       "Run the bad migration",
+			Instruction: "Simulate the change going wrong: delete the ConfigMap the payments team depends on.",
+			Command:     "kubectl delete configmap payments-config -n " + s.Namespace,
+			Verify: func(ctx context.Context) Check {
+				_, err := s.clientset.CoreV1().ConfigMaps(s.Namespace).Get(ctx, "payments-config", metav1.GetOptions{})
+				return Check{Name: "ConfigMap deleted", Passed: apierrors.IsNotFound(err), Details: "ConfigMap 'payments-config' still exists."}
+			},
+		},
+		{
+			Title:       "Restore the snapshot",
+			Instruction: "Restore the snapshot into a fresh data directory. The live data directory stays untouched.",
+			Command:     fmt.Sprintf("kubectl -n kube-system exec %s -- etcdutl snapshot restore %s --data-dir %s", etcdPodRef, etcdBackupPath, etcdRestoreDir),
+			Verify: func(ctx context.Context) Check {
+				return s.snapshotValid(ctx, "Snapshot restored", etcdRestoreDir+"/member/snap/db")
+			},
+		},
+		{
+			Title: "The cut-over",
+			Instruction: "In a real recovery you would now move /etc/kubernetes/manifests/etcd.yaml out of the manifests directory to stop etcd, " +
+				"point its etcd-data hostPath at " + etcdRestoreDir + " and move the manifest back. The drill stops here: cutting over would roll back the whole dojo cluster.",
+		},
+	}
+}
+
+func (s *OpsEtcdBackup) Solve(ctx context.Context) error {
+	if _, err := s.execEtcd(ctx, append(append([]string{"etcdctl"}, etcdctlFlags...), "snapshot", "save", etcdBackupPath)); err != nil {
+		return err
+	}
+	err := s.clientset.CoreV1().ConfigMaps(s.Namespace).Delete(ctx, "payments-config", metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	_, err = s.execEtcd(ctx, []string{"etcdutl", "snapshot", "restore", etcdBackupPath, "--data-dir", etcdRestoreDir})
+	return err
+}
+
+// CleanupSteps implements CleanupDescriber.
+func (s *OpsEtcdBackup) CleanupSteps() []CleanupStep {
+	return []CleanupStep{{
+		Description: "etcd snapshot and restore on the control-plane node",
+		Command:     "docker exec k8s-dojo-control-plane rm -rf " + etcdBackupPath + " " + etcdRestoreDir,
+	}}
+}
+
+func (s *OpsEtcdBackup) Cleanup(ctx context.Context) error {
+	_ = s.removeBackups(ctx)
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
+
+// snapshotValid checks that path holds a readable etcd database.
+func (s *OpsEtcdBackup) snapshotValid(ctx context.Context, name, path string) Check {
+	if _, err := s.execEtcd(ctx, []string{"etcdutl", "snapshot", "status", path}); err != nil {
+		return Check{Name: name, Details: fmt.Sprintf("No valid etcd database at %s.", path)}
+	}
+	return Check{Name: name, Passed: true}
+}
+
+// removeBackups deletes the drill's files from the control-plane node.
+func (s *OpsEtcdBackup) removeBackups(ctx context.Context) error {
+	pod, err := s.etcdPod(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = runOnNode(ctx, s.clientset, s.Namespace, pod.Spec.NodeName, []string{etcdDataDir}, []string{"rm", "-rf", etcdBackupPath, etcdRestoreDir})
+	return err
+}
+
+// execEtcd runs a command in the etcd container.
+func (s *OpsEtcdBackup) execEtcd(ctx context.Context, command []string) (k8s.ExecResult, error) {
+	pod, err := s.etcdPod(ctx)
+	if err != nil {
+		return k8s.ExecResult{}, err
+	}
+	return k8s.Exec(ctx, s.clientset, s.restConfig, pod.Namespace, pod.Name, "etcd", command)
+}
+
+func (s *OpsEtcdBackup) etcdPod(ctx context.Context) (*corev1.Pod, error) {
+	pods, err := s.clientset.CoreV1().Pods("kube-system").List(ctx, metav1.ListOptions{LabelSelector: "component=etcd"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find etcd: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no etcd pod found in kube-system")
+	}
+	return &pods.Items[0], nil
+}
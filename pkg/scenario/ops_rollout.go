@@ -0,0 +1,177 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// rolloutReplicas is the capacity the Service must keep through the cut-over.
+const rolloutReplicas = 2
+
+// OpsBlueGreen scenario: v2 of the shop is deployed next to v1 but gets no
+// traffic. The learner cuts the Service over by its selector without dropping
+// capacity, and keeps v1 around for a rollback.
+type OpsBlueGreen struct {
+	BaseScenario
+	clientset kubernetes.Interface
+}
+
+func NewOpsBlueGreen(clientset kubernetes.Interface) *OpsBlueGreen {
+	return &OpsBlueGreen{
+		BaseScenario: BaseScenario{Namespace: "ops-rollout"},
+		clientset:    clientset,
+	}
+}
+
+var opsBlueGreenMetadata = Metadata{
+	ID:          "ops-blue-green",
+	Name:        "Ops: Blue/Green Cut-Over",
+	Description: fmt.Sprintf("Release day: 'shop-green' (v2) is deployed next to 'shop-blue' (v1), but Service 'shop' still sends every request to v1. Move all traffic to v2 with at least %d ready pods serving it, and keep 'shop-blue' around in case you need to roll back.", rolloutReplicas),
+	Difficulty:  DifficultyMedium,
+	Category:    "Operations",
+	Tags:        []string{"services", "selectors", "rollouts", "ckad"},
+	Hints: []string{
+		"`kubectl get pods -n {{ .Namespace }} --show-labels` shows which labels tell the versions apart",
+		"`kubectl get endpoints shop -o wide` lists the pods behind the Service",
+		"Check how many replicas 'shop-green' runs before switching the Service selector",
+		"Prefer a canary? Select on `app=shop` alone so both versions share traffic, then move the selector to v2 once it looks healthy",
+	},
+}
+
+func (s *OpsBlueGreen) GetMetadata() Metadata {
+	return opsBlueGreenMetadata
+}
+
+func (s *OpsBlueGreen) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Green was deployed scaled to zero, so an early selector switch is an outage
+	for _, d := range []struct {
+		name, version string
+		replicas      int32
+	}{
+		{"shop-blue", "v1", rolloutReplicas},
+		{"shop-green", "v2", 0},
+	} {
+		replicas := d.replicas
+		labels := map[string]string{"app": "shop", "version": d.version}
+		_, err := s.clientset.AppsV1().Deployments(s.Namespace).Create(ctx, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: d.name},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  "shop",
+							Image: "nginx:alpine",
+							Env:   []corev1.EnvVar{{Name: "SHOP_VERSION", Value: d.version}},
+						}},
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.clientset.CoreV1().Services(s.Namespace).Create(ctx, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "shop", "version": "v1"},
+			Ports:    []corev1.ServicePort{{Port: 80}},
+		},
+	}, metav1.CreateOptions{})
+	return err
+}
+
+// WaitReady blocks until v1 is serving.
+func (s *OpsBlueGreen) WaitReady(ctx context.Context) error {
+	return waitForPods(ctx, s.clientset, s.Namespace, "version=v1", podReady)
+}
+
+func (s *OpsBlueGreen) Validate(ctx context.Context) Result {
+	versions, err := s.endpointVersions(ctx)
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+
+	cutOver := Check{Name: "All traffic on v2", Passed: versions["v1"] == 0 && versions["v2"] > 0}
+	switch {
+	case versions["v1"] > 0:
+		cutOver.Details = fmt.Sprintf("Service 'shop' still sends traffic to %d v1 pod(s).", versions["v1"])
+	case !cutOver.Passed:
+		cutOver.Details = "Service 'shop' has no v2 pods behind it."
+	}
+
+	capacity := Check{
+		Name:    "Full capacity on v2",
+		Passed:  versions["v2"] >= rolloutReplicas,
+		Details: fmt.Sprintf("Only %d ready v2 pod(s) serve the Service, %d are needed.", versions["v2"], rolloutReplicas),
+	}
+
+	rollback := Check{Name: "Blue kept for rollback", Passed: true}
+	if _, err := s.clientset.AppsV1().Deployments(s.Namespace).Get(ctx, "shop-blue", metav1.GetOptions{}); err != nil {
+		rollback = Check{Name: rollback.Name, Details: "Deployment 'shop-blue' is gone, so there is nothing to roll back to."}
+	}
+
+	return NewResult("Success! v2 takes all the traffic and v1 is standing by for a rollback.", cutOver, capacity, rollback)
+}
+
+func (s *OpsBlueGreen) Solve(ctx context.Context) error {
+	err := updateDeployment(ctx, s.clientset, s.Namespace, "shop-green", func(dep *appsv1.Deployment) {
+		replicas := int32(rolloutReplicas)
+		dep.Spec.Replicas = &replicas
+	})
+	if err != nil {
+		return err
+	}
+	if err := waitForPods(ctx, s.clientset, s.Namespace, "version=v2", podReady); err != nil {
+		return err
+	}
+	return updateService(ctx, s.clientset, s.Namespace, "shop", func(svc *corev1.Service) {
+		svc.Spec.Selector = map[string]string{"app": "shop", "version": "v2"}
+	})
+}
+
+func (s *OpsBlueGreen) Cleanup(ctx context.Context) error {
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
+
+// endpointVersions counts the ready endpoints of Service 'shop' by the version label of their pods.
+func (s *OpsBlueGreen) endpointVersions(ctx context.Context) (map[string]int, error) {
+	ep, err := s.clientset.CoreV1().Endpoints(s.Namespace).Get(ctx, "shop", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoints of Service 'shop': %w", err)
+	}
+	pods, err := s.clientset.CoreV1().Pods(s.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	version := make(map[string]string, len(pods.Items))
+	for _, pod := range pods.Items {
+		version[pod.Name] = pod.Labels["version"]
+	}
+
+	counts := map[string]int{}
+	for _, subset := range ep.Subsets {
+		for _, addr := range subset.Addresses {
+			if addr.TargetRef != nil && addr.TargetRef.Kind == "Pod" {
+				counts[version[addr.TargetRef.Name]]++
+			}
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,184 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-dojo/pkg/scenario/check"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// upgradeAnnotation records the kubelet version the drill's node was upgraded from.
+const upgradeAnnotation = "k8s-dojo.io/upgraded-from"
+
+// OpsUpgradeDrill scenario: a node upgrade left the node cordoned and the next
+// drain blocked by a PodDisruptionBudget. Kind clusters here have a single node,
+// so the kubelet upgrade itself is simulated and the drill covers the steps
+// around it: getting the node back into service and making it drainable again.
+type OpsUpgradeDrill struct {
+	BaseScenario
+	clientset kubernetes.Interface
+}
+
+func NewOpsUpgradeDrill(clientset kubernetes.Interface) *OpsUpgradeDrill {
+	return &OpsUpgradeDrill{
+		BaseScenario: BaseScenario{Namespace: "ops-upgrade"},
+		clientset:    clientset,
+	}
+}
+
+var opsUpgradeDrillMetadata = Metadata{
+	ID:          "ops-upgrade-drill",
+	Name:        "Ops: The Stalled Upgrade",
+	Description: "The node's kubelet was upgraded from the version in its `" + upgradeAnnotation + "` annotation, but the node never came back into service: the 'api' pods are Pending and the next drain is already blocked.",
+	Difficulty:  DifficultyHard,
+	Category:    "Operations",
+	Tags:        []string{"drain", "pdb", "nodes", "cka"},
+	Hints: []string{
+		"`kubectl get nodes` shows SchedulingDisabled: the upgrade runbook cordoned the node",
+		"Finish the runbook with `kubectl uncordon <node>`",
+		"`kubectl get pdb` shows ALLOWED DISRUPTIONS 0, so the next `kubectl drain` would hang; relax the budget",
+	},
+	ClusterAccess: true,
+}
+
+func (s *OpsUpgradeDrill) GetMetadata() Metadata {
+	return opsUpgradeDrillMetadata
+}
+
+func (s *OpsUpgradeDrill) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Cordon the node as `kubectl drain` does before an upgrade
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes.Items {
+		from := previousMinor(node.Status.NodeInfo.KubeletVersion)
+		patch := fmt.Sprintf(`{"spec":{"unschedulable":true},"metadata":{"annotations":{%q:%q}}}`, upgradeAnnotation, from)
+		if _, err := s.clientset.CoreV1().Nodes().Patch(ctx, node.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+			return err
+		}
+	}
+
+	replicas := int32(2)
+	labels := map[string]string{"app": "api"}
+	_, err = s.clientset.AppsV1().Deployments(s.Namespace).Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "api", Image: "nginx:alpine"}},
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// A budget that never allows a disruption blocks every future drain
+	minAvailable := intstr.FromInt32(replicas)
+	_, err = s.clientset.PolicyV1().PodDisruptionBudgets(s.Namespace).Create(ctx, &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-pdb"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: labels},
+		},
+	}, metav1.CreateOptions{})
+	return err
+}
+
+// WaitReady blocks until the scheduler has rejected the pods.
+func (s *OpsUpgradeDrill) WaitReady(ctx context.Context) error {
+	return waitForPods(ctx, s.clientset, s.Namespace, "app=api", podUnschedulable)
+}
+
+func (s *OpsUpgradeDrill) Validate(ctx context.Context) Result {
+	schedulable := Check{Name: "Node back in service", Passed: true}
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			schedulable.Passed = false
+			schedulable.Details = fmt.Sprintf("Node %s is still cordoned.", node.Name)
+		}
+	}
+
+	drainable := Check{Name: "Next drain can proceed", Details: "PodDisruptionBudget 'api-pdb' allows no disruptions, so a drain would hang."}
+	pdb, err := s.clientset.PolicyV1().PodDisruptionBudgets(s.Namespace).Get(ctx, "api-pdb", metav1.GetOptions{})
+	switch {
+	case err == nil:
+		drainable.Passed = pdb.Status.DisruptionsAllowed > 0
+	case apierrors.IsNotFound(err):
+		drainable.Passed = true // No budget protects the pods at all, but nothing blocks the drain either
+	}
+
+	return NewResult("Success! The node is back in service and can be drained for the next upgrade.",
+		schedulable,
+		check.DeploymentAvailable(ctx, s.clientset, s.Namespace, "api"),
+		drainable,
+	)
+}
+
+func (s *OpsUpgradeDrill) Solve(ctx context.Context) error {
+	if err := s.uncordon(ctx); err != nil {
+		return err
+	}
+	patch := []byte(`{"spec":{"minAvailable":null,"maxUnavailable":1}}`)
+	_, err := s.clientset.PolicyV1().PodDisruptionBudgets(s.Namespace).Patch(ctx, "api-pdb", types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// CleanupSteps implements CleanupDescriber.
+func (s *OpsUpgradeDrill) CleanupSteps() []CleanupStep {
+	return []CleanupStep{{Description: "cordon and upgrade annotation on the node", Command: "kubectl uncordon $(kubectl get nodes -o name) && kubectl annotate nodes --all " + upgradeAnnotation + "-"}}
+}
+
+func (s *OpsUpgradeDrill) Cleanup(ctx context.Context) error {
+	_ = s.uncordon(ctx)
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
+
+// uncordon makes every node schedulable again and drops the drill's annotation.
+func (s *OpsUpgradeDrill) uncordon(ctx context.Context) error {
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":null},"metadata":{"annotations":{%q:null}}}`, upgradeAnnotation)
+	for _, node := range nodes.Items {
+		if _, err := s.clientset.CoreV1().Nodes().Patch(ctx, node.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// previousMinor returns the release before a kubelet version, e.g. v1.30.0 for v1.31.2.
+func previousMinor(version string) string {
+	var major, minor int
+	if _, err := fmt.Sscanf(version, "v%d.%d", &major, &minor); err != nil || minor == 0 {
+		return version
+	}
+	return fmt.Sprintf("v%d.%d.0", major, minor-1)
+}
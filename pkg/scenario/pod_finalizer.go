@@ -2,34 +2,41 @@ package scenario
 
 import (
 	"context"
+	"fmt"
+
+	"k8s-dojo/pkg/scenario/check"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 )
 
 // PodFinalizerStuck scenario: Pod stuck in Terminating.
 type PodFinalizerStuck struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewPodFinalizerStuck(clientset *kubernetes.Clientset) *PodFinalizerStuck {
+func NewPodFinalizerStuck(clientset kubernetes.Interface) *PodFinalizerStuck {
 	return &PodFinalizerStuck{
 		BaseScenario: BaseScenario{Namespace: "pod-stuck"},
 		clientset:    clientset,
 	}
 }
 
+var podFinalizerStuckMetadata = Metadata{
+	ID:          "pod-finalizer-stuck",
+	Name:        "Lifecycle: The Undying Pod",
+	Description: "A Pod is stuck in 'Terminating' state and won't go away. Force delete doesn't help.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Lifecycle",
+	Tags:        []string{"finalizers", "deletion"},
+	Hints:       []string{"Check `metadata.finalizers`", "Remove the finalizer to release the pod"},
+}
+
 func (s *PodFinalizerStuck) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "pod-finalizer-stuck",
-		Name:        "Lifecycle: The Undying Pod",
-		Description: "A Pod is stuck in 'Terminating' state and won't go away. Force delete doesn't help.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Lifecycle",
-		Hints:       []string{"Check `metadata.finalizers`", "Remove the finalizer to release the pod"},
-	}
+	return podFinalizerStuckMetadata
 }
 
 func (s *PodFinalizerStuck) Setup(ctx context.Context) error {
@@ -61,12 +68,23 @@ func (s *PodFinalizerStuck) Setup(ctx context.Context) error {
 }
 
 func (s *PodFinalizerStuck) Validate(ctx context.Context) Result {
-	_, err := s.clientset.CoreV1().Pods(s.Namespace).Get(ctx, "zombie", metav1.GetOptions{})
-	if err != nil {
-		return Result{Solved: true, Message: "Success! Pod is gone."}
-	}
+	return NewResult("Success! Pod is gone.",
+		check.PodDeleted(ctx, s.clientset, s.Namespace, "zombie").OrElse("Pod stuck in Terminating."),
+	)
+}
+
+func (s *PodFinalizerStuck) Solve(ctx context.Context) error {
+	patch := []byte(`{"metadata":{"finalizers":null}}`)
+	_, err := s.clientset.CoreV1().Pods(s.Namespace).Patch(ctx, "zombie", types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
 
-	return Result{Solved: false, Message: "Pod stuck in Terminating."}
+// CleanupSteps implements CleanupDescriber. The finalizer has to go before the namespace can be deleted.
+func (s *PodFinalizerStuck) CleanupSteps() []CleanupStep {
+	return []CleanupStep{{
+		Description: "finalizer on pod zombie",
+		Command:     fmt.Sprintf(`kubectl patch pod zombie -n %s --type merge -p '{"metadata":{"finalizers":null}}'`, s.Namespace),
+	}}
 }
 
 func (s *PodFinalizerStuck) Cleanup(ctx context.Context) error {
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -12,25 +14,37 @@ import (
 // ProbeLivenessFail scenario: Liveness probe check fails.
 type ProbeLivenessFail struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewProbeLivenessFail(clientset *kubernetes.Clientset) *ProbeLivenessFail {
+func NewProbeLivenessFail(clientset kubernetes.Interface) *ProbeLivenessFail {
 	return &ProbeLivenessFail{
 		BaseScenario: BaseScenario{Namespace: "probe-fail"},
 		clientset:    clientset,
 	}
 }
 
+var probeLivenessFailMetadata = Metadata{
+	ID:          "probe-liveness-fail",
+	Name:        "Lifecycle: Liveness Failure",
+	Description: "The Pod keeps restarting. Investigating the Liveness Probe configuration.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Lifecycle",
+	Tags:        []string{"probes", "liveness", "ckad"},
+	Hints:       []string{"Check `kubectl describe pod` events", "Verify the livenessProbe port"},
+	Commands: []Command{
+		{Group: CommandInspect, Command: "kubectl get pods -n {{ .Namespace }}"},
+		{Group: CommandEvents, Command: "kubectl describe pod unstable-app -n {{ .Namespace }}"},
+		{Group: CommandLogs, Command: "kubectl logs unstable-app -n {{ .Namespace }} --previous"},
+	},
+	DocsLinks: []DocLink{
+		{Title: "Liveness, Readiness and Startup Probes", URL: "https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/"},
+		{Title: "Pod Lifecycle", URL: "https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#container-probes"},
+	},
+}
+
 func (s *ProbeLivenessFail) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "probe-liveness-fail",
-		Name:        "Lifecycle: Liveness Failure",
-		Description: "The Pod keeps restarting. Investigating the Liveness Probe configuration.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Lifecycle",
-		Hints:       []string{"Check `kubectl describe pod` events", "Verify the livenessProbe port"},
-	}
+	return probeLivenessFailMetadata
 }
 
 func (s *ProbeLivenessFail) Setup(ctx context.Context) error {
@@ -65,12 +79,38 @@ func (s *ProbeLivenessFail) Setup(ctx context.Context) error {
 	return err
 }
 
+// WaitReady blocks until the liveness probe has restarted the container.
+func (s *ProbeLivenessFail) WaitReady(ctx context.Context) error {
+	return waitForPods(ctx, s.clientset, s.Namespace, "", containerRestarted)
+}
+
 func (s *ProbeLivenessFail) Validate(ctx context.Context) Result {
 	pod, err := s.clientset.CoreV1().Pods(s.Namespace).Get(ctx, "unstable-app", metav1.GetOptions{})
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
+	res := s.validate(pod)
+	if !res.Solved {
+		return res
+	}
+	// A corrected spec only counts once the container stops being killed
+	return NewResult(res.Message,
+		Check{Name: "Liveness probe port", Passed: true},
+		check.PodStable(ctx, s.clientset, s.Namespace, "unstable-app", check.DefaultStableWindow),
+	)
+}
+
+// ValidateManifests implements ManifestValidator.
+func (s *ProbeLivenessFail) ValidateManifests(objs Manifests) Result {
+	var pod corev1.Pod
+	if res, ok := objs.Find("Pod", "unstable-app", &pod); !ok {
+		return res
+	}
+	return s.validate(&pod)
+}
 
+// validate checks the pod spec, live or from a manifest.
+func (s *ProbeLivenessFail) validate(pod *corev1.Pod) Result {
 	// Check if configured correctly
 	if len(pod.Spec.Containers) > 0 {
 		probe := pod.Spec.Containers[0].LivenessProbe
@@ -83,6 +123,12 @@ func (s *ProbeLivenessFail) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "Liveness probe matches incorrect port."}
 }
 
+func (s *ProbeLivenessFail) Solve(ctx context.Context) error {
+	return recreatePod(ctx, s.clientset, s.Namespace, "unstable-app", func(pod *corev1.Pod) {
+		pod.Spec.Containers[0].LivenessProbe.HTTPGet.Port = intstr.FromInt(80)
+	})
+}
+
 func (s *ProbeLivenessFail) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
@@ -12,25 +12,32 @@ import (
 // ProbeReadinessTimeout scenario: Readiness probe timeout too short.
 type ProbeReadinessTimeout struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewProbeReadinessTimeout(clientset *kubernetes.Clientset) *ProbeReadinessTimeout {
+func NewProbeReadinessTimeout(clientset kubernetes.Interface) *ProbeReadinessTimeout {
 	return &ProbeReadinessTimeout{
 		BaseScenario: BaseScenario{Namespace: "probe-ready"},
 		clientset:    clientset,
 	}
 }
 
+var probeReadinessTimeoutMetadata = Metadata{
+	ID:          "probe-readiness-timeout",
+	Name:        "Lifecycle: Readiness Timeout",
+	Description: "The Pod is running but never becomes Ready. The app is slow to respond.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Lifecycle",
+	Tags:        []string{"probes", "readiness", "ckad"},
+	Hints:       []string{"The app takes 2s to respond", "Check readinessProbe `timeoutSeconds` (default is 1s)"},
+	DocsLinks: []DocLink{
+		{Title: "Liveness, Readiness and Startup Probes", URL: "https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/"},
+		{Title: "Pod Lifecycle", URL: "https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#container-probes"},
+	},
+}
+
 func (s *ProbeReadinessTimeout) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "probe-readiness-timeout",
-		Name:        "Lifecycle: Readiness Timeout",
-		Description: "The Pod is running but never becomes Ready. The app is slow to respond.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Lifecycle",
-		Hints:       []string{"The app takes 2s to respond", "Check readinessProbe `timeoutSeconds` (default is 1s)"},
-	}
+	return probeReadinessTimeoutMetadata
 }
 
 func (s *ProbeReadinessTimeout) Setup(ctx context.Context) error {
@@ -77,7 +84,20 @@ func (s *ProbeReadinessTimeout) Validate(ctx context.Context) Result {
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
+	return s.validate(pod)
+}
 
+// ValidateManifests implements ManifestValidator.
+func (s *ProbeReadinessTimeout) ValidateManifests(objs Manifests) Result {
+	var pod corev1.Pod
+	if res, ok := objs.Find("Pod", "slow-app", &pod); !ok {
+		return res
+	}
+	return s.validate(&pod)
+}
+
+// validate checks the pod spec, live or from a manifest.
+func (s *ProbeReadinessTimeout) validate(pod *corev1.Pod) Result {
 	if len(pod.Spec.Containers) > 0 {
 		probe := pod.Spec.Containers[0].ReadinessProbe
 		if probe != nil && probe.TimeoutSeconds > 1 {
@@ -87,6 +107,12 @@ func (s *ProbeReadinessTimeout) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "Readiness timeout is still 1s."}
 }
 
+func (s *ProbeReadinessTimeout) Solve(ctx context.Context) error {
+	return recreatePod(ctx, s.clientset, s.Namespace, "slow-app", func(pod *corev1.Pod) {
+		pod.Spec.Containers[0].ReadinessProbe.TimeoutSeconds = 5
+	})
+}
+
 func (s *ProbeReadinessTimeout) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
@@ -0,0 +1,28 @@
+package scenario
+
+import "context"
+
+// Quiz is implemented by scenarios that ask the learner a question about the
+// cluster ("which container is crashing?") instead of, or as well as, asking
+// them to fix it. The learner types an answer in the TUI and it is graded
+// together with Validate.
+type Quiz interface {
+	Question() string
+
+	// CheckAnswer grades a non-empty answer, usually against the live cluster state.
+	CheckAnswer(ctx context.Context, answer string) Check
+}
+
+// GradeAnswer adds the grade of answer to res, the quiz scenario's Validate
+// result. The answer is only graded once everything else passes; an empty
+// answer means none was submitted yet.
+func GradeAnswer(ctx context.Context, q Quiz, res Result, answer string) Result {
+	if !res.Solved {
+		return res
+	}
+	c := Check{Name: "Answer", Details: "Answer the question to finish: " + q.Question()}
+	if answer != "" {
+		c = q.CheckAnswer(ctx, answer)
+	}
+	return NewResult(res.Message, append(res.Checks, c)...)
+}
@@ -0,0 +1,118 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+// quizContainers are the containers of the quiz pod; Setup picks one to crash.
+var quizContainers = []string{"web", "log-shipper", "metrics"}
+
+// QuizCrashingContainer scenario: a quiz rather than a fix. One container of a
+// multi-container pod keeps crashing and the learner names it.
+type QuizCrashingContainer struct {
+	BaseScenario
+	clientset kubernetes.Interface
+}
+
+func NewQuizCrashingContainer(clientset kubernetes.Interface) *QuizCrashingContainer {
+	return &QuizCrashingContainer{
+		BaseScenario: BaseScenario{Namespace: "quiz-crash"},
+		clientset:    clientset,
+	}
+}
+
+var quizCrashingContainerMetadata = Metadata{
+	ID:          "quiz-crashing-container",
+	Name:        "Quiz: Who Keeps Crashing?",
+	Description: "Pod 'storefront' runs three containers and its RESTARTS column keeps climbing. Nothing needs fixing this time: find out which container is at fault.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Lifecycle",
+	Tags:        []string{"crashloop", "logs", "quiz"},
+	Hints: []string{
+		"`kubectl get pod storefront` only shows the total restarts",
+		"`kubectl describe pod storefront` lists the state and restart count of each container",
+		"`kubectl get pod storefront -o jsonpath='{.status.containerStatuses[*].name}'` prints the container names",
+	},
+}
+
+func (s *QuizCrashingContainer) GetMetadata() Metadata {
+	return quizCrashingContainerMetadata
+}
+
+// Question implements Quiz.
+func (s *QuizCrashingContainer) Question() string {
+	return "Which container of pod 'storefront' keeps crashing?"
+}
+
+func (s *QuizCrashingContainer) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// A different culprit each attempt, so replays can't be answered from memory
+	crashing := quizContainers[utilrand.Intn(len(quizContainers))]
+	var containers []corev1.Container
+	for _, name := range quizContainers {
+		command := "while true; do sleep 3600; done"
+		if name == crashing {
+			command = "echo 'starting'; sleep 5; exit 1"
+		}
+		containers = append(containers, corev1.Container{
+			Name:    name,
+			Image:   "busybox",
+			Command: []string{"sh", "-c", command},
+		})
+	}
+
+	_, err = s.clientset.CoreV1().Pods(s.Namespace).Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "storefront", Labels: map[string]string{"app": "storefront"}},
+		Spec:       corev1.PodSpec{Containers: containers},
+	}, metav1.CreateOptions{})
+	return err
+}
+
+// WaitReady blocks until the crashing container has restarted.
+func (s *QuizCrashingContainer) WaitReady(ctx context.Context) error {
+	return waitForPods(ctx, s.clientset, s.Namespace, "app=storefront", containerRestarted)
+}
+
+// Validate only requires the pod to still exist; the answer is graded by CheckAnswer.
+func (s *QuizCrashingContainer) Validate(ctx context.Context) Result {
+	if _, err := s.clientset.CoreV1().Pods(s.Namespace).Get(ctx, "storefront", metav1.GetOptions{}); err != nil {
+		return Result{Solved: false, Message: "Pod 'storefront' is gone. Reset the scenario to bring it back."}
+	}
+	return Result{Solved: true, Message: "Correct! Per-container restart counts point at the culprit even when the pod looks fine overall."}
+}
+
+// CheckAnswer implements Quiz. The culprit is read back from the pod, so
+// grading works without the state of the process that set it up.
+func (s *QuizCrashingContainer) CheckAnswer(ctx context.Context, answer string) Check {
+	c := Check{Name: "Crashing container named"}
+	pod, err := s.clientset.CoreV1().Pods(s.Namespace).Get(ctx, "storefront", metav1.GetOptions{})
+	if err != nil {
+		c.Details = fmt.Sprintf("Failed to read pod 'storefront': %v", err)
+		return c
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.RestartCount > 0 && strings.EqualFold(cs.Name, answer) {
+			c.Passed = true
+			return c
+		}
+	}
+	c.Details = fmt.Sprintf("'%s' is not the crashing container. Compare the containers' restart counts.", answer)
+	return c
+}
+
+func (s *QuizCrashingContainer) Cleanup(ctx context.Context) error {
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
@@ -0,0 +1,143 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s-dojo/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ReadinessGate is implemented by scenarios whose symptom takes time to appear.
+// The engine calls WaitReady after Setup and only presents the scenario once it returns.
+type ReadinessGate interface {
+	// WaitReady blocks until the broken state is observable or ctx is done.
+	WaitReady(ctx context.Context) error
+}
+
+// readyPollInterval is how often readiness conditions are re-evaluated.
+const readyPollInterval = time.Second
+
+// podPredicate reports whether a pod shows the expected state.
+type podPredicate func(pod corev1.Pod) bool
+
+// waitForPods polls until any pod matching the label selector satisfies the predicate.
+// An empty selector matches every pod in the namespace.
+func waitForPods(ctx context.Context, clientset kubernetes.Interface, namespace, selector string, pred podPredicate) error {
+	var last string
+	return wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, nil // Transient errors are retried until ctx expires
+		}
+		for _, pod := range pods.Items {
+			if pred(pod) {
+				return true, nil
+			}
+		}
+		if step := waitingStep(pods.Items); step != last {
+			k8s.ReportProgress(ctx, "%s", step)
+			last = step
+		}
+		return false, nil
+	})
+}
+
+// waitingStep describes what pods are doing while waitForPods waits for them.
+func waitingStep(pods []corev1.Pod) string {
+	if len(pods) == 0 {
+		return "Waiting for pods to be created…"
+	}
+	pod := pods[0]
+	state := string(pod.Status.Phase)
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			state = cs.State.Waiting.Reason
+			break
+		}
+	}
+	return fmt.Sprintf("Waiting for pod %s (%s)…", pod.Name, state)
+}
+
+// waitForEvent polls until an event with the given reason is recorded in the namespace.
+func waitForEvent(ctx context.Context, clientset kubernetes.Interface, namespace, reason string) error {
+	return wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+		events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: "reason=" + reason,
+		})
+		if err != nil {
+			return false, nil
+		}
+		return len(events.Items) > 0, nil
+	})
+}
+
+// containerWaiting matches pods with a container waiting for one of the reasons.
+func containerWaiting(reasons ...string) podPredicate {
+	return func(pod corev1.Pod) bool {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting == nil {
+				continue
+			}
+			for _, r := range reasons {
+				if cs.State.Waiting.Reason == r {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// containerRestarted matches pods where a container has restarted at least once.
+func containerRestarted(pod corev1.Pod) bool {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.RestartCount > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// initContainerFailed matches pods with an init container that exited non-zero.
+func initContainerFailed(pod corev1.Pod) bool {
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.LastTerminationState.Terminated != nil && cs.LastTerminationState.Terminated.ExitCode != 0 {
+			return true
+		}
+		if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// podUnschedulable matches pods the scheduler has rejected.
+func podUnschedulable(pod corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+			return true
+		}
+	}
+	return false
+}
+
+// podRunning matches pods in the Running phase.
+func podRunning(pod corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodRunning
+}
+
+// podReady matches pods whose Ready condition is true, i.e. that receive Service traffic.
+func podReady(pod corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -2,80 +2,340 @@
 package scenario
 
 import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"k8s-dojo/pkg/k8s"
+
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
-// Registry holds all available scenarios.
+// Deps are the per-session dependencies scenarios are constructed with.
+type Deps struct {
+	Clientset kubernetes.Interface
+	Config    *rest.Config      // For exec; nil disables exec-based checks
+	Dynamic   dynamic.Interface // For CRDs and custom resources
+	Client    *k8s.Client       // For scenarios that apply manifests
+
+	// NamespaceSuffix is appended to the namespaces of the scenarios, e.g.
+	// a session ID, so sessions sharing a cluster get their own namespaces.
+	// Cluster-scoped objects such as CRDs, ClusterRoles and node taints are
+	// not suffixed and are still shared.
+	NamespaceSuffix string
+}
+
+// DepsFor returns the dependencies backed by client.
+func DepsFor(client *k8s.Client) Deps {
+	return Deps{Clientset: client.Clientset, Config: client.Config, Dynamic: client.Dynamic, Client: client}
+}
+
+// Applier applies manifests; *k8s.Client implements it.
+type Applier interface {
+	ApplyYAML(ctx context.Context, manifest string, defaultNamespace string) error
+}
+
+// applier returns Client as an Applier, nil if there is none.
+func (d Deps) applier() Applier {
+	if d.Client == nil {
+		return nil
+	}
+	return d.Client
+}
+
+// Factory constructs a scenario.
+type Factory func(Deps) Scenario
+
+// builtin registers a Go scenario: its metadata, static so it can be listed
+// without constructing the scenario, and its factory.
+type builtin struct {
+	meta Metadata
+	new  Factory
+}
+
+// builtins are the Go scenarios, in listing order.
+// Each one's GetMetadata returns the metadata registered here.
+var builtins = []builtin{
+	// Networking
+	{netServiceSelectorMetadata, func(d Deps) Scenario { return NewNetServiceSelector(d.Clientset) }},
+	{netGrpcBalanceMetadata, func(d Deps) Scenario { return NewNetGrpcBalance(d.Clientset) }},
+	{netSourceIPMetadata, func(d Deps) Scenario { return NewNetSourceIP(d.Clientset) }},
+	{netDNSNdotsMetadata, func(d Deps) Scenario { return NewNetDNSNdots(d.Clientset) }},
+	{netPolDNSBlockMetadata, func(d Deps) Scenario { return NewNetPolDNSBlock(d.Clientset, d.Config) }},
+
+	// Lifecycle
+	{imagePullBackOffMetadata, func(d Deps) Scenario { return NewImagePullBackOff(d.Clientset) }},
+	{lifeCrashConfigMetadata, func(d Deps) Scenario { return NewLifeCrashConfig(d.Clientset) }},
+	{lifeGracefulShutdownMetadata, func(d Deps) Scenario { return NewLifeGracefulShutdown(d.Clientset) }},
+	{lifeRolloutUndoMetadata, func(d Deps) Scenario { return NewLifeRolloutUndo(d.Clientset) }},
+	{quizCrashingContainerMetadata, func(d Deps) Scenario { return NewQuizCrashingContainer(d.Clientset) }},
+
+	// Scheduling
+	{schedNodeAffinityMetadata, func(d Deps) Scenario { return NewSchedNodeAffinity(d.Clientset) }},
+	{schedMissingSchedulerMetadata, func(d Deps) Scenario { return NewSchedMissingScheduler(d.Clientset) }},
+
+	// Security
+	{secRBACForbiddenMetadata, func(d Deps) Scenario { return NewSecRBACForbidden(d.Clientset) }},
+	{secPrivilegedPolicyMetadata, func(d Deps) Scenario { return NewSecPrivilegedPolicy(d.Clientset, d.applier()) }},
+	{secImageDigestMetadata, func(d Deps) Scenario { return NewSecImageDigest(d.Clientset) }},
+
+	// Storage
+	{storagePVCPendingMetadata, func(d Deps) Scenario { return NewStoragePVCPending(d.Clientset) }},
+	{storageZonalAffinityMetadata, func(d Deps) Scenario { return NewStorageZonalAffinity(d.Clientset) }},
+
+	// Ops & Kernel
+	{kernelOOMDisableMetadata, func(d Deps) Scenario { return NewKernelOOMDisable(d.Clientset) }},
+	{opsConfigChecksumMetadata, func(d Deps) Scenario { return NewOpsConfigChecksum(d.Clientset) }},
+	{opsUpgradeDrillMetadata, func(d Deps) Scenario { return NewOpsUpgradeDrill(d.Clientset) }},
+	{opsEtcdBackupMetadata, func(d Deps) Scenario { return NewOpsEtcdBackup(d.Clientset, d.Config) }},
+	{opsCertExpiryMetadata, func(d Deps) Scenario { return NewOpsCertExpiry(d.Clientset) }},
+	{opsBlueGreenMetadata, func(d Deps) Scenario { return NewOpsBlueGreen(d.Clientset) }},
+
+	// Batch 3
+	{netTargetPortMismatchMetadata, func(d Deps) Scenario { return NewNetTargetPortMismatch(d.Clientset, d.Config) }},
+	{ingressPathErrorMetadata, func(d Deps) Scenario { return NewIngressPathError(d.Clientset) }},
+	{ingressTLSMismatchMetadata, func(d Deps) Scenario { return NewIngressTLSMismatch(d.Clientset) }},
+
+	{probeLivenessFailMetadata, func(d Deps) Scenario { return NewProbeLivenessFail(d.Clientset) }},
+	{probeReadinessTimeoutMetadata, func(d Deps) Scenario { return NewProbeReadinessTimeout(d.Clientset) }},
+	{initContainerCrashMetadata, func(d Deps) Scenario { return NewInitContainerCrash(d.Clientset) }},
+	{podFinalizerStuckMetadata, func(d Deps) Scenario { return NewPodFinalizerStuck(d.Clientset) }},
+
+	{schedTaintTolerationMetadata, func(d Deps) Scenario { return NewSchedTaintToleration(d.Clientset) }},
+
+	{secFSGroupDeniedMetadata, func(d Deps) Scenario { return NewSecFSGroupDenied(d.Clientset) }},
+	{secSANoMountMetadata, func(d Deps) Scenario { return NewSecSANoMount(d.Clientset) }},
+
+	{storageSubpathOverwriteMetadata, func(d Deps) Scenario { return NewStorageSubpathOverwrite(d.Clientset) }},
+
+	{resourceQuotaExceededMetadata, func(d Deps) Scenario { return NewResourceQuotaExceeded(d.Clientset) }},
+	{resourceLimitRangeMetadata, func(d Deps) Scenario { return NewResourceLimitRange(d.Clientset) }},
+	{resNoisyNeighborMetadata, func(d Deps) Scenario { return NewResNoisyNeighbor(d.Clientset, d.applier()) }},
+	{resVPARightSizeMetadata, func(d Deps) Scenario { return NewResVPARightSize(d.Clientset, d.Dynamic, d.applier()) }},
+
+	// Operators
+	{opCRDSchemaRejectMetadata, func(d Deps) Scenario { return NewOpCRDSchemaReject(d.Clientset, d.Dynamic, d.applier()) }},
+	{opCRDMissingMetadata, func(d Deps) Scenario { return NewOpCRDMissing(d.Clientset, d.Dynamic, d.applier()) }},
+}
+
+// entry is a registered scenario, constructed on first use.
+type entry struct {
+	meta Metadata
+	new  func() Scenario
+	def  *Definition // Set for YAML scenarios
+
+	mu       sync.Mutex
+	scenario Scenario
+}
+
+func (e *entry) get() Scenario {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.scenario == nil {
+		e.scenario = e.new()
+	}
+	return e.scenario
+}
+
+// metadata returns the scenario's metadata without constructing it.
+func (e *entry) metadata() Metadata {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.scenario != nil {
+		return e.scenario.GetMetadata()
+	}
+	return e.meta
+}
+
+// setDefinition swaps in a reloaded YAML definition.
+func (e *entry) setDefinition(def *Definition) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.def = def
+	e.meta = def.metadata()
+	if y, ok := e.scenario.(*YAMLScenario); ok {
+		y.SetDefinition(def)
+	}
+}
+
+// Registry holds all available scenarios. Scenarios are registered as
+// metadata plus a factory and only constructed when first used, so listing
+// stays cheap with many packs installed.
+// It is safe for concurrent use so YAML scenarios can be reloaded while the TUI runs.
 type Registry struct {
-	scenarios []Scenario
+	deps Deps // Scenarios are constructed with these
+
+	mu      sync.RWMutex
+	entries []*entry
 }
 
 // NewRegistry creates a new scenario registry with all available scenarios.
-func NewRegistry(clientset *kubernetes.Clientset) *Registry {
-	return &Registry{
-		scenarios: []Scenario{
-			// Networking
-			NewNetServiceSelector(clientset),
-			NewNetGrpcBalance(clientset),
-			NewNetSourceIP(clientset),
-			NewNetDNSNdots(clientset),
-			NewNetPolDNSBlock(clientset),
-
-			// Lifecycle
-			NewImagePullBackOff(clientset),
-			NewLifeCrashConfig(clientset),
-			NewLifeGracefulShutdown(clientset),
+// They are constructed with the dependencies of client when first used.
+func NewRegistry(client *k8s.Client) *Registry {
+	return NewSessionRegistry(DepsFor(client))
+}
 
-			// Scheduling
-			NewSchedNodeAffinity(clientset),
-			NewSchedMissingScheduler(clientset),
+// NewSessionRegistry creates a registry whose scenarios, Go and YAML, are
+// constructed with deps when first used.
+func NewSessionRegistry(deps Deps) *Registry {
+	r := &Registry{deps: deps}
+	for _, b := range builtins {
+		r.entries = append(r.entries, &entry{meta: b.meta, new: func() Scenario { return b.construct(deps) }})
+	}
+	return r
+}
 
-			// Security
-			NewSecRBACForbidden(clientset),
-			NewSecPrivilegedPolicy(clientset),
-			NewSecImageDigest(clientset),
+// construct builds the scenario with deps, in the session's namespaces.
+func (b builtin) construct(deps Deps) Scenario {
+	return inSession(b.new(deps), deps)
+}
 
-			// Storage
-			NewStoragePVCPending(clientset),
-			NewStorageZonalAffinity(clientset),
+// inSession appends the session's namespace suffix to s, if any.
+func inSession(s Scenario, deps Deps) Scenario {
+	if n, ok := s.(interface{ suffixNamespace(string) }); ok && deps.NamespaceSuffix != "" {
+		n.suffixNamespace(deps.NamespaceSuffix)
+	}
+	return s
+}
 
-			// Ops & Kernel
-			NewKernelOOMDisable(clientset),
-			NewOpsConfigChecksum(clientset),
+// yamlEntry registers a YAML definition.
+func (r *Registry) yamlEntry(def *Definition) *entry {
+	e := &entry{meta: def.metadata(), def: def}
+	e.new = func() Scenario {
+		return inSession(NewYAMLScenario(r.deps.Client, e.def), r.deps)
+	}
+	return e
+}
 
-			// Batch 3
-			NewNetTargetPortMismatch(clientset),
-			NewIngressPathError(clientset),
-			NewIngressTLSMismatch(clientset),
+// LoadYAML adds the YAML scenarios found in dir to the registry.
+// Definitions whose ID is already registered are skipped.
+func (r *Registry) LoadYAML(dir string) error {
+	defs, err := LoadDefinitions(dir)
+	if err != nil {
+		return err
+	}
+	r.add(defs)
+	return nil
+}
 
-			NewProbeLivenessFail(clientset),
-			NewProbeReadinessTimeout(clientset),
-			NewInitContainerCrash(clientset),
-			NewPodFinalizerStuck(clientset),
+// LoadPack adds the YAML scenarios of an installed pack, grouped under category.
+// Scenarios whose scripts may not run, see Definition.RefusePackScripts,
+// are refused.
+func (r *Registry) LoadPack(dir, category string, trustScripts bool) error {
+	defs, err := LoadDefinitions(dir)
+	if err != nil {
+		return err
+	}
+	for _, def := range defs {
+		if err := def.RefusePackScripts(trustScripts); err != nil {
+			return err
+		}
+		def.Category = category
+	}
+	r.add(defs)
+	return nil
+}
 
-			NewSchedTaintToleration(clientset),
+func (r *Registry) add(defs []*Definition) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, def := range defs {
+		if r.find(def.ID) != nil {
+			continue
+		}
+		r.entries = append(r.entries, r.yamlEntry(def))
+	}
+}
 
-			NewSecFSGroupDenied(clientset),
-			NewSecSANoMount(clientset),
+// ReloadYAML re-reads dir and syncs the registry's YAML scenarios from it.
+// Existing scenarios are updated in place, so a running scenario picks up
+// new hints and checks; definitions whose file was removed are dropped.
+// On a parse error the registry is left unchanged.
+func (r *Registry) ReloadYAML(dir string) error {
+	defs, err := LoadDefinitions(dir)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]*Definition, len(defs))
+	for _, def := range defs {
+		byID[def.ID] = def
+	}
 
-			NewStorageSubpathOverwrite(clientset),
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-			NewResourceQuotaExceeded(clientset),
-			NewResourceLimitRange(clientset),
-		},
+	kept := r.entries[:0:0]
+	for _, e := range r.entries {
+		if e.def == nil || !inDir(e.def.Source, dir) {
+			kept = append(kept, e)
+			continue
+		}
+		def, ok := byID[e.meta.ID]
+		if !ok {
+			continue
+		}
+		e.setDefinition(def)
+		delete(byID, def.ID)
+		kept = append(kept, e)
 	}
+	for _, def := range defs {
+		if _, isNew := byID[def.ID]; isNew && findByID(kept, def.ID) == nil {
+			kept = append(kept, r.yamlEntry(def))
+		}
+	}
+	r.entries = kept
+	return nil
+}
+
+// inDir reports whether file lives directly in dir.
+func inDir(file, dir string) bool {
+	return filepath.Clean(filepath.Dir(file)) == filepath.Clean(dir)
 }
 
-// List returns all available scenarios.
+// List returns all available scenarios, constructing any not used yet.
+// Use Metadata when only their descriptions are needed.
 func (r *Registry) List() []Scenario {
-	return r.scenarios
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	scenarios := make([]Scenario, len(r.entries))
+	for i, e := range r.entries {
+		scenarios[i] = e.get()
+	}
+	return scenarios
+}
+
+// Metadata returns the metadata of all available scenarios without
+// constructing them.
+func (r *Registry) Metadata() []Metadata {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	metas := make([]Metadata, len(r.entries))
+	for i, e := range r.entries {
+		metas[i] = e.metadata()
+	}
+	return metas
 }
 
 // Get returns a scenario by its ID.
 func (r *Registry) Get(id string) Scenario {
-	for _, s := range r.scenarios {
-		if s.GetMetadata().ID == id {
-			return s
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if e := r.find(id); e != nil {
+		return e.get()
+	}
+	return nil
+}
+
+func (r *Registry) find(id string) *entry {
+	return findByID(r.entries, id)
+}
+
+func findByID(entries []*entry, id string) *entry {
+	for _, e := range entries {
+		if e.meta.ID == id {
+			return e
 		}
 	}
 	return nil
@@ -83,5 +343,7 @@ func (r *Registry) Get(id string) Scenario {
 
 // Count returns the number of available scenarios.
 func (r *Registry) Count() int {
-	return len(r.scenarios)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.entries)
 }
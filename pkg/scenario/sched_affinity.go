@@ -11,25 +11,31 @@ import (
 // SchedNodeAffinity scenario: GPU scheduling using Node Affinity.
 type SchedNodeAffinity struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewSchedNodeAffinity(clientset *kubernetes.Clientset) *SchedNodeAffinity {
+func NewSchedNodeAffinity(clientset kubernetes.Interface) *SchedNodeAffinity {
 	return &SchedNodeAffinity{
 		BaseScenario: BaseScenario{Namespace: "sched-affinity"},
 		clientset:    clientset,
 	}
 }
 
+var schedNodeAffinityMetadata = Metadata{
+	ID:          "sched-node-affinity",
+	Name:        "Scheduling: The Sticky GPU",
+	Description: "A Pod requesting 'special' hardware is Pending. Force it to run on the node labeled 'hardware=gpu'.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Scheduling",
+	Tags:        []string{"affinity", "scheduling", "nodes", "cka"},
+	Hints:       []string{"Tolerations are not enough", "Use NodeAffinity", "The node already has label 'hardware=gpu'"},
+	DocsLinks: []DocLink{
+		{Title: "Assigning Pods to Nodes", URL: "https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/"},
+	},
+}
+
 func (s *SchedNodeAffinity) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "sched-node-affinity",
-		Name:        "Scheduling: The Sticky GPU",
-		Description: "A Pod requesting 'special' hardware is Pending. Force it to run on the node labeled 'hardware=gpu'.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Scheduling",
-		Hints:       []string{"Tolerations are not enough", "Use NodeAffinity", "The node already has label 'hardware=gpu'"},
-	}
+	return schedNodeAffinityMetadata
 }
 
 func (s *SchedNodeAffinity) Setup(ctx context.Context) error {
@@ -75,6 +81,24 @@ func (s *SchedNodeAffinity) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "Pod spec does not have NodeAffinity configured."}
 }
 
+func (s *SchedNodeAffinity) Solve(ctx context.Context) error {
+	return recreatePod(ctx, s.clientset, s.Namespace, "gpu-workload", func(pod *corev1.Pod) {
+		pod.Spec.Affinity = &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "hardware",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"gpu"},
+						}},
+					}},
+				},
+			},
+		}
+	})
+}
+
 func (s *SchedNodeAffinity) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -11,25 +13,28 @@ import (
 // SchedMissingScheduler scenario: Pod Pending due to non-existent scheduler.
 type SchedMissingScheduler struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewSchedMissingScheduler(clientset *kubernetes.Clientset) *SchedMissingScheduler {
+func NewSchedMissingScheduler(clientset kubernetes.Interface) *SchedMissingScheduler {
 	return &SchedMissingScheduler{
 		BaseScenario: BaseScenario{Namespace: "sched-missing"},
 		clientset:    clientset,
 	}
 }
 
+var schedMissingSchedulerMetadata = Metadata{
+	ID:          "sched-missing-scheduler",
+	Name:        "Scheduling: The Ghost Scheduler",
+	Description: "Pod is stuck in Pending state forever. Investigate why.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Scheduling",
+	Tags:        []string{"scheduler", "scheduling", "cka"},
+	Hints:       []string{"Check `kubectl describe pod` events", "Look at `schedulerName` in Pod spec"},
+}
+
 func (s *SchedMissingScheduler) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "sched-missing-scheduler",
-		Name:        "Scheduling: The Ghost Scheduler",
-		Description: "Pod is stuck in Pending state forever. Investigate why.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Scheduling",
-		Hints:       []string{"Check `kubectl describe pod` events", "Look at `schedulerName` in Pod spec"},
-	}
+	return schedMissingSchedulerMetadata
 }
 
 func (s *SchedMissingScheduler) Setup(ctx context.Context) error {
@@ -57,13 +62,18 @@ func (s *SchedMissingScheduler) Validate(ctx context.Context) Result {
 		return Result{Solved: false, Message: err.Error()}
 	}
 
-	if pod.Spec.SchedulerName == "default-scheduler" || pod.Spec.SchedulerName == "" {
-		if pod.Status.Phase == corev1.PodRunning {
-			return Result{Solved: true, Message: "Success! Pod is running."}
-		}
-		return Result{Solved: false, Message: "Scheduler fixed, waiting for Pod to start..."}
-	}
-	return Result{Solved: false, Message: "Pod still using invalid scheduler: " + pod.Spec.SchedulerName}
+	return NewResult("Success! Pod is running.",
+		check.FieldEquals("Scheduler", pod.Spec.SchedulerName, corev1.DefaultSchedulerName).
+			OrElse("Pod still using invalid scheduler: "+pod.Spec.SchedulerName),
+		check.PodRunning(ctx, s.clientset, s.Namespace, "custom-pod").
+			OrElse("Scheduler fixed, waiting for Pod to start..."),
+	)
+}
+
+func (s *SchedMissingScheduler) Solve(ctx context.Context) error {
+	return recreatePod(ctx, s.clientset, s.Namespace, "custom-pod", func(pod *corev1.Pod) {
+		pod.Spec.SchedulerName = corev1.DefaultSchedulerName
+	})
 }
 
 func (s *SchedMissingScheduler) Cleanup(ctx context.Context) error {
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -11,25 +13,31 @@ import (
 // SchedTaintToleration scenario: Pod pending due to NoSchedule taint.
 type SchedTaintToleration struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewSchedTaintToleration(clientset *kubernetes.Clientset) *SchedTaintToleration {
+func NewSchedTaintToleration(clientset kubernetes.Interface) *SchedTaintToleration {
 	return &SchedTaintToleration{
 		BaseScenario: BaseScenario{Namespace: "sched-taint"},
 		clientset:    clientset,
 	}
 }
 
+var schedTaintTolerationMetadata = Metadata{
+	ID:          "sched-taint-toleration",
+	Name:        "Scheduling: Forbidden Node",
+	Description: "Pod is Pending. describe shows '1 node(s) had untolerated taint {dedicated: db}'.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Scheduling",
+	Tags:        []string{"taints", "tolerations", "scheduling", "cka"},
+	Hints:       []string{"Add a `toleration` to the Pod", "Match key, value, and effect"},
+	DocsLinks: []DocLink{
+		{Title: "Taints and Tolerations", URL: "https://kubernetes.io/docs/concepts/scheduling-eviction/taint-and-toleration/"},
+	},
+}
+
 func (s *SchedTaintToleration) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "sched-taint-toleration",
-		Name:        "Scheduling: Forbidden Node",
-		Description: "Pod is Pending. describe shows '1 node(s) had untolerated taint {dedicated: db}'.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Scheduling",
-		Hints:       []string{"Add a `toleration` to the Pod", "Match key, value, and effect"},
-	}
+	return schedTaintTolerationMetadata
 }
 
 func (s *SchedTaintToleration) Setup(ctx context.Context) error {
@@ -65,16 +73,31 @@ func (s *SchedTaintToleration) Setup(ctx context.Context) error {
 	return err
 }
 
+// WaitReady blocks until the scheduler has rejected the pod.
+func (s *SchedTaintToleration) WaitReady(ctx context.Context) error {
+	return waitForPods(ctx, s.clientset, s.Namespace, "", podUnschedulable)
+}
+
 func (s *SchedTaintToleration) Validate(ctx context.Context) Result {
-	pod, err := s.clientset.CoreV1().Pods(s.Namespace).Get(ctx, "db-pod", metav1.GetOptions{})
-	if err != nil {
-		return Result{Solved: false, Message: err.Error()}
-	}
+	return NewResult("Success! Pod is running.",
+		check.PodRunning(ctx, s.clientset, s.Namespace, "db-pod"),
+	)
+}
 
-	if pod.Status.Phase == corev1.PodRunning {
-		return Result{Solved: true, Message: "Success! Pod is running."}
-	}
-	return Result{Solved: false, Message: "Pod is Pending."}
+func (s *SchedTaintToleration) Solve(ctx context.Context) error {
+	return recreatePod(ctx, s.clientset, s.Namespace, "db-pod", func(pod *corev1.Pod) {
+		pod.Spec.Tolerations = append(pod.Spec.Tolerations, corev1.Toleration{
+			Key:      "dedicated",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "db",
+			Effect:   corev1.TaintEffectNoSchedule,
+		})
+	})
+}
+
+// CleanupSteps implements CleanupDescriber.
+func (s *SchedTaintToleration) CleanupSteps() []CleanupStep {
+	return []CleanupStep{{Description: "dedicated taint on the node", Command: "kubectl taint nodes --all dedicated-"}}
 }
 
 func (s *SchedTaintToleration) Cleanup(ctx context.Context) error {
@@ -0,0 +1,122 @@
+package scenario
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Search weights: a tag says more about a scenario than words in its description.
+const (
+	tagWeight         = 3
+	titleWeight       = 2
+	descriptionWeight = 1
+)
+
+// Index is a free-form search index over scenario metadata.
+type Index struct {
+	entries []indexEntry
+}
+
+type indexEntry struct {
+	scenario Scenario
+	tags     map[string]bool
+	title    []string // Words of the ID, name and category
+	words    []string // Words of the description
+}
+
+// NewIndex indexes the scenarios' IDs, names, categories, tags and descriptions.
+func NewIndex(scenarios []Scenario) *Index {
+	idx := &Index{}
+	for _, s := range scenarios {
+		meta := s.GetMetadata()
+		e := indexEntry{scenario: s, tags: map[string]bool{}}
+		for _, t := range meta.Tags {
+			e.tags[strings.ToLower(t)] = true
+		}
+		e.title = searchWords(meta.ID + " " + meta.Name + " " + meta.Category)
+		e.words = searchWords(meta.Description)
+		idx.entries = append(idx.entries, e)
+	}
+	return idx
+}
+
+// Search returns the scenarios matching every term of query, best matches
+// first. A term matches a tag exactly or a word by prefix; `tag:dns` only
+// matches the tag. An empty query returns every scenario.
+func (idx *Index) Search(query string) []Scenario {
+	terms := strings.Fields(strings.ToLower(query))
+
+	type hit struct {
+		scenario Scenario
+		score    int
+	}
+	var hits []hit
+	for _, e := range idx.entries {
+		total := 0
+		for _, term := range terms {
+			score := e.score(term)
+			if score == 0 {
+				total = 0
+				break
+			}
+			total += score
+		}
+		if total > 0 || len(terms) == 0 {
+			hits = append(hits, hit{e.scenario, total})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+
+	out := make([]Scenario, len(hits))
+	for i, h := range hits {
+		out[i] = h.scenario
+	}
+	return out
+}
+
+// score rates how well a single lower-case term matches the entry, 0 for no match.
+func (e indexEntry) score(term string) int {
+	if tag, ok := strings.CutPrefix(term, "tag:"); ok {
+		if e.tags[tag] {
+			return tagWeight
+		}
+		return 0
+	}
+	if e.tags[term] {
+		return tagWeight
+	}
+	if hasPrefix(e.title, term) {
+		return titleWeight
+	}
+	if hasPrefix(e.words, term) {
+		return descriptionWeight
+	}
+	return 0
+}
+
+// HasTag reports whether the scenario is tagged with tag, ignoring case.
+func (m Metadata) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchWords splits text into lower-case words at anything but letters and digits.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func hasPrefix(words []string, prefix string) bool {
+	for _, w := range words {
+		if strings.HasPrefix(w, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package scenario
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -13,25 +14,28 @@ import (
 // SecImageDigest scenario: Enforce image digest.
 type SecImageDigest struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewSecImageDigest(clientset *kubernetes.Clientset) *SecImageDigest {
+func NewSecImageDigest(clientset kubernetes.Interface) *SecImageDigest {
 	return &SecImageDigest{
 		BaseScenario: BaseScenario{Namespace: "sec-digest"},
 		clientset:    clientset,
 	}
 }
 
+var secImageDigestMetadata = Metadata{
+	ID:          "sec-image-digest",
+	Name:        "Security: Supply Chain Integrity",
+	Description: "The Deployment uses a mutable tag `nginx:latest`. Update it to use an immutable SHA256 digest.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Security",
+	Tags:        []string{"images", "supply-chain", "cks"},
+	Hints:       []string{"Find the digest for nginx:latest", "Update image field to use name@sha256:..."},
+}
+
 func (s *SecImageDigest) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "sec-image-digest",
-		Name:        "Security: Supply Chain Integrity",
-		Description: "The Deployment uses a mutable tag `nginx:latest`. Update it to use an immutable SHA256 digest.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Security",
-		Hints:       []string{"Find the digest for nginx:latest", "Update image field to use name@sha256:..."},
-	}
+	return secImageDigestMetadata
 }
 
 func (s *SecImageDigest) Setup(ctx context.Context) error {
@@ -78,6 +82,29 @@ func (s *SecImageDigest) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "Image is still using a tag, not a digest."}
 }
 
+func (s *SecImageDigest) Solve(ctx context.Context) error {
+	// Pin to the digest the running pod actually pulled
+	pods, err := s.clientset.CoreV1().Pods(s.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app=web"})
+	if err != nil {
+		return err
+	}
+	digest := ""
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if i := strings.Index(cs.ImageID, "@sha256:"); i >= 0 {
+				digest = cs.ImageID[i:]
+			}
+		}
+	}
+	if digest == "" {
+		return fmt.Errorf("no pulled image digest found for deployment web")
+	}
+
+	return updateDeployment(ctx, s.clientset, s.Namespace, "web", func(dep *appsv1.Deployment) {
+		dep.Spec.Template.Spec.Containers[0].Image = "nginx" + digest
+	})
+}
+
 func (s *SecImageDigest) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -11,25 +13,28 @@ import (
 // SecFSGroupDenied scenario: User cannot write to volume.
 type SecFSGroupDenied struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewSecFSGroupDenied(clientset *kubernetes.Clientset) *SecFSGroupDenied {
+func NewSecFSGroupDenied(clientset kubernetes.Interface) *SecFSGroupDenied {
 	return &SecFSGroupDenied{
 		BaseScenario: BaseScenario{Namespace: "sec-fsgroup"},
 		clientset:    clientset,
 	}
 }
 
+var secFSGroupDeniedMetadata = Metadata{
+	ID:          "sec-fsgroup-denied",
+	Name:        "Security: Permission Denied",
+	Description: "Container running as user 1000 cannot write to the mounted volume.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Security",
+	Tags:        []string{"securitycontext", "fsgroup", "volumes", "cks"},
+	Hints:       []string{"Volume is owned by root", "Use `securityContext.fsGroup` to change volume ownership"},
+}
+
 func (s *SecFSGroupDenied) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "sec-fsgroup-denied",
-		Name:        "Security: Permission Denied",
-		Description: "Container running as user 1000 cannot write to the mounted volume.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Security",
-		Hints:       []string{"Volume is owned by root", "Use `securityContext.fsGroup` to change volume ownership"},
-	}
+	return secFSGroupDeniedMetadata
 }
 
 func (s *SecFSGroupDenied) Setup(ctx context.Context) error {
@@ -74,12 +79,20 @@ func (s *SecFSGroupDenied) Validate(ctx context.Context) Result {
 		return Result{Solved: false, Message: err.Error()}
 	}
 
+	var fsGroup int64
 	if pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.FSGroup != nil {
-		if *pod.Spec.SecurityContext.FSGroup == 1000 {
-			return Result{Solved: true, Message: "Success! FSGroup configured."}
-		}
+		fsGroup = *pod.Spec.SecurityContext.FSGroup
 	}
-	return Result{Solved: false, Message: "FSGroup missing or incorrect."}
+	return NewResult("Success! FSGroup configured.",
+		check.FieldEquals("FSGroup", fsGroup, int64(1000)).OrElse("FSGroup missing or incorrect."),
+	)
+}
+
+func (s *SecFSGroupDenied) Solve(ctx context.Context) error {
+	fsGroup := int64(1000)
+	return recreatePod(ctx, s.clientset, s.Namespace, "writer", func(pod *corev1.Pod) {
+		pod.Spec.SecurityContext.FSGroup = &fsGroup
+	})
 }
 
 func (s *SecFSGroupDenied) Cleanup(ctx context.Context) error {
@@ -12,25 +12,52 @@ import (
 // SecPrivilegedPolicy scenario: Fix privileged pod.
 type SecPrivilegedPolicy struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
+	applier   Applier
 }
 
-func NewSecPrivilegedPolicy(clientset *kubernetes.Clientset) *SecPrivilegedPolicy {
+func NewSecPrivilegedPolicy(clientset kubernetes.Interface, applier Applier) *SecPrivilegedPolicy {
 	return &SecPrivilegedPolicy{
 		BaseScenario: BaseScenario{Namespace: "sec-priv"},
 		clientset:    clientset,
+		applier:      applier,
 	}
 }
 
+const secPrivilegedManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: risky-app
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: risky
+  template:
+    metadata:
+      labels:
+        app: risky
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:alpine
+        securityContext:
+          privileged: true
+`
+
+var secPrivilegedPolicyMetadata = Metadata{
+	ID:          "sec-privileged-policy",
+	Name:        "Security: The Privileged Container",
+	Description: "A Deployment is running with `privileged: true`. Harden it by removing this flag.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Security",
+	Tags:        []string{"pod-security", "securitycontext", "cks"},
+	Hints:       []string{"Edit the deployment", "Look for `privileged: true` in securityContext"},
+}
+
 func (s *SecPrivilegedPolicy) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "sec-privileged-policy",
-		Name:        "Security: The Privileged Container",
-		Description: "A Deployment is running with `privileged: true`. Harden it by removing this flag.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Security",
-		Hints:       []string{"Edit the deployment", "Look for `privileged: true` in securityContext"},
-	}
+	return secPrivilegedPolicyMetadata
 }
 
 func (s *SecPrivilegedPolicy) Setup(ctx context.Context) error {
@@ -41,29 +68,7 @@ func (s *SecPrivilegedPolicy) Setup(ctx context.Context) error {
 		return err
 	}
 
-	privileged := true
-	replicas := int32(1)
-	_, err = s.clientset.AppsV1().Deployments(s.Namespace).Create(ctx, &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{Name: "risky-app"},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
-			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "risky"}},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "risky"}},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{{
-						Name:  "nginx",
-						Image: "nginx:alpine",
-						SecurityContext: &corev1.SecurityContext{
-							Privileged: &privileged,
-						},
-					}},
-				},
-			},
-		},
-	}, metav1.CreateOptions{})
-
-	return err
+	return s.applier.ApplyYAML(ctx, secPrivilegedManifest, s.Namespace)
 }
 
 func (s *SecPrivilegedPolicy) Validate(ctx context.Context) Result {
@@ -71,7 +76,20 @@ func (s *SecPrivilegedPolicy) Validate(ctx context.Context) Result {
 	if err != nil {
 		return Result{Solved: false, Message: err.Error()}
 	}
+	return s.validate(dep)
+}
+
+// ValidateManifests implements ManifestValidator.
+func (s *SecPrivilegedPolicy) ValidateManifests(objs Manifests) Result {
+	var dep appsv1.Deployment
+	if res, ok := objs.Find("Deployment", "risky-app", &dep); !ok {
+		return res
+	}
+	return s.validate(&dep)
+}
 
+// validate checks the deployment spec, live or from a manifest.
+func (s *SecPrivilegedPolicy) validate(dep *appsv1.Deployment) Result {
 	if len(dep.Spec.Template.Spec.Containers) > 0 {
 		sc := dep.Spec.Template.Spec.Containers[0].SecurityContext
 		if sc == nil || sc.Privileged == nil || *sc.Privileged == false {
@@ -81,6 +99,12 @@ func (s *SecPrivilegedPolicy) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "Container is still privileged."}
 }
 
+func (s *SecPrivilegedPolicy) Solve(ctx context.Context) error {
+	return updateDeployment(ctx, s.clientset, s.Namespace, "risky-app", func(dep *appsv1.Deployment) {
+		dep.Spec.Template.Spec.Containers[0].SecurityContext = nil
+	})
+}
+
 func (s *SecPrivilegedPolicy) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
@@ -12,25 +12,31 @@ import (
 // SecRBACForbidden scenario: Role missing permissions.
 type SecRBACForbidden struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewSecRBACForbidden(clientset *kubernetes.Clientset) *SecRBACForbidden {
+func NewSecRBACForbidden(clientset kubernetes.Interface) *SecRBACForbidden {
 	return &SecRBACForbidden{
 		BaseScenario: BaseScenario{Namespace: "sec-rbac"},
 		clientset:    clientset,
 	}
 }
 
+var secRBACForbiddenMetadata = Metadata{
+	ID:          "rbac-forbidden",
+	Name:        "Security: Access Denied",
+	Description: "The 'intern' service account cannot list pods. Fix the Role permissions.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Security",
+	Tags:        []string{"rbac", "serviceaccounts", "cka", "cks"},
+	Hints:       []string{"Use `kubectl get role`", "Edit the Role to add 'list' verb"},
+	DocsLinks: []DocLink{
+		{Title: "Using RBAC Authorization", URL: "https://kubernetes.io/docs/reference/access-authn-authz/rbac/"},
+	},
+}
+
 func (s *SecRBACForbidden) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "rbac-forbidden",
-		Name:        "Security: Access Denied",
-		Description: "The 'intern' service account cannot list pods. Fix the Role permissions.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Security",
-		Hints:       []string{"Use `kubectl get role`", "Edit the Role to add 'list' verb"},
-	}
+	return secRBACForbiddenMetadata
 }
 
 func (s *SecRBACForbidden) Setup(ctx context.Context) error {
@@ -96,6 +102,16 @@ func (s *SecRBACForbidden) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "Role still missing 'list' verb."}
 }
 
+func (s *SecRBACForbidden) Solve(ctx context.Context) error {
+	role, err := s.clientset.RbacV1().Roles(s.Namespace).Get(ctx, "pod-reader", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	role.Rules[0].Verbs = append(role.Rules[0].Verbs, "list")
+	_, err = s.clientset.RbacV1().Roles(s.Namespace).Update(ctx, role, metav1.UpdateOptions{})
+	return err
+}
+
 func (s *SecRBACForbidden) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
@@ -11,25 +11,28 @@ import (
 // SecSANoMount scenario: automountServiceAccountToken: false.
 type SecSANoMount struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewSecSANoMount(clientset *kubernetes.Clientset) *SecSANoMount {
+func NewSecSANoMount(clientset kubernetes.Interface) *SecSANoMount {
 	return &SecSANoMount{
 		BaseScenario: BaseScenario{Namespace: "sec-sa"},
 		clientset:    clientset,
 	}
 }
 
+var secSANoMountMetadata = Metadata{
+	ID:          "sec-sa-nomount",
+	Name:        "Security: No Certificates",
+	Description: "The app needs to talk to K8s API but cannot find credentials.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Security",
+	Tags:        []string{"serviceaccounts", "tokens", "ckad", "cks"},
+	Hints:       []string{"Auto-mounting of service account token is disabled", "Set `automountServiceAccountToken: true`"},
+}
+
 func (s *SecSANoMount) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "sec-sa-nomount",
-		Name:        "Security: No Certificates",
-		Description: "The app needs to talk to K8s API but cannot find credentials.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Security",
-		Hints:       []string{"Auto-mounting of service account token is disabled", "Set `automountServiceAccountToken: true`"},
-	}
+	return secSANoMountMetadata
 }
 
 func (s *SecSANoMount) Setup(ctx context.Context) error {
@@ -68,6 +71,12 @@ func (s *SecSANoMount) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "Automount is disabled."}
 }
 
+func (s *SecSANoMount) Solve(ctx context.Context) error {
+	return recreatePod(ctx, s.clientset, s.Namespace, "dashboard", func(pod *corev1.Pod) {
+		pod.Spec.AutomountServiceAccountToken = nil
+	})
+}
+
 func (s *SecSANoMount) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
@@ -0,0 +1,115 @@
+package scenario
+
+import (
+	"context"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// Solver is implemented by scenarios that can fix themselves.
+// It is used by the author test harness to prove a scenario is solvable.
+type Solver interface {
+	// Solve applies a reference fix to the broken environment.
+	Solve(ctx context.Context) error
+}
+
+// updateDeployment applies a mutation to a deployment, retrying on conflicts.
+func updateDeployment(ctx context.Context, clientset kubernetes.Interface, namespace, name string, mutate func(*appsv1.Deployment)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		dep, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(dep)
+		_, err = clientset.AppsV1().Deployments(namespace).Update(ctx, dep, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// updateService applies a mutation to a service, retrying on conflicts.
+func updateService(ctx context.Context, clientset kubernetes.Interface, namespace, name string, mutate func(*corev1.Service)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		svc, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(svc)
+		_, err = clientset.CoreV1().Services(namespace).Update(ctx, svc, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// recreatePod deletes a pod and creates a mutated copy, for fixes to immutable pod fields.
+func recreatePod(ctx context.Context, clientset kubernetes.Interface, namespace, name string, mutate func(*corev1.Pod)) error {
+	old, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        old.Name,
+			Labels:      old.Labels,
+			Annotations: old.Annotations,
+		},
+		Spec: *old.Spec.DeepCopy(),
+	}
+	pod.Spec.NodeName = ""
+	stripServiceAccountVolumes(&pod.Spec)
+	mutate(pod)
+
+	if err := deleteAndWait(ctx, clientset, namespace, name); err != nil {
+		return err
+	}
+	_, err = clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+	return err
+}
+
+// deleteAndWait deletes a pod immediately and waits until it is gone.
+func deleteAndWait(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
+	grace := int64(0)
+	err := clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{GracePeriodSeconds: &grace})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+		_, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		return apierrors.IsNotFound(err), nil
+	})
+}
+
+// stripServiceAccountVolumes removes the token volume injected by admission,
+// so a copied pod spec can be submitted again.
+func stripServiceAccountVolumes(spec *corev1.PodSpec) {
+	const prefix = "kube-api-access-"
+
+	volumes := spec.Volumes[:0]
+	for _, v := range spec.Volumes {
+		if !strings.HasPrefix(v.Name, prefix) {
+			volumes = append(volumes, v)
+		}
+	}
+	spec.Volumes = volumes
+
+	strip := func(containers []corev1.Container) {
+		for i := range containers {
+			mounts := containers[i].VolumeMounts[:0]
+			for _, m := range containers[i].VolumeMounts {
+				if !strings.HasPrefix(m.Name, prefix) {
+					mounts = append(mounts, m)
+				}
+			}
+			containers[i].VolumeMounts = mounts
+		}
+	}
+	strip(spec.InitContainers)
+	strip(spec.Containers)
+}
@@ -3,33 +3,48 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 )
 
 // StoragePVCPending scenario: PVC Pending due to wrong StorageClass.
 type StoragePVCPending struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewStoragePVCPending(clientset *kubernetes.Clientset) *StoragePVCPending {
+func NewStoragePVCPending(clientset kubernetes.Interface) *StoragePVCPending {
 	return &StoragePVCPending{
 		BaseScenario: BaseScenario{Namespace: "storage-pvc"},
 		clientset:    clientset,
 	}
 }
 
+var storagePVCPendingMetadata = Metadata{
+	ID:          "storage-pvc-pending",
+	Name:        "Storage: PVC Stuck Pending",
+	Description: "A PersistentVolumeClaim is stuck in Pending state. The Pod is also pending.",
+	Difficulty:  DifficultyEasy,
+	Category:    "Storage",
+	Tags:        []string{"pvc", "storageclass", "cka"},
+	Hints:       []string{"Describe the PVC", "Check storageClassName", "The cluster uses 'standard' class"},
+	Commands: []Command{
+		{Group: CommandInspect, Command: "kubectl get pvc,pods -n {{ .Namespace }}"},
+		{Group: CommandInspect, Command: "kubectl get storageclass"},
+		{Group: CommandEvents, Command: "kubectl describe pvc data-pvc -n {{ .Namespace }}"},
+	},
+	DocsLinks: []DocLink{
+		{Title: "Persistent Volumes", URL: "https://kubernetes.io/docs/concepts/storage/persistent-volumes/"},
+	},
+}
+
 func (s *StoragePVCPending) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "storage-pvc-pending",
-		Name:        "Storage: PVC Stuck Pending",
-		Description: "A PersistentVolumeClaim is stuck in Pending state. The Pod is also pending.",
-		Difficulty:  DifficultyEasy,
-		Category:    "Storage",
-		Hints:       []string{"Describe the PVC", "Check storageClassName", "The cluster uses 'standard' class"},
-	}
+	return storagePVCPendingMetadata
 }
 
 func (s *StoragePVCPending) Setup(ctx context.Context) error {
@@ -90,10 +105,56 @@ func (s *StoragePVCPending) Validate(ctx context.Context) Result {
 		return Result{Solved: false, Message: err.Error()}
 	}
 
-	if pvc.Status.Phase == corev1.ClaimBound {
-		return Result{Solved: true, Message: "Success! PVC is Bound."}
+	return NewResult("Success! PVC is Bound.",
+		check.FieldEquals("PVC phase", pvc.Status.Phase, corev1.ClaimBound),
+	)
+}
+
+func (s *StoragePVCPending) Solve(ctx context.Context) error {
+	// storageClassName is immutable and the pending pod protects the claim, so recreate both
+	pvc, err := s.clientset.CoreV1().PersistentVolumeClaims(s.Namespace).Get(ctx, "data-pvc", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	pod, err := s.clientset.CoreV1().Pods(s.Namespace).Get(ctx, "db", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := deleteAndWait(ctx, s.clientset, s.Namespace, "db"); err != nil {
+		return err
+	}
+	if err := s.clientset.CoreV1().PersistentVolumeClaims(s.Namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{}); err != nil {
+		return err
 	}
-	return Result{Solved: false, Message: "PVC is still Pending."}
+	err = wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+		_, err := s.clientset.CoreV1().PersistentVolumeClaims(s.Namespace).Get(ctx, pvc.Name, metav1.GetOptions{})
+		return apierrors.IsNotFound(err), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	standard := "standard"
+	_, err = s.clientset.CoreV1().PersistentVolumeClaims(s.Namespace).Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: pvc.Name},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      pvc.Spec.AccessModes,
+			StorageClassName: &standard,
+			Resources:        pvc.Spec.Resources,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	spec := *pod.Spec.DeepCopy()
+	spec.NodeName = ""
+	stripServiceAccountVolumes(&spec)
+	_, err = s.clientset.CoreV1().Pods(s.Namespace).Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Labels: pod.Labels},
+		Spec:       spec,
+	}, metav1.CreateOptions{})
+	return err
 }
 
 func (s *StoragePVCPending) Cleanup(ctx context.Context) error {
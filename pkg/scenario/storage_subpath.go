@@ -11,25 +11,28 @@ import (
 // StorageSubpathOverwrite scenario: Mount hides existing files.
 type StorageSubpathOverwrite struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewStorageSubpathOverwrite(clientset *kubernetes.Clientset) *StorageSubpathOverwrite {
+func NewStorageSubpathOverwrite(clientset kubernetes.Interface) *StorageSubpathOverwrite {
 	return &StorageSubpathOverwrite{
 		BaseScenario: BaseScenario{Namespace: "storage-subpath"},
 		clientset:    clientset,
 	}
 }
 
+var storageSubpathOverwriteMetadata = Metadata{
+	ID:          "storage-subpath-overwrite",
+	Name:        "Storage: File Wipeout",
+	Description: "Mounting a file to /etc/app/config.json hides the rest of /etc/app/. Fix it.",
+	Difficulty:  DifficultyMedium,
+	Category:    "Storage",
+	Tags:        []string{"volumes", "subpath", "configmaps", "ckad"},
+	Hints:       []string{"Accessing other files in directory fails", "Use `subPath` to mount a single file"},
+}
+
 func (s *StorageSubpathOverwrite) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "storage-subpath-overwrite",
-		Name:        "Storage: File Wipeout",
-		Description: "Mounting a file to /etc/app/config.json hides the rest of /etc/app/. Fix it.",
-		Difficulty:  DifficultyMedium,
-		Category:    "Storage",
-		Hints:       []string{"Accessing other files in directory fails", "Use `subPath` to mount a single file"},
-	}
+	return storageSubpathOverwriteMetadata
 }
 
 func (s *StorageSubpathOverwrite) Setup(ctx context.Context) error {
@@ -86,6 +89,17 @@ func (s *StorageSubpathOverwrite) Validate(ctx context.Context) Result {
 	return Result{Solved: false, Message: "Volume mount is still overwriting entire directory."}
 }
 
+func (s *StorageSubpathOverwrite) Solve(ctx context.Context) error {
+	return recreatePod(ctx, s.clientset, s.Namespace, "app", func(pod *corev1.Pod) {
+		for i, vm := range pod.Spec.Containers[0].VolumeMounts {
+			if vm.Name == "config" {
+				pod.Spec.Containers[0].VolumeMounts[i].MountPath = "/etc/nginx/config.json"
+				pod.Spec.Containers[0].VolumeMounts[i].SubPath = "config.json"
+			}
+		}
+	})
+}
+
 func (s *StorageSubpathOverwrite) Cleanup(ctx context.Context) error {
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
@@ -3,6 +3,8 @@ package scenario
 import (
 	"context"
 
+	"k8s-dojo/pkg/scenario/check"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -11,25 +13,30 @@ import (
 // StorageZonalAffinity scenario: Pod/PV in different zones (Simulated).
 type StorageZonalAffinity struct {
 	BaseScenario
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
-func NewStorageZonalAffinity(clientset *kubernetes.Clientset) *StorageZonalAffinity {
+func NewStorageZonalAffinity(clientset kubernetes.Interface) *StorageZonalAffinity {
 	return &StorageZonalAffinity{
 		BaseScenario: BaseScenario{Namespace: "storage-zonal"},
 		clientset:    clientset,
 	}
 }
 
+var storageZonalAffinityMetadata = Metadata{
+	ID:          "storage-zonal-affinity",
+	Name:        "Storage: Zonal Connectivity",
+	Description: "Pod cannot mount the PV because they are in different zones. Fix the affinity.",
+	Difficulty:  DifficultyHard,
+	Category:    "Storage",
+	Tags:        []string{"pvc", "topology", "scheduling"},
+	Hints:       []string{"Check PV NodeAffinity", "Ensure Pod is scheduled in the same zone", "Kind usually only has one zone, this is a simulation"},
+
+	ClusterAccess: true,
+}
+
 func (s *StorageZonalAffinity) GetMetadata() Metadata {
-	return Metadata{
-		ID:          "storage-zonal-affinity",
-		Name:        "Storage: Zonal Connectivity",
-		Description: "Pod cannot mount the PV because they are in different zones. Fix the affinity.",
-		Difficulty:  DifficultyHard,
-		Category:    "Storage",
-		Hints:       []string{"Check PV NodeAffinity", "Ensure Pod is scheduled in the same zone", "Kind usually only has one zone, this is a simulation"},
-	}
+	return storageZonalAffinityMetadata
 }
 
 func (s *StorageZonalAffinity) Setup(ctx context.Context) error {
@@ -43,7 +50,7 @@ func (s *StorageZonalAffinity) Setup(ctx context.Context) error {
 	// Create a PV simulating a specific zone
 	scName := "manual"
 	_, err = s.clientset.CoreV1().PersistentVolumes().Create(ctx, &corev1.PersistentVolume{
-		ObjectMeta: metav1.ObjectMeta{Name: "zone-pv"},
+		ObjectMeta: metav1.ObjectMeta{Name: s.pvName()},
 		Spec: corev1.PersistentVolumeSpec{
 			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
 			Capacity:         corev1.ResourceList{corev1.ResourceStorage: mustParse("1Gi")},
@@ -73,7 +80,7 @@ func (s *StorageZonalAffinity) Setup(ctx context.Context) error {
 		Spec: corev1.PersistentVolumeClaimSpec{
 			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
 			StorageClassName: &scName,
-			VolumeName:       "zone-pv",
+			VolumeName:       s.pvName(),
 			Resources: corev1.VolumeResourceRequirements{
 				Requests: corev1.ResourceList{corev1.ResourceStorage: mustParse("1Gi")},
 			},
@@ -92,18 +99,37 @@ func (s *StorageZonalAffinity) Setup(ctx context.Context) error {
 }
 
 func (s *StorageZonalAffinity) Validate(ctx context.Context) Result {
-	pod, err := s.clientset.CoreV1().Pods(s.Namespace).Get(ctx, "zone-pod", metav1.GetOptions{})
+	return NewResult("Success! Pod successfully mounted the Zonal PV.",
+		check.PodRunning(ctx, s.clientset, s.Namespace, "zone-pod"),
+	)
+}
+
+// pvName derives the cluster-scoped PV name from the namespace so attempts don't collide.
+func (s *StorageZonalAffinity) pvName() string {
+	return s.Namespace + "-pv"
+}
+
+func (s *StorageZonalAffinity) Solve(ctx context.Context) error {
+	// PV node affinity is immutable; give the node the zone the volume expects
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
-		return Result{Solved: false, Message: err.Error()}
+		return err
 	}
-
-	if pod.Status.Phase == corev1.PodRunning {
-		return Result{Solved: true, Message: "Success! Pod successfully mounted the Zonal PV."}
+	for _, node := range nodes.Items {
+		node.Labels["topology.kubernetes.io/zone"] = "us-east-1a"
+		if _, err := s.clientset.CoreV1().Nodes().Update(ctx, &node, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
 	}
-	return Result{Solved: false, Message: "Pod is not Running."}
+	return nil
+}
+
+// CleanupSteps implements CleanupDescriber.
+func (s *StorageZonalAffinity) CleanupSteps() []CleanupStep {
+	return []CleanupStep{{Description: "persistent volume " + s.pvName(), Command: "kubectl delete pv " + s.pvName()}}
 }
 
 func (s *StorageZonalAffinity) Cleanup(ctx context.Context) error {
-	_ = s.clientset.CoreV1().PersistentVolumes().Delete(ctx, "zone-pv", metav1.DeleteOptions{})
+	_ = s.clientset.CoreV1().PersistentVolumes().Delete(ctx, s.pvName(), metav1.DeleteOptions{})
 	return s.clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
 }
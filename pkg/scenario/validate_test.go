@@ -0,0 +1,764 @@
+package scenario
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s-dojo/pkg/k8s"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// validateCase holds the objects of a scenario's broken and fixed
+// environments, in the scenario's namespace. Unstructured objects are served
+// by the dynamic client, everything else by the clientset.
+type validateCase struct {
+	broken func(namespace string) []runtime.Object
+	fixed  func(namespace string) []runtime.Object
+}
+
+// validateSkips are the built-in scenarios whose Validate needs more than
+// fake clients can serve.
+var validateSkips = map[string]string{
+	"net-target-port-mismatch": "port-forwards to the Service and sends it an HTTP request",
+	"ops-cert-expiry":          "runs kubeadm on the control plane node",
+	"ops-etcd-backup":          "runs etcdctl on the control plane node",
+	"res-noisy-neighbor":       "reads pod CPU usage from the metrics API",
+}
+
+// customListKinds are the list kinds of the custom resources served by the fake dynamic client.
+var customListKinds = map[schema.GroupVersionResource]string{
+	crdGVR:      "CustomResourceDefinitionList",
+	widgetGVR:   "WidgetList",
+	databaseGVR: "DatabaseList",
+	vpaGVR:      "VerticalPodAutoscalerList",
+}
+
+// runValidate checks that Validate fails on the broken objects and passes on
+// the fixed ones.
+func runValidate(t *testing.T, b builtin, c validateCase) {
+	t.Helper()
+	envs := []struct {
+		name    string
+		objects func(string) []runtime.Object
+		solved  bool
+	}{
+		{"broken", c.broken, false},
+		{"fixed", c.fixed, true},
+	}
+	for _, env := range envs {
+		t.Run(env.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), customListKinds)
+			s := b.construct(Deps{Clientset: clientset, Dynamic: dyn})
+			for _, obj := range env.objects(s.GetNamespace()) {
+				tracker := clientset.Tracker()
+				if _, ok := obj.(*unstructured.Unstructured); ok {
+					tracker = dyn.Tracker()
+				}
+				if err := tracker.Add(obj); err != nil {
+					t.Fatalf("Failed to seed %T: %v", obj, err)
+				}
+			}
+			res := s.Validate(context.Background())
+			if res.Solved != env.solved {
+				t.Errorf("Expected solved=%t, got %t (%s)", env.solved, res.Solved, res.Message)
+			}
+		})
+	}
+}
+
+func objectMeta(namespace, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Namespace: namespace, Name: name}
+}
+
+// runningPod returns a pod whose containers have been running and ready for an hour.
+func runningPod(namespace, name string, containers ...corev1.Container) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: objectMeta(namespace, name),
+		Spec:       corev1.PodSpec{Containers: containers},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	started := metav1.NewTime(time.Now().Add(-time.Hour))
+	for _, c := range containers {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  c.Name,
+			Ready: true,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: started}},
+		})
+	}
+	return pod
+}
+
+// pendingPod returns a pod that hasn't started.
+func pendingPod(namespace, name string, containers ...corev1.Container) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: objectMeta(namespace, name),
+		Spec:       corev1.PodSpec{Containers: containers},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+}
+
+// deployment returns a deployment of one replica running containers, fully
+// rolled out if available is set and without available replicas otherwise.
+func deployment(namespace, name string, available bool, containers ...corev1.Container) *appsv1.Deployment {
+	dep := &appsv1.Deployment{
+		ObjectMeta: objectMeta(namespace, name),
+		Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: containers}}},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1},
+	}
+	if available {
+		dep.Status.ReadyReplicas = 1
+		dep.Status.AvailableReplicas = 1
+	}
+	return dep
+}
+
+func container(name string) corev1.Container {
+	return corev1.Container{Name: name, Image: "nginx:alpine"}
+}
+
+func objects(objs ...runtime.Object) []runtime.Object {
+	return objs
+}
+
+// custom returns an unstructured object for the dynamic client.
+func custom(apiVersion, kind, namespace, name string, content map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: content}
+	if obj.Object == nil {
+		obj.Object = map[string]interface{}{}
+	}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]validateCase{
+		// Networking
+		"net-service-selector": {
+			broken: func(ns string) []runtime.Object {
+				return objects(&corev1.Endpoints{ObjectMeta: objectMeta(ns, "web-service")})
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(&corev1.Endpoints{
+					ObjectMeta: objectMeta(ns, "web-service"),
+					Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.244.0.5"}}}},
+				})
+			},
+		},
+		"net-grpc-balance": {
+			broken: func(ns string) []runtime.Object {
+				return objects(&corev1.Service{ObjectMeta: objectMeta(ns, "grpc-service"), Spec: corev1.ServiceSpec{ClusterIP: "10.96.12.7"}})
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(&corev1.Service{ObjectMeta: objectMeta(ns, "grpc-service"), Spec: corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone}})
+			},
+		},
+		"net-source-ip": {
+			broken: func(ns string) []runtime.Object {
+				return objects(&corev1.Service{ObjectMeta: objectMeta(ns, "public-service"), Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort, ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeCluster,
+				}})
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(&corev1.Service{ObjectMeta: objectMeta(ns, "public-service"), Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort, ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+				}})
+			},
+		},
+		"net-dns-ndots": {
+			broken: func(ns string) []runtime.Object {
+				return objects(ndotsPod(ns, "5"))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(ndotsPod(ns, "2"))
+			},
+		},
+		"netpol-dns-block": {
+			broken: func(ns string) []runtime.Object {
+				return objects(blockedPod(ns), dnsPolicy(ns))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(blockedPod(ns), dnsPolicy(ns, networkingv1.NetworkPolicyEgressRule{
+					Ports: []networkingv1.NetworkPolicyPort{{Port: &dnsPort}},
+				}))
+			},
+		},
+		"ingress-path-error": {
+			broken: func(ns string) []runtime.Object {
+				return objects(appIngress(ns, "/ap"))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(appIngress(ns, "/app"))
+			},
+		},
+		"ingress-tls-mismatch": {
+			broken: func(ns string) []runtime.Object {
+				return objects(tlsIngress(ns), &corev1.Secret{ObjectMeta: objectMeta(ns, "connection-secure")})
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(tlsIngress(ns), &corev1.Secret{ObjectMeta: objectMeta(ns, "tls-secret")})
+			},
+		},
+
+		// Lifecycle
+		"image-pull-backoff": {
+			broken: func(ns string) []runtime.Object {
+				pod := pendingPod(ns, "web-server-1", container("nginx"))
+				pod.Labels = map[string]string{"app": "web-server"}
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name:  "nginx",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+				}}
+				return objects(pod)
+			},
+			fixed: func(ns string) []runtime.Object {
+				pod := runningPod(ns, "web-server-2", container("nginx"))
+				pod.Labels = map[string]string{"app": "web-server"}
+				return objects(pod)
+			},
+		},
+		"crashloop-missing-config": {
+			broken: func(ns string) []runtime.Object {
+				pod := pendingPod(ns, "crash-app", container("app"))
+				pod.Labels = map[string]string{"app": "crash"}
+				return objects(pod)
+			},
+			fixed: func(ns string) []runtime.Object {
+				pod := runningPod(ns, "crash-app", container("app"))
+				pod.Labels = map[string]string{"app": "crash"}
+				return objects(pod)
+			},
+		},
+		"life-graceful-shutdown": {
+			broken: func(ns string) []runtime.Object {
+				return objects(deployment(ns, "web", true, container("web")))
+			},
+			fixed: func(ns string) []runtime.Object {
+				c := container("web")
+				c.Lifecycle = &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"sleep", "10"}}}}
+				return objects(deployment(ns, "web", true, c))
+			},
+		},
+		"life-rollout-undo": {
+			broken: func(ns string) []runtime.Object {
+				return objects(apiDeployment(ns, "3"), apiReplicaSet(ns, "api-7f9c", "3", ""))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(apiDeployment(ns, "4"), apiReplicaSet(ns, "api-7f9c", "3", ""), apiReplicaSet(ns, "api-5d4b", "4", "1"))
+			},
+		},
+		"quiz-crashing-container": {
+			broken: func(ns string) []runtime.Object {
+				return nil // The learner deleted the pod instead of answering
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(runningPod(ns, "storefront", container("web"), container("metrics")))
+			},
+		},
+		"probe-liveness-fail": {
+			broken: func(ns string) []runtime.Object {
+				return objects(livenessPod(ns, 8080))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(livenessPod(ns, 80))
+			},
+		},
+		"probe-readiness-timeout": {
+			broken: func(ns string) []runtime.Object {
+				return objects(readinessPod(ns, 1))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(readinessPod(ns, 5))
+			},
+		},
+		"init-container-crash": {
+			broken: func(ns string) []runtime.Object {
+				return objects(initPod(ns, 1))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(initPod(ns, 0))
+			},
+		},
+		"pod-finalizer-stuck": {
+			broken: func(ns string) []runtime.Object {
+				pod := runningPod(ns, "zombie", container("app"))
+				deleted := metav1.Now()
+				pod.DeletionTimestamp = &deleted
+				pod.Finalizers = []string{"dojo.example.com/cleanup"}
+				return objects(pod)
+			},
+			fixed: func(ns string) []runtime.Object {
+				return nil
+			},
+		},
+
+		// Scheduling
+		"sched-node-affinity": {
+			broken: func(ns string) []runtime.Object {
+				return objects(&corev1.Pod{ObjectMeta: objectMeta(ns, "gpu-workload")})
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(&corev1.Pod{ObjectMeta: objectMeta(ns, "gpu-workload"), Spec: corev1.PodSpec{
+					Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}},
+				}})
+			},
+		},
+		"sched-missing-scheduler": {
+			broken: func(ns string) []runtime.Object {
+				pod := pendingPod(ns, "custom-pod", container("app"))
+				pod.Spec.SchedulerName = "my-custom-scheduler"
+				return objects(pod)
+			},
+			fixed: func(ns string) []runtime.Object {
+				pod := runningPod(ns, "custom-pod", container("app"))
+				pod.Spec.SchedulerName = corev1.DefaultSchedulerName
+				return objects(pod)
+			},
+		},
+		"sched-taint-toleration": {
+			broken: func(ns string) []runtime.Object {
+				return objects(pendingPod(ns, "db-pod", container("db")))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(runningPod(ns, "db-pod", container("db")))
+			},
+		},
+
+		// Security
+		"rbac-forbidden": {
+			broken: func(ns string) []runtime.Object {
+				return objects(podReader(ns, "get"))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(podReader(ns, "get", "list"))
+			},
+		},
+		"sec-privileged-policy": {
+			broken: func(ns string) []runtime.Object {
+				return objects(privilegedDeployment(ns, true))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(privilegedDeployment(ns, false))
+			},
+		},
+		"sec-image-digest": {
+			broken: func(ns string) []runtime.Object {
+				return objects(deployment(ns, "web", true, corev1.Container{Name: "web", Image: "nginx:1.25"}))
+			},
+			fixed: func(ns string) []runtime.Object {
+				image := "nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+				return objects(deployment(ns, "web", true, corev1.Container{Name: "web", Image: image}))
+			},
+		},
+		"sec-fsgroup-denied": {
+			broken: func(ns string) []runtime.Object {
+				return objects(pendingPod(ns, "writer", container("writer")))
+			},
+			fixed: func(ns string) []runtime.Object {
+				fsGroup := int64(1000)
+				pod := runningPod(ns, "writer", container("writer"))
+				pod.Spec.SecurityContext = &corev1.PodSecurityContext{FSGroup: &fsGroup}
+				return objects(pod)
+			},
+		},
+		"sec-sa-nomount": {
+			broken: func(ns string) []runtime.Object {
+				automount := false
+				return objects(&corev1.Pod{ObjectMeta: objectMeta(ns, "dashboard"), Spec: corev1.PodSpec{
+					AutomountServiceAccountToken: &automount,
+				}})
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(&corev1.Pod{ObjectMeta: objectMeta(ns, "dashboard")})
+			},
+		},
+
+		// Storage
+		"storage-pvc-pending": {
+			broken: func(ns string) []runtime.Object {
+				return objects(&corev1.PersistentVolumeClaim{
+					ObjectMeta: objectMeta(ns, "data-pvc"),
+					Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+				})
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(&corev1.PersistentVolumeClaim{
+					ObjectMeta: objectMeta(ns, "data-pvc"),
+					Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+				})
+			},
+		},
+		"storage-zonal-affinity": {
+			broken: func(ns string) []runtime.Object {
+				return objects(pendingPod(ns, "zone-pod", container("app")))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(runningPod(ns, "zone-pod", container("app")))
+			},
+		},
+		"storage-subpath-overwrite": {
+			broken: func(ns string) []runtime.Object {
+				return objects(subPathPod(ns, ""))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(subPathPod(ns, "app.conf"))
+			},
+		},
+
+		// Ops & Kernel
+		"kernel-oom-disable": {
+			broken: func(ns string) []runtime.Object {
+				pod := runningPod(ns, "critical-pod", container("app"))
+				pod.Status.QOSClass = corev1.PodQOSBurstable
+				return objects(pod)
+			},
+			fixed: func(ns string) []runtime.Object {
+				pod := runningPod(ns, "critical-pod", container("app"))
+				pod.Status.QOSClass = corev1.PodQOSGuaranteed
+				return objects(pod)
+			},
+		},
+		"ops-config-checksum": {
+			broken: func(ns string) []runtime.Object {
+				return objects(deployment(ns, "gitops-app", true, container("app")))
+			},
+			fixed: func(ns string) []runtime.Object {
+				dep := deployment(ns, "gitops-app", true, container("app"))
+				dep.Spec.Template.Annotations = map[string]string{"checksum/config": "9f86d081884c7d65"}
+				return objects(dep)
+			},
+		},
+		"ops-upgrade-drill": {
+			broken: func(ns string) []runtime.Object {
+				return objects(upgradeNode(true), apiPDB(ns, 0), deployment(ns, "api", true, container("api")))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(upgradeNode(false), apiPDB(ns, 1), deployment(ns, "api", true, container("api")))
+			},
+		},
+		"ops-blue-green": {
+			broken: func(ns string) []runtime.Object {
+				return append(shopPods(ns), shopEndpoints(ns, "shop-blue-a", "shop-blue-b"), deployment(ns, "shop-blue", true, container("shop")))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return append(shopPods(ns), shopEndpoints(ns, "shop-green-a", "shop-green-b"), deployment(ns, "shop-blue", true, container("shop")))
+			},
+		},
+
+		// Resources
+		"resource-quota-exceeded": {
+			broken: func(ns string) []runtime.Object {
+				return objects(&appsv1.Deployment{ObjectMeta: objectMeta(ns, "blocked-dep")})
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(&appsv1.Deployment{
+					ObjectMeta: objectMeta(ns, "blocked-dep"),
+					Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+				})
+			},
+		},
+		"resource-limit-range": {
+			broken: func(ns string) []runtime.Object {
+				return objects(deployment(ns, "gaint-backend", false, container("backend")))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(deployment(ns, "gaint-backend", true, container("backend")))
+			},
+		},
+		"res-vpa-right-size": {
+			broken: func(ns string) []runtime.Object {
+				return objects(ledgerVPA(ns), ledgerDeployment(ns, "1", "1Gi"))
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(ledgerVPA(ns), ledgerDeployment(ns, "100m", "128Mi"))
+			},
+		},
+
+		// Operators
+		"crd-schema-reject": {
+			broken: func(ns string) []runtime.Object {
+				// The API server rejected the Database, so it doesn't exist
+				return nil
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(custom("dojo.example.com/v1", "Database", ns, "orders-db", map[string]interface{}{
+					"spec": map[string]interface{}{"engine": "postgres", "replicas": int64(3)},
+				}))
+			},
+		},
+		"crd-missing": {
+			broken: func(ns string) []runtime.Object {
+				return nil
+			},
+			fixed: func(ns string) []runtime.Object {
+				return objects(establishedCRD(widgetCRDName), custom("dojo.example.com/v1", "Widget", ns, "my-widget", map[string]interface{}{
+					"spec": map[string]interface{}{"size": int64(3)},
+				}))
+			},
+		},
+	}
+
+	for _, b := range builtins {
+		id := b.meta.ID
+		t.Run(id, func(t *testing.T) {
+			if reason, ok := validateSkips[id]; ok {
+				t.Skipf("Validate %s", reason)
+			}
+			c, ok := cases[id]
+			if !ok {
+				t.Fatalf("No broken and fixed fixtures for %s; add them or list it in validateSkips", id)
+			}
+			runValidate(t, b, c)
+		})
+	}
+}
+
+func TestNetPolDNSBlockPolicyDeleted(t *testing.T) {
+	ns := NewNetPolDNSBlock(nil, nil).GetNamespace()
+	ingressOnly := dnsPolicy(ns)
+	ingressOnly.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+
+	for name, objs := range map[string][]runtime.Object{
+		"deleted":      objects(blockedPod(ns)),
+		"ingress only": objects(blockedPod(ns), ingressOnly),
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := NewNetPolDNSBlock(fake.NewSimpleClientset(objs...), nil)
+			if res := s.Validate(context.Background()); !res.Solved {
+				t.Errorf("Expected the pod's egress to be unrestricted, got %s", res.Message)
+			}
+		})
+	}
+}
+
+func TestRegistryUsesClientset(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	reg := NewRegistry(&k8s.Client{Clientset: clientset})
+	s := reg.Get("image-pull-backoff")
+	if s == nil {
+		t.Fatal("Scenario image-pull-backoff not registered")
+	}
+	res := s.Validate(context.Background())
+	if res.Solved || len(clientset.Actions()) == 0 {
+		t.Errorf("Expected an unsolved result from the fake clientset, got %+v", res)
+	}
+}
+
+func readinessPod(namespace string, timeout int32) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: objectMeta(namespace, "slow-app"), Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:           "app",
+			ReadinessProbe: &corev1.Probe{TimeoutSeconds: timeout},
+		}},
+	}}
+}
+
+func livenessPod(namespace string, port int) *corev1.Pod {
+	c := container("app")
+	c.LivenessProbe = &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromInt(port)},
+	}}
+	pod := runningPod(namespace, "unstable-app", c)
+	if port != 80 {
+		pod.Status.ContainerStatuses[0].RestartCount = 6
+	}
+	return pod
+}
+
+func initPod(namespace string, exitCode int32) *corev1.Pod {
+	pod := pendingPod(namespace, "app", container("app"))
+	if exitCode == 0 {
+		pod = runningPod(namespace, "app", container("app"))
+	}
+	pod.Spec.InitContainers = []corev1.Container{{Name: "init", Image: "busybox"}}
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "init",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+	}}
+	return pod
+}
+
+func ndotsPod(namespace, ndots string) *corev1.Pod {
+	pod := runningPod(namespace, "legacy-app", container("app"))
+	pod.Spec.DNSConfig = &corev1.PodDNSConfig{Options: []corev1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}}}
+	return pod
+}
+
+var dnsPort = intstr.FromInt(53)
+
+func blockedPod(namespace string) *corev1.Pod {
+	pod := runningPod(namespace, "blocked-pod", container("app"))
+	pod.Labels = map[string]string{"app": "secure"}
+	return pod
+}
+
+// dnsPolicy returns the scenario's policy selecting every pod, allowing the given egress.
+func dnsPolicy(namespace string, egress ...networkingv1.NetworkPolicyEgressRule) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{ObjectMeta: objectMeta(namespace, "deny-all-egress"), Spec: networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		Egress:      egress,
+	}}
+}
+
+func appIngress(namespace, path string) *networkingv1.Ingress {
+	prefix := networkingv1.PathTypePrefix
+	return &networkingv1.Ingress{ObjectMeta: objectMeta(namespace, "app-ingress"), Spec: networkingv1.IngressSpec{
+		Rules: []networkingv1.IngressRule{{IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+			Paths: []networkingv1.HTTPIngressPath{{Path: path, PathType: &prefix, Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{Name: "app-svc", Port: networkingv1.ServiceBackendPort{Number: 80}},
+			}}},
+		}}}},
+	}}
+}
+
+func tlsIngress(namespace string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{ObjectMeta: objectMeta(namespace, "secure-ingress"), Spec: networkingv1.IngressSpec{
+		TLS: []networkingv1.IngressTLS{{Hosts: []string{"secure.local"}, SecretName: "tls-secret"}},
+	}}
+}
+
+func apiDeployment(namespace, revision string) *appsv1.Deployment {
+	dep := deployment(namespace, "api", true, container("api"))
+	dep.Annotations = map[string]string{revisionAnnotation: revision}
+	return dep
+}
+
+func apiReplicaSet(namespace, name, revision, history string) *appsv1.ReplicaSet {
+	rs := &appsv1.ReplicaSet{ObjectMeta: objectMeta(namespace, name)}
+	rs.Labels = map[string]string{"app": "api"}
+	rs.Annotations = map[string]string{revisionAnnotation: revision}
+	if history != "" {
+		rs.Annotations[revisionHistoryAnnotation] = history
+	}
+	return rs
+}
+
+func podReader(namespace string, verbs ...string) *rbacv1.Role {
+	return &rbacv1.Role{ObjectMeta: objectMeta(namespace, "pod-reader"), Rules: []rbacv1.PolicyRule{{
+		APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: verbs,
+	}}}
+}
+
+func privilegedDeployment(namespace string, privileged bool) *appsv1.Deployment {
+	c := container("nginx")
+	c.SecurityContext = &corev1.SecurityContext{Privileged: &privileged}
+	return deployment(namespace, "risky-app", true, c)
+}
+
+func subPathPod(namespace, subPath string) *corev1.Pod {
+	c := container("app")
+	c.VolumeMounts = []corev1.VolumeMount{{Name: "config", MountPath: "/etc/nginx/conf.d/app.conf", SubPath: subPath}}
+	return runningPod(namespace, "app", c)
+}
+
+func upgradeNode(cordoned bool) *corev1.Node {
+	return &corev1.Node{ObjectMeta: objectMeta("", "k8s-dojo-worker"), Spec: corev1.NodeSpec{Unschedulable: cordoned}}
+}
+
+func apiPDB(namespace string, allowed int32) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{ObjectMeta: objectMeta(namespace, "api-pdb"), Status: policyv1.PodDisruptionBudgetStatus{
+		DisruptionsAllowed: allowed,
+	}}
+}
+
+// shopPods returns two blue (v1) and two green (v2) pods.
+func shopPods(namespace string) []runtime.Object {
+	var pods []runtime.Object
+	for name, version := range map[string]string{"shop-blue-a": "v1", "shop-blue-b": "v1", "shop-green-a": "v2", "shop-green-b": "v2"} {
+		pod := runningPod(namespace, name, container("shop"))
+		pod.Labels = map[string]string{"app": "shop", "version": version}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// shopEndpoints returns the endpoints of Service shop, pointing at pods.
+func shopEndpoints(namespace string, pods ...string) *corev1.Endpoints {
+	subset := corev1.EndpointSubset{}
+	for i, pod := range pods {
+		subset.Addresses = append(subset.Addresses, corev1.EndpointAddress{
+			IP:        "10.244.0." + string(rune('2'+i)),
+			TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: pod, Namespace: namespace},
+		})
+	}
+	return &corev1.Endpoints{ObjectMeta: objectMeta(namespace, "shop"), Subsets: []corev1.EndpointSubset{subset}}
+}
+
+func ledgerDeployment(namespace, cpu, memory string) *appsv1.Deployment {
+	c := container("ledger")
+	c.Resources.Requests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+	return deployment(namespace, "ledger", true, c)
+}
+
+// ledgerVPA returns VPA ledger with a recommendation of 50m-200m CPU and 64Mi-256Mi memory.
+func ledgerVPA(namespace string) *unstructured.Unstructured {
+	return custom("autoscaling.k8s.io/v1", "VerticalPodAutoscaler", namespace, "ledger", map[string]interface{}{
+		"status": map[string]interface{}{"recommendation": map[string]interface{}{
+			"containerRecommendations": []interface{}{map[string]interface{}{
+				"containerName": "ledger",
+				"target":        map[string]interface{}{"cpu": "100m", "memory": "128Mi"},
+				"lowerBound":    map[string]interface{}{"cpu": "50m", "memory": "64Mi"},
+				"upperBound":    map[string]interface{}{"cpu": "200m", "memory": "256Mi"},
+			}},
+		}},
+	})
+}
+
+func establishedCRD(name string) *unstructured.Unstructured {
+	return custom("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", name, map[string]interface{}{
+		"status": map[string]interface{}{"conditions": []interface{}{
+			map[string]interface{}{"type": "Established", "status": "True"},
+		}},
+	})
+}
+
+func TestDeleteUnusedCRD(t *testing.T) {
+	ctx := context.Background()
+	owned := establishedCRD(databaseCRDName)
+	owned.SetLabels(map[string]string{crdOwnerLabel: "k8s-dojo"})
+	database := func(namespace string) *unstructured.Unstructured {
+		return custom("dojo.example.com/v1", "Database", namespace, "orders-db", nil)
+	}
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		deleted bool
+	}{
+		{"only this namespace", objects(owned, database("op-crd-schema")), true},
+		{"used by another session", objects(owned, database("op-crd-schema"), database("op-crd-schema-bob")), false},
+		{"not installed by k8s-dojo", objects(establishedCRD(databaseCRDName)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), customListKinds, tt.objects...)
+			if err := deleteUnusedCRD(ctx, dyn, databaseCRDName, databaseGVR, "op-crd-schema"); err != nil {
+				t.Fatalf("deleteUnusedCRD failed: %v", err)
+			}
+			installed, _ := crdEstablished(ctx, dyn, databaseCRDName)
+			if installed == tt.deleted {
+				t.Errorf("CRD deleted = %t, want %t", !installed, tt.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var vpaGVR = schema.GroupVersionResource{Group: "autoscaling.k8s.io", Version: "v1", Resource: "verticalpodautoscalers"}
+
+const vpaCRDName = "verticalpodautoscalers.autoscaling.k8s.io"
+
+// vpaRecommenderManifest installs the Vertical Pod Autoscaler in
+// recommend-only mode: its CRDs and the recommender, without the updater or
+// admission controller, so recommendations are published but pods are never
+// resized or evicted. The CRD schemas are left open; the recommender
+// validates its own objects.
+const vpaRecommenderManifest = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: verticalpodautoscalers.autoscaling.k8s.io
+spec:
+  group: autoscaling.k8s.io
+  scope: Namespaced
+  names:
+    plural: verticalpodautoscalers
+    singular: verticalpodautoscaler
+    kind: VerticalPodAutoscaler
+    shortNames: ["vpa"]
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    subresources:
+      status: {}
+    schema:
+      openAPIV3Schema:
+        type: object
+        x-kubernetes-preserve-unknown-fields: true
+    additionalPrinterColumns:
+    - name: Mode
+      type: string
+      jsonPath: .spec.updatePolicy.updateMode
+    - name: CPU
+      type: string
+      jsonPath: .status.recommendation.containerRecommendations[0].target.cpu
+    - name: Mem
+      type: string
+      jsonPath: .status.recommendation.containerRecommendations[0].target.memory
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: verticalpodautoscalercheckpoints.autoscaling.k8s.io
+spec:
+  group: autoscaling.k8s.io
+  scope: Namespaced
+  names:
+    plural: verticalpodautoscalercheckpoints
+    singular: verticalpodautoscalercheckpoint
+    kind: VerticalPodAutoscalerCheckpoint
+    shortNames: ["vpacheckpoint"]
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        x-kubernetes-preserve-unknown-fields: true
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: vpa-recommender
+  namespace: kube-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: k8s-dojo:vpa-recommender
+rules:
+- apiGroups: [""]
+  resources: ["pods", "nodes", "limitranges"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: [""]
+  resources: ["events"]
+  verbs: ["create", "patch"]
+- apiGroups: ["metrics.k8s.io"]
+  resources: ["pods"]
+  verbs: ["get", "list"]
+- apiGroups: ["apps"]
+  resources: ["deployments", "replicasets", "statefulsets", "daemonsets"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: ["*"]
+  resources: ["*/scale"]
+  verbs: ["get", "watch"]
+- apiGroups: ["batch"]
+  resources: ["jobs", "cronjobs"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: ["autoscaling.k8s.io"]
+  resources: ["verticalpodautoscalers", "verticalpodautoscalers/status"]
+  verbs: ["get", "list", "watch", "patch", "update"]
+- apiGroups: ["autoscaling.k8s.io"]
+  resources: ["verticalpodautoscalercheckpoints"]
+  verbs: ["get", "list", "watch", "create", "patch", "delete"]
+- apiGroups: ["coordination.k8s.io"]
+  resources: ["leases"]
+  verbs: ["get", "create", "update"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: k8s-dojo:vpa-recommender
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: k8s-dojo:vpa-recommender
+subjects:
+- kind: ServiceAccount
+  name: vpa-recommender
+  namespace: kube-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: vpa-recommender
+  namespace: kube-system
+spec:
+  selector:
+    matchLabels:
+      app: vpa-recommender
+  template:
+    metadata:
+      labels:
+        app: vpa-recommender
+    spec:
+      serviceAccountName: vpa-recommender
+      containers:
+      - name: recommender
+        image: registry.k8s.io/autoscaling/vpa-recommender:1.2.1
+        args:
+        - --recommender-interval=15s
+        resources:
+          requests:
+            cpu: 50m
+            memory: 100Mi
+        securityContext:
+          runAsNonRoot: true
+          runAsUser: 65534
+`
+
+// ensureVPARecommender installs the VPA recommender unless the cluster already
+// runs a VPA, and waits for its API. Like metrics-server, it stays installed.
+func ensureVPARecommender(ctx context.Context, clientset kubernetes.Interface, dyn dynamic.Interface, applier Applier) error {
+	established, err := crdEstablished(ctx, dyn, vpaCRDName)
+	if err != nil {
+		return fmt.Errorf("failed to look up the VPA: %w", err)
+	}
+	if !established {
+		if err := applier.ApplyYAML(ctx, vpaRecommenderManifest, ""); err != nil {
+			return fmt.Errorf("failed to install the VPA recommender: %w", err)
+		}
+		return waitForCRD(ctx, dyn, vpaCRDName)
+	}
+
+	// A VPA installed some other way must run a recommender in kube-system too
+	_, err = clientset.AppsV1().Deployments("kube-system").Get(ctx, "vpa-recommender", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("the VPA CRD is installed but no vpa-recommender runs in kube-system")
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WatchYAML polls dir and reloads the registry's YAML scenarios whenever a
+// *.yaml file is added, changed or removed. onReload is called after every
+// reload attempt with its error, if any. It blocks until ctx is cancelled.
+func (r *Registry) WatchYAML(ctx context.Context, dir string, interval time.Duration, onReload func(error)) {
+	last := fingerprint(dir)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := fingerprint(dir)
+			if current == last {
+				continue
+			}
+			last = current
+			onReload(r.ReloadYAML(dir))
+		}
+	}
+}
+
+// fingerprint summarizes the name, size and modification time of each YAML file in dir.
+func fingerprint(dir string) string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	var fp string
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		fp += fmt.Sprintf("%s:%d:%d;", filepath.Base(f), info.Size(), info.ModTime().UnixNano())
+	}
+	return fp
+}
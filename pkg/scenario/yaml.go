@@ -0,0 +1,510 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s-dojo/pkg/apps"
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/policy"
+	"k8s-dojo/pkg/scenario/check"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// setupScriptTimeout bounds a definition's setup script, which may wait for
+// what it creates.
+const setupScriptTimeout = 3 * time.Minute
+
+// Definition is a scenario described in YAML instead of Go.
+type Definition struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	Difficulty     Difficulty      `json:"difficulty"`
+	Category       string          `json:"category"`
+	Tags           []string        `json:"tags,omitempty"`
+	Hints          []string        `json:"hints,omitempty"`
+	Commands       []Command       `json:"commands,omitempty"` // Quick commands, see Metadata.Commands
+	Docs           []DocLink       `json:"docs,omitempty"`     // See Metadata.DocsLinks
+	TimeLimit      metav1.Duration `json:"timeLimit,omitempty"`
+	Version        int             `json:"version,omitempty"` // Content version, see Metadata.Version
+	ClusterAccess  bool            `json:"clusterAccess,omitempty"`
+	Namespace      string          `json:"namespace"`
+	App            string          `json:"app,omitempty"`   // Reference app deployed before setup, see pkg/apps
+	Setup          string          `json:"setup"`           // Manifest applied into the namespace
+	Solve          string          `json:"solve,omitempty"` // Reference fix, applied the same way
+	SuccessMessage string          `json:"successMessage,omitempty"`
+	SetupScript    string          `json:"setupScript,omitempty"`
+	Checks         []CheckSpec     `json:"checks"`
+	Guide          []GuideSpec     `json:"guide,omitempty"` // Steps for guided mode
+	Source         string          `json:"-"`               // File the definition was loaded from
+}
+
+// GuideSpec declares one guided-mode step of a YAML scenario.
+// Steps without a check are advanced by the learner.
+type GuideSpec struct {
+	Title       string     `json:"title"`
+	Instruction string     `json:"instruction"`
+	Command     string     `json:"command,omitempty"`
+	Check       *CheckSpec `json:"check,omitempty"`
+}
+
+// CheckSpec declares one validation condition of a YAML scenario.
+type CheckSpec struct {
+	Type string `json:"type"` // podRunning, podsRunning, podStable, podsStable, deploymentAvailable, deploymentRolledOut, endpointsNonEmpty, fieldEquals, script, cel
+	Name string `json:"name,omitempty"`
+
+	// Target object; Selector is used by podsRunning, podsStable and cel
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Object     string `json:"object,omitempty"`
+	Selector   string `json:"selector,omitempty"`
+
+	// fieldEquals only
+	Path  string `json:"path,omitempty"`
+	Value string `json:"value,omitempty"`
+
+	// cel only: a CEL condition over object (and e.g. deployment for kind
+	// Deployment) or, without object, over the list of objects matching selector
+	Expression string `json:"expression,omitempty"`
+
+	// podStable and podsStable only: how long containers must stay up, e.g. "45s"
+	For string `json:"for,omitempty"`
+
+	// script only: shell run with NAMESPACE set, passing on exit 0. RunIn is
+	// "pod" (default), a disposable validator pod of Image with read access to
+	// the namespace, or "local", on this machine with KUBECONFIG set; Expect
+	// is a regular expression the output must match.
+	Script  string `json:"script,omitempty"`
+	RunIn   string `json:"runIn,omitempty"`
+	Image   string `json:"image,omitempty"`
+	Expect  string `json:"expect,omitempty"`
+	Timeout string `json:"timeout,omitempty"` // e.g. "1m"
+
+	// Message shown when the check fails, instead of the default
+	Failure string `json:"failure,omitempty"`
+
+	// How much a failure matters: blocker (default), warn or info. Only
+	// blockers keep the scenario from being solved.
+	Severity string `json:"severity,omitempty"`
+
+	// Documentation linked when the check fails, instead of the default
+	Doc string `json:"doc,omitempty"`
+}
+
+// checkTypes lists the supported CheckSpec types.
+var checkTypes = map[string]bool{
+	"podRunning":          true,
+	"podsRunning":         true,
+	"podStable":           true,
+	"podsStable":          true,
+	"deploymentAvailable": true,
+	"deploymentRolledOut": true,
+	"endpointsNonEmpty":   true,
+	"fieldEquals":         true,
+	"script":              true,
+	"cel":                 true,
+}
+
+func (c CheckSpec) validate() error {
+	if !checkTypes[c.Type] {
+		return fmt.Errorf("unknown type %q", c.Type)
+	}
+	if c.Type == "fieldEquals" && (c.Kind == "" || c.Path == "") {
+		return fmt.Errorf("fieldEquals needs kind and path")
+	}
+	if c.Type == "cel" {
+		if c.Kind == "" || c.Expression == "" {
+			return fmt.Errorf("cel needs kind and expression")
+		}
+		if _, err := c.celExpression(); err != nil {
+			return err
+		}
+	}
+	if c.Type == "script" && strings.TrimSpace(c.Script) == "" {
+		return fmt.Errorf("script check needs a script")
+	}
+	if _, err := c.window(); err != nil {
+		return err
+	}
+	if _, err := c.scriptOptions(); err != nil {
+		return err
+	}
+	if _, err := check.ParseSeverity(c.Severity); err != nil {
+		return err
+	}
+	if c.Doc != "" {
+		if err := validateDocURL(c.Doc); err != nil {
+			return fmt.Errorf("doc: %w", err)
+		}
+	}
+	return nil
+}
+
+// celExpression compiles the condition of a cel check.
+func (c CheckSpec) celExpression() (*policy.Expression, error) {
+	return check.CompileCEL(c.Expression, c.Kind, c.Object == "")
+}
+
+// apiVersion returns the target's apiVersion, v1 unless set.
+func (c CheckSpec) apiVersion() string {
+	if c.APIVersion == "" {
+		return "v1"
+	}
+	return c.APIVersion
+}
+
+// scriptOptions returns how a script check runs its script.
+func (c CheckSpec) scriptOptions() (check.ScriptOptions, error) {
+	opts := check.ScriptOptions{Image: c.Image}
+	switch c.RunIn {
+	case "", "pod":
+		opts.InPod = true
+	case "local":
+	default:
+		return opts, fmt.Errorf("invalid runIn %q, want pod or local", c.RunIn)
+	}
+	if c.Expect != "" {
+		re, err := regexp.Compile(c.Expect)
+		if err != nil {
+			return opts, fmt.Errorf("invalid expect %q: %w", c.Expect, err)
+		}
+		opts.Expect = re
+	}
+	if c.Timeout != "" {
+		d, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return opts, fmt.Errorf("invalid timeout %q: %w", c.Timeout, err)
+		}
+		opts.Timeout = d
+	}
+	return opts, nil
+}
+
+// window returns how long a podStable or podsStable check requires containers to stay up.
+func (c CheckSpec) window() (time.Duration, error) {
+	if c.For == "" {
+		return check.DefaultStableWindow, nil
+	}
+	d, err := time.ParseDuration(c.For)
+	if err != nil {
+		return 0, fmt.Errorf("invalid for %q: %w", c.For, err)
+	}
+	return d, nil
+}
+
+// ParseDefinition decodes and validates a YAML scenario definition.
+func ParseDefinition(data []byte) (*Definition, error) {
+	var def Definition
+	if err := yaml.UnmarshalStrict(data, &def); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	if err := def.validate(); err != nil {
+		return nil, err
+	}
+	if def.Namespace == "" {
+		def.Namespace = def.ID
+	}
+	return &def, nil
+}
+
+func (d *Definition) validate() error {
+	switch {
+	case d.ID == "":
+		return fmt.Errorf("scenario is missing id")
+	case d.Name == "":
+		return fmt.Errorf("scenario %s is missing name", d.ID)
+	case len(d.Checks) == 0:
+		return fmt.Errorf("scenario %s has no checks", d.ID)
+	}
+	for i, c := range d.Checks {
+		if err := c.validate(); err != nil {
+			return fmt.Errorf("scenario %s: check %d: %w", d.ID, i, err)
+		}
+	}
+	for i, h := range d.Hints {
+		if err := ValidateHint(h); err != nil {
+			return fmt.Errorf("scenario %s: hint %d: %w", d.ID, i, err)
+		}
+	}
+	for i, c := range d.Commands {
+		if err := c.validate(); err != nil {
+			return fmt.Errorf("scenario %s: command %d: %w", d.ID, i, err)
+		}
+	}
+	for i, link := range d.Docs {
+		if err := link.validate(); err != nil {
+			return fmt.Errorf("scenario %s: doc %d: %w", d.ID, i, err)
+		}
+	}
+	for i, g := range d.Guide {
+		if g.Title == "" {
+			return fmt.Errorf("scenario %s: guide step %d is missing title", d.ID, i)
+		}
+		if g.Check == nil {
+			continue
+		}
+		if err := g.Check.validate(); err != nil {
+			return fmt.Errorf("scenario %s: guide step %d: %w", d.ID, i, err)
+		}
+	}
+	if d.App != "" && apps.Get(d.App) == nil {
+		return fmt.Errorf("scenario %s: unknown app %q", d.ID, d.App)
+	}
+	if _, err := k8s.DecodeManifest(d.Setup); err != nil {
+		return fmt.Errorf("scenario %s: invalid setup manifest: %w", d.ID, err)
+	}
+	return nil
+}
+
+// RefusePackScripts returns an error if the definition, as pack content, may
+// not run its scripts: script checks must run in a validator pod, and a setup
+// script or a quick command other than a plain kubectl invocation, which run
+// on this machine with the learner's kubeconfig, only run if the user trusts
+// the pack.
+func (d *Definition) RefusePackScripts(trusted bool) error {
+	specs := append([]CheckSpec(nil), d.Checks...)
+	for _, g := range d.Guide {
+		if g.Check != nil {
+			specs = append(specs, *g.Check)
+		}
+	}
+	for _, c := range specs {
+		if c.Type == "script" && c.RunIn == "local" {
+			return fmt.Errorf("scenario %s: script check %q runs on this machine; pack checks must run in a pod", d.ID, c.Name)
+		}
+	}
+	if trusted {
+		return nil
+	}
+	if d.SetupScript != "" {
+		return fmt.Errorf("scenario %s runs a setupScript on this machine; trust the pack with --trust-scripts only if you trust its author", d.ID)
+	}
+	for _, c := range d.Commands {
+		if !c.plainKubectl() {
+			return fmt.Errorf("scenario %s: quick command %q is not a plain kubectl command; trust the pack with --trust-scripts only if you trust its author", d.ID, c.Command)
+		}
+	}
+	return nil
+}
+
+// LoadDefinitions parses every *.yaml file in dir. A missing directory yields no definitions.
+func LoadDefinitions(dir string) ([]*Definition, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scenarios: %w", err)
+	}
+
+	var defs []*Definition
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f, err)
+		}
+		def, err := ParseDefinition(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(f), err)
+		}
+		def.Source = f
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// DefaultScenarioDir returns the directory YAML scenarios are loaded from (~/.k8s-dojo/scenarios).
+func DefaultScenarioDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".k8s-dojo", "scenarios")
+	}
+	return filepath.Join(home, ".k8s-dojo", "scenarios")
+}
+
+// YAMLScenario runs a Definition.
+type YAMLScenario struct {
+	BaseScenario
+	client *k8s.Client
+
+	mu  sync.RWMutex
+	def *Definition
+}
+
+// NewYAMLScenario creates a scenario from a parsed definition.
+func NewYAMLScenario(client *k8s.Client, def *Definition) *YAMLScenario {
+	return &YAMLScenario{
+		BaseScenario: BaseScenario{Namespace: def.Namespace},
+		def:          def,
+		client:       client,
+	}
+}
+
+// Definition returns the underlying definition.
+func (s *YAMLScenario) Definition() *Definition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.def
+}
+
+// SetDefinition swaps in a reloaded definition. Metadata, checks and the
+// solve manifest take effect immediately; the namespace is kept.
+func (s *YAMLScenario) SetDefinition(def *Definition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.def = def
+}
+
+func (s *YAMLScenario) GetMetadata() Metadata {
+	return s.Definition().metadata()
+}
+
+// metadata describes the scenario the definition declares.
+func (def *Definition) metadata() Metadata {
+	return Metadata{
+		ID:          def.ID,
+		Name:        def.Name,
+		Description: def.Description,
+		Difficulty:  def.Difficulty,
+		Category:    def.Category,
+		Tags:        def.Tags,
+		Hints:       def.Hints,
+		TimeLimit:   def.TimeLimit.Duration,
+		Version:     def.Version,
+
+		ClusterAccess: def.ClusterAccess,
+		Commands:      def.Commands,
+		DocsLinks:     def.Docs,
+	}
+}
+
+func (s *YAMLScenario) Setup(ctx context.Context) error {
+	_, err := s.client.Clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// The setup manifest breaks a healthy app, so wait for it first
+	def := s.Definition()
+	if app := apps.Get(def.App); app != nil {
+		if err := app.Deploy(ctx, s.client, s.Namespace); err != nil {
+			return err
+		}
+		readyCtx, cancel := context.WithTimeout(ctx, apps.ReadyTimeout)
+		err := app.WaitReady(readyCtx, s.client.Clientset, s.Namespace)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	if err := s.client.ApplyYAML(ctx, def.Setup, s.Namespace); err != nil {
+		return err
+	}
+	if def.SetupScript == "" {
+		return nil
+	}
+	if _, err := check.RunScript(ctx, s.client, s.Namespace, def.SetupScript, setupScriptTimeout); err != nil {
+		return fmt.Errorf("setup script failed: %w", err)
+	}
+	return nil
+}
+
+func (s *YAMLScenario) Validate(ctx context.Context) Result {
+	def := s.Definition()
+	checks := make([]Check, 0, len(def.Checks))
+	for _, spec := range def.Checks {
+		checks = append(checks, s.evaluate(ctx, spec))
+	}
+
+	msg := def.SuccessMessage
+	if msg == "" {
+		msg = "Success! All checks passed."
+	}
+	return NewResult(msg, checks...)
+}
+
+// evaluate runs one declared check.
+func (s *YAMLScenario) evaluate(ctx context.Context, spec CheckSpec) Check {
+	cs := s.client.Clientset
+
+	var c Check
+	switch spec.Type {
+	case "podRunning":
+		c = check.PodRunning(ctx, cs, s.Namespace, spec.Object)
+	case "podsRunning":
+		c = check.PodsRunning(ctx, cs, s.Namespace, spec.Selector)
+	case "podStable":
+		window, _ := spec.window() // Checked when the definition was parsed
+		c = check.PodStable(ctx, cs, s.Namespace, spec.Object, window)
+	case "podsStable":
+		window, _ := spec.window()
+		c = check.PodsStable(ctx, cs, s.Namespace, spec.Selector, window)
+	case "deploymentAvailable":
+		c = check.DeploymentAvailable(ctx, cs, s.Namespace, spec.Object)
+	case "deploymentRolledOut":
+		c = check.DeploymentRolledOut(ctx, cs, s.Namespace, spec.Object)
+	case "endpointsNonEmpty":
+		c = check.EndpointsNonEmpty(ctx, cs, s.Namespace, spec.Object)
+	case "fieldEquals":
+		c = check.ObjectFieldEquals(ctx, s.client, spec.apiVersion(), spec.Kind, s.Namespace, spec.Object, spec.Path, spec.Value)
+	case "cel":
+		expr, _ := spec.celExpression() // Checked when the definition was parsed
+		c = check.CEL(ctx, s.client, spec.apiVersion(), spec.Kind, s.Namespace, spec.Object, spec.Selector, expr)
+	case "script":
+		opts, _ := spec.scriptOptions()
+		c = check.Script(ctx, s.client, s.Namespace, spec.Script, opts)
+	}
+
+	if spec.Name != "" {
+		c = c.Named(spec.Name)
+	}
+	if spec.Failure != "" {
+		c = c.OrElse(spec.Failure)
+	}
+	if spec.Severity != "" {
+		severity, _ := check.ParseSeverity(spec.Severity) // Checked when the definition was parsed
+		c = c.WithSeverity(severity)
+	}
+	if spec.Doc != "" {
+		c = c.WithDoc(spec.Doc)
+	}
+	return c
+}
+
+// Guide returns the definition's guided steps.
+func (s *YAMLScenario) Guide() []GuideStep {
+	def := s.Definition()
+	steps := make([]GuideStep, 0, len(def.Guide))
+	for _, g := range def.Guide {
+		step := GuideStep{Title: g.Title, Instruction: g.Instruction, Command: g.Command}
+		if g.Check != nil {
+			spec := *g.Check
+			step.Verify = func(ctx context.Context) Check {
+				return s.evaluate(ctx, spec)
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func (s *YAMLScenario) Solve(ctx context.Context) error {
+	def := s.Definition()
+	if strings.TrimSpace(def.Solve) == "" {
+		return fmt.Errorf("scenario %s has no solve manifest", def.ID)
+	}
+	return s.client.ApplyYAML(ctx, def.Solve, s.Namespace)
+}
+
+func (s *YAMLScenario) Cleanup(ctx context.Context) error {
+	return s.client.Clientset.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{})
+}
@@ -0,0 +1,89 @@
+// Package score computes the points awarded for solving a scenario.
+package score
+
+import (
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+const (
+	// MaxHintPenalty caps the hint deduction, as a fraction of the base points.
+	MaxHintPenalty = 0.6
+
+	// FreeChecks is how many checks an attempt may run before each further check costs points.
+	FreeChecks = 3
+
+	// MaxCheckPenalty caps the check deduction, as a fraction of the base points.
+	MaxCheckPenalty = 0.2
+
+	// MinPoints is awarded for any solve, however many hints it took.
+	MinPoints = 10
+)
+
+// Attempt describes a solve to be scored.
+type Attempt struct {
+	Difficulty scenario.Difficulty
+	TimeLimit  time.Duration // Par time when set; otherwise ParTime applies
+	Elapsed    time.Duration
+	Hints      int // Distinct hints revealed
+	Checks     int // Validations run, including the one that passed
+}
+
+// Breakdown is the points for a solve and how they were reached.
+type Breakdown struct {
+	Base         int
+	TimeBonus    int
+	HintPenalty  int
+	CheckPenalty int
+}
+
+// Total returns the points awarded.
+func (b Breakdown) Total() int {
+	return max(MinPoints, b.Base+b.TimeBonus-b.HintPenalty-b.CheckPenalty)
+}
+
+// BasePoints returns the points for solving a scenario of the given difficulty.
+func BasePoints(d scenario.Difficulty) int {
+	switch d {
+	case scenario.DifficultyMedium:
+		return 200
+	case scenario.DifficultyHard:
+		return 300
+	}
+	return 100
+}
+
+// ParTime returns the target solve time for a difficulty.
+func ParTime(d scenario.Difficulty) time.Duration {
+	switch d {
+	case scenario.DifficultyMedium:
+		return 15 * time.Minute
+	case scenario.DifficultyHard:
+		return 30 * time.Minute
+	}
+	return 5 * time.Minute
+}
+
+// Compute scores a solve. Beating par earns up to half the base points again,
+// scaled by how much time was left; each hint costs 15% of the base and each
+// check beyond FreeChecks costs 2 points, both capped.
+func Compute(a Attempt) Breakdown {
+	base := BasePoints(a.Difficulty)
+	b := Breakdown{Base: base}
+
+	par := a.TimeLimit
+	if par <= 0 {
+		par = ParTime(a.Difficulty)
+	}
+	if a.Elapsed < par {
+		left := float64(par-a.Elapsed) / float64(par)
+		b.TimeBonus = int(float64(base) / 2 * left)
+	}
+
+	b.HintPenalty = min(a.Hints*base*15/100, int(float64(base)*MaxHintPenalty))
+	if extra := a.Checks - FreeChecks; extra > 0 {
+		b.CheckPenalty = min(extra*2, int(float64(base)*MaxCheckPenalty))
+	}
+	return b
+}
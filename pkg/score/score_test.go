@@ -0,0 +1,59 @@
+package score
+
+import (
+	"testing"
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt Attempt
+		want    Breakdown
+		total   int
+	}{
+		{
+			name:    "easy at par, no hints",
+			attempt: Attempt{Difficulty: scenario.DifficultyEasy, Elapsed: 5 * time.Minute, Checks: 1},
+			want:    Breakdown{Base: 100},
+			total:   100,
+		},
+		{
+			name:    "medium in a third of par",
+			attempt: Attempt{Difficulty: scenario.DifficultyMedium, Elapsed: 5 * time.Minute, Checks: 2},
+			want:    Breakdown{Base: 200, TimeBonus: 66},
+			total:   266,
+		},
+		{
+			name:    "hard with hints and many checks",
+			attempt: Attempt{Difficulty: scenario.DifficultyHard, Elapsed: time.Hour, Hints: 2, Checks: 8},
+			want:    Breakdown{Base: 300, HintPenalty: 90, CheckPenalty: 10},
+			total:   200,
+		},
+		{
+			name:    "penalties are capped",
+			attempt: Attempt{Difficulty: scenario.DifficultyEasy, Elapsed: time.Hour, Hints: 10, Checks: 100},
+			want:    Breakdown{Base: 100, HintPenalty: 60, CheckPenalty: 20},
+			total:   20,
+		},
+		{
+			name:    "time limit overrides par",
+			attempt: Attempt{Difficulty: scenario.DifficultyEasy, TimeLimit: 10 * time.Minute, Elapsed: 5 * time.Minute},
+			want:    Breakdown{Base: 100, TimeBonus: 25},
+			total:   125,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compute(tt.attempt)
+			if got != tt.want {
+				t.Errorf("Compute() = %+v, want %+v", got, tt.want)
+			}
+			if got.Total() != tt.total {
+				t.Errorf("Total() = %d, want %d", got.Total(), tt.total)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Package skills summarises progress per category and renders it as ASCII
+// bars and a radar chart for the statistics view.
+package skills
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/state"
+)
+
+// Category is the progress in one scenario category.
+type Category struct {
+	Name      string
+	Total     int
+	Completed int
+
+	// Solves and AverageSolve cover timed solves only; completions saved
+	// before solve times were recorded are not counted.
+	Solves       int
+	AverageSolve time.Duration
+}
+
+// Completion returns the fraction of the category's scenarios completed.
+func (c Category) Completion() float64 {
+	if c.Total == 0 {
+		return 0
+	}
+	return float64(c.Completed) / float64(c.Total)
+}
+
+// ByCategory computes progress per category, in order of first appearance in catalog.
+func ByCategory(catalog []scenario.Metadata, st *state.State) []Category {
+	if st == nil {
+		st = &state.State{}
+	}
+	index := make(map[string]int)
+	var cats []Category
+	var seconds []int
+	for _, meta := range catalog {
+		i, ok := index[meta.Category]
+		if !ok {
+			i = len(cats)
+			index[meta.Category] = i
+			cats = append(cats, Category{Name: meta.Category})
+			seconds = append(seconds, 0)
+		}
+		cats[i].Total++
+		if st.CompletedScenarios[meta.ID] {
+			cats[i].Completed++
+		}
+		if solve, ok := st.Solves[meta.ID]; ok {
+			cats[i].Solves += solve.Count
+			seconds[i] += solve.TotalSeconds
+		}
+	}
+	for i := range cats {
+		if cats[i].Solves > 0 {
+			cats[i].AverageSolve = time.Duration(seconds[i]/cats[i].Solves) * time.Second
+		}
+	}
+	return cats
+}
+
+// Bar renders fraction (0 to 1) as a bar of width cells.
+func Bar(fraction float64, width int) string {
+	fraction = math.Max(0, math.Min(1, fraction))
+	filled := int(math.Round(fraction * float64(width)))
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// Radar renders values (0 to 1) on one axis per label. Characters are about
+// twice as tall as wide, so x is stretched to keep the chart round. At least
+// three axes are needed for a meaningful shape.
+func Radar(labels []string, values []float64, radius int) string {
+	margin := 0
+	for _, l := range labels {
+		margin = max(margin, len([]rune(l))+1)
+	}
+	width := 4*radius + 1 + 2*margin
+	height := 2*radius + 3 // One row above and below for labels
+	grid := make([][]rune, height)
+	for y := range grid {
+		grid[y] = []rune(strings.Repeat(" ", width))
+	}
+	cx, cy := float64(margin+2*radius), float64(radius+1)
+	set := func(x, y float64, r rune) {
+		col, row := int(math.Round(x)), int(math.Round(y))
+		if row >= 0 && row < height && col >= 0 && col < width {
+			grid[row][col] = r
+		}
+	}
+	point := func(i int, scale float64) (float64, float64) {
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(len(labels))
+		return cx + 2*scale*math.Cos(angle), cy + scale*math.Sin(angle)
+	}
+
+	// Axes
+	for i := range labels {
+		for k := 1; k <= radius; k++ {
+			x, y := point(i, float64(k))
+			set(x, y, '·')
+		}
+	}
+	set(cx, cy, '+')
+
+	// Outline of the values, then their vertices on top
+	for i := range labels {
+		x0, y0 := point(i, float64(radius)*clamp(values[i]))
+		j := (i + 1) % len(labels)
+		x1, y1 := point(j, float64(radius)*clamp(values[j]))
+		steps := int(math.Max(math.Abs(x1-x0), math.Abs(y1-y0)))
+		for s := 1; s < steps; s++ {
+			t := float64(s) / float64(steps)
+			set(x0+(x1-x0)*t, y0+(y1-y0)*t, '*')
+		}
+	}
+	for i := range labels {
+		x, y := point(i, float64(radius)*clamp(values[i]))
+		set(x, y, '●')
+	}
+
+	// Labels just beyond each axis tip, aligned away from the chart
+	for i, l := range labels {
+		x, y := point(i, float64(radius)+1)
+		label := []rune(l)
+		start := int(math.Round(x))
+		switch {
+		case x < cx-1:
+			start -= len(label) - 1
+		case math.Abs(x-cx) <= 1:
+			start -= len(label) / 2
+		}
+		row := int(math.Round(y))
+		for k, r := range label {
+			if col := start + k; row >= 0 && row < height && col >= 0 && col < width {
+				grid[row][col] = r
+			}
+		}
+	}
+
+	lines := make([]string, height)
+	for y, row := range grid {
+		lines[y] = strings.TrimRight(string(row), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
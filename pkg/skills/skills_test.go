@@ -0,0 +1,60 @@
+package skills
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/state"
+)
+
+func TestByCategory(t *testing.T) {
+	catalog := []scenario.Metadata{
+		{ID: "n1", Category: "Networking"},
+		{ID: "s1", Category: "Storage"},
+		{ID: "n2", Category: "Networking"},
+	}
+	st := &state.State{CompletedScenarios: map[string]bool{"n1": true, "n2": true}}
+	st.RecordSolve("n1", 2*time.Minute, time.Now())
+	st.RecordSolve("n1", 4*time.Minute, time.Now())
+	st.RecordSolve("n2", 6*time.Minute, time.Now())
+
+	cats := ByCategory(catalog, st)
+	if len(cats) != 2 || cats[0].Name != "Networking" || cats[1].Name != "Storage" {
+		t.Fatalf("unexpected categories: %+v", cats)
+	}
+	net := cats[0]
+	if net.Completion() != 1 || net.Solves != 3 || net.AverageSolve != 4*time.Minute {
+		t.Errorf("Networking: %+v", net)
+	}
+	if cats[1].Completion() != 0 || cats[1].AverageSolve != 0 {
+		t.Errorf("Storage: %+v", cats[1])
+	}
+}
+
+func TestBar(t *testing.T) {
+	if got := Bar(0.5, 10); got != "█████░░░░░" {
+		t.Errorf("Bar(0.5) = %q", got)
+	}
+	if got := Bar(2, 4); got != "████" {
+		t.Errorf("Bar(2) = %q, want it clamped", got)
+	}
+}
+
+func TestRadar(t *testing.T) {
+	labels := []string{"Networking", "Storage", "Security"}
+	out := Radar(labels, []float64{1, 0.5, 0}, 4)
+	lines := strings.Split(out, "\n")
+	if len(lines) != 2*4+3 {
+		t.Errorf("got %d lines, want %d", len(lines), 2*4+3)
+	}
+	for _, l := range labels {
+		if !strings.Contains(out, l) {
+			t.Errorf("label %s missing from:\n%s", l, out)
+		}
+	}
+	if !strings.Contains(lines[1], "●") {
+		t.Errorf("full value should reach the top of the chart:\n%s", out)
+	}
+}
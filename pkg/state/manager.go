@@ -6,12 +6,110 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 )
 
 // State represents the persistent application state.
 type State struct {
 	CompletedScenarios map[string]bool `json:"completed_scenarios"`
 	LastActiveScenario string          `json:"last_active_scenario,omitempty"`
+
+	// CompletedVersions records the scenario content version each completion was graded against.
+	// Completions saved before versioning have no entry and count as version 1.
+	CompletedVersions map[string]int `json:"completed_versions,omitempty"`
+
+	// Solves records solve times per scenario. Completions saved before solve
+	// times were tracked have no entry.
+	Solves map[string]Solve `json:"solves,omitempty"`
+
+	// ActiveDays counts solves per local calendar day (YYYY-MM-DD), for streaks and weekly goals.
+	ActiveDays map[string]int `json:"active_days,omitempty"`
+
+	// WeeklyGoal is the number of scenarios the user aims to solve each week, 0 for none.
+	WeeklyGoal int `json:"weekly_goal,omitempty"`
+
+	// LastSeenRelease is the newest changelog release the user has seen.
+	LastSeenRelease string `json:"last_seen_release,omitempty"`
+
+	// NewScenarios records when the scenarios added by an upgrade were first seen.
+	NewScenarios map[string]time.Time `json:"new_scenarios,omitempty"`
+}
+
+// NewBadgeDuration is how long a scenario added by an upgrade counts as new.
+const NewBadgeDuration = 14 * 24 * time.Hour
+
+// Solve aggregates the timed solves of one scenario.
+type Solve struct {
+	Count        int       `json:"count"`
+	TotalSeconds int       `json:"total_seconds"`
+	BestSeconds  int       `json:"best_seconds"`
+	LastSolved   time.Time `json:"last_solved"`
+}
+
+// Average returns the mean solve time.
+func (s Solve) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return time.Duration(s.TotalSeconds/s.Count) * time.Second
+}
+
+// RecordSolve adds a solve that took elapsed and finished at.
+func (s *State) RecordSolve(scenarioID string, elapsed time.Duration, at time.Time) {
+	if s.Solves == nil {
+		s.Solves = make(map[string]Solve)
+	}
+	secs := int(elapsed.Seconds())
+	solve := s.Solves[scenarioID]
+	if solve.Count == 0 || secs < solve.BestSeconds {
+		solve.BestSeconds = secs
+	}
+	solve.Count++
+	solve.TotalSeconds += secs
+	solve.LastSolved = at
+	s.Solves[scenarioID] = solve
+
+	if s.ActiveDays == nil {
+		s.ActiveDays = make(map[string]int)
+	}
+	s.ActiveDays[dayKey(at)]++
+}
+
+// CompletedVersion returns the content version a scenario was completed at, or 0 if it is not completed.
+func (s *State) CompletedVersion(scenarioID string) int {
+	if !s.CompletedScenarios[scenarioID] {
+		return 0
+	}
+	if v, ok := s.CompletedVersions[scenarioID]; ok {
+		return v
+	}
+	return 1
+}
+
+// IsOutdated reports whether a scenario was completed against an older content version than current.
+func (s *State) IsOutdated(scenarioID string, current int) bool {
+	v := s.CompletedVersion(scenarioID)
+	return v > 0 && v < current
+}
+
+// SeeRelease records that the user has seen release version, which with the
+// releases before it added scenarios. Scenarios seen before keep their date.
+func (s *State) SeeRelease(version string, scenarios []string, now time.Time) {
+	s.LastSeenRelease = version
+	if s.NewScenarios == nil {
+		s.NewScenarios = make(map[string]time.Time)
+	}
+	for _, id := range scenarios {
+		if _, ok := s.NewScenarios[id]; !ok {
+			s.NewScenarios[id] = now
+		}
+	}
+}
+
+// IsNew reports whether a scenario was added by an upgrade in the last NewBadgeDuration.
+func (s *State) IsNew(scenarioID string, now time.Time) bool {
+	seen, ok := s.NewScenarios[scenarioID]
+	return ok && now.Sub(seen) < NewBadgeDuration
 }
 
 // Manager handles saving and loading of application state.
@@ -45,6 +143,9 @@ func (m *Manager) Load() (*State, error) {
 	// Default empty state
 	state := &State{
 		CompletedScenarios: make(map[string]bool),
+		CompletedVersions:  make(map[string]int),
+		Solves:             make(map[string]Solve),
+		ActiveDays:         make(map[string]int),
 	}
 
 	data, err := os.ReadFile(m.path)
@@ -62,6 +163,15 @@ func (m *Manager) Load() (*State, error) {
 	if state.CompletedScenarios == nil {
 		state.CompletedScenarios = make(map[string]bool)
 	}
+	if state.CompletedVersions == nil {
+		state.CompletedVersions = make(map[string]int)
+	}
+	if state.Solves == nil {
+		state.Solves = make(map[string]Solve)
+	}
+	if state.ActiveDays == nil {
+		state.ActiveDays = make(map[string]int)
+	}
 
 	return state, nil
 }
@@ -90,12 +200,56 @@ func (m *Manager) Save(state *State) error {
 
 // MarkScenarioCompleted updates the state to mark a scenario as completed.
 func (m *Manager) MarkScenarioCompleted(scenarioID string) error {
+	return m.MarkScenarioCompletedVersion(scenarioID, 1)
+}
+
+// MarkScenarioCompletedVersion marks a scenario as completed at the given content version.
+func (m *Manager) MarkScenarioCompletedVersion(scenarioID string, version int) error {
+	state, err := m.Load()
+	if err != nil {
+		return err
+	}
+
+	state.CompletedScenarios[scenarioID] = true
+	state.CompletedVersions[scenarioID] = version
+
+	return m.Save(state)
+}
+
+// RecordSolve marks a scenario as completed at the given content version and
+// records how long the solve took.
+func (m *Manager) RecordSolve(scenarioID string, version int, elapsed time.Duration) error {
 	state, err := m.Load()
 	if err != nil {
 		return err
 	}
 
 	state.CompletedScenarios[scenarioID] = true
+	state.CompletedVersions[scenarioID] = version
+	state.RecordSolve(scenarioID, elapsed, time.Now())
 
 	return m.Save(state)
 }
+
+// MarkReleaseSeen records that the user has seen release version and the scenarios it added.
+func (m *Manager) MarkReleaseSeen(version string, scenarios []string, now time.Time) error {
+	state, err := m.Load()
+	if err != nil {
+		return err
+	}
+	state.SeeRelease(version, scenarios, now)
+	return m.Save(state)
+}
+
+// SetWeeklyGoal sets the number of scenarios to solve each week. 0 clears the goal.
+func (m *Manager) SetWeeklyGoal(goal int) error {
+	if goal < 0 {
+		return fmt.Errorf("weekly goal must not be negative: %d", goal)
+	}
+	state, err := m.Load()
+	if err != nil {
+		return err
+	}
+	state.WeeklyGoal = goal
+	return m.Save(state)
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestManager(t *testing.T) {
@@ -58,3 +59,106 @@ func TestManager(t *testing.T) {
 		t.Error("Expected test-scenario to be completed in new instance")
 	}
 }
+
+func TestCompletedVersions(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+
+	// State saved before versioning has completions without versions
+	legacy := `{"completed_scenarios": {"old-scenario": true}}`
+	if err := os.WriteFile(statePath, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr, err := NewManager(statePath)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	state, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if v := state.CompletedVersion("old-scenario"); v != 1 {
+		t.Errorf("Expected legacy completion at v1, got v%d", v)
+	}
+	if !state.IsOutdated("old-scenario", 2) {
+		t.Error("Expected v1 completion to be outdated at v2")
+	}
+	if state.IsOutdated("missing", 2) {
+		t.Error("Expected uncompleted scenario not to be outdated")
+	}
+
+	if err := mgr.MarkScenarioCompletedVersion("old-scenario", 2); err != nil {
+		t.Fatalf("MarkScenarioCompletedVersion failed: %v", err)
+	}
+	state, err = mgr.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if state.IsOutdated("old-scenario", 2) {
+		t.Error("Expected v2 completion to be current")
+	}
+}
+
+func TestRecordSolve(t *testing.T) {
+	mgr, err := NewManager(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	if err := mgr.RecordSolve("s", 2, 5*time.Minute); err != nil {
+		t.Fatalf("RecordSolve failed: %v", err)
+	}
+	if err := mgr.RecordSolve("s", 2, 3*time.Minute); err != nil {
+		t.Fatalf("RecordSolve failed: %v", err)
+	}
+
+	state, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if state.CompletedVersion("s") != 2 {
+		t.Errorf("Expected completion at v2, got v%d", state.CompletedVersion("s"))
+	}
+	solve := state.Solves["s"]
+	if solve.Count != 2 || solve.BestSeconds != 180 {
+		t.Errorf("Unexpected solve record: %+v", solve)
+	}
+	if solve.Average() != 4*time.Minute {
+		t.Errorf("Expected 4m average, got %s", solve.Average())
+	}
+	if solve.LastSolved.IsZero() {
+		t.Error("Expected LastSolved to be set")
+	}
+}
+
+func TestMarkReleaseSeen(t *testing.T) {
+	mgr, err := NewManager(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	start := time.Now()
+	if err := mgr.MarkReleaseSeen("1.1.0", []string{"a"}, start); err != nil {
+		t.Fatalf("MarkReleaseSeen failed: %v", err)
+	}
+	later := start.Add(10 * 24 * time.Hour)
+	if err := mgr.MarkReleaseSeen("1.2.0", []string{"a", "b"}, later); err != nil {
+		t.Fatalf("MarkReleaseSeen failed: %v", err)
+	}
+
+	state, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if state.LastSeenRelease != "1.2.0" {
+		t.Errorf("Expected release 1.2.0 to be seen, got %q", state.LastSeenRelease)
+	}
+	end := start.Add(NewBadgeDuration)
+	if state.IsNew("a", end) {
+		t.Error("Expected a to stop being new two weeks after it was first seen")
+	}
+	if !state.IsNew("b", end) {
+		t.Error("Expected b to still be new")
+	}
+	if state.IsNew("c", start) {
+		t.Error("Expected a scenario no release added not to be new")
+	}
+}
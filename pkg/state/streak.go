@@ -0,0 +1,75 @@
+package state
+
+import (
+	"fmt"
+	"time"
+)
+
+// StreakMilestones are the streak lengths, in days, that earn an achievement.
+var StreakMilestones = []int{3, 7, 14, 30, 60, 100, 365}
+
+// dayKey identifies a local calendar day in ActiveDays.
+func dayKey(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+// Streak returns the number of consecutive days with at least one solve,
+// ending today. A streak that ended yesterday is still alive until today ends.
+func (s *State) Streak(now time.Time) int {
+	day := now
+	if s.ActiveDays[dayKey(day)] == 0 {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for s.ActiveDays[dayKey(day)] > 0 {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// WeekSolves returns the number of solves in the week containing now, starting on Monday.
+func (s *State) WeekSolves(now time.Time) int {
+	offset := (int(now.Weekday()) + 6) % 7 // Days since Monday
+	monday := now.AddDate(0, 0, -offset)
+	total := 0
+	for i := 0; i < 7; i++ {
+		total += s.ActiveDays[dayKey(monday.AddDate(0, 0, i))]
+	}
+	return total
+}
+
+// Training is a snapshot of streak and weekly goal progress.
+type Training struct {
+	Streak     int `json:"streak"`
+	WeekSolves int `json:"week_solves"`
+	WeeklyGoal int `json:"weekly_goal"` // 0 when no goal is set
+}
+
+// Training returns the streak and weekly goal progress at now.
+func (s *State) Training(now time.Time) Training {
+	return Training{Streak: s.Streak(now), WeekSolves: s.WeekSolves(now), WeeklyGoal: s.WeeklyGoal}
+}
+
+// String renders the progress for the header, e.g. "🔥 3d  🎯 2/5 this week".
+func (t Training) String() string {
+	out := fmt.Sprintf("🔥 %dd", t.Streak)
+	if t.WeeklyGoal > 0 {
+		out += fmt.Sprintf("  🎯 %d/%d this week", t.WeekSolves, t.WeeklyGoal)
+	}
+	return out
+}
+
+// Milestones returns the achievements reached between two snapshots.
+func Milestones(before, after Training) []string {
+	var out []string
+	for _, m := range StreakMilestones {
+		if before.Streak < m && after.Streak >= m {
+			out = append(out, fmt.Sprintf("%d-day streak!", m))
+		}
+	}
+	if g := after.WeeklyGoal; g > 0 && before.WeekSolves < g && after.WeekSolves >= g {
+		out = append(out, fmt.Sprintf("Weekly goal reached: %d scenarios", g))
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package state
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStreak(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 3, 11, 20, 0, 0, 0, time.Local)
+	st := &State{ActiveDays: map[string]int{
+		"2026-03-06": 1, // Friday, breaks before the streak
+		"2026-03-08": 2, // Sunday, previous week
+		"2026-03-09": 1, // Monday
+		"2026-03-10": 3, // Tuesday
+	}}
+
+	if got := st.Streak(now); got != 3 {
+		t.Errorf("Expected a 3-day streak still alive today, got %d", got)
+	}
+	if got := st.Streak(now.AddDate(0, 0, 1)); got != 0 {
+		t.Errorf("Expected the streak to be broken after a day off, got %d", got)
+	}
+	if got := st.WeekSolves(now); got != 4 {
+		t.Errorf("Expected 4 solves since Monday, got %d", got)
+	}
+
+	st.RecordSolve("s", time.Minute, now)
+	if got := st.Streak(now); got != 4 {
+		t.Errorf("Expected a 4-day streak after solving today, got %d", got)
+	}
+}
+
+func TestMilestones(t *testing.T) {
+	before := Training{Streak: 2, WeekSolves: 4, WeeklyGoal: 5}
+	after := Training{Streak: 3, WeekSolves: 5, WeeklyGoal: 5}
+	want := []string{"3-day streak!", "Weekly goal reached: 5 scenarios"}
+	if got := Milestones(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Milestones() = %v, want %v", got, want)
+	}
+	if got := Milestones(after, Training{Streak: 3, WeekSolves: 6, WeeklyGoal: 5}); len(got) != 0 {
+		t.Errorf("Expected no repeated milestones, got %v", got)
+	}
+}
+
+func TestSetWeeklyGoal(t *testing.T) {
+	mgr, err := NewManager(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	if err := mgr.SetWeeklyGoal(-1); err == nil {
+		t.Error("Expected a negative goal to be rejected")
+	}
+	if err := mgr.SetWeeklyGoal(5); err != nil {
+		t.Fatalf("SetWeeklyGoal failed: %v", err)
+	}
+	state, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if state.WeeklyGoal != 5 {
+		t.Errorf("Expected weekly goal 5, got %d", state.WeeklyGoal)
+	}
+}
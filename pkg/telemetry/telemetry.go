@@ -0,0 +1,305 @@
+// Package telemetry provides opt-in, anonymous scenario analytics.
+//
+// Nothing is sent unless the user runs `k8s-dojo telemetry enable`.
+// One record is sent per scenario attempt: which scenario, whether it was
+// solved or abandoned, how long it took, which hints were used and which
+// checks were still failing. No cluster contents, commands or personal data
+// are included; attempts are linked only by a random install ID. The same
+// records are kept locally in ~/.k8s-dojo/attempts.jsonl and never uploaded.
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"k8s-dojo/pkg/engine"
+)
+
+// Config is the persisted telemetry opt-in.
+type Config struct {
+	Enabled   bool   `json:"enabled"`
+	Endpoint  string `json:"endpoint"`
+	InstallID string `json:"installId"`
+}
+
+// DefaultConfigPath returns ~/.k8s-dojo/telemetry.json.
+func DefaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".k8s-dojo", "telemetry.json")
+	}
+	return filepath.Join(home, ".k8s-dojo", "telemetry.json")
+}
+
+// LoadConfig reads the telemetry config. A missing file means telemetry is off.
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		path = DefaultConfigPath()
+	}
+	cfg := &Config{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read telemetry config: %w", err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse telemetry config: %w", err)
+	}
+	return cfg, nil
+}
+
+// SaveConfig writes the telemetry config, generating an install ID if needed.
+func SaveConfig(path string, cfg *Config) error {
+	if path == "" {
+		path = DefaultConfigPath()
+	}
+	if cfg.InstallID == "" {
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			return fmt.Errorf("failed to generate install id: %w", err)
+		}
+		cfg.InstallID = hex.EncodeToString(id)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal telemetry config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write telemetry config: %w", err)
+	}
+	return nil
+}
+
+// Outcomes of an attempt.
+const (
+	OutcomeSolved    = "solved"
+	OutcomeAbandoned = "abandoned"
+)
+
+// Attempt is the record sent for one scenario attempt.
+type Attempt struct {
+	InstallID     string   `json:"installId"`
+	ScenarioID    string   `json:"scenarioId"`
+	Outcome       string   `json:"outcome"`
+	Seconds       int      `json:"seconds"`
+	HintsUsed     []int    `json:"hintsUsed"`
+	Checks        int      `json:"checks"`                  // Validations run during the attempt
+	ChecksPassed  int      `json:"checksPassed"`            // Conditions met at the last validation
+	FailingChecks []string `json:"failingChecks,omitempty"` // Names of conditions still failing at the end
+}
+
+// Plugin records attempts from engine events and hands each finished one to its sink:
+// the configured endpoint, or the local history file.
+type Plugin struct {
+	cfg    Config
+	client *http.Client
+	sink   func(Attempt) error
+
+	mu      sync.Mutex
+	current *Attempt
+	hints   map[int]bool
+	sending sync.WaitGroup
+}
+
+// New creates the telemetry plugin, or returns nil if telemetry is not enabled.
+func New(cfg *Config) *Plugin {
+	if cfg == nil || !cfg.Enabled || cfg.Endpoint == "" {
+		return nil
+	}
+	p := &Plugin{
+		cfg:    *cfg,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+	p.sink = p.send
+	return p
+}
+
+// DefaultHistoryPath returns ~/.k8s-dojo/attempts.jsonl.
+func DefaultHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".k8s-dojo", "attempts.jsonl")
+	}
+	return filepath.Join(home, ".k8s-dojo", "attempts.jsonl")
+}
+
+// NewHistory creates a plugin that appends attempts to a local JSON lines file
+// (DefaultHistoryPath if path is empty). The history never leaves the machine,
+// so it is kept whether or not telemetry is enabled.
+func NewHistory(path string) *Plugin {
+	if path == "" {
+		path = DefaultHistoryPath()
+	}
+	var mu sync.Mutex
+	return &Plugin{sink: func(a Attempt) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return appendAttempt(path, a)
+	}}
+}
+
+func appendAttempt(path string, a Attempt) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open attempt history: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write attempt history: %w", err)
+	}
+	return nil
+}
+
+// ReadAttempts decodes attempt records, one JSON object per line, as written to the
+// local history or collected from the telemetry endpoint. Blank lines are skipped.
+func ReadAttempts(r io.Reader) ([]Attempt, error) {
+	var attempts []Attempt
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var a Attempt
+		if err := json.Unmarshal(text, &a); err != nil {
+			return nil, fmt.Errorf("failed to parse attempt on line %d: %w", line, err)
+		}
+		attempts = append(attempts, a)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// LoadAttempts reads an attempts file. A missing file yields no attempts.
+func LoadAttempts(path string) ([]Attempt, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attempts: %w", err)
+	}
+	defer f.Close()
+	return ReadAttempts(f)
+}
+
+// Name implements engine.Plugin.
+func (p *Plugin) Name() string { return "telemetry" }
+
+// Register implements engine.Plugin.
+func (p *Plugin) Register(bus *engine.EventBus) {
+	bus.Subscribe(p.handle)
+}
+
+func (p *Plugin) handle(e engine.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch e.Type {
+	case engine.EventScenarioStarted:
+		p.current = &Attempt{InstallID: p.cfg.InstallID, ScenarioID: e.ScenarioID, HintsUsed: []int{}}
+		p.hints = make(map[int]bool)
+
+	case engine.EventHintUsed:
+		if p.current != nil && !p.hints[e.HintIndex] {
+			p.hints[e.HintIndex] = true
+			p.current.HintsUsed = append(p.current.HintsUsed, e.HintIndex)
+		}
+
+	case engine.EventCheckPerformed:
+		if p.current != nil && e.Result != nil {
+			p.current.Checks++
+			p.current.ChecksPassed = e.Result.PassedCount()
+			p.current.FailingChecks = nil
+			for _, c := range e.Result.Checks {
+				if !c.Passed {
+					p.current.FailingChecks = append(p.current.FailingChecks, c.Name)
+				}
+			}
+		}
+
+	case engine.EventSolved:
+		p.finish(OutcomeSolved, e.Elapsed)
+
+	case engine.EventCleanedUp, engine.EventAbandoned:
+		// Cleanup without a prior solve means the learner gave up
+		if p.current != nil && p.current.ScenarioID == e.ScenarioID {
+			p.finish(OutcomeAbandoned, e.Elapsed)
+		}
+	}
+}
+
+// finish sends the current attempt in the background. Callers hold p.mu.
+func (p *Plugin) finish(outcome string, elapsed time.Duration) {
+	if p.current == nil {
+		return
+	}
+	a := *p.current
+	a.Outcome = outcome
+	a.Seconds = int(elapsed.Seconds())
+	p.current = nil
+
+	p.sending.Add(1)
+	go func() {
+		defer p.sending.Done()
+		_ = p.sink(a)
+	}()
+}
+
+func (p *Plugin) send(a Attempt) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Post(p.cfg.Endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Abandon records the in-progress attempt as abandoned, e.g. when the app quits mid-scenario.
+func (p *Plugin) Abandon(elapsed time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finish(OutcomeAbandoned, elapsed)
+}
+
+// Flush waits for in-flight records to be sent, until ctx is done.
+func (p *Plugin) Flush(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		p.sending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
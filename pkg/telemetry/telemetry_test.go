@@ -0,0 +1,99 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-dojo/pkg/engine"
+	"k8s-dojo/pkg/scenario"
+)
+
+func TestPlugin(t *testing.T) {
+	records := make(chan Attempt, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var a Attempt
+		if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+			t.Errorf("Failed to decode record: %v", err)
+		}
+		records <- a
+	}))
+	defer srv.Close()
+
+	if New(&Config{Endpoint: srv.URL}) != nil {
+		t.Fatal("Expected no plugin when telemetry is not enabled")
+	}
+	p := New(&Config{Enabled: true, Endpoint: srv.URL, InstallID: "test"})
+	bus := engine.NewEventBus()
+	p.Register(bus)
+
+	failing := scenario.NewResult("ok",
+		scenario.Check{Name: "Pods exist", Passed: true},
+		scenario.Check{Name: "Pod running", Passed: false},
+	)
+	bus.Publish(engine.Event{Type: engine.EventScenarioStarted, ScenarioID: "demo"})
+	bus.Publish(engine.Event{Type: engine.EventHintUsed, ScenarioID: "demo", HintIndex: 0})
+	bus.Publish(engine.Event{Type: engine.EventHintUsed, ScenarioID: "demo", HintIndex: 0})
+	bus.Publish(engine.Event{Type: engine.EventCheckPerformed, ScenarioID: "demo", Result: &failing})
+	bus.Publish(engine.Event{Type: engine.EventCleanedUp, ScenarioID: "demo", Elapsed: 90 * time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p.Flush(ctx)
+
+	select {
+	case a := <-records:
+		if a.Outcome != OutcomeAbandoned || a.Seconds != 90 {
+			t.Errorf("Expected abandoned after 90s, got %s after %ds", a.Outcome, a.Seconds)
+		}
+		if len(a.HintsUsed) != 1 || a.ChecksPassed != 1 {
+			t.Errorf("Expected 1 hint and 1 passed check, got %v and %d", a.HintsUsed, a.ChecksPassed)
+		}
+		if len(a.FailingChecks) != 1 || a.FailingChecks[0] != "Pod running" {
+			t.Errorf("Expected failing [Pod running], got %v", a.FailingChecks)
+		}
+	default:
+		t.Fatal("Expected an attempt record")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "attempts.jsonl")
+	p := NewHistory(path)
+	bus := engine.NewEventBus()
+	p.Register(bus)
+
+	solved := scenario.NewResult("ok", scenario.Check{Name: "Pod running", Passed: true})
+	bus.Publish(engine.Event{Type: engine.EventScenarioStarted, ScenarioID: "demo"})
+	bus.Publish(engine.Event{Type: engine.EventSolved, ScenarioID: "demo", Elapsed: 2 * time.Minute, Result: &solved})
+	bus.Publish(engine.Event{Type: engine.EventScenarioStarted, ScenarioID: "other"})
+	bus.Publish(engine.Event{Type: engine.EventCleanedUp, ScenarioID: "other", Elapsed: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p.Flush(ctx)
+
+	attempts, err := LoadAttempts(path)
+	if err != nil {
+		t.Fatalf("LoadAttempts failed: %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("Expected 2 attempts, got %d", len(attempts))
+	}
+	outcomes := map[string]string{}
+	for _, a := range attempts {
+		outcomes[a.ScenarioID] = a.Outcome
+	}
+	if outcomes["demo"] != OutcomeSolved || outcomes["other"] != OutcomeAbandoned {
+		t.Errorf("Unexpected outcomes: %v", outcomes)
+	}
+
+	// A missing history is empty, not an error
+	if attempts, err := LoadAttempts(filepath.Join(t.TempDir(), "missing.jsonl")); err != nil || len(attempts) != 0 {
+		t.Errorf("Expected no attempts for missing file, got %v, %v", attempts, err)
+	}
+}
@@ -4,19 +4,38 @@ package tui
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"k8s.io/client-go/tools/clientcmd"
 
+	"k8s-dojo/pkg/assistant"
+	"k8s-dojo/pkg/browser"
+	"k8s-dojo/pkg/calibrate"
+	"k8s-dojo/pkg/changelog"
+	"k8s-dojo/pkg/chaos"
 	"k8s-dojo/pkg/cluster"
+	"k8s-dojo/pkg/coop"
 	"k8s-dojo/pkg/engine"
+	"k8s-dojo/pkg/explain"
 	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/packs"
+	"k8s-dojo/pkg/proctor"
+	"k8s-dojo/pkg/recommend"
+	"k8s-dojo/pkg/record"
+	"k8s-dojo/pkg/remote"
 	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/score"
+	"k8s-dojo/pkg/skills"
 	"k8s-dojo/pkg/state"
+	"k8s-dojo/pkg/telemetry"
 	"k8s-dojo/pkg/tui/components"
 )
 
@@ -38,8 +57,9 @@ const (
 	ViewDashboard
 	ViewScenarioRunning
 	ViewSuccess
-	ViewConfirmRestart
-	ViewConfirmQuit
+	ViewConfirm
+	ViewStats
+	ViewWhatsNew
 )
 
 // AppModel is the main Bubbletea model with the new component architecture.
@@ -85,47 +105,129 @@ type AppModel struct {
 	engineInstance *engine.Engine
 	registry       *scenario.Registry
 	stateManager   *state.Manager
+	assistant      *assistant.Assistant // nil unless configured
+	telemetry      *telemetry.Plugin    // nil unless the user opted in
+	history        *telemetry.Plugin    // Local attempt history, for difficulty calibration
+	communityStats map[string]calibrate.Stats
+
+	// Suggested next scenarios on the dashboard
+	attemptHistory  []telemetry.Attempt
+	recommendations []recommend.Recommendation
+
+	// Per-category progress for the stats view
+	skills []skills.Category
+
+	// Built-in release notes and the ones the What's New view shows
+	releases []changelog.Release
+	whatsNew []changelog.Release
 
 	// State
 	completedScenarios map[string]bool
-	confirmSelection   int // 0: Yes, 1: No
+	progress           *state.State // Shares CompletedScenarios; tracks completion versions
+
+	// Open confirm dialog and what each of its buttons does
+	confirm        components.ConfirmDialog
+	confirmActions []confirmAction
+
+	// kubectl cheat sheet, drawn over the terminal while open
+	cheatSheet     components.CheatSheetModel
+	cheatSheetOpen bool
 
 	// Running scenario
 	currentScenario scenario.Scenario
 	lastCheckResult scenario.Result
 	checkInterval   time.Duration
+	confirmChecks   int           // Consecutive passing checks before a fix counts
+	idlePause       time.Duration // Inactivity before the timer pauses itself, 0 disables
+	lastInput       time.Time
+	idlePolicy      IdlePolicy
+	checkLoop       int  // Current auto-check loop; ticks from older loops are dropped
+	checking        bool // A check is in flight
 
-	// Window size
-	width  int
-	height int
+	starting          bool // StartScenario is running
+	progressListening bool // Setup steps are being relayed
 
-	// Quit flag
-	quitting bool
-}
+	// Quiz scenarios: the answer field is open while answering
+	answerInput textinput.Model
+	answering   bool
 
-// scenarioItem implements list.Item for scenarios.
-type scenarioItem struct {
-	scenario scenario.Scenario
-}
+	// Dashboard search: the sidebar lists only scenarios matching the query
+	searchInput textinput.Model
+	searchQuery string
+	searching   bool
 
-func (i scenarioItem) Title() string       { return i.scenario.GetMetadata().Name }
-func (i scenarioItem) Description() string { return i.scenario.GetMetadata().Description }
-func (i scenarioItem) FilterValue() string { return i.scenario.GetMetadata().Name }
+	// Guided mode: step-by-step cards instead of hints
+	guidedMode bool
 
-// Messages
-type bootstrapDoneMsg struct {
-	kubeconfig string
-	err        error
-}
+	// Hard mode: chaos on replays of completed scenarios
+	hardMode bool
 
-type checkResultMsg struct {
-	result scenario.Result
-}
+	// Restricted mode: kubectl in the terminal is limited to the scenario namespace
+	restricted       bool
+	learnerNamespace string // Namespace the terminal's credentials are limited to, "" for full access
+
+	// Dev mode: reload YAML scenarios on change
+	devMode bool
+	reloads chan error
+
+	// Guardrails: warn about deletions outside the scenario
+	guardrails bool
+	violations chan guardMsg
+
+	// Demo mode: replay a scenario's solution, nil otherwise
+	demo *demoState
+
+	// Session recording of the terminal, nil unless enabled
+	recorder *record.Recorder
+
+	// Remote dojo host running Kind, nil for a local cluster
+	remote *remote.Host
+
+	// Create the cluster even if the host looks too small
+	skipPreflight bool
+
+	// Scenario left running on quit; shared so the caller can report it after exit
+	kept *keptEnvironment
+
+	// Solved scenario kept for exploring, nil when none
+	exploring *engine.Detached
+
+	// Cluster lifecycle: a paused cluster is resumed on bootstrap
+	clusterStatus cluster.Status
+	pauseOnQuit   bool
+
+	// Classroom session on a fleet cluster, nil board otherwise
+	session     string
+	clusterName string // Fleet cluster of the session, "" for the usual one
+	proctor     *proctor.Board
+	notice      string // Latest notice pushed to the session, shown until noticeUntil
+	noticeUntil time.Time
 
-type tickMsg time.Time
+	// Co-op: two learners on one scenario, nil store otherwise
+	coop      *coop.Store
+	share     *coop.Share // Shared as owner
+	partner   string      // Partner following the share, "" for none
+	following *coop.Share // Joined as partner: the owner's latest state
+	coopName  string      // Partner's name, shown to the owner
+	coopLeft  time.Time   // Start of the owner's scenario the partner left
 
-type progressTickMsg time.Time
-type finalDelayMsg time.Time
+	// Latest resource use of the node containers, nil until sampled
+	usage *cluster.Usage
+
+	// Health monitor: checks are skipped while the API server is unreachable
+	connLost     bool
+	reconnecting bool
+
+	// Rate limits and retries for the cluster client
+	clientOptions k8s.ClientOptions
+
+	// Window size
+	width  int
+	height int
+
+	// Quit flag
+	quitting bool
+}
 
 // NewAppModel creates a new TUI model with the enhanced architecture.
 func NewAppModel() AppModel {
@@ -146,17 +248,95 @@ func NewAppModel() AppModel {
 		focus:              FocusSidebar,
 		versions:           cluster.SupportedVersions(),
 		checkInterval:      2 * time.Second,
+		confirmChecks:      engine.DefaultConfirmChecks,
+		idlePause:          DefaultIdlePause,
+		idlePolicy:         IdlePolicy{After: DefaultIdleCleanup},
 		header:             components.NewHeaderModel(),
 		sidebar:            components.NewSidebarModel(),
 		content:            components.NewContentModel(),
 		terminal:           components.NewTerminalModel(),
 		statusbar:          components.NewStatusBarModel(),
-		success:            components.NewSuccessModel(),
+		success:            components.NewSuccessModel(keymap.SuccessKeyMap()),
+		cheatSheet:         components.NewCheatSheetModel(components.KubectlCheatSheet),
 		bootstrap:          components.NewProgressModel(),
 		completedScenarios: make(map[string]bool),
+		progress:           &state.State{CompletedScenarios: make(map[string]bool), CompletedVersions: make(map[string]int)},
+		kept:               &keptEnvironment{},
+		clientOptions:      k8s.DefaultClientOptions(),
 	}
 }
 
+// keptEnvironment records a scenario the user chose to keep for inspection on quit.
+type keptEnvironment struct {
+	scenario string
+	steps    []scenario.CleanupStep
+}
+
+// EnableGuidedMode runs scenarios that offer a guide step by step, replacing hints with instruction cards.
+func (m *AppModel) EnableGuidedMode() {
+	m.guidedMode = true
+}
+
+// EnableHardMode injects minor faults (pod deletions, label flips) while
+// replaying completed scenarios.
+func (m *AppModel) EnableHardMode() {
+	m.hardMode = true
+}
+
+// SetConfirmChecks sets how many consecutive checks must pass before a fix
+// counts, so a crash-looping pod that is briefly Running doesn't solve a scenario.
+func (m *AppModel) SetConfirmChecks(n int) {
+	m.confirmChecks = n
+}
+
+// SetIdlePause sets how long without input pauses the scenario timer and
+// checks. Zero disables auto-pause.
+func (m *AppModel) SetIdlePause(d time.Duration) {
+	m.idlePause = d
+}
+
+// SetClientOptions sets the rate limits and retries of the cluster client.
+func (m *AppModel) SetClientOptions(opts k8s.ClientOptions) {
+	m.clientOptions = opts
+}
+
+// EnableDevMode watches the YAML scenario directory and reloads scenarios when files change.
+func (m *AppModel) EnableDevMode() {
+	m.devMode = true
+}
+
+// EnableRecording records the terminal session to a new file in dir (default: ~/.k8s-dojo/recordings).
+func (m *AppModel) EnableRecording(dir string) error {
+	r, err := record.NewRecorder(dir, 80, 24)
+	if err != nil {
+		return err
+	}
+	m.recorder = r
+	m.terminal.SetRecorder(r)
+	return nil
+}
+
+// StopRecording closes the session recording and returns its path, or "" if recording is off.
+func (m *AppModel) StopRecording() (string, error) {
+	if m.recorder == nil {
+		return "", nil
+	}
+	return m.recorder.Path(), m.recorder.Close()
+}
+
+// EnableRemote drives a cluster on a remote host: the API server is tunnelled over
+// SSH and the terminal opens a shell on the host. DOCKER_HOST must already point at it.
+func (m *AppModel) EnableRemote(h *remote.Host) {
+	m.remote = h
+	name, args := h.ShellCommand()
+	m.terminal.SetShell(name, args...)
+}
+
+// SkipPreflight creates the cluster without checking the host's CPUs, memory and disk.
+func (m *AppModel) SkipPreflight() {
+	m.skipPreflight = true
+}
+
 // SetTerminalProgram sets the tea.Program reference on the terminal for async output refresh.
 func (m *AppModel) SetTerminalProgram(p *tea.Program) {
 	m.terminal.SetProgram(p)
@@ -165,7 +345,19 @@ func (m *AppModel) SetTerminalProgram(p *tea.Program) {
 // Init initializes the model.
 func (m AppModel) Init() tea.Cmd {
 	// Note: Don't call tea.EnterAltScreen here since main.go uses tea.WithAltScreen()
-	return m.bootstrap.Init()
+	if m.demo != nil {
+		return tea.Batch(m.bootstrap.Init(), m.doBootstrap(), m.tickProgress())
+	}
+	return tea.Batch(m.bootstrap.Init(), m.checkClusterStatus, m.tickProctor(), m.tickCoop())
+}
+
+// checkClusterStatus detects a paused cluster so the version picker can offer to resume it.
+func (m AppModel) checkClusterStatus() tea.Msg {
+	status, err := m.dojoCluster().Status()
+	if err != nil {
+		return nil
+	}
+	return clusterStatusMsg{status: status}
 }
 
 // Update handles messages.
@@ -174,10 +366,19 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.lastInput = time.Now()
+		if m.view == ViewScenarioRunning && m.engineInstance != nil && m.engineInstance.Paused() {
+			m.resume()
+			return m, nil // The key only wakes the dojo up
+		}
+
 		// Global quit handling
 		// Skip global quit if in terminal to allow shell interrupts
 		allowQuit := true
-		if m.view == ViewScenarioRunning && m.focus == FocusTerminal {
+		if m.view == ViewScenarioRunning && (m.focus == FocusTerminal || m.answering || m.cheatSheetOpen) {
+			allowQuit = false
+		}
+		if m.view == ViewDashboard && m.searching {
 			allowQuit = false
 		}
 
@@ -188,29 +389,15 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.cleanup()
 			}
 
-			// If already in a confirmation/dialog view, let that view handle the key (usually cancel)
-			if m.view == ViewConfirmQuit || m.view == ViewConfirmRestart {
-				// Fall through to view-specific update
-			} else {
-				// For all other views, show confirmation
-				m.previousView = m.view // Remember where we came from
-				m.view = ViewConfirmQuit
-				m.confirmSelection = 1 // Default to No
-				return m, nil
+			// A dialog handles the key itself, usually as cancel
+			if m.view != ViewConfirm {
+				return m.confirmQuit()
 			}
 		}
 
-		// Tab for focus switching (Sidebar → Content → Terminal → Sidebar)
-		if key.Matches(msg, m.keymap.Tab) && m.view == ViewScenarioRunning {
-			switch m.focus {
-			case FocusSidebar:
-				m.focus = FocusContent
-			case FocusContent:
-				m.focus = FocusTerminal
-			case FocusTerminal:
-				m.focus = FocusSidebar
-			}
-			m.updateFocusStyles()
+		// Tab and Shift+Tab cycle the focus (Sidebar → Content → Terminal → Sidebar)
+		if m.handleFocusKey(msg) {
+			return m, nil
 		}
 
 	case tea.WindowSizeMsg:
@@ -227,13 +414,81 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case bootstrapDoneMsg:
 		return m.handleBootstrapDone(msg)
 
+	case clusterStatusMsg:
+		m.clusterStatus = msg.status
+		return m, nil
+
+	case proctorTickMsg:
+		return m, m.syncProctor()
+
+	case proctorInboxMsg:
+		return m.handleProctorInbox(msg)
+
+	case coopTickMsg:
+		return m, m.syncCoop()
+
+	case coopSyncMsg:
+		return m.handleCoopSync(msg)
+
+	case usageTickMsg:
+		return m, m.sampleUsage()
+
+	case usageMsg:
+		return m.handleUsage(msg)
+
+	case healthTickMsg:
+		if m.idleExpired() {
+			return m.cleanupIdle()
+		}
+		return m, m.pingCluster()
+
+	case healthResultMsg:
+		return m.handleHealthResult(msg)
+
+	case reconnectDoneMsg:
+		return m.handleReconnectDone(msg)
+
+	case guardMsg:
+		return m.handleGuard(msg)
+
+	case scenariosReloadedMsg:
+		return m.handleScenariosReloaded(msg)
+
+	case assistantHintMsg:
+		if m.currentScenario == nil || m.currentScenario.GetMetadata().ID != msg.scenarioID {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.content.SetNote(assistantLabel, fmt.Sprintf("Assistant unavailable: %v", msg.err))
+		} else {
+			m.content.SetNote(assistantLabel, msg.hint)
+		}
+		return m, nil
+
 	case checkResultMsg:
+		if msg.loop != m.checkLoop || m.view != ViewScenarioRunning {
+			if msg.loop == m.checkLoop {
+				m.checking = false
+			}
+			return m, nil // A left scenario's check, or one the view can't show
+		}
 		return m.handleCheckResult(msg)
 
+	case shellExitedMsg:
+		return m.handleShellExited(msg)
+
 	case tickMsg:
-		if m.view == ViewScenarioRunning {
-			return m, m.checkScenario()
+		if msg.loop != m.checkLoop || m.view != ViewScenarioRunning {
+			return m, nil // Stale loop, or the scenario is no longer running
+		}
+		if m.idlePause > 0 && !m.engineInstance.Paused() && time.Since(m.lastInput) > m.idlePause {
+			m.pause(m.lastInput)
+		}
+		if m.connLost || m.engineInstance.Paused() {
+			return m, m.nextCheck()
 		}
+		check := m.requestCheck()
+		return m, tea.Batch(check, m.nextCheck())
 
 	case progressTickMsg:
 		if m.view == ViewBootstrap {
@@ -274,15 +529,66 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case finalDelayMsg:
 		return m.finalizeBootstrap()
 
+	case setupProgressMsg:
+		return m.handleSetupProgress(msg)
+
 	case scenarioStartedMsg:
+		m.starting = false
 		if msg.err != nil {
-			m.content.SetStatus(fmt.Sprintf("Failed to start scenario: %v", msg.err), false)
+			m.content.SetStatus(withRemediation(fmt.Sprintf("Failed to start scenario: %v", msg.err), msg.err), false)
 			return m, nil
 		}
-		m.content.SetStatus("Scenario started. Use kubectl in the terminal below to investigate!", false)
-		return m, tea.Tick(m.checkInterval, func(t time.Time) tea.Msg {
-			return tickMsg(t)
-		})
+		// The engine allocates a fresh namespace per attempt
+		if m.currentScenario != nil {
+			m.setScenarioNamespace(m.currentScenario.GetNamespace())
+		}
+		if m.following != nil {
+			m.header.StartTimerAt(m.engineInstance.StartedAt()) // The owner's clock
+		}
+		status := "Scenario started. Use kubectl in the terminal below to investigate!"
+		if m.currentScenario != nil {
+			if v := m.staleVersion(m.currentScenario); v > 0 {
+				status = fmt.Sprintf("This scenario changed since you completed v%d. Solve it again to update your record. %s", v, status)
+			}
+		}
+		if m.chaosActive() {
+			status = "🔥 Hard mode: expect extra faults while you work. " + status
+		}
+		m.content.SetStatus(status, false)
+		m.loadGuide()
+		loop := m.startCheckLoop()
+		return m, tea.Batch(m.playDemo(), m.restrictTerminal(), loop)
+
+	case scenarioResetMsg:
+		if m.currentScenario == nil {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.content.SetStatus(withRemediation(fmt.Sprintf("Reset failed: %v", msg.err), msg.err), false)
+			return m, nil
+		}
+		m.setScenarioNamespace(m.currentScenario.GetNamespace())
+		m.content.SetStatus("Scenario reset to its initial state. Your edits were reverted.", false)
+		m.loadGuide()
+		return m, m.restrictTerminal()
+
+	case restrictedMsg:
+		return m.handleRestricted(msg)
+
+	case demoTickMsg:
+		return m.handleDemoTick()
+
+	case cleanupDoneMsg:
+		switch {
+		case msg.err != nil:
+			m.statusbar.SetMessage(fmt.Sprintf("Cleanup of %s failed: %v", msg.scenarioID, msg.err))
+		case len(m.engineInstance.CleaningUp()) == 0:
+			m.statusbar.SetMessage("")
+		}
+		return m, nil
+
+	case components.SuccessChosenMsg:
+		return m.handleSuccessChosen(msg)
 
 	case components.TerminalOutputMsg:
 		// Terminal has new output, just return to trigger re-render
@@ -301,10 +607,12 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateScenarioRunning(msg)
 	case ViewSuccess:
 		return m.updateSuccess(msg)
-	case ViewConfirmRestart:
-		return m.updateConfirmRestart(msg)
-	case ViewConfirmQuit:
-		return m.updateConfirmQuit(msg)
+	case ViewConfirm:
+		return m.updateConfirm(msg)
+	case ViewStats:
+		return m.updateStats(msg)
+	case ViewWhatsNew:
+		return m.updateWhatsNew(msg)
 	}
 
 	return m, tea.Batch(cmds...)
@@ -325,18 +633,13 @@ func (m *AppModel) updateComponentSizes() {
 	m.content.SetSize(m.layout.ContentWidth, infoH)
 	// Terminal gets the terminal height (lower area)
 	m.terminal.SetSize(m.layout.ContentWidth, termH)
+	m.cheatSheet.SetSize(m.layout.ContentWidth, termH)
 
 	m.statusbar.SetWidth(m.width)
 	m.success.SetSize(m.width, m.height)
 	m.bootstrap.SetWidth(m.width)
 }
 
-func (m *AppModel) updateFocusStyles() {
-	m.sidebar.SetFocus(m.focus == FocusSidebar)
-	m.content.SetFocus(m.focus == FocusContent)
-	m.terminal.SetFocus(m.focus == FocusTerminal)
-}
-
 func (m AppModel) handleBootstrapDone(msg bootstrapDoneMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
 		m.bootstrapErr = msg.err
@@ -344,27 +647,75 @@ func (m AppModel) handleBootstrapDone(msg bootstrapDoneMsg) (tea.Model, tea.Cmd)
 	}
 
 	// Create K8s client
-	client, err := k8s.NewClientFromKubeconfig(msg.kubeconfig)
+	client, err := k8s.NewClientFromKubeconfigWithOptions(msg.kubeconfig, m.clientOptions)
 	if err != nil {
 		m.bootstrapErr = err
 		return m, nil
 	}
 	m.k8sClient = client
 	m.kubeconfig = msg.kubeconfig
+	if len(msg.warnings) > 0 {
+		m.statusbar.SetMessage("⚠ Low resources: " + strings.Join(msg.warnings, "; "))
+	}
 	m.terminal.SetKubeconfig(msg.kubeconfig)
-	m.registry = scenario.NewRegistry(client.Clientset)
-	m.engineInstance = engine.NewEngine(m.registry)
+	m.registry = scenario.NewRegistry(client)
+	_ = m.registry.LoadYAML(scenario.DefaultScenarioDir()) // YAML scenarios are optional
+	if packManager, err := packs.NewManager(""); err == nil {
+		_ = packManager.LoadInto(m.registry)
+	}
+	m.engineInstance = engine.NewEngine(m.registry, client.Clientset)
+	m.engineInstance.EnableSnapshots(client)
+	m.engineInstance.SetConfirmChecks(m.confirmChecks)
+
+	// Telemetry is opt-in via `k8s-dojo telemetry enable`
+	if cfg, err := telemetry.LoadConfig(""); err == nil {
+		if p := telemetry.New(cfg); p != nil {
+			m.telemetry = p
+			m.engineInstance.Use(p)
+		}
+	}
+
+	// The local history feeds `k8s-dojo dev calibrate`; community averages are optional
+	m.history = telemetry.NewHistory("")
+	m.engineInstance.Use(m.history)
+	m.communityStats, _ = calibrate.LoadStats("")
+	m.attemptHistory, _ = telemetry.LoadAttempts(telemetry.DefaultHistoryPath())
+
+	// The hint assistant is opt-in via its config file
+	if cfg, err := assistant.LoadConfig(""); err == nil && cfg != nil {
+		m.assistant = assistant.New(cfg)
+		m.assistant.Secrets = []string{msg.kubeconfig}
+	}
 
 	// Initialize state manager and load state
 	m.stateManager, err = state.NewManager("")
 	if err == nil {
 		if st, err := m.stateManager.Load(); err == nil {
+			m.progress = st
 			m.completedScenarios = st.CompletedScenarios
 		}
+		m.header.SetProgress(m.progress.Training(time.Now()).String())
+
+		// Persist completions via the engine event bus
+		stateManager := m.stateManager
+		registry := m.registry
+		m.engineInstance.Subscribe(func(e engine.Event) {
+			if e.Type != engine.EventSolved {
+				return
+			}
+			version := 1
+			if s := registry.Get(e.ScenarioID); s != nil {
+				version = s.GetMetadata().ContentVersion()
+			}
+			_ = stateManager.RecordSolve(e.ScenarioID, version, e.Elapsed)
+		})
 	}
+	m.loadReleases()
 
 	// Build sidebar items from categories
 	m.buildSidebarItems()
+	m.refreshRecommendations()
+	background := tea.Batch(m.watchScenarios(), m.watchGuardrails(), m.tickHealth(), m.sampleUsage())
 
 	// Set header version
 	m.header.SetVersion(m.versions[m.selectedVersion].Version)
@@ -387,29 +738,77 @@ func (m AppModel) handleBootstrapDone(msg bootstrapDoneMsg) (tea.Model, tea.Cmd)
 		m.bootstrap.SetSteps(steps)
 		m.bootstrap.SetPercent(1.0)
 
-		return m, tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg {
+		return m, tea.Batch(background, tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg {
 			return finalDelayMsg(t)
-		})
+		}))
 	}
 
 	// If animation is still running, do nothing. It will catch m.bootstrapRealDone flag.
-	return m, nil
+	return m, background
+}
+
+// watchScenarios starts the YAML watcher in dev mode and returns a command
+// that delivers its reload results.
+func (m *AppModel) watchScenarios() tea.Cmd {
+	if !m.devMode {
+		return nil
+	}
+	m.reloads = make(chan error, 1)
+	reloads := m.reloads
+	go m.registry.WatchYAML(context.Background(), scenario.DefaultScenarioDir(), time.Second, func(err error) {
+		reloads <- err
+	})
+	return m.waitForReload()
+}
+
+func (m AppModel) waitForReload() tea.Cmd {
+	reloads := m.reloads
+	return func() tea.Msg {
+		return scenariosReloadedMsg{err: <-reloads}
+	}
+}
+
+func (m AppModel) handleScenariosReloaded(msg scenariosReloadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Scenario reload failed: %v", msg.err))
+		return m, m.waitForReload()
+	}
+
+	m.buildSidebarItems()
+	m.refreshRecommendations()
+	if m.currentScenario != nil {
+		if _, ok := m.currentScenario.(*scenario.YAMLScenario); ok {
+			meta := m.currentScenario.GetMetadata()
+			m.header.SetTitle("🥋 " + meta.Name)
+			m.content.SetHints(meta.Hints)
+			m.content.SetCommands(meta.QuickCommands())
+			m.content.SetDocs(meta.DocsLinks)
+		}
+	}
+	m.statusbar.SetMessage(fmt.Sprintf("Reloaded scenarios at %s", time.Now().Format("15:04:05")))
+	return m, m.waitForReload()
 }
 
 func (m AppModel) finalizeBootstrap() (tea.Model, tea.Cmd) {
+	if m.demo != nil {
+		return m.startDemoScenario()
+	}
+
 	// Switch to dashboard view
 	m.view = ViewDashboard
-	m.focus = FocusSidebar
-	m.updateFocusStyles()
+	m.setFocus(FocusSidebar)
+	if len(m.whatsNew) > 0 {
+		m.view = ViewWhatsNew // Once, after an upgrade
+	}
 	return m, nil
 }
 
 func (m *AppModel) buildSidebarItems() {
 	// Group scenarios by category
 	catMap := make(map[string][]scenario.Scenario)
-	preferredOrder := []string{"Networking", "Lifecycle", "Scheduling", "Security", "Storage", "Ops", "Resources", "Kernel"}
+	preferredOrder := []string{"Networking", "Lifecycle", "Scheduling", "Security", "Storage", "Ops", "Operators", "Resources", "Kernel"}
 
-	for _, s := range m.registry.List() {
+	for _, s := range m.listedScenarios() {
 		cat := s.GetMetadata().Category
 		if cat == "" {
 			cat = "Uncategorized"
@@ -417,6 +816,7 @@ func (m *AppModel) buildSidebarItems() {
 		catMap[cat] = append(catMap[cat], s)
 	}
 
+	now := time.Now()
 	var items []components.SidebarItem
 	for _, cat := range preferredOrder {
 		if scenarios, ok := catMap[cat]; ok {
@@ -432,6 +832,7 @@ func (m *AppModel) buildSidebarItems() {
 					Description: s.GetMetadata().Description,
 					Category:    cat,
 					Completed:   m.completedScenarios[s.GetMetadata().ID],
+					IsNew:       m.progress.IsNew(s.GetMetadata().ID, now),
 				})
 			}
 			items = append(items, catItem)
@@ -439,8 +840,14 @@ func (m *AppModel) buildSidebarItems() {
 		}
 	}
 
-	// Add remaining categories
-	for cat, scenarios := range catMap {
+	// Add remaining categories (e.g. installed packs) in a stable order
+	var remaining []string
+	for cat := range catMap {
+		remaining = append(remaining, cat)
+	}
+	sort.Strings(remaining)
+	for _, cat := range remaining {
+		scenarios := catMap[cat]
 		catItem := components.SidebarItem{
 			ID:         cat,
 			Title:      cat,
@@ -448,11 +855,13 @@ func (m *AppModel) buildSidebarItems() {
 		}
 		for _, s := range scenarios {
 			catItem.Children = append(catItem.Children, components.SidebarItem{
-				ID:          s.GetMetadata().ID,
-				Title:       s.GetMetadata().Name,
-				Description: s.GetMetadata().Description,
-				Category:    cat,
-				Completed:   m.completedScenarios[s.GetMetadata().ID],
+				ID:           s.GetMetadata().ID,
+				Title:        s.GetMetadata().Name,
+				Description:  s.GetMetadata().Description,
+				Category:     cat,
+				Completed:    m.completedScenarios[s.GetMetadata().ID],
+				StaleVersion: m.staleVersion(s),
+				IsNew:        m.progress.IsNew(s.GetMetadata().ID, now),
 			})
 		}
 		items = append(items, catItem)
@@ -461,31 +870,112 @@ func (m *AppModel) buildSidebarItems() {
 	m.sidebar.SetItems(items)
 }
 
+// markCompleted records a completion of s at its current content version.
+func (m *AppModel) markCompleted(s scenario.Scenario) {
+	meta := s.GetMetadata()
+	m.completedScenarios[meta.ID] = true
+	m.progress.CompletedVersions[meta.ID] = meta.ContentVersion()
+	m.refreshRecommendations()
+}
+
+// refreshRecommendations recomputes the suggested next scenarios.
+func (m *AppModel) refreshRecommendations() {
+	m.recommendations = recommend.Recommend(m.registry.Metadata(), m.progress, m.attemptHistory, recommend.DefaultCount)
+}
+
+// recommendationsView renders the "train next" widget for the dashboard preview.
+func (m AppModel) recommendationsView() string {
+	if len(m.recommendations) == 0 {
+		return ""
+	}
+	lines := []string{m.styles.Highlight.Render("🎯 Train next")}
+	for i, r := range m.recommendations {
+		lines = append(lines, m.styles.Text.Render(fmt.Sprintf("%d. %s", i+1, r.Name))+
+			m.styles.TextMuted.Render(" — "+r.Reason))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recommendation returns the suggestion for a scenario, or nil.
+func (m AppModel) recommendation(id string) *recommend.Recommendation {
+	for i := range m.recommendations {
+		if m.recommendations[i].ScenarioID == id {
+			return &m.recommendations[i]
+		}
+	}
+	return nil
+}
+
+// staleVersion returns the older content version s was completed at, or 0
+// if it is not completed or the completion is current.
+func (m AppModel) staleVersion(s scenario.Scenario) int {
+	meta := s.GetMetadata()
+	if !m.progress.IsOutdated(meta.ID, meta.ContentVersion()) {
+		return 0
+	}
+	return m.progress.CompletedVersion(meta.ID)
+}
+
 func (m AppModel) handleCheckResult(msg checkResultMsg) (tea.Model, tea.Cmd) {
+	m.checking = false
 	m.lastCheckResult = msg.result
+	if m.content.Guided() {
+		m.content.SetGuideStep(msg.guideStep)
+	}
 
 	if m.engineInstance != nil {
 		elapsed := m.engineInstance.GetElapsedTime()
-		m.content.SetStatus(msg.result.Message, msg.result.Solved)
+		status := msg.result.Message
+		if confirming, _ := m.engineInstance.Confirming(); !msg.result.Solved && confirming == 0 && len(msg.result.Checks) > 1 {
+			status = fmt.Sprintf("%s (%s)", status, msg.result.Summary())
+		}
+		m.content.SetStatus(status, msg.result.Solved)
+		checks := make([]components.CheckLine, len(msg.result.Checks))
+		for i, c := range msg.result.Checks {
+			checks[i] = components.CheckLine{Name: c.Name, Passed: c.Passed, Details: c.Details, Severity: c.Severity, DocURL: c.DocURL}
+		}
+		m.content.SetChecks(checks)
 
 		if msg.result.Solved {
-			// Persist completion state
-			if m.stateManager != nil {
-				_ = m.stateManager.MarkScenarioCompleted(m.currentScenario.GetMetadata().ID)
+			meta := m.currentScenario.GetMetadata()
+			previous := m.progress.Solves[meta.ID]
+			now := time.Now()
+			before := m.progress.Training(now)
+			m.progress.RecordSolve(meta.ID, elapsed, now)
+			m.markCompleted(m.currentScenario)
+			m.header.SetProgress(m.progress.Training(now).String())
+
+			attempt := m.engineInstance.AttemptStats()
+			stats := components.SuccessStats{
+				HintsUsed: attempt.HintsUsed,
+				Checks:    attempt.Checks,
+				Points: score.Compute(score.Attempt{
+					Difficulty: meta.Difficulty,
+					TimeLimit:  meta.TimeLimit,
+					Elapsed:    elapsed,
+					Hints:      attempt.HintsUsed,
+					Checks:     attempt.Checks,
+				}),
+				Achievements: state.Milestones(before, m.progress.Training(now)),
+			}
+			if previous.Count > 0 {
+				stats.PreviousBest = time.Duration(previous.BestSeconds) * time.Second
+			}
+			if len(m.recommendations) > 0 {
+				next := m.recommendations[0]
+				stats.Next = fmt.Sprintf("%s (%s)", next.Name, next.Reason)
 			}
-			m.completedScenarios[m.currentScenario.GetMetadata().ID] = true
 
-			m.success.SetScenario(m.currentScenario.GetMetadata().Name)
+			m.success.SetScenario(meta.Name)
 			m.success.SetMessage(msg.result.Message)
 			m.success.SetElapsedTime(elapsed)
+			m.success.SetStats(stats)
 			m.view = ViewSuccess
 			return m, nil
 		}
 	}
 
-	return m, tea.Tick(m.checkInterval, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
+	return m, nil
 }
 
 func (m AppModel) updateVersionSelect(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -500,23 +990,7 @@ func (m AppModel) updateVersionSelect(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedVersion++
 			}
 		case key.Matches(keyMsg, m.keymap.Enter):
-			m.view = ViewBootstrap
-			m.bootstrap.SetTitle("Preparing Training Environment")
-			m.bootstrap.SetSubtitle(fmt.Sprintf("Creating Kind cluster (%s)...", m.versions[m.selectedVersion].Version))
-			// Define steps - first two are already complete
-			steps := []components.ProgressStep{
-				{Label: "Docker detected", Complete: true},
-				{Label: "Kind installed", Complete: true},
-				{Label: "Pulling node image", Active: true},
-				{Label: "Starting control plane"},
-				{Label: "Configuring kubeconfig"},
-			}
-			m.bootstrap.SetSteps(steps)
-			// Start from step 2 (0-indexed) since first two steps are complete
-			// This means bootstrapStep represents the NEXT step to process
-			m.bootstrapStep = 2
-			// Initial percent: step 2 out of 5 steps = ~33%
-			m.bootstrap.SetPercent(float64(m.bootstrapStep) / float64(len(steps)))
+			m.prepareBootstrap()
 			return m, tea.Batch(
 				m.doBootstrap(),
 				m.tickProgress(),
@@ -526,6 +1000,31 @@ func (m AppModel) updateVersionSelect(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// prepareBootstrap switches to the bootstrap view for the selected version.
+func (m *AppModel) prepareBootstrap() {
+	m.view = ViewBootstrap
+	m.bootstrap.SetTitle("Preparing Training Environment")
+	if m.clusterStatus == cluster.StatusPaused {
+		m.bootstrap.SetSubtitle("Resuming paused cluster...")
+	} else {
+		m.bootstrap.SetSubtitle(fmt.Sprintf("Creating Kind cluster (%s)...", m.versions[m.selectedVersion].Version))
+	}
+	// Define steps - first two are already complete
+	steps := []components.ProgressStep{
+		{Label: "Docker detected", Complete: true},
+		{Label: "Kind installed", Complete: true},
+		{Label: "Pulling node image", Active: true},
+		{Label: "Starting control plane"},
+		{Label: "Configuring kubeconfig"},
+	}
+	m.bootstrap.SetSteps(steps)
+	// Start from step 2 (0-indexed) since first two steps are complete
+	// This means bootstrapStep represents the NEXT step to process
+	m.bootstrapStep = 2
+	// Initial percent: step 2 out of 5 steps = ~33%
+	m.bootstrap.SetPercent(float64(m.bootstrapStep) / float64(len(steps)))
+}
+
 func (m AppModel) updateBootstrap(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	m.bootstrap, cmd = m.bootstrap.Update(msg)
@@ -533,23 +1032,49 @@ func (m AppModel) updateBootstrap(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m AppModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.searching {
+		return m.updateSearch(msg)
+	}
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(keyMsg, m.keymap.Search) {
+			return m.startSearch()
+		}
+		if key.Matches(keyMsg, m.keymap.Escape) && m.searchQuery != "" {
+			m.setSearchQuery("")
+			return m, nil
+		}
+		if key.Matches(keyMsg, m.keymap.PauseCluster) {
+			m.pauseOnQuit = true
+			m.quitting = true
+			m.statusbar.SetMessage("Pausing cluster...")
+			return m, m.cleanup()
+		}
+		if key.Matches(keyMsg, m.keymap.Stats) {
+			return m.openStats()
+		}
+		if key.Matches(keyMsg, m.keymap.WhatsNew) && len(m.releases) > 0 {
+			return m.openWhatsNew()
+		}
+		if key.Matches(keyMsg, m.keymap.CleanupKept) && m.exploring != nil {
+			return m, m.cleanupExplored()
+		}
+		if key.Matches(keyMsg, m.keymap.Enter) && m.following != nil {
+			m.statusbar.SetMessage(fmt.Sprintf("In co-op, %s picks the scenario.", m.following.Owner))
+			return m, nil
+		}
 		if key.Matches(keyMsg, m.keymap.Enter) {
 			// Start selected scenario
-			if item := m.sidebar.SelectedItem(); item != nil && !item.IsCategory {
-				for _, s := range m.registry.List() {
-					if s.GetMetadata().ID == item.ID {
-						m.currentScenario = s
-
-						// Check if already completed
-						if m.completedScenarios[s.GetMetadata().ID] {
-							m.view = ViewConfirmRestart
-							m.confirmSelection = 1 // Default to No (Safe)
-							return m, nil
-						}
-
-						return m.startSelectedScenario(s)
+			item := m.sidebar.SelectedItem()
+			if item != nil && !item.IsCategory {
+				if s := m.registry.Get(item.ID); s != nil {
+					m.currentScenario = s
+
+					// Check if already completed
+					if m.completedScenarios[item.ID] {
+						return m.confirmRestart()
 					}
+
+					return m.startSelectedScenario(s)
 				}
 			}
 		}
@@ -561,30 +1086,63 @@ func (m AppModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m AppModel) updateScenarioRunning(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.answering {
+		return m.updateAnswer(msg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.cheatSheetOpen {
+		return m.updateCheatSheet(keyMsg)
+	}
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		// F1 opens the cheat sheet from the terminal too; shells don't use it
+		if key.Matches(keyMsg, m.keymap.CheatSheet) && (m.focus != FocusTerminal || keyMsg.Type == tea.KeyF1) {
+			return m.openCheatSheet()
+		}
 		// Only handle shortcuts if NOT focused on terminal
 		if m.focus != FocusTerminal {
 			switch {
 			case key.Matches(keyMsg, m.keymap.Check):
-				return m, m.checkScenario()
+				return m, m.requestCheck()
 			case key.Matches(keyMsg, m.keymap.ToggleHints):
 				m.content.ToggleHints()
+				m.recordHint()
 			case key.Matches(keyMsg, m.keymap.NextHint):
+				if m.content.Guided() {
+					m.content.SetGuideStep(m.engineInstance.AdvanceGuide())
+					return m, nil
+				}
 				m.content.NextHint()
+				m.recordHint()
 			case key.Matches(keyMsg, m.keymap.PrevHint):
 				m.content.PrevHint()
+				m.recordHint()
+			case key.Matches(keyMsg, m.keymap.RunCommand):
+				m.runQuickCommand(keyMsg)
+				return m, nil
+			case key.Matches(keyMsg, m.keymap.Explain):
+				m.explainTerminalError()
+			case key.Matches(keyMsg, m.keymap.Docs):
+				m.openDocs()
+			case key.Matches(keyMsg, m.keymap.Answer):
+				return m.startAnswer()
+			case key.Matches(keyMsg, m.keymap.Shell):
+				return m, m.openShell()
+			case key.Matches(keyMsg, m.keymap.Pause):
+				m.pause(time.Now())
+				return m, nil
+			case key.Matches(keyMsg, m.keymap.Reset) && m.following != nil:
+				m.content.SetStatus(fmt.Sprintf("Only %s can reset the scenario.", m.following.Owner), false)
+			case key.Matches(keyMsg, m.keymap.Reset):
+				return m.confirmReset()
+			case key.Matches(keyMsg, m.keymap.Assistant):
+				if m.assistant != nil && m.currentScenario != nil {
+					m.content.SetNote(assistantLabel, "Thinking…")
+					return m, m.askAssistant()
+				}
 			case key.Matches(keyMsg, m.keymap.Escape):
-				// Return to dashboard
-				ctx := context.Background()
-				if m.engineInstance != nil {
-					_ = m.engineInstance.Cleanup(ctx)
+				if m.following != nil {
+					m.coopLeft = m.engineInstance.StartedAt() // Don't follow it straight back in
 				}
-				m.terminal.Stop()
-				m.header.SetTitle("🥋 K8s-Dojo")
-				m.header.ResetTimer()
-				m.view = ViewDashboard
-				m.currentScenario = nil
-				return m, nil
+				return m, m.leaveScenario()
 			}
 		}
 	}
@@ -606,45 +1164,68 @@ func (m AppModel) updateScenarioRunning(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
-func (m AppModel) updateSuccess(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		switch {
-		// Navigation
-		case key.Matches(keyMsg, m.keymap.Left), key.Matches(keyMsg, m.keymap.ShiftTab), key.Matches(keyMsg, m.keymap.Up):
-			m.success.PrevButton()
-			return m, nil
-		case key.Matches(keyMsg, m.keymap.Right), key.Matches(keyMsg, m.keymap.Tab), key.Matches(keyMsg, m.keymap.Down):
-			m.success.NextButton()
-			return m, nil
-
-		case key.Matches(keyMsg, m.keymap.Enter):
-			if m.success.SelectedButton() == 1 {
-				// Retry
-				return m.handleRetry()
-			}
-			// Continue
-			return m.handleReturnToDashboard()
+// recordHint reports the currently visible hint to the engine.
+func (m *AppModel) recordHint() {
+	if m.engineInstance == nil || !m.content.HintsVisible() {
+		return
+	}
+	m.engineInstance.RecordHint(m.content.CurrentHint())
+}
 
-		case key.Matches(keyMsg, m.keymap.ReturnMenu):
-			return m.handleReturnToDashboard()
+func (m AppModel) updateSuccess(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.success, cmd = m.success.Update(msg)
+	return m, cmd
+}
 
-		case key.Matches(keyMsg, m.keymap.Retry):
-			return m.handleRetry()
+// handleSuccessChosen carries out the button chosen on the success screen.
+func (m AppModel) handleSuccessChosen(msg components.SuccessChosenMsg) (tea.Model, tea.Cmd) {
+	if m.view != ViewSuccess {
+		return m, nil
+	}
+	switch msg.Action {
+	case components.SuccessRetry:
+		return m.handleRetry()
+	case components.SuccessKeepExploring:
+		return m.handleKeepExploring()
+	case components.SuccessWalkthrough:
+		if m.currentScenario != nil {
+			m.success.RevealWalkthrough(scenario.DemoScript(m.currentScenario))
 		}
+		return m, nil
 	}
-	return m, nil
+	return m.handleReturnToDashboard()
 }
 
 func (m AppModel) handleReturnToDashboard() (tea.Model, tea.Cmd) {
+	return m.returnToDashboard((*AppModel).cleanupInBackground)
+}
+
+// handleKeepExploring returns to the dashboard but leaves the solved scenario's
+// resources in place until the user cleans them up or starts another scenario.
+func (m AppModel) handleKeepExploring() (tea.Model, tea.Cmd) {
+	return m.returnToDashboard(func(m *AppModel) tea.Cmd {
+		if m.engineInstance == nil {
+			return nil
+		}
+		cmd := m.cleanupExplored() // Only one scenario is kept at a time
+		m.exploring = m.engineInstance.Detach()
+		if m.exploring != nil {
+			m.refreshBanner()
+			m.statusbar.SetMessage(fmt.Sprintf("Kept namespace %s. Press x to clean it up when you're done.", m.exploring.Scenario().GetNamespace()))
+		}
+		return cmd
+	})
+}
+
+// returnToDashboard leaves the success screen; release detaches the scenario.
+func (m AppModel) returnToDashboard(release func(*AppModel) tea.Cmd) (tea.Model, tea.Cmd) {
 	// Mark current scenario as completed
 	if m.currentScenario != nil {
-		m.completedScenarios[m.currentScenario.GetMetadata().ID] = true
+		m.markCompleted(m.currentScenario)
 	}
 
-	ctx := context.Background()
-	if m.engineInstance != nil {
-		_ = m.engineInstance.Cleanup(ctx)
-	}
+	cmd := release(&m)
 
 	m.header.SetTitle("🥋 K8s-Dojo")
 	m.header.ResetTimer()
@@ -653,42 +1234,73 @@ func (m AppModel) handleReturnToDashboard() (tea.Model, tea.Cmd) {
 	m.buildSidebarItems()
 
 	m.view = ViewDashboard
-	m.focus = FocusSidebar // Explicitly set focus to Sidebar
-	m.updateFocusStyles()  // Apply focus styles
+	m.setFocus(FocusSidebar)
 
 	m.currentScenario = nil
 	m.lastCheckResult = scenario.Result{}
 
-	return m, nil
+	return m, cmd
 }
 
 func (m AppModel) handleRetry() (tea.Model, tea.Cmd) {
 	// Restart same scenario
 	m.header.StartTimer()
+	m.lastInput = time.Now()
 	m.view = ViewScenarioRunning
-	return m, tea.Batch(
-		m.startScenario(),
-		tea.Tick(m.checkInterval, func(t time.Time) tea.Msg {
-			return tickMsg(t)
-		}),
-	)
+	loop := m.startCheckLoop()
+	m.starting = true
+	return m, tea.Batch(m.startScenario(), m.listenProgress(), loop)
 }
 
 // Commands
 
 func (m AppModel) doBootstrap() tea.Cmd {
 	return func() tea.Msg {
-		manager := cluster.NewManager()
+		var warnings []string
+		opts := cluster.DefaultManagerOptions()
+		opts.Name = m.clusterName
+		opts.SkipPreflight = m.skipPreflight
+		opts.Warn = func(w string) { warnings = append(warnings, w) }
+
+		manager := cluster.NewManagerWithOptions(opts)
 		kubeconfig, err := manager.EnsureCluster(m.versions[m.selectedVersion])
-		return bootstrapDoneMsg{kubeconfig: kubeconfig, err: err}
+		if err == nil && m.remote != nil {
+			kubeconfig, err = m.remote.Connect(context.Background(), kubeconfig)
+		}
+		return bootstrapDoneMsg{kubeconfig: kubeconfig, err: err, warnings: warnings}
+	}
+}
+
+// resetScenario restores the scenario's initial state in place, falling back
+// to a full restart when no snapshot was captured.
+func (m AppModel) resetScenario() tea.Cmd {
+	inPlace := m.engineInstance.CanReset()
+	id := m.currentScenario.GetMetadata().ID
+	return func() tea.Msg {
+		ctx := context.Background()
+		if !inPlace {
+			return scenarioResetMsg{err: m.engineInstance.StartScenario(ctx, id)}
+		}
+		return scenarioResetMsg{err: m.engineInstance.ResetScenario(ctx)}
 	}
 }
 
-type scenarioStartedMsg struct {
-	err error
+// chaosActive reports whether hard mode applies to the current scenario.
+// It only adds noise to scenarios the user has already solved.
+func (m AppModel) chaosActive() bool {
+	return m.hardMode && m.currentScenario != nil && m.completedScenarios[m.currentScenario.GetMetadata().ID]
 }
 
 func (m AppModel) startScenario() tea.Cmd {
+	if m.following != nil {
+		return m.joinScenario()
+	}
+	if m.chaosActive() {
+		opts := chaos.DefaultOptions()
+		m.engineInstance.SetChaos(&opts)
+	} else {
+		m.engineInstance.SetChaos(nil)
+	}
 	return func() tea.Msg {
 		ctx := context.Background()
 		err := m.engineInstance.StartScenario(ctx, m.currentScenario.GetMetadata().ID)
@@ -696,33 +1308,87 @@ func (m AppModel) startScenario() tea.Cmd {
 	}
 }
 
+// setScenarioNamespace points the content panel, hints and quick commands at the scenario's namespace.
+func (m *AppModel) setScenarioNamespace(namespace string) {
+	m.content.SetNamespace(namespace)
+	values := scenario.HintValues{Namespace: namespace, ClusterContext: m.clusterContext()}
+	m.content.SetHintValues(values)
+	m.cheatSheet.SetHintValues(values)
+}
+
+// runQuickCommand types the quick command bound to the pressed number key
+// into the terminal and runs it.
+func (m *AppModel) runQuickCommand(keyMsg tea.KeyMsg) {
+	command, ok := m.content.Command(int(keyMsg.String()[0] - '1'))
+	if !ok || !m.terminal.IsRunning() {
+		return
+	}
+	m.terminal.SendInput(command + "\r")
+	m.setFocus(FocusTerminal)
+}
+
+// openDocs opens the scenario's next docs link in the browser, or shows its
+// URL if there is none to open it in.
+func (m *AppModel) openDocs() {
+	doc, ok := m.content.NextDoc()
+	if !ok {
+		m.statusbar.SetMessage("This scenario has no docs links.")
+		return
+	}
+	if err := browser.Open(doc.URL); err != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("%s: %s", doc.Title, doc.URL))
+		return
+	}
+	m.statusbar.SetMessage("Opened " + doc.Title + " in your browser")
+}
+
+// clusterContext returns the dojo cluster's kubeconfig context; a remote
+// host's kubeconfig may name it differently than Kind does.
+func (m *AppModel) clusterContext() string {
+	if config, err := clientcmd.Load([]byte(m.kubeconfig)); err == nil && config.CurrentContext != "" {
+		return config.CurrentContext
+	}
+	return "kind-" + m.dojoCluster().Name()
+}
+
 func (m AppModel) startSelectedScenario(s scenario.Scenario) (tea.Model, tea.Cmd) {
+	if m.recorder != nil {
+		m.recorder.Marker(s.GetMetadata().ID)
+	}
 	m.view = ViewScenarioRunning
+	m.cheatSheetOpen = false
 	m.header.SetTitle("🥋 " + s.GetMetadata().Name)
 	m.header.StartTimer()
+	m.lastInput = time.Now()
 
 	// Setup content panel
 	m.content.SetScenario(
 		s.GetMetadata().Name,
-		s.GetMetadata().Description,
+		scenarioDescription(s),
 		s.GetNamespace(),
 	)
-	m.content.SetCommands([]string{
-		fmt.Sprintf("kubectl config use-context kind-%s", cluster.ClusterName),
-		fmt.Sprintf("kubectl get pods -n %s", s.GetNamespace()),
-	})
+	m.setScenarioNamespace(s.GetNamespace())
 	m.content.SetHints(s.GetMetadata().Hints)
+	m.content.SetCommands(s.GetMetadata().QuickCommands())
+	m.content.SetDocs(s.GetMetadata().DocsLinks)
 	m.content.SetStatus("Setting up scenario environment...", false)
 
 	// Auto-focus terminal for immediate input
-	m.focus = FocusTerminal
-	m.updateFocusStyles()
+	m.setFocus(FocusTerminal)
 
 	// Reset terminal to clear previous state
 	m.terminal.Stop()
+	m.terminal.SetKubeconfig(m.kubeconfig)
+	m.learnerNamespace = ""
 
+	// Starting another scenario ends exploring the last one
+	cleanup := m.cleanupExplored()
+
+	m.starting = true
 	return m, tea.Batch(
+		cleanup,
 		m.startScenario(),
+		m.listenProgress(),
 		m.terminal.Start(),
 		// Note: We DO NOT start the check ticker here.
 		// The check ticker will be started by handleCheckResult when startScenario completes.
@@ -730,28 +1396,227 @@ func (m AppModel) startSelectedScenario(s scenario.Scenario) (tea.Model, tea.Cmd
 	)
 }
 
+// startCheckLoop starts the auto-check loop, replacing any loop still running.
+func (m *AppModel) startCheckLoop() tea.Cmd {
+	m.checkLoop++
+	m.checking = false
+	return m.nextCheck()
+}
+
+// nextCheck schedules the next tick of the current auto-check loop.
+func (m AppModel) nextCheck() tea.Cmd {
+	loop := m.checkLoop
+	return tea.Tick(m.checkInterval, func(time.Time) tea.Msg {
+		return tickMsg{loop: loop}
+	})
+}
+
+// requestCheck runs a check unless one is already in flight, in which case
+// its result answers this request too.
+func (m *AppModel) requestCheck() tea.Cmd {
+	if m.checking {
+		return nil
+	}
+	m.checking = true
+	return m.checkScenario()
+}
+
 func (m AppModel) checkScenario() tea.Cmd {
+	guided := m.content.Guided()
+	loop := m.checkLoop
 	return func() tea.Msg {
 		ctx := context.Background()
+		var step int
+		if guided {
+			step = m.engineInstance.CheckGuide(ctx)
+		}
 		result, err := m.engineInstance.Check(ctx)
 		if err != nil {
-			return checkResultMsg{result: scenario.Result{Solved: false, Message: err.Error()}}
+			return checkResultMsg{result: scenario.Result{Solved: false, Message: err.Error()}, guideStep: step, loop: loop}
 		}
-		return checkResultMsg{result: result}
+		return checkResultMsg{result: result, guideStep: step, loop: loop}
 	}
 }
 
+const assistantLabel = "🤖 Assistant"
+
+// explainTerminalError explains the most recent known error visible in the terminal.
+func (m *AppModel) explainTerminalError() {
+	match := explain.Find(m.terminal.ScreenLines())
+	if match == nil {
+		m.content.SetNote("📖 Explain", "No known error found in the terminal. Run a command that shows it, e.g. kubectl get pods or kubectl describe.")
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n%s", match.Line, match.Explanation)
+	var practice []string
+	for _, id := range match.Scenarios {
+		if s := m.registry.Get(id); s != nil {
+			meta := s.GetMetadata()
+			practice = append(practice, fmt.Sprintf("%s (%s)", meta.Name, meta.Category))
+		}
+	}
+	if len(practice) > 0 {
+		fmt.Fprintf(&b, "\n\nPractice: %s", strings.Join(practice, ", "))
+	}
+	m.content.SetNote("📖 "+match.Title, b.String())
+}
+
+// askAssistant requests a contextual nudge for the running scenario.
+func (m AppModel) askAssistant() tea.Cmd {
+	meta := m.currentScenario.GetMetadata()
+	namespace := m.currentScenario.GetNamespace()
+	req := assistant.Request{
+		Scenario:    meta.Name,
+		Description: meta.Description,
+		Namespace:   namespace,
+		Commands:    m.terminal.RecentCommands(10),
+	}
+	for _, c := range m.lastCheckResult.Checks {
+		if !c.Passed {
+			req.Failing = append(req.Failing, c.Name+": "+c.Details)
+		}
+	}
+
+	a, clientset := m.assistant, m.k8sClient.Clientset
+	return func() tea.Msg {
+		ctx := context.Background()
+		req.State = assistant.DescribeNamespace(ctx, clientset, namespace)
+		hint, err := a.Hint(ctx, req)
+		return assistantHintMsg{scenarioID: meta.ID, hint: hint, err: err}
+	}
+}
+
+// loadGuide shows the running scenario's guided steps when guided mode is on.
+func (m *AppModel) loadGuide() {
+	if !m.guidedMode || m.engineInstance == nil {
+		return
+	}
+	steps, current := m.engineInstance.Guide()
+	if len(steps) == 0 {
+		return
+	}
+
+	cards := make([]components.GuideCard, len(steps))
+	for i, step := range steps {
+		cards[i] = components.GuideCard{
+			Title:       step.Title,
+			Instruction: step.Instruction,
+			Command:     step.Command,
+			Manual:      step.Verify == nil,
+		}
+	}
+	m.content.SetGuide(cards)
+	m.content.SetGuideStep(current)
+}
+
 func (m AppModel) tickProgress() tea.Cmd {
 	return tea.Tick(800*time.Millisecond, func(t time.Time) tea.Msg {
 		return progressTickMsg(t)
 	})
 }
 
+// leaveScenario returns to the dashboard, cleaning up the running scenario in
+// the background.
+func (m *AppModel) leaveScenario() tea.Cmd {
+	cmd := m.cleanupInBackground()
+	m.terminal.Stop()
+	m.header.SetTitle("🥋 K8s-Dojo")
+	m.header.ResetTimer()
+	m.view = ViewDashboard
+	m.setFocus(FocusSidebar) // The dashboard only navigates the sidebar
+	m.currentScenario = nil
+	// Drop ticks and checks still in flight for the scenario left
+	m.checkLoop++
+	m.checking = false
+	return cmd
+}
+
+// cleanupInBackground detaches the running scenario and tears it down asynchronously.
+func (m *AppModel) cleanupInBackground() tea.Cmd {
+	if m.engineInstance == nil {
+		return nil
+	}
+	if m.currentScenario != nil {
+		m.unrestrictTerminal(m.currentScenario.GetNamespace())
+	}
+	id, run := m.engineInstance.BeginCleanup()
+	if run == nil {
+		return nil
+	}
+
+	m.statusbar.SetMessage("Cleaning previous scenario…")
+	return func() tea.Msg {
+		err := run(context.Background())
+		return cleanupDoneMsg{scenarioID: id, err: err}
+	}
+}
+
+// cleanupExplored starts cleaning up the scenario kept after its solve, if any.
+func (m *AppModel) cleanupExplored() tea.Cmd {
+	if m.exploring == nil {
+		return nil
+	}
+	d := m.exploring
+	m.exploring = nil
+	m.refreshBanner()
+	m.unrestrictTerminal(d.Scenario().GetNamespace())
+
+	run := d.BeginCleanup()
+	m.statusbar.SetMessage("Cleaning previous scenario…")
+	return func() tea.Msg {
+		err := run(context.Background())
+		return cleanupDoneMsg{scenarioID: d.ScenarioID(), err: err}
+	}
+}
+
+// refreshBanner shows the most important notice in the header: a lost
+// connection, then a notice pushed to the session, then a scenario kept for
+// exploring, then the co-op session.
+func (m *AppModel) refreshBanner() {
+	switch {
+	case m.connLost:
+		m.header.SetBanner("⚠ Cluster unreachable, reconnecting...")
+	case m.activeNotice() != "":
+		m.header.SetBanner("📣 " + m.activeNotice())
+	case m.exploring != nil:
+		m.header.SetBanner(fmt.Sprintf("🔎 Exploring %s · x to clean up", m.exploring.Scenario().GetNamespace()))
+	case m.coopBanner() != "":
+		m.header.SetBanner(m.coopBanner())
+	default:
+		m.header.SetBanner("")
+	}
+}
+
 func (m AppModel) cleanup() tea.Cmd {
 	return func() tea.Msg {
 		if m.engineInstance != nil {
-			ctx := context.Background()
-			_ = m.engineInstance.Cleanup(ctx)
+			if m.kept.scenario != "" {
+				m.engineInstance.Abandon()
+			} else {
+				_ = m.engineInstance.Cleanup(context.Background())
+				if m.exploring != nil {
+					_ = m.exploring.BeginCleanup()(context.Background())
+				}
+			}
+		}
+		if m.proctor != nil {
+			_ = m.proctor.Leave(m.session)
+		}
+		if m.share != nil {
+			_ = m.coop.Close(m.share.Token)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		for _, p := range []*telemetry.Plugin{m.telemetry, m.history} {
+			if p != nil {
+				p.Flush(ctx)
+			}
+		}
+		cancel()
+		if m.pauseOnQuit {
+			// Resumed on the next start, or with `k8s-dojo cluster resume`
+			_ = m.dojoCluster().Pause()
 		}
 		return tea.Quit()
 	}
@@ -781,10 +1646,12 @@ func (m AppModel) View() string {
 		return m.viewScenarioRunning()
 	case ViewSuccess:
 		return m.viewSuccess()
-	case ViewConfirmRestart:
-		return m.viewConfirmRestart()
-	case ViewConfirmQuit:
-		return m.viewConfirmQuit()
+	case ViewConfirm:
+		return m.viewConfirm()
+	case ViewStats:
+		return m.viewStats()
+	case ViewWhatsNew:
+		return m.viewWhatsNew()
 	}
 
 	return ""
@@ -828,6 +1695,10 @@ func (m AppModel) viewVersionSelect() string {
 		"",
 		boxStyle.Render(boxContent),
 	)
+	if m.clusterStatus == cluster.StatusPaused {
+		notice := m.styles.Highlight.Render("⏸  The dojo cluster is paused. Press Enter to resume it.")
+		content = lipgloss.JoinVertical(lipgloss.Center, content, "", notice)
+	}
 
 	// Status bar
 	m.statusbar.SetKeys(components.ContextualStatusBar("version-select"))
@@ -841,6 +1712,9 @@ func (m AppModel) viewVersionSelect() string {
 func (m AppModel) viewBootstrap() string {
 	if m.bootstrapErr != nil {
 		errContent := m.styles.Error.Render("❌ Error: " + m.bootstrapErr.Error())
+		if hint := remediation(m.bootstrapErr); hint != "" {
+			errContent += "\n\n" + m.styles.TextMuted.Width(min(m.width-4, 80)).Render("💡 "+hint)
+		}
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, errContent)
 	}
 
@@ -860,16 +1734,30 @@ func (m AppModel) viewDashboard() string {
 		Width(m.layout.ContentWidth - 2).
 		Height(m.layout.InfoHeight - 2)
 
-	var contentText string
-	if item := m.sidebar.SelectedItem(); item != nil && !item.IsCategory {
-		contentText = m.styles.Title.Render("🔧 "+item.Title) + "\n\n"
+	contentText := m.searchView()
+	item := m.sidebar.SelectedItem()
+	if item != nil && !item.IsCategory {
+		contentText += m.styles.Title.Render("🔧 "+item.Title) + "\n\n"
 		contentText += m.styles.Text.Render(item.Description) + "\n\n"
+		if s, ok := m.communityStats[item.ID]; ok && s.Solved > 0 {
+			contentText += m.styles.TextMuted.Render(fmt.Sprintf("👥 Community average: %s (%d attempts)", s.MedianTime().Round(time.Minute), s.Attempts)) + "\n\n"
+		}
+		if r := m.recommendation(item.ID); r != nil {
+			contentText += m.styles.TextMuted.Render("🎯 Recommended: "+r.Reason) + "\n\n"
+		}
 		contentText += m.styles.Highlight.Render("Press Enter to start")
 	} else if item != nil && item.IsCategory {
-		contentText = m.styles.Title.Render(CategoryIcon(item.Title)+" "+item.Title) + "\n\n"
+		contentText += m.styles.Title.Render(CategoryIcon(item.Title)+" "+item.Title) + "\n\n"
 		contentText += m.styles.TextMuted.Render("Use h/l to expand/collapse, j/k to navigate")
+	} else if m.searchQuery != "" {
+		contentText += m.styles.TextMuted.Render("No scenarios match the search")
 	} else {
-		contentText = m.styles.TextMuted.Render("Select a scenario to begin")
+		contentText += m.styles.TextMuted.Render("Select a scenario to begin")
+	}
+	if item == nil || item.IsCategory {
+		if rec := m.recommendationsView(); rec != "" {
+			contentText += "\n\n" + rec
+		}
 	}
 	content := contentStyle.Render(contentText)
 
@@ -895,6 +1783,9 @@ func (m AppModel) viewScenarioRunning() string {
 	sidebar := m.sidebar.View()
 	content := m.content.View()
 	terminal := m.terminal.View()
+	if m.cheatSheetOpen {
+		terminal = m.cheatSheet.View()
+	}
 
 	// Right side is content (top) + terminal (bottom)
 	rightSide := lipgloss.JoinVertical(lipgloss.Left, content, terminal)
@@ -902,7 +1793,15 @@ func (m AppModel) viewScenarioRunning() string {
 	mainArea := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, rightSide)
 
 	// Status bar
-	m.statusbar.SetKeys(components.ContextualStatusBar("scenario-running"))
+	keys := "scenario-running"
+	switch {
+	case m.cheatSheetOpen:
+		keys = "cheat-sheet"
+	case m.focus == FocusTerminal:
+		keys = "scenario-terminal"
+	}
+	m.statusbar.SetKeys(components.ContextualStatusBar(keys))
+	m.statusbar.SetFocusLegend(m.focusLegend())
 	statusBar := m.statusbar.View()
 
 	return lipgloss.JoinVertical(lipgloss.Left, header, mainArea, statusBar)
@@ -912,126 +1811,128 @@ func (m AppModel) viewSuccess() string {
 	return m.success.View()
 }
 
-func (m AppModel) updateConfirmRestart(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		switch {
-		// Navigation
-		case key.Matches(keyMsg, m.keymap.Left), key.Matches(keyMsg, m.keymap.ShiftTab), key.Matches(keyMsg, m.keymap.Up):
-			m.confirmSelection = (m.confirmSelection - 1 + 2) % 2
-			return m, nil
-		case key.Matches(keyMsg, m.keymap.Right), key.Matches(keyMsg, m.keymap.Tab), key.Matches(keyMsg, m.keymap.Down):
-			m.confirmSelection = (m.confirmSelection + 1) % 2
-			return m, nil
-
-		case key.Matches(keyMsg, m.keymap.Enter):
-			if m.confirmSelection == 0 {
-				return m.startSelectedScenario(m.currentScenario)
-			}
-			// Cancel
-			m.view = ViewDashboard
-			m.currentScenario = nil
-			return m, nil
-
-		case key.Matches(keyMsg, m.keymap.Escape), key.Matches(keyMsg, m.keymap.Quit):
-			// Cancel
-			m.view = ViewDashboard
-			m.currentScenario = nil
-			return m, nil
-		// Allow 'y' and 'n' as distinct from general keymap
-		case keyMsg.String() == "y":
-			return m.startSelectedScenario(m.currentScenario)
-		case keyMsg.String() == "n":
-			m.view = ViewDashboard
-			m.currentScenario = nil
-			return m, nil
-		}
+// confirmRestart asks before replaying the selected, already completed scenario.
+func (m AppModel) confirmRestart() (tea.Model, tea.Cmd) {
+	name := m.currentScenario.GetMetadata().Name
+	msg := fmt.Sprintf("You have already completed\n'%s'.\n\nRestarting will reset the environment.\nAre you sure?", name)
+	if v := m.staleVersion(m.currentScenario); v > 0 {
+		msg = fmt.Sprintf("You completed v%d of\n'%s'.\n\nIt has been updated since.\nSolve it again to update your record?", v, name)
 	}
-	return m, nil
+	dialog := components.NewConfirmDialog("⚠️  Restart Scenario?", msg,
+		[]components.ConfirmButton{{Label: "Yes", Key: "y"}, {Label: "No", Key: "n"}}, 1)
+	return m.openConfirm(dialog,
+		func(m AppModel) (tea.Model, tea.Cmd) { return m.startSelectedScenario(m.currentScenario) },
+		func(m AppModel) (tea.Model, tea.Cmd) {
+			m.currentScenario = nil
+			return closeConfirm(m)
+		},
+	)
 }
 
-func (m AppModel) viewConfirmRestart() string {
-	// Re-use success styles for consistent look, or simpler box
-	title := m.styles.Title.Render("⚠️  Restart Scenario?")
+// confirmReset asks before reverting the learner's edits to the running scenario.
+func (m AppModel) confirmReset() (tea.Model, tea.Cmd) {
+	msg := fmt.Sprintf("Your edits in namespace %s\nare reverted to the initial broken state.", m.currentScenario.GetNamespace())
+	dialog := components.NewConfirmDialog("↺  Reset Scenario?", msg,
+		[]components.ConfirmButton{{Label: "Reset", Key: "y"}, {Label: "Cancel", Key: "n"}}, 1)
+	dialog.SetDanger(true)
+	return m.openConfirm(dialog,
+		func(m AppModel) (tea.Model, tea.Cmd) {
+			m.view = m.previousView
+			m.content.SetStatus("Resetting scenario to its initial state...", false)
+			return m, m.resetScenario()
+		},
+		closeConfirm,
+	)
+}
 
-	msg := fmt.Sprintf("\nYou have already completed\n'%s'.\n\nRestarting will reset the environment.\nAre you sure?\n", m.currentScenario.GetMetadata().Name)
+// quitChoice is a button in the quit dialog.
+type quitChoice int
 
-	yesBtn := "[ Yes (y) ]"
-	noBtn := "[ No (n) ]"
+const (
+	quitCleanup quitChoice = iota // Clean up and quit
+	quitKeep                      // Quit, leaving the scenario running for inspection
+	quitCancel
+)
 
-	if m.confirmSelection == 0 {
-		yesBtn = m.styles.ActiveItem.Render(yesBtn)
-		noBtn = m.styles.TextMuted.Render(noBtn)
-	} else {
-		yesBtn = m.styles.TextMuted.Render(yesBtn)
-		noBtn = m.styles.ActiveItem.Render(noBtn)
+// leftovers returns the scenarios whose resources quitting would clean up:
+// the running one and the one kept for exploring.
+func (m AppModel) leftovers() []scenario.Scenario {
+	var out []scenario.Scenario
+	if m.currentScenario != nil {
+		out = append(out, m.currentScenario)
 	}
-
-	buttons := yesBtn + "    " + noBtn
-
-	boxStyle := m.styles.Box.Width(50).Align(lipgloss.Center).BorderForeground(lipgloss.Color("#fab387")) // Peach/Orange for warning
-	boxContent := title + "\n" + m.styles.Text.Render(msg) + "\n" + buttons
-
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(boxContent))
+	if m.exploring != nil {
+		out = append(out, m.exploring.Scenario())
+	}
+	return out
 }
 
-func (m AppModel) updateConfirmQuit(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		switch {
-		// Navigation
-		case key.Matches(keyMsg, m.keymap.Left), key.Matches(keyMsg, m.keymap.ShiftTab), key.Matches(keyMsg, m.keymap.Up):
-			m.confirmSelection = (m.confirmSelection - 1 + 2) % 2
-			return m, nil
-		case key.Matches(keyMsg, m.keymap.Right), key.Matches(keyMsg, m.keymap.Tab), key.Matches(keyMsg, m.keymap.Down):
-			m.confirmSelection = (m.confirmSelection + 1) % 2
-			return m, nil
+// quitChoices returns the quit dialog's buttons. Keeping the environment is
+// only offered when there is something to keep.
+func (m AppModel) quitChoices() []quitChoice {
+	if len(m.leftovers()) > 0 {
+		return []quitChoice{quitCleanup, quitKeep, quitCancel}
+	}
+	return []quitChoice{quitCleanup, quitCancel}
+}
 
-		case key.Matches(keyMsg, m.keymap.Enter):
-			if m.confirmSelection == 0 {
-				// Yes, quit
-				m.quitting = true
-				return m, m.cleanup()
+// confirmQuit asks whether to quit, listing what quitting would clean up.
+func (m AppModel) confirmQuit() (tea.Model, tea.Cmd) {
+	msg := "Are you sure you want to exit?"
+	width := 40
+	if leftovers := m.leftovers(); len(leftovers) > 0 {
+		var lines []string
+		for _, s := range leftovers {
+			for _, step := range scenario.CleanupPlan(s) {
+				lines = append(lines, "• "+step.Description)
 			}
-			// Cancel
-			m.view = ViewDashboard
-			return m, nil
-
-		case key.Matches(keyMsg, m.keymap.Escape), key.Matches(keyMsg, m.keymap.Quit):
-			// Cancel
-			m.view = m.previousView
-			return m, nil
-
-		// Allow 'y' and 'n'
-		case keyMsg.String() == "y":
-			m.quitting = true
-			return m, m.cleanup()
-		case keyMsg.String() == "n":
-			m.view = m.previousView
-			return m, nil
 		}
+		msg = "Quitting cleans up your scenario environment:\n" + strings.Join(lines, "\n") +
+			"\n\nKeep it to inspect it with kubectl after quitting."
+		width = 60
 	}
-	return m, nil
-}
-
-func (m AppModel) viewConfirmQuit() string {
-	title := m.styles.Title.Render("👋  Quit K8s-Dojo?")
-
-	msg := "\nAre you sure you want to exit?\n"
 
-	yesBtn := "[ Yes (y) ]"
-	noBtn := "[ No (n) ]"
-
-	if m.confirmSelection == 0 {
-		yesBtn = m.styles.ActiveItem.Render(yesBtn)
-		noBtn = m.styles.TextMuted.Render(noBtn)
-	} else {
-		yesBtn = m.styles.TextMuted.Render(yesBtn)
-		noBtn = m.styles.ActiveItem.Render(noBtn)
+	choices := m.quitChoices()
+	buttons := map[quitChoice]components.ConfirmButton{
+		quitCleanup: {Label: "Yes", Key: "y"},
+		quitKeep:    {Label: "Keep environment", Key: "k"},
+		quitCancel:  {Label: "No", Key: "n"},
+	}
+	if len(choices) == 3 {
+		buttons[quitCleanup] = components.ConfirmButton{Label: "Clean up", Key: "y"}
+	}
+	var dialogButtons []components.ConfirmButton
+	var actions []confirmAction
+	for _, c := range choices {
+		dialogButtons = append(dialogButtons, buttons[c])
+		actions = append(actions, func(m AppModel) (tea.Model, tea.Cmd) { return m.quitWith(c) })
 	}
 
-	buttons := yesBtn + "    " + noBtn
+	dialog := components.NewConfirmDialog("👋  Quit K8s-Dojo?", msg, dialogButtons, len(choices)-1)
+	dialog.SetWidth(width)
+	return m.openConfirm(dialog, actions...)
+}
 
-	boxStyle := m.styles.Box.Width(40).Align(lipgloss.Center).BorderForeground(lipgloss.Color("#fab387"))
-	boxContent := title + "\n" + m.styles.Text.Render(msg) + "\n" + buttons
+func (m AppModel) quitWith(choice quitChoice) (tea.Model, tea.Cmd) {
+	switch choice {
+	case quitKeep:
+		var names []string
+		for _, s := range m.leftovers() {
+			names = append(names, s.GetMetadata().Name)
+			m.kept.steps = append(m.kept.steps, scenario.CleanupPlan(s)...)
+		}
+		m.kept.scenario = strings.Join(names, ", ")
+		fallthrough
+	case quitCleanup:
+		m.quitting = true
+		return m, m.cleanup()
+	}
+	m.view = m.previousView
+	return m, nil
+}
 
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(boxContent))
+// KeptEnvironment returns the scenario the user chose to leave running on quit
+// and how to clean it up by hand. The name is empty when nothing was kept.
+func (m *AppModel) KeptEnvironment() (string, []scenario.CleanupStep) {
+	return m.kept.scenario, m.kept.steps
 }
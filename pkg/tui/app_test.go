@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/engine"
+	"k8s-dojo/pkg/k8s"
+	"k8s-dojo/pkg/scenario"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHintKeysRecordHints(t *testing.T) {
+	const id = "net-service-selector"
+	clientset := fake.NewSimpleClientset()
+	reg := scenario.NewSessionRegistry(scenario.Deps{Clientset: clientset, Client: &k8s.Client{Clientset: clientset}})
+	eng := engine.NewEngine(reg, clientset)
+	if err := eng.StartScenario(context.Background(), id); err != nil {
+		t.Fatalf("StartScenario failed: %v", err)
+	}
+
+	m := NewAppModel()
+	m.engineInstance = eng
+	m.view = ViewScenarioRunning
+	m.focus = FocusContent
+	m.content.SetHints(reg.Get(id).GetMetadata().Hints)
+
+	press := func(k string) {
+		t.Helper()
+		model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = model.(AppModel)
+	}
+	used := func() int { return eng.AttemptStats().HintsUsed }
+
+	press("n") // Moves to the second hint, but the box is hidden
+	if n := used(); n != 0 {
+		t.Fatalf("Hidden hint counted: HintsUsed = %d", n)
+	}
+	press("h")
+	if n := used(); n != 1 {
+		t.Fatalf("After showing hints, HintsUsed = %d, want 1", n)
+	}
+	press("p")
+	press("n") // Back to a hint already counted
+	if n := used(); n != 2 {
+		t.Errorf("After moving between two hints, HintsUsed = %d, want 2", n)
+	}
+	press("h")
+	press("n")
+	if n := used(); n != 2 {
+		t.Errorf("Hint cycled while hidden counted: HintsUsed = %d, want 2", n)
+	}
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openCheatSheet shows the kubectl cheat sheet over the terminal.
+func (m AppModel) openCheatSheet() (tea.Model, tea.Cmd) {
+	m.cheatSheetOpen = true
+	return m, nil
+}
+
+// updateCheatSheet handles keys while the cheat sheet is open. Enter types
+// the selected command into the terminal without running it, so the learner
+// can fill in its placeholders; Escape and the cheat sheet key close it.
+func (m AppModel) updateCheatSheet(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keymap.Escape) || key.Matches(msg, m.keymap.CheatSheet) || msg.String() == "q" {
+		m.cheatSheetOpen = false
+		return m, nil
+	}
+	var command string
+	m.cheatSheet, command = m.cheatSheet.Update(msg)
+	if command == "" {
+		return m, nil
+	}
+	m.cheatSheetOpen = false
+	if !m.terminal.IsRunning() {
+		m.statusbar.SetMessage("The terminal isn't running; type it yourself: " + command)
+		return m, nil
+	}
+	m.terminal.SendInput(command)
+	m.setFocus(FocusTerminal)
+	return m, nil
+}
@@ -0,0 +1,228 @@
+package components
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// CheatCommand is a kubectl command of the cheat sheet. Like a quick command,
+// it is a template filled in with HintValues; <placeholders> such as <pod>
+// are left for the learner to fill in.
+type CheatCommand struct {
+	Command     string
+	Description string
+}
+
+// CheatCategory groups the cheat sheet's commands under a tab.
+type CheatCategory struct {
+	Name     string
+	Commands []CheatCommand
+}
+
+// KubectlCheatSheet lists the commands beginners reach for most, by task.
+// Placeholders come last where kubectl allows it, so the learner can type
+// them straight after the command is injected.
+var KubectlCheatSheet = []CheatCategory{
+	{Name: "Inspect", Commands: []CheatCommand{
+		{Command: "kubectl -n {{ .Namespace }} get pods -o wide", Description: "List pods with their node and IP"},
+		{Command: "kubectl -n {{ .Namespace }} get all", Description: "List the common resources"},
+		{Command: "kubectl -n {{ .Namespace }} describe pod <pod>", Description: "Show a pod's spec, state and events"},
+		{Command: "kubectl -n {{ .Namespace }} get pod -o yaml <pod>", Description: "Dump a pod's full manifest"},
+		{Command: "kubectl -n {{ .Namespace }} get events --sort-by=.lastTimestamp", Description: "Show recent events, newest last"},
+		{Command: "kubectl explain <field>", Description: "Document a field, e.g. pod.spec.containers"},
+	}},
+	{Name: "Debug", Commands: []CheatCommand{
+		{Command: "kubectl -n {{ .Namespace }} logs <pod>", Description: "Print a pod's logs"},
+		{Command: "kubectl -n {{ .Namespace }} logs --previous <pod>", Description: "Logs of the last crashed container"},
+		{Command: "kubectl -n {{ .Namespace }} logs -f deployment/<deployment>", Description: "Follow a deployment's logs"},
+		{Command: "kubectl -n {{ .Namespace }} exec -it <pod> -- sh", Description: "Open a shell in a container"},
+		{Command: "kubectl -n {{ .Namespace }} run debug --rm -it --image=busybox --restart=Never -- sh", Description: "Start a throwaway debug pod"},
+		{Command: "kubectl -n {{ .Namespace }} top pod", Description: "Show pod CPU and memory usage"},
+	}},
+	{Name: "Fix", Commands: []CheatCommand{
+		{Command: "kubectl -n {{ .Namespace }} edit deployment/<deployment>", Description: "Edit a resource in your editor"},
+		{Command: "kubectl -n {{ .Namespace }} apply -f <file>", Description: "Create or update from a manifest"},
+		{Command: "kubectl -n {{ .Namespace }} delete pod <pod>", Description: "Delete a pod; its controller recreates it"},
+		{Command: "kubectl -n {{ .Namespace }} label pod <pod> <key>=<value>", Description: "Add or change a label"},
+		{Command: "kubectl -n {{ .Namespace }} set image deployment/<deployment> <container>=<image>", Description: "Change a container's image"},
+	}},
+	{Name: "Rollouts", Commands: []CheatCommand{
+		{Command: "kubectl -n {{ .Namespace }} rollout status deployment/<deployment>", Description: "Wait for a rollout to finish"},
+		{Command: "kubectl -n {{ .Namespace }} rollout history deployment/<deployment>", Description: "List a deployment's revisions"},
+		{Command: "kubectl -n {{ .Namespace }} rollout undo deployment/<deployment>", Description: "Roll back to the previous revision"},
+		{Command: "kubectl -n {{ .Namespace }} rollout restart deployment/<deployment>", Description: "Recreate a deployment's pods"},
+		{Command: "kubectl -n {{ .Namespace }} scale --replicas=2 deployment/<deployment>", Description: "Change the replica count"},
+	}},
+	{Name: "Network", Commands: []CheatCommand{
+		{Command: "kubectl -n {{ .Namespace }} get svc,endpoints", Description: "List services and the pods behind them"},
+		{Command: "kubectl -n {{ .Namespace }} describe svc <service>", Description: "Show a service's selector and ports"},
+		{Command: "kubectl -n {{ .Namespace }} get networkpolicy", Description: "List network policies"},
+		{Command: "kubectl -n {{ .Namespace }} port-forward svc/<service> 8080:80", Description: "Reach a service on localhost:8080"},
+		{Command: "kubectl -n {{ .Namespace }} run curl --rm -it --image=busybox --restart=Never -- wget -qO- <url>", Description: "Fetch a URL from inside the cluster"},
+	}},
+	{Name: "Cluster", Commands: []CheatCommand{
+		{Command: "kubectl get nodes -o wide", Description: "List nodes with their roles and IPs"},
+		{Command: "kubectl describe node <node>", Description: "Show a node's taints, labels and capacity"},
+		{Command: "kubectl -n {{ .Namespace }} auth can-i --list", Description: "List what you are allowed to do"},
+		{Command: "kubectl api-resources", Description: "List resource types and short names"},
+		{Command: "kubectl config get-contexts", Description: "List kubeconfig contexts"},
+	}},
+}
+
+// trailingPlaceholder matches a <placeholder> at the end of a command.
+var trailingPlaceholder = regexp.MustCompile(`<[^<>\s]+>$`)
+
+// CheatSheetModel shows the cheat sheet one category at a time and picks a
+// command to type into the terminal.
+type CheatSheetModel struct {
+	categories []CheatCategory
+	category   int
+	selected   int
+	values     scenario.HintValues
+	width      int
+	height     int
+	styles     CheatSheetStyles
+}
+
+// CheatSheetStyles contains styles for the cheat sheet.
+type CheatSheetStyles struct {
+	Box         lipgloss.Style
+	Tab         lipgloss.Style
+	TabActive   lipgloss.Style
+	Command     lipgloss.Style
+	Selected    lipgloss.Style
+	Description lipgloss.Style
+	Muted       lipgloss.Style
+}
+
+// NewCheatSheetStyles creates adaptive cheat sheet styles.
+func NewCheatSheetStyles() CheatSheetStyles {
+	primary := lipgloss.AdaptiveColor{Light: "#8839ef", Dark: "#cba6f7"}
+	secondary := lipgloss.AdaptiveColor{Light: "#209fb5", Dark: "#74c7ec"}
+	text := lipgloss.AdaptiveColor{Light: "#4c4f69", Dark: "#cdd6f4"}
+	textMuted := lipgloss.AdaptiveColor{Light: "#8c8fa1", Dark: "#6c7086"}
+
+	return CheatSheetStyles{
+		Box: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(secondary).
+			Padding(0, 1),
+		Tab: lipgloss.NewStyle().
+			Foreground(textMuted).
+			Padding(0, 1),
+		TabActive: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(primary).
+			Underline(true).
+			Padding(0, 1),
+		Command: lipgloss.NewStyle().
+			Foreground(text),
+		Selected: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(primary),
+		Description: lipgloss.NewStyle().
+			Foreground(textMuted).
+			Italic(true),
+		Muted: lipgloss.NewStyle().
+			Foreground(textMuted),
+	}
+}
+
+// NewCheatSheetModel creates a cheat sheet of the given categories.
+func NewCheatSheetModel(categories []CheatCategory) CheatSheetModel {
+	return CheatSheetModel{
+		categories: categories,
+		styles:     NewCheatSheetStyles(),
+	}
+}
+
+// SetSize sets the cheat sheet's outer size; it is drawn over the terminal.
+func (m *CheatSheetModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetHintValues sets what command templates such as {{ .Namespace }} render to.
+func (m *CheatSheetModel) SetHintValues(values scenario.HintValues) {
+	m.values = values
+}
+
+// Update handles a key press and returns the chosen command as it should be
+// typed, or "" if none was. A trailing placeholder is left off, so the
+// learner types its value where the cursor ends up.
+func (m CheatSheetModel) Update(msg tea.Msg) (CheatSheetModel, string) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(m.categories) == 0 {
+		return m, ""
+	}
+	commands := m.categories[m.category].Commands
+	switch {
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("left", "h", "shift+tab"))):
+		m.category = (m.category - 1 + len(m.categories)) % len(m.categories)
+		m.selected = 0
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("right", "l", "tab"))):
+		m.category = (m.category + 1) % len(m.categories)
+		m.selected = 0
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("up", "k"))):
+		if m.selected > 0 {
+			m.selected--
+		}
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("down", "j"))):
+		if m.selected < len(commands)-1 {
+			m.selected++
+		}
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("enter"))):
+		if m.selected < len(commands) {
+			return m, trailingPlaceholder.ReplaceAllString(m.render(commands[m.selected]), "")
+		}
+	}
+	return m, ""
+}
+
+func (m CheatSheetModel) render(c CheatCommand) string {
+	return scenario.RenderHint(c.Command, m.values)
+}
+
+// View renders the cheat sheet box.
+func (m CheatSheetModel) View() string {
+	var tabs []string
+	for i, c := range m.categories {
+		if i == m.category {
+			tabs = append(tabs, m.styles.TabActive.Render(c.Name))
+		} else {
+			tabs = append(tabs, m.styles.Tab.Render(c.Name))
+		}
+	}
+
+	lines := []string{lipgloss.JoinHorizontal(lipgloss.Top, tabs...), ""}
+	if len(m.categories) > 0 {
+		commands := m.categories[m.category].Commands
+		// Two lines per command, below the tabs and above the help line
+		visible := max((m.height-6)/2, 1)
+		start := max(m.selected-visible+1, 0)
+		// Cut long commands rather than wrap them, which would break the count
+		line := lipgloss.NewStyle().MaxWidth(max(m.width-4, 1))
+		for i := start; i < len(commands) && i < start+visible; i++ {
+			c := commands[i]
+			cursor, style := "  ", m.styles.Command
+			if i == m.selected {
+				cursor, style = "› ", m.styles.Selected
+			}
+			lines = append(lines,
+				line.Render(cursor+style.Render(m.render(c))),
+				line.Render("    "+m.styles.Description.Render(c.Description)))
+		}
+	}
+	lines = append(lines, "", m.styles.Muted.Render("enter: type into terminal • esc: close"))
+
+	return m.styles.Box.
+		Width(max(m.width-2, 1)).
+		Height(max(m.height-2, 1)).
+		Render(strings.Join(lines, "\n"))
+}
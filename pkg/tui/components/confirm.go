@@ -0,0 +1,147 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// NoChoice is returned by ConfirmDialog.Update while no button is chosen.
+const NoChoice = -1
+
+// ConfirmButton is one choice of a confirm dialog.
+type ConfirmButton struct {
+	Label string // e.g. "Yes"
+	Key   string // Shortcut that chooses it directly, e.g. "y"; optional
+}
+
+// ConfirmDialog asks the user to choose between a few buttons, e.g. before
+// a destructive action. The cancel button is selected to start with and is
+// chosen by esc and q.
+type ConfirmDialog struct {
+	title    string
+	message  string
+	buttons  []ConfirmButton
+	cancel   int
+	selected int
+	danger   bool
+	width    int
+	styles   ConfirmStyles
+}
+
+// ConfirmStyles contains styles for confirm dialogs.
+type ConfirmStyles struct {
+	Box          lipgloss.Style
+	WarnBorder   lipgloss.Color
+	DangerBorder lipgloss.AdaptiveColor
+	Title        lipgloss.Style
+	Text         lipgloss.Style
+	Button       lipgloss.Style
+	ButtonActive lipgloss.Style
+}
+
+// NewConfirmStyles creates adaptive confirm dialog styles.
+func NewConfirmStyles() ConfirmStyles {
+	primary := lipgloss.AdaptiveColor{Light: "#8839ef", Dark: "#cba6f7"}
+	text := lipgloss.AdaptiveColor{Light: "#4c4f69", Dark: "#cdd6f4"}
+	textMuted := lipgloss.AdaptiveColor{Light: "#8c8fa1", Dark: "#6c7086"}
+
+	return ConfirmStyles{
+		Box: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(1, 2).
+			Align(lipgloss.Center),
+		WarnBorder:   lipgloss.Color("#fab387"), // Peach
+		DangerBorder: lipgloss.AdaptiveColor{Light: "#d20f39", Dark: "#f38ba8"},
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(primary).
+			MarginBottom(1),
+		Text: lipgloss.NewStyle().
+			Foreground(text),
+		Button: lipgloss.NewStyle().
+			Foreground(textMuted),
+		ButtonActive: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(primary),
+	}
+}
+
+// NewConfirmDialog creates a dialog with the given buttons; cancel is the
+// index of the one that backs out.
+func NewConfirmDialog(title, message string, buttons []ConfirmButton, cancel int) ConfirmDialog {
+	return ConfirmDialog{
+		title:    title,
+		message:  message,
+		buttons:  buttons,
+		cancel:   cancel,
+		selected: cancel,
+		width:    50,
+		styles:   NewConfirmStyles(),
+	}
+}
+
+// SetDanger styles the dialog for an action that can't be undone.
+func (d *ConfirmDialog) SetDanger(danger bool) {
+	d.danger = danger
+}
+
+// SetWidth sets the dialog's width.
+func (d *ConfirmDialog) SetWidth(width int) {
+	d.width = width
+}
+
+// Update handles a key press and returns the index of the chosen button, or
+// NoChoice if none was.
+func (d ConfirmDialog) Update(msg tea.Msg) (ConfirmDialog, int) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(d.buttons) == 0 {
+		return d, NoChoice
+	}
+	switch {
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("left", "h", "up", "shift+tab"))):
+		d.selected = (d.selected - 1 + len(d.buttons)) % len(d.buttons)
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("right", "l", "down", "tab"))):
+		d.selected = (d.selected + 1) % len(d.buttons)
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("enter"))):
+		return d, d.selected
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("esc", "q", "ctrl+c"))):
+		return d, d.cancel
+	default:
+		for i, b := range d.buttons {
+			if b.Key != "" && keyMsg.String() == b.Key {
+				return d, i
+			}
+		}
+	}
+	return d, NoChoice
+}
+
+// View renders the dialog box.
+func (d ConfirmDialog) View() string {
+	var buttons []string
+	for i, b := range d.buttons {
+		label := b.Label
+		if b.Key != "" {
+			label = fmt.Sprintf("%s (%s)", label, b.Key)
+		}
+		label = "[ " + label + " ]"
+		if i == d.selected {
+			buttons = append(buttons, d.styles.ButtonActive.Render(label))
+		} else {
+			buttons = append(buttons, d.styles.Button.Render(label))
+		}
+	}
+
+	box := d.styles.Box.Width(d.width).BorderForeground(d.styles.WarnBorder)
+	if d.danger {
+		box = box.BorderForeground(d.styles.DangerBorder)
+	}
+	content := d.styles.Title.Render(d.title) + "\n" +
+		d.styles.Text.Render("\n"+d.message+"\n") + "\n" +
+		strings.Join(buttons, "  ")
+	return box.Render(content)
+}
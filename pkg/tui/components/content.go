@@ -8,6 +8,8 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"k8s-dojo/pkg/scenario"
 )
 
 // ContentModel represents the main content panel.
@@ -17,10 +19,22 @@ type ContentModel struct {
 	namespace   string
 	status      string
 	statusOK    bool
-	commands    []string
+	checks      []CheckLine
+	commands    []scenario.Command
 	hints       []string
 	currentHint int
 	showHints   bool
+	guide       []GuideCard
+	guideStep   int
+	noteLabel   string
+	note        string
+
+	// hintValues fill in hint templates when they are shown
+	hintValues scenario.HintValues
+
+	// docs are opened in turn by NextDoc; nextDoc is the one opened next
+	docs    []scenario.DocLink
+	nextDoc int
 
 	viewport viewport.Model
 	width    int
@@ -29,6 +43,24 @@ type ContentModel struct {
 	styles   ContentStyles
 }
 
+// GuideCard is one step of a guided walkthrough, shown in place of hints.
+type GuideCard struct {
+	Title       string
+	Instruction string
+	Command     string
+	Manual      bool // Advanced by the learner rather than verified
+}
+
+// CheckLine is one validation check, shown live under the status.
+type CheckLine struct {
+	Name    string
+	Passed  bool
+	Details string
+
+	Severity scenario.Severity // Colors a failure; "" means blocker
+	DocURL   string            // Linked under a failure
+}
+
 // ContentStyles contains styles for the content panel.
 type ContentStyles struct {
 	Container     lipgloss.Style
@@ -39,6 +71,8 @@ type ContentStyles struct {
 	Text          lipgloss.Style
 	StatusOK      lipgloss.Style
 	StatusError   lipgloss.Style
+	StatusWarn    lipgloss.Style
+	StatusInfo    lipgloss.Style
 	CommandBox    lipgloss.Style
 	Command       lipgloss.Style
 	HintBox       lipgloss.Style
@@ -93,6 +127,14 @@ func NewContentStyles() ContentStyles {
 			Bold(true).
 			Foreground(errorColor),
 
+		StatusWarn: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(warning),
+
+		StatusInfo: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(secondary),
+
 		CommandBox: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(secondary).
@@ -119,9 +161,13 @@ func NewContentStyles() ContentStyles {
 
 // NewContentModel creates a new content model.
 func NewContentModel() ContentModel {
+	vp := viewport.New(0, 0)
+	// h and l are hint keys, and the content is wrapped to fit anyway
+	vp.KeyMap.Left.SetEnabled(false)
+	vp.KeyMap.Right.SetEnabled(false)
 	return ContentModel{
 		styles:   NewContentStyles(),
-		viewport: viewport.New(0, 0),
+		viewport: vp,
 	}
 }
 
@@ -132,7 +178,17 @@ func (m *ContentModel) SetScenario(title, description, namespace string) {
 	m.namespace = namespace
 	m.status = ""
 	m.statusOK = false
+	m.checks = nil
 	m.currentHint = 0
+	m.guide = nil
+	m.guideStep = 0
+	m.note = ""
+	m.viewport.GotoTop()
+}
+
+// SetNamespace updates the namespace shown for the running scenario.
+func (m *ContentModel) SetNamespace(namespace string) {
+	m.namespace = namespace
 }
 
 // SetStatus sets the current status.
@@ -141,9 +197,49 @@ func (m *ContentModel) SetStatus(status string, ok bool) {
 	m.statusOK = ok
 }
 
-// SetCommands sets the quick commands.
-func (m *ContentModel) SetCommands(commands []string) {
-	m.commands = commands
+// SetChecks sets the checks of the latest validation.
+func (m *ContentModel) SetChecks(checks []CheckLine) {
+	m.checks = checks
+}
+
+// MaxQuickCommands is how many quick commands are shown, one per number key.
+const MaxQuickCommands = 9
+
+// SetCommands sets the quick commands, listed by group.
+func (m *ContentModel) SetCommands(commands []scenario.Command) {
+	m.commands = nil
+	for _, group := range scenario.CommandGroups {
+		for _, c := range commands {
+			if c.Group == group && len(m.commands) < MaxQuickCommands {
+				m.commands = append(m.commands, c)
+			}
+		}
+	}
+}
+
+// Command returns the i-th quick command as listed, 0-based, with its
+// template filled in.
+func (m ContentModel) Command(i int) (string, bool) {
+	if i < 0 || i >= len(m.commands) {
+		return "", false
+	}
+	return scenario.RenderHint(m.commands[i].Command, m.hintValues), true
+}
+
+// SetDocs sets the documentation links.
+func (m *ContentModel) SetDocs(docs []scenario.DocLink) {
+	m.docs = docs
+	m.nextDoc = 0
+}
+
+// NextDoc returns the documentation link to open next, cycling through them.
+func (m *ContentModel) NextDoc() (scenario.DocLink, bool) {
+	if len(m.docs) == 0 {
+		return scenario.DocLink{}, false
+	}
+	doc := m.docs[m.nextDoc%len(m.docs)]
+	m.nextDoc = (m.nextDoc + 1) % len(m.docs)
+	return doc, true
 }
 
 // SetHints sets the hints.
@@ -152,11 +248,49 @@ func (m *ContentModel) SetHints(hints []string) {
 	m.currentHint = 0
 }
 
+// SetHintValues sets what hint templates such as {{ .Namespace }} render to.
+func (m *ContentModel) SetHintValues(values scenario.HintValues) {
+	m.hintValues = values
+}
+
+// SetGuide switches the hint box to guided steps, starting at the first.
+func (m *ContentModel) SetGuide(cards []GuideCard) {
+	m.guide = cards
+	m.guideStep = 0
+}
+
+// SetGuideStep sets the active guided step; len(cards) means all are done.
+func (m *ContentModel) SetGuideStep(step int) {
+	m.guideStep = step
+}
+
+// Guided reports whether guided steps are shown.
+func (m ContentModel) Guided() bool {
+	return len(m.guide) > 0
+}
+
+// SetNote shows a labelled box above the hints, e.g. an assistant nudge
+// or an error explanation. An empty note hides it.
+func (m *ContentModel) SetNote(label, note string) {
+	m.noteLabel = label
+	m.note = note
+}
+
 // ToggleHints toggles hint visibility.
 func (m *ContentModel) ToggleHints() {
 	m.showHints = !m.showHints
 }
 
+// HintsVisible reports whether the hint box is shown.
+func (m ContentModel) HintsVisible() bool {
+	return m.showHints && len(m.hints) > 0
+}
+
+// CurrentHint returns the index of the hint being shown.
+func (m ContentModel) CurrentHint() int {
+	return m.currentHint
+}
+
 // NextHint cycles to the next hint.
 func (m *ContentModel) NextHint() {
 	if len(m.hints) > 0 {
@@ -175,8 +309,9 @@ func (m *ContentModel) PrevHint() {
 func (m *ContentModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	m.viewport.Width = width - 6
-	m.viewport.Height = height - 10
+	// Inside the border and padding, less a row for the scroll indicator
+	m.viewport.Width = max(0, width-6)
+	m.viewport.Height = max(0, height-5)
 }
 
 // SetFocus sets the focus state.
@@ -189,15 +324,77 @@ func (m ContentModel) IsFocused() bool {
 	return m.focused
 }
 
-// Update handles input.
+// Update handles input, scrolling the panel when it is focused.
 func (m ContentModel) Update(msg tea.Msg) (ContentModel, tea.Cmd) {
 	var cmd tea.Cmd
+	m.viewport.SetContent(m.body()) // Scroll bounds follow what was set since the last frame
 	m.viewport, cmd = m.viewport.Update(msg)
 	return m, cmd
 }
 
 // View renders the content panel.
+// guideView renders the active guided step.
+func (m ContentModel) guideView() string {
+	width := m.width - 10
+	if m.guideStep >= len(m.guide) {
+		label := m.styles.HintLabel.Render("🧭 Guide complete")
+		return m.styles.HintBox.Width(width).Render(label + "\n" + m.styles.Text.Render("All steps done. Press c to check your fix."))
+	}
+
+	card := m.guide[m.guideStep]
+	var b strings.Builder
+	b.WriteString(m.styles.HintLabel.Render(fmt.Sprintf("🧭 Step %d/%d: %s", m.guideStep+1, len(m.guide), card.Title)))
+	b.WriteString("\n" + m.styles.Text.Render(card.Instruction))
+	if card.Command != "" {
+		b.WriteString("\n\n" + m.styles.Command.Render("$ "+card.Command))
+	}
+	if card.Manual {
+		b.WriteString("\n\n" + m.styles.Muted.Render("Press n when you are done."))
+	} else {
+		b.WriteString("\n\n" + m.styles.Muted.Render("Waiting for this step to be done…"))
+	}
+	return m.styles.HintBox.Width(width).Render(b.String())
+}
+
 func (m ContentModel) View() string {
+	m.viewport.SetContent(m.body())
+	view := m.viewport.View() + "\n" + m.scrollIndicator()
+
+	// Apply container style
+	container := m.styles.Container
+	if m.focused {
+		container = m.styles.FocusedBorder
+	}
+
+	return container.
+		Width(m.width - 2).
+		Height(m.height - 2).
+		Render(view)
+}
+
+// scrollIndicator tells where the panel is scrolled to, when its content
+// doesn't fit. It is empty otherwise.
+func (m ContentModel) scrollIndicator() string {
+	if m.viewport.AtTop() && m.viewport.AtBottom() {
+		return ""
+	}
+	var indicator string
+	switch {
+	case !m.focused:
+		indicator = "↓ more, tab to scroll"
+	case m.viewport.AtTop():
+		indicator = "↓ j/pgdn"
+	case m.viewport.AtBottom():
+		indicator = "↑ k/pgup"
+	default:
+		indicator = "↕ j/k"
+	}
+	indicator = fmt.Sprintf("%s · %3.0f%%", indicator, m.viewport.ScrollPercent()*100)
+	return lipgloss.PlaceHorizontal(m.viewport.Width, lipgloss.Right, m.styles.Muted.Render(indicator))
+}
+
+// body renders the panel's content, wrapped to its width, before scrolling.
+func (m ContentModel) body() string {
 	var b strings.Builder
 
 	// Title
@@ -242,43 +439,87 @@ func (m ContentModel) View() string {
 		b.WriteString("\n")
 	}
 
+	// Live checks, with what the failing ones are still waiting on
+	if len(m.checks) > 0 && !m.statusOK {
+		for _, c := range m.checks {
+			if c.Passed {
+				b.WriteString("  " + m.styles.StatusOK.Render("✓") + " " + m.styles.Muted.Render(c.Name))
+			} else {
+				line := c.Name
+				if c.Details != "" {
+					line += ": " + c.Details
+				}
+				b.WriteString("  " + m.failedCheckIndicator(c.Severity) + " " + m.styles.Text.Render(line))
+				if c.DocURL != "" {
+					b.WriteString("\n    " + m.styles.Muted.Render("📖 "+c.DocURL))
+				}
+			}
+			b.WriteString("\n")
+		}
+	}
+
 	// Commands box
 	if len(m.commands) > 0 {
 		cmdWidth := m.width - 10
 		var cmdLines []string
-		for _, cmd := range m.commands {
-			// Add consistent left padding for alignment
-			cmdLines = append(cmdLines, "  "+m.styles.Command.Render(cmd))
+		group := ""
+		for i := range m.commands {
+			if m.commands[i].Group != group {
+				group = m.commands[i].Group
+				cmdLines = append(cmdLines, m.styles.Muted.Render(group))
+			}
+			cmd, _ := m.Command(i)
+			// Numbered for the key that runs it; padded for alignment
+			cmdLines = append(cmdLines, "  "+m.styles.Muted.Render(fmt.Sprintf("%d", i+1))+" "+m.styles.Command.Render(cmd))
 		}
 		cmdContent := strings.Join(cmdLines, "\n")
 		cmdBox := m.styles.CommandBox.Width(cmdWidth).Render(
-			m.styles.Muted.Render("Quick Commands") + "\n" + cmdContent,
+			m.styles.Muted.Render("Quick Commands (press a number to run)") + "\n" + cmdContent,
 		)
 		b.WriteString(cmdBox)
 		b.WriteString("\n")
 	}
 
-	// Hints box
-	if m.showHints && len(m.hints) > 0 {
+	// Docs links, with their URLs for terminals that can't open a browser
+	if len(m.docs) > 0 {
+		b.WriteString(m.styles.Muted.Render("📖 Docs (press d to open)") + "\n")
+		for _, d := range m.docs {
+			b.WriteString("  " + m.styles.Text.Render(d.Title) + " " + m.styles.Muted.Render(d.URL) + "\n")
+		}
+	}
+
+	// Note box
+	if m.note != "" {
+		label := m.styles.HintLabel.Render(m.noteLabel)
+		b.WriteString(m.styles.HintBox.Width(m.width - 10).Render(label + "\n" + m.styles.Text.Render(m.note)))
+		b.WriteString("\n")
+	}
+
+	// Guide box replaces hints in guided mode
+	if m.Guided() {
+		b.WriteString(m.guideView())
+	} else if m.showHints && len(m.hints) > 0 {
 		hintWidth := m.width - 10
 		hintLabel := m.styles.HintLabel.Render(
 			fmt.Sprintf("💡 Hints (%d/%d)", m.currentHint+1, len(m.hints)),
 		)
-		hintContent := m.styles.Text.Render(m.hints[m.currentHint])
+		hintContent := m.styles.Text.Render(scenario.RenderHint(m.hints[m.currentHint], m.hintValues))
 		hintBox := m.styles.HintBox.Width(hintWidth).Render(
 			hintLabel + "\n" + hintContent,
 		)
 		b.WriteString(hintBox)
 	}
 
-	// Apply container style
-	container := m.styles.Container
-	if m.focused {
-		container = m.styles.FocusedBorder
-	}
+	return lipgloss.NewStyle().Width(m.viewport.Width).Render(b.String())
+}
 
-	return container.
-		Width(m.width - 2).
-		Height(m.height - 2).
-		Render(b.String())
+// failedCheckIndicator marks a failing check by how much it matters.
+func (m ContentModel) failedCheckIndicator(severity scenario.Severity) string {
+	switch severity {
+	case scenario.SeverityWarn:
+		return m.styles.StatusWarn.Render("!")
+	case scenario.SeverityInfo:
+		return m.styles.StatusInfo.Render("i")
+	}
+	return m.styles.StatusError.Render("✗")
 }
@@ -12,7 +12,10 @@ import (
 type HeaderModel struct {
 	title     string
 	version   string
+	banner    string
+	progress  string
 	startTime time.Time
+	pausedAt  time.Time // Set while the timer is paused
 	width     int
 	styles    HeaderStyles
 }
@@ -23,6 +26,8 @@ type HeaderStyles struct {
 	Title     lipgloss.Style
 	Version   lipgloss.Style
 	Timer     lipgloss.Style
+	Banner    lipgloss.Style
+	Progress  lipgloss.Style
 }
 
 // NewHeaderStyles creates adaptive header styles.
@@ -32,6 +37,7 @@ func NewHeaderStyles() HeaderStyles {
 	secondary := lipgloss.AdaptiveColor{Light: "#209fb5", Dark: "#74c7ec"}
 	accent := lipgloss.AdaptiveColor{Light: "#fe640b", Dark: "#fab387"}
 	textBold := lipgloss.AdaptiveColor{Light: "#eff1f5", Dark: "#1e1e2e"}
+	warning := lipgloss.AdaptiveColor{Light: "#d20f39", Dark: "#f38ba8"}
 
 	return HeaderStyles{
 		Container: lipgloss.NewStyle().
@@ -53,6 +59,13 @@ func NewHeaderStyles() HeaderStyles {
 		Timer: lipgloss.NewStyle().
 			Foreground(accent).
 			Bold(true),
+
+		Banner: lipgloss.NewStyle().
+			Foreground(warning).
+			Bold(true),
+
+		Progress: lipgloss.NewStyle().
+			Foreground(accent),
 	}
 }
 
@@ -74,6 +87,18 @@ func (m *HeaderModel) SetVersion(version string) {
 	m.version = version
 }
 
+// SetBanner shows a notice between the title and the version badge.
+// An empty string hides it.
+func (m *HeaderModel) SetBanner(banner string) {
+	m.banner = banner
+}
+
+// SetProgress shows training progress, such as the streak and weekly goal,
+// left of the version badge. An empty string hides it.
+func (m *HeaderModel) SetProgress(progress string) {
+	m.progress = progress
+}
+
 // SetWidth sets the header width.
 func (m *HeaderModel) SetWidth(width int) {
 	m.width = width
@@ -84,9 +109,36 @@ func (m *HeaderModel) StartTimer() {
 	m.startTime = time.Now()
 }
 
+// StartTimerAt starts the elapsed time timer as of t, e.g. when a co-op partner started.
+func (m *HeaderModel) StartTimerAt(t time.Time) {
+	m.startTime = t
+	m.pausedAt = time.Time{}
+}
+
 // ResetTimer resets the timer.
 func (m *HeaderModel) ResetTimer() {
 	m.startTime = time.Time{}
+	m.pausedAt = time.Time{}
+}
+
+// PauseTimer freezes the timer as of at.
+func (m *HeaderModel) PauseTimer(at time.Time) {
+	if m.startTime.IsZero() || !m.pausedAt.IsZero() {
+		return
+	}
+	if at.Before(m.startTime) {
+		at = m.startTime
+	}
+	m.pausedAt = at
+}
+
+// ResumeTimer restarts a paused timer, leaving out the time it was paused.
+func (m *HeaderModel) ResumeTimer() {
+	if m.pausedAt.IsZero() {
+		return
+	}
+	m.startTime = m.startTime.Add(time.Since(m.pausedAt))
+	m.pausedAt = time.Time{}
 }
 
 // ElapsedTime returns the elapsed time since timer started.
@@ -94,6 +146,9 @@ func (m HeaderModel) ElapsedTime() time.Duration {
 	if m.startTime.IsZero() {
 		return 0
 	}
+	if !m.pausedAt.IsZero() {
+		return m.pausedAt.Sub(m.startTime)
+	}
 	return time.Since(m.startTime)
 }
 
@@ -104,12 +159,19 @@ func (m HeaderModel) View() string {
 
 	// Right: Version badge + Timer
 	var right string
+	if m.progress != "" {
+		right = m.styles.Progress.Render(m.progress) + "  "
+	}
 	if m.version != "" {
-		right = m.styles.Version.Render(m.version)
+		right += m.styles.Version.Render(m.version)
 	}
 	if !m.startTime.IsZero() {
 		elapsed := m.ElapsedTime().Round(time.Second)
-		timer := m.styles.Timer.Render(fmt.Sprintf("⏱ %s", elapsed))
+		icon := "⏱"
+		if !m.pausedAt.IsZero() {
+			icon = "⏸"
+		}
+		timer := m.styles.Timer.Render(fmt.Sprintf("%s %s", icon, elapsed))
 		if right != "" {
 			right = right + "  " + timer
 		} else {
@@ -126,6 +188,9 @@ func (m HeaderModel) View() string {
 		spacerWidth = 1
 	}
 	spacer := lipgloss.NewStyle().Width(spacerWidth).Render("")
+	if m.banner != "" {
+		spacer = lipgloss.PlaceHorizontal(spacerWidth, lipgloss.Center, m.styles.Banner.MaxWidth(spacerWidth).Render(m.banner))
+	}
 
 	content := lipgloss.JoinHorizontal(lipgloss.Top, left, spacer, right)
 
@@ -0,0 +1,102 @@
+package components
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// shellStopTimeout bounds how long the shell and its children get to exit on
+// SIGHUP and SIGTERM before they are killed.
+const shellStopTimeout = 2 * time.Second
+
+// terminateSession stops a shell started by pty.Start and everything it ran.
+// The shell leads its own session, and job control puts each of its jobs
+// (kubectl port-forward, watch) in a process group of its own, so signalling
+// the shell's group alone would leak them. exited is closed once the shell
+// has been reaped. It returns the PIDs still alive in the session afterwards.
+func terminateSession(cmd *exec.Cmd, exited <-chan struct{}) []int {
+	sid := cmd.Process.Pid
+
+	// Hang up like a closing terminal window, then ask politely
+	for _, sig := range []syscall.Signal{syscall.SIGHUP, syscall.SIGTERM} {
+		signalSession(sid, sig)
+	}
+	deadline := time.Now().Add(shellStopTimeout)
+	select {
+	case <-exited:
+	case <-time.After(shellStopTimeout):
+	}
+	for time.Now().Before(deadline) && len(sessionProcesses(sid)) > 0 {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if len(sessionProcesses(sid)) > 0 {
+		signalSession(sid, syscall.SIGKILL)
+		for i := 0; i < 10 && len(sessionProcesses(sid)) > 0; i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+	select {
+	case <-exited:
+	case <-time.After(100 * time.Millisecond):
+	}
+	return sessionProcesses(sid)
+}
+
+// signalSession sends sig to the shell's process group and to every other
+// process in its session.
+func signalSession(sid int, sig syscall.Signal) {
+	_ = syscall.Kill(-sid, sig)
+	for _, pid := range sessionProcesses(sid) {
+		_ = syscall.Kill(pid, sig)
+	}
+}
+
+// sessionProcesses returns the live processes of session sid other than
+// zombies, read from /proc. Without /proc (macOS) it returns nil, leaving the
+// process-group signal to do the work.
+func sessionProcesses(sid int) []int {
+	stats, _ := filepath.Glob("/proc/[0-9]*/stat")
+	var pids []int
+	for _, path := range stats {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue // Exited meanwhile
+		}
+		pid, session, state, ok := parseProcStat(string(data))
+		if ok && session == sid && state != "Z" {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+// parseProcStat extracts the PID, session ID and state from a /proc/<pid>/stat line.
+// The command name is parenthesised and may contain spaces, so fields are
+// counted from its closing parenthesis.
+func parseProcStat(stat string) (pid, session int, state string, ok bool) {
+	open := strings.IndexByte(stat, '(')
+	end := strings.LastIndexByte(stat, ')')
+	if open < 0 || end < open {
+		return 0, 0, "", false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(stat[:open]))
+	if err != nil {
+		return 0, 0, "", false
+	}
+	// After the name: state ppid pgrp session ...
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 4 {
+		return 0, 0, "", false
+	}
+	session, err = strconv.Atoi(fields[3])
+	if err != nil {
+		return 0, 0, "", false
+	}
+	return pid, session, fields[0], true
+}
@@ -0,0 +1,96 @@
+package components
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/creack/pty"
+)
+
+func TestParseProcStat(t *testing.T) {
+	tests := []struct {
+		stat    string
+		pid     int
+		session int
+		state   string
+		ok      bool
+	}{
+		{"4242 (kubectl) S 4200 4242 4200 34816 4242 4194560", 4242, 4200, "S", true},
+		{"77 (tmux: server) Z 1 77 77 0 -1 4194624", 77, 77, "Z", true},
+		{"99 (a) b (c)) R 1 99 12 0 -1", 99, 12, "R", true}, // Name with parentheses
+		{"12 (sh) S 1", 0, 0, "", false},
+		{"garbage", 0, 0, "", false},
+	}
+	for _, tt := range tests {
+		pid, session, state, ok := parseProcStat(tt.stat)
+		if pid != tt.pid || session != tt.session || state != tt.state || ok != tt.ok {
+			t.Errorf("parseProcStat(%q) = %d, %d, %q, %t; want %d, %d, %q, %t",
+				tt.stat, pid, session, state, ok, tt.pid, tt.session, tt.state, tt.ok)
+		}
+	}
+}
+
+// startSession starts an interactive shell on a PTY, like the terminal does,
+// has it run jobs, and reaps the shell once it exits.
+func startSession(t *testing.T, jobs string) (*exec.Cmd, <-chan struct{}) {
+	t.Helper()
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("Session processes are listed from /proc")
+	}
+	cmd := exec.Command("sh", "-i")
+	ptyFile, err := pty.Start(cmd)
+	if err != nil {
+		t.Fatalf("Failed to start shell: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		ptyFile.Close()
+	})
+	go func() { _, _ = io.Copy(io.Discard, ptyFile) }()
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+	if _, err := ptyFile.WriteString(jobs + "\n"); err != nil {
+		t.Fatalf("Failed to type jobs: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(sessionProcesses(cmd.Process.Pid)) < 3 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if n := len(sessionProcesses(cmd.Process.Pid)); n < 3 {
+		t.Fatalf("Expected the shell and its 2 jobs in the session, found %d processes", n)
+	}
+	return cmd, exited
+}
+
+func TestTerminateSessionLeavesNoOrphans(t *testing.T) {
+	tests := []struct {
+		name string
+		jobs string
+	}{
+		// Job control puts each job, like kubectl port-forward &, in a group of its own
+		{"jobs", "sleep 60 & sleep 60 &"},
+		// Jobs that ignore the polite signals and need SIGKILL
+		{"stubborn", `trap "" HUP TERM; sleep 60 & sleep 60 &`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, exited := startSession(t, tt.jobs)
+			if left := terminateSession(cmd, exited); len(left) > 0 {
+				t.Errorf("Processes left in the session: %v", left)
+			}
+			select {
+			case <-exited:
+			default:
+				t.Error("Expected the shell to be reaped")
+			}
+		})
+	}
+}
@@ -19,12 +19,19 @@ type SidebarItem struct {
 	Category    string
 	Completed   bool
 	Children    []SidebarItem
+
+	// StaleVersion is set when the scenario was completed against an older content version.
+	StaleVersion int
+
+	// IsNew flags a scenario added by a recent upgrade.
+	IsNew bool
 }
 
 // SidebarModel represents the left sidebar with collapsible categories.
 type SidebarModel struct {
 	items          []SidebarItem
 	cursor         int
+	offset         int // First visible row of the item list
 	expanded       map[string]bool
 	width          int
 	height         int
@@ -107,6 +114,7 @@ func NewSidebarModel() SidebarModel {
 func (m *SidebarModel) SetItems(items []SidebarItem) {
 	m.items = items
 	m.cursor = 0
+	m.offset = 0
 
 	// Auto-expand all categories initially
 	for _, item := range items {
@@ -196,62 +204,127 @@ func (m SidebarModel) Update(msg tea.Msg) (SidebarModel, tea.Cmd) {
 			if item := m.SelectedItem(); item != nil && item.IsCategory {
 				m.expanded[item.ID] = true
 			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("pgdown", "ctrl+d"))):
+			m.cursor = min(m.cursor+m.listRows(), len(flat)-1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("pgup", "ctrl+u"))):
+			m.cursor = max(m.cursor-m.listRows(), 0)
 		case key.Matches(msg, key.NewBinding(key.WithKeys("g"))):
 			m.cursor = 0
 		case key.Matches(msg, key.NewBinding(key.WithKeys("G"))):
 			m.cursor = len(flat) - 1
 		}
+		m.scrollToCursor()
 	}
 
 	return m, nil
 }
 
+// sidebarChromeRows are the rows around the item list: the title, the
+// progress summary with its trailing newline and the container's border and
+// padding.
+const sidebarChromeRows = 11
+
+// listRows returns how many rows of items fit.
+func (m SidebarModel) listRows() int {
+	return max(1, m.height-sidebarChromeRows)
+}
+
+// scrollToCursor moves the window over the item list as little as possible
+// to keep the cursor in view.
+func (m *SidebarModel) scrollToCursor() {
+	flat := m.flattenItems()
+	m.cursor = max(0, min(m.cursor, len(flat)-1))
+	rows := m.listRows()
+	if len(flat) <= rows {
+		m.offset = 0
+		return
+	}
+	m.offset = max(0, min(m.offset, len(flat)-rows))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	for m.cursor >= m.offset+m.capacity(flat, m.offset) {
+		m.offset++
+	}
+}
+
+// capacity returns how many items fit from offset, one fewer when the
+// category of the first shows as a sticky header above it.
+func (m SidebarModel) capacity(flat []*SidebarItem, offset int) int {
+	if m.stickyHeader(flat, offset) != nil {
+		return max(1, m.listRows()-1)
+	}
+	return m.listRows()
+}
+
+// stickyHeader returns the category of a scenario scrolled to the top of the
+// list, whose own header has scrolled out of view.
+func (m SidebarModel) stickyHeader(flat []*SidebarItem, offset int) *SidebarItem {
+	if offset <= 0 || offset >= len(flat) || flat[offset].IsCategory {
+		return nil
+	}
+	for i := range m.items {
+		if m.items[i].IsCategory && m.items[i].ID == flat[offset].Category {
+			return &m.items[i]
+		}
+	}
+	return nil
+}
+
 // View renders the sidebar.
 func (m SidebarModel) View() string {
 	var b strings.Builder
 
-	// Title
+	m.scrollToCursor() // The height may have changed since the last key press
+	flat := m.flattenItems()
+	start := m.offset
+	end := min(len(flat), start+m.capacity(flat, start))
+
+	// Title, with the rows in view once the list scrolls
 	title := m.styles.Category.Render("▼ Modules")
+	if start > 0 || end < len(flat) {
+		title += m.styles.Muted.Render(fmt.Sprintf(" %d-%d/%d", start+1, end, len(flat)))
+	}
 	b.WriteString(title + "\n")
 
-	flat := m.flattenItems()
+	if header := m.stickyHeader(flat, start); header != nil {
+		b.WriteString(m.categoryLine(header, false) + "\n")
+	}
 
-	// Render items
-	for i, item := range flat {
+	// Render the items in view
+	for i := start; i < end; i++ {
+		item := flat[i]
 		isActive := i == m.cursor
 
 		var line string
 		if item.IsCategory {
-			// Category header
-			icon := categoryIcon(item.Title)
-			arrow := "├─"
-			if m.expanded[item.ID] {
-				arrow = "▼"
-			} else {
-				arrow = "▶"
-			}
-
-			label := fmt.Sprintf("%s %s %s", arrow, icon, item.Title)
-			if isActive {
-				line = m.styles.CategoryActive.Render(label)
-			} else {
-				line = m.styles.Category.Render(label)
-			}
+			line = m.categoryLine(item, isActive)
 		} else {
 			// Scenario item
 			var status string
-			if item.Completed {
+			switch {
+			case item.StaleVersion > 0:
+				status = "◐"
+			case item.Completed:
 				status = "●"
-			} else {
+			default:
 				status = "○"
 			}
 
 			// Truncate title if needed
 			titleWidth := m.width - 10
 			title := item.Title
+			suffix := ""
+			if item.StaleVersion > 0 {
+				suffix = fmt.Sprintf(" (v%d)", item.StaleVersion)
+			} else if item.IsNew {
+				suffix = " NEW"
+			}
+			titleWidth -= len(suffix)
 			if len(title) > titleWidth && titleWidth > 3 {
 				title = title[:titleWidth-2] + ".."
 			}
+			title += suffix
 
 			label := fmt.Sprintf("  │ %s %s", status, title)
 
@@ -266,7 +339,6 @@ func (m SidebarModel) View() string {
 
 		b.WriteString(line + "\n")
 	}
-
 	// Progress summary
 	b.WriteString("\n")
 	b.WriteString(m.styles.Muted.Render("──────────────────") + "\n")
@@ -299,6 +371,32 @@ func (m SidebarModel) View() string {
 		Render(b.String())
 }
 
+// categoryLine renders a category header. A collapsed one counts its
+// completed scenarios, e.g. "▶ 🌐 Networking 3/12".
+func (m SidebarModel) categoryLine(item *SidebarItem, isActive bool) string {
+	arrow := "▼"
+	if !m.expanded[item.ID] {
+		arrow = "▶"
+	}
+	label := fmt.Sprintf("%s %s %s", arrow, categoryIcon(item.Title), item.Title)
+
+	var summary string
+	if !m.expanded[item.ID] {
+		completed := 0
+		for _, child := range item.Children {
+			if child.Completed {
+				completed++
+			}
+		}
+		summary = " " + m.styles.Muted.Render(fmt.Sprintf("%d/%d", completed, len(item.Children)))
+	}
+
+	if isActive {
+		return m.styles.CategoryActive.Render(label) + summary
+	}
+	return m.styles.Category.Render(label) + summary
+}
+
 func categoryIcon(category string) string {
 	icons := map[string]string{
 		"Networking": "🌐",
@@ -307,11 +405,15 @@ func categoryIcon(category string) string {
 		"Security":   "🔒",
 		"Storage":    "💾",
 		"Ops":        "⚙️",
+		"Operators":  "🧩",
 		"Resources":  "📊",
 		"Kernel":     "🐧",
 	}
 	if icon, ok := icons[category]; ok {
 		return icon
 	}
+	if strings.HasPrefix(category, "Pack: ") {
+		return "📦"
+	}
 	return "📁"
 }
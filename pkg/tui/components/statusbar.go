@@ -10,9 +10,18 @@ import (
 
 // StatusBarModel represents the bottom status bar with keybindings.
 type StatusBarModel struct {
-	keys   []key.Binding
-	width  int
-	styles StatusBarStyles
+	keys    []key.Binding
+	message string
+	width   int
+	styles  StatusBarStyles
+
+	// Right-aligned resource meter, highlighted when warn is set
+	meter     string
+	meterWarn bool
+
+	// Panels Tab cycles through, with the focused one highlighted
+	panels      []string
+	activePanel int
 }
 
 // StatusBarStyles contains styles for the status bar.
@@ -20,6 +29,7 @@ type StatusBarStyles struct {
 	Container lipgloss.Style
 	Key       lipgloss.Style
 	Separator lipgloss.Style
+	Message   lipgloss.Style
 }
 
 // NewStatusBarStyles creates adaptive status bar styles.
@@ -42,6 +52,10 @@ func NewStatusBarStyles() StatusBarStyles {
 
 		Separator: lipgloss.NewStyle().
 			Foreground(border),
+
+		Message: lipgloss.NewStyle().
+			Foreground(accent).
+			Italic(true),
 	}
 }
 
@@ -57,6 +71,26 @@ func (m *StatusBarModel) SetKeys(keys []key.Binding) {
 	m.keys = keys
 }
 
+// SetMessage shows a background activity message before the keybindings.
+// An empty message clears it.
+func (m *StatusBarModel) SetMessage(message string) {
+	m.message = message
+}
+
+// SetMeter shows a short resource reading at the right of the bar, in the
+// message style when warn is set. An empty meter clears it.
+func (m *StatusBarModel) SetMeter(meter string, warn bool) {
+	m.meter = meter
+	m.meterWarn = warn
+}
+
+// SetFocusLegend lists the panels focus cycles through, highlighting the one
+// at active. No panels hides the legend.
+func (m *StatusBarModel) SetFocusLegend(panels []string, active int) {
+	m.panels = panels
+	m.activePanel = active
+}
+
 // SetWidth sets the status bar width.
 func (m *StatusBarModel) SetWidth(width int) {
 	m.width = width
@@ -78,12 +112,41 @@ func (m StatusBarModel) View() string {
 
 	sep := m.styles.Separator.Render("  ")
 	content := strings.Join(parts, sep)
+	if m.message != "" {
+		content = m.styles.Message.Render(m.message) + sep + content
+	}
+	if len(m.panels) > 0 {
+		content = m.focusLegend() + sep + content
+	}
+	if m.meter != "" {
+		meter := m.meter
+		if m.meterWarn {
+			meter = m.styles.Message.Render(meter)
+		}
+		// Dropped rather than wrapped when the bar is too narrow
+		if gap := m.width - 4 - lipgloss.Width(content) - lipgloss.Width(meter); gap >= 2 {
+			content += strings.Repeat(" ", gap) + meter
+		}
+	}
 
 	return m.styles.Container.
 		Width(m.width - 2).
 		Render(content)
 }
 
+// focusLegend renders the panels, e.g. "Sidebar › [Terminal] › Info".
+func (m StatusBarModel) focusLegend() string {
+	names := make([]string, len(m.panels))
+	for i, p := range m.panels {
+		if i == m.activePanel {
+			names[i] = m.styles.Key.Render("[" + p + "]")
+		} else {
+			names[i] = p
+		}
+	}
+	return strings.Join(names, m.styles.Separator.Render(" › "))
+}
+
 // ContextualStatusBar returns keybindings text for a specific context.
 func ContextualStatusBar(context string) []key.Binding {
 	switch context {
@@ -108,6 +171,8 @@ func ContextualStatusBar(context string) []key.Binding {
 			key.NewBinding(key.WithKeys("↓/j"), key.WithHelp("↓/j", "down")),
 			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "start")),
 			key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+			key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "stats")),
+			key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pause")),
 			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
 			key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
 		}
@@ -115,15 +180,36 @@ func ContextualStatusBar(context string) []key.Binding {
 		return []key.Binding{
 			key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "check")),
 			key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "hints")),
-			key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "focus")),
+			key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "explain")),
+			key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "docs")),
+			key.NewBinding(key.WithKeys("K"), key.WithHelp("K", "cheat sheet")),
+			key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "reset")),
+			key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab/⇧tab", "focus")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "leave")),
 			key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
 			key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
 		}
+	case "scenario-terminal":
+		// Everything else, esc and q included, goes to the shell
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab/⇧tab", "leave terminal")),
+			key.NewBinding(key.WithKeys("f1"), key.WithHelp("F1", "cheat sheet")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc/q", "sent to the shell")),
+		}
+	case "cheat-sheet":
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("left", "right"), key.WithHelp("←/→", "category")),
+			key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑/↓", "select")),
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "type into terminal")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close")),
+		}
 	case "success":
 		return []key.Binding{
 			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "continue")),
 			key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "retry")),
 			key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "menu")),
+			key.NewBinding(key.WithKeys("k"), key.WithHelp("k", "keep exploring")),
+			key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "walkthrough")),
 			key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
 		}
 	default:
@@ -6,19 +6,73 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"k8s-dojo/pkg/scenario"
+	"k8s-dojo/pkg/score"
+)
+
+// SuccessAction is a button of the success screen.
+type SuccessAction int
+
+// Success screen buttons, in display order.
+const (
+	SuccessContinue SuccessAction = iota
+	SuccessRetry
+	SuccessKeepExploring
+	SuccessWalkthrough
 )
 
+// successButtons labels the buttons; the key of each action's binding is
+// added to the label.
+var successButtons = []string{"Continue", "Retry", "Keep exploring", "Walkthrough"}
+
+// SuccessChosenMsg is sent when a button of the success screen is chosen.
+type SuccessChosenMsg struct {
+	Action SuccessAction
+}
+
+// SuccessKeyMap defines the success screen's keybindings.
+type SuccessKeyMap struct {
+	Prev   []key.Binding // Select the previous button
+	Next   []key.Binding // Select the next button
+	Choose key.Binding   // Choose the selected button
+
+	// Choose a button directly
+	Continue      []key.Binding
+	Retry         key.Binding
+	KeepExploring key.Binding
+	Walkthrough   key.Binding
+}
+
 // SuccessModel represents the success/victory screen.
 type SuccessModel struct {
 	scenarioName string
 	message      string
 	elapsedTime  time.Duration
 	points       int
+	stats        *SuccessStats
 	width        int
 	height       int
 	styles       SuccessStyles
-	selectedBtn  int // 0: Continue, 1: Retry
+	keys         SuccessKeyMap
+	selected     SuccessAction
+
+	// The solution, once revealed with the walkthrough button
+	walkthrough []scenario.DemoStep
+	revealed    bool
+}
+
+// SuccessStats is the breakdown of a solve shown under the elapsed time.
+type SuccessStats struct {
+	HintsUsed    int
+	Checks       int
+	PreviousBest time.Duration // Fastest earlier solve, 0 on the first one
+	Points       score.Breakdown
+	Next         string // Recommended next scenario and why, empty for none
+	Achievements []string
 }
 
 // SuccessStyles contains styles for the success screen.
@@ -31,6 +85,7 @@ type SuccessStyles struct {
 	Muted     lipgloss.Style
 	Button    lipgloss.Style
 	Box       lipgloss.Style
+	Toast     lipgloss.Style
 }
 
 // NewSuccessStyles creates adaptive success styles.
@@ -76,22 +131,33 @@ func NewSuccessStyles() SuccessStyles {
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(success).
 			Padding(1, 2).
-			Width(40).
+			Width(50).
 			Align(lipgloss.Center),
+
+		Toast: lipgloss.NewStyle().
+			Foreground(textBold).
+			Background(secondary).
+			Bold(true).
+			Padding(0, 2),
 	}
 }
 
 // NewSuccessModel creates a new success model.
-func NewSuccessModel() SuccessModel {
+func NewSuccessModel(keys SuccessKeyMap) SuccessModel {
 	return SuccessModel{
 		points: 100,
 		styles: NewSuccessStyles(),
+		keys:   keys,
 	}
 }
 
-// SetScenario sets the completed scenario name.
+// SetScenario sets the completed scenario name. The selection returns to
+// Continue and the walkthrough is hidden again.
 func (m *SuccessModel) SetScenario(name string) {
 	m.scenarioName = name
+	m.selected = SuccessContinue
+	m.walkthrough = nil
+	m.revealed = false
 }
 
 // SetMessage sets the success message.
@@ -109,31 +175,98 @@ func (m *SuccessModel) SetPoints(points int) {
 	m.points = points
 }
 
+// SetStats sets the solve breakdown. The points shown become its total.
+func (m *SuccessModel) SetStats(stats SuccessStats) {
+	m.stats = &stats
+	m.points = stats.Points.Total()
+}
+
 // SetSize sets the dimensions.
 func (m *SuccessModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 }
 
-// NextButton selects the next button.
-func (m *SuccessModel) NextButton() {
-	m.selectedBtn = (m.selectedBtn + 1) % 2
+// RevealWalkthrough shows the steps that solve the scenario under the stats.
+func (m *SuccessModel) RevealWalkthrough(steps []scenario.DemoStep) {
+	m.walkthrough = steps
+	m.revealed = true
+}
+
+// Update moves the button selection and, when a button is chosen, returns a
+// command sending SuccessChosenMsg.
+func (m SuccessModel) Update(msg tea.Msg) (SuccessModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	buttons := SuccessAction(len(successButtons))
+	switch {
+	// Before navigation, which also binds k
+	case key.Matches(keyMsg, m.keys.KeepExploring):
+		return m, m.choose(SuccessKeepExploring)
+	case key.Matches(keyMsg, m.keys.Retry):
+		return m, m.choose(SuccessRetry)
+	case key.Matches(keyMsg, m.keys.Walkthrough):
+		return m, m.choose(SuccessWalkthrough)
+	case key.Matches(keyMsg, m.keys.Continue...):
+		return m, m.choose(SuccessContinue)
+
+	case key.Matches(keyMsg, m.keys.Prev...):
+		m.selected = (m.selected - 1 + buttons) % buttons
+	case key.Matches(keyMsg, m.keys.Next...):
+		m.selected = (m.selected + 1) % buttons
+	case key.Matches(keyMsg, m.keys.Choose):
+		return m, m.choose(m.selected)
+	}
+	return m, nil
 }
 
-// PrevButton selects the previous button.
-func (m *SuccessModel) PrevButton() {
-	m.selectedBtn = (m.selectedBtn - 1 + 2) % 2
+// choose selects a button and reports it chosen.
+func (m *SuccessModel) choose(action SuccessAction) tea.Cmd {
+	m.selected = action
+	return func() tea.Msg {
+		return SuccessChosenMsg{Action: action}
+	}
 }
 
-// SelectedButton returns the index of the selected button (0: Continue, 1: Retry).
-func (m *SuccessModel) SelectedButton() int {
-	return m.selectedBtn
+// buttonLabel returns a button's label with the key that chooses it, e.g.
+// "Retry (r)".
+func (m SuccessModel) buttonLabel(action SuccessAction) string {
+	var binding key.Binding
+	switch action {
+	case SuccessContinue:
+		if len(m.keys.Continue) > 0 {
+			binding = m.keys.Continue[0]
+		}
+	case SuccessRetry:
+		binding = m.keys.Retry
+	case SuccessKeepExploring:
+		binding = m.keys.KeepExploring
+	case SuccessWalkthrough:
+		binding = m.keys.Walkthrough
+	}
+	label := successButtons[action]
+	if k := binding.Help().Key; k != "" {
+		label = fmt.Sprintf("%s (%s)", label, k)
+	}
+	return label
 }
 
 // View renders the success screen.
 func (m SuccessModel) View() string {
 	var b strings.Builder
 
+	// Achievement toast
+	if m.stats != nil && len(m.stats.Achievements) > 0 {
+		var lines []string
+		for _, a := range m.stats.Achievements {
+			lines = append(lines, "🏅 Achievement unlocked: "+a)
+		}
+		b.WriteString(m.styles.Toast.Render(strings.Join(lines, "\n")))
+		b.WriteString("\n\n")
+	}
+
 	// Big success title
 	b.WriteString(m.styles.Title.Render("🎉  S U C C E S S !"))
 	b.WriteString("\n\n")
@@ -143,19 +276,23 @@ func (m SuccessModel) View() string {
 	b.WriteString(boxContent)
 	b.WriteString("\n\n")
 
-	// Action buttons
-	continueBtn := " Continue "
-	retryBtn := "  Retry   "
+	if m.revealed {
+		b.WriteString(m.renderWalkthrough())
+		b.WriteString("\n\n")
+	}
 
-	if m.selectedBtn == 0 {
-		continueBtn = m.styles.Button.Render(continueBtn)
-		retryBtn = m.styles.Muted.Render(retryBtn)
-	} else {
-		continueBtn = m.styles.Muted.Render(continueBtn)
-		retryBtn = m.styles.Button.Render(retryBtn)
+	// Action buttons
+	var buttons []string
+	for i := range successButtons {
+		label := m.buttonLabel(SuccessAction(i))
+		if SuccessAction(i) == m.selected {
+			buttons = append(buttons, m.styles.Button.Render(label))
+		} else {
+			buttons = append(buttons, m.styles.Muted.Padding(0, 2).Render(label))
+		}
 	}
 
-	b.WriteString(continueBtn + "    " + retryBtn)
+	b.WriteString(strings.Join(buttons, "  "))
 
 	// Center everything
 	content := b.String()
@@ -188,7 +325,63 @@ func (m SuccessModel) renderBox() string {
 	// Stats
 	b.WriteString(m.styles.Stats.Render(fmt.Sprintf("⏱ Time: %s", m.elapsedTime.Round(time.Second))))
 	b.WriteString("\n")
+	if m.stats != nil {
+		b.WriteString(m.renderStats())
+	}
 	b.WriteString(m.styles.Stats.Render(fmt.Sprintf("★ Points: +%d", m.points)))
+	if m.stats != nil {
+		b.WriteString("\n")
+		b.WriteString(m.renderPoints())
+		if m.stats.Next != "" {
+			b.WriteString("\n\n")
+			b.WriteString(m.styles.Muted.Render("Next up: " + m.stats.Next))
+		}
+	}
 
 	return m.styles.Box.Render(b.String())
 }
+
+// renderStats renders the personal best comparison, hints and checks.
+func (m SuccessModel) renderStats() string {
+	s := m.stats
+	var lines []string
+	switch best := s.PreviousBest; {
+	case best == 0:
+		lines = append(lines, "🏁 First solve")
+	case m.elapsedTime < best:
+		lines = append(lines, fmt.Sprintf("🏆 New personal best! (was %s)", best.Round(time.Second)))
+	default:
+		lines = append(lines, fmt.Sprintf("🏁 Personal best: %s", best.Round(time.Second)))
+	}
+	lines = append(lines, fmt.Sprintf("💡 Hints used: %d", s.HintsUsed), fmt.Sprintf("🔍 Checks: %d", s.Checks))
+	return m.styles.Muted.Render(strings.Join(lines, "\n")) + "\n"
+}
+
+// renderPoints renders how the points were reached.
+func (m SuccessModel) renderPoints() string {
+	p := m.stats.Points
+	lines := []string{fmt.Sprintf("base %d", p.Base)}
+	if p.TimeBonus > 0 {
+		lines = append(lines, fmt.Sprintf("speed +%d", p.TimeBonus))
+	}
+	if p.HintPenalty > 0 {
+		lines = append(lines, fmt.Sprintf("hints -%d", p.HintPenalty))
+	}
+	if p.CheckPenalty > 0 {
+		lines = append(lines, fmt.Sprintf("checks -%d", p.CheckPenalty))
+	}
+	return m.styles.Muted.Render(strings.Join(lines, " · "))
+}
+
+// renderWalkthrough renders the revealed solution steps.
+func (m SuccessModel) renderWalkthrough() string {
+	if len(m.walkthrough) == 0 {
+		return m.styles.Muted.Render("This scenario has no walkthrough.")
+	}
+	lines := []string{m.styles.Subtitle.Render("Walkthrough")}
+	for i, step := range m.walkthrough {
+		lines = append(lines, m.styles.Muted.Render(fmt.Sprintf("%d. %s", i+1, step.Caption)))
+		lines = append(lines, m.styles.Stats.Render("   $ "+step.Command))
+	}
+	return m.styles.Box.Align(lipgloss.Left).Render(strings.Join(lines, "\n"))
+}
@@ -8,13 +8,17 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
-	"syscall"
+	"sync/atomic"
 	"time"
 
+	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/creack/pty"
 	"github.com/hinshun/vt10x"
+	"k8s.io/klog/v2"
+
+	"k8s-dojo/pkg/record"
 )
 
 // TerminalOutputMsg is sent when new terminal output is available.
@@ -52,9 +56,10 @@ func NewTerminalStyles() TerminalStyles {
 
 // TerminalModel represents an embedded terminal using vt10x for emulation.
 type TerminalModel struct {
-	// PTY and process
-	pty *os.File
-	cmd *exec.Cmd
+	// PTY and process; exited is closed once the shell has been reaped
+	pty    *os.File
+	cmd    *exec.Cmd
+	exited chan struct{}
 
 	// Virtual Terminal Emulator
 	term vt10x.Terminal
@@ -77,12 +82,46 @@ type TerminalModel struct {
 	// Styles
 	styles TerminalStyles
 
-	// Shell path
-	shell string
+	// Shell path and arguments
+	shell     string
+	shellArgs []string
 
 	// Environment for kubectl
 	kubeconfig     string
 	kubeconfigPath string
+
+	// Session recording, nil unless enabled
+	recorder *record.Recorder
+
+	// Bracketed paste mode as last set by the program in the terminal;
+	// modeTail holds the end of the previous read in case a sequence was split
+	bracketedPaste bool
+	modeTail       []byte
+
+	// Serializes chunked paste writes
+	pasteMu sync.Mutex
+
+	// Styled rows from the last View, re-rendered only when their cells change
+	rows []renderedRow
+
+	// Set while a TerminalOutputMsg is scheduled, so bursts of output share one
+	notifyPending atomic.Bool
+	lastNotify    atomic.Int64 // Unix nanoseconds of the last TerminalOutputMsg
+}
+
+// Output is batched for outputFrame before the TUI is told to redraw, or for
+// outputFloodFrame while output keeps streaming, e.g. during `kubectl logs -f`.
+const (
+	outputFrame      = 16 * time.Millisecond
+	outputFloodFrame = 33 * time.Millisecond
+)
+
+// renderedRow caches the styled output of one terminal row.
+type renderedRow struct {
+	cells  []vt10x.Glyph
+	cursor int // Column of the cursor drawn in this row, -1 for none
+	line   string
+	valid  bool
 }
 
 // NewTerminalModel creates a new terminal model.
@@ -108,6 +147,25 @@ func (m *TerminalModel) SetProgram(p *tea.Program) {
 	m.program = p
 }
 
+// SetRecorder records everything the shell prints, and terminal resizes, to r.
+func (m *TerminalModel) SetRecorder(r *record.Recorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recorder = r
+	if m.width > 0 && m.height > 0 {
+		r.Resize(m.width-4, m.height-2)
+	}
+}
+
+// SetShell replaces the local shell, e.g. with an ssh command for a remote host.
+// It takes effect the next time the terminal starts.
+func (m *TerminalModel) SetShell(name string, args ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shell = name
+	m.shellArgs = args
+}
+
 // SetKubeconfig sets the kubeconfig path for kubectl commands.
 func (m *TerminalModel) SetKubeconfig(kubeconfig string) {
 	m.mu.Lock()
@@ -115,6 +173,35 @@ func (m *TerminalModel) SetKubeconfig(kubeconfig string) {
 	m.kubeconfig = kubeconfig
 }
 
+// Kubeconfig returns the kubeconfig the terminal's kubectl uses.
+func (m *TerminalModel) Kubeconfig() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.kubeconfig
+}
+
+// ShellCommand returns the shell the terminal runs and its arguments.
+func (m *TerminalModel) ShellCommand() (string, []string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.shell, m.shellArgs
+}
+
+// UpdateKubeconfig replaces the kubeconfig, rewriting the file a running shell
+// already points KUBECONFIG at so kubectl picks up the new connection.
+func (m *TerminalModel) UpdateKubeconfig(kubeconfig string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.kubeconfig = kubeconfig
+	if m.kubeconfigPath == "" {
+		return nil
+	}
+	if err := os.WriteFile(m.kubeconfigPath, []byte(kubeconfig), 0o600); err != nil {
+		return fmt.Errorf("failed to update kubeconfig: %w", err)
+	}
+	return nil
+}
+
 // Start spawns a new shell with PTY.
 func (m *TerminalModel) Start() tea.Cmd {
 	return func() tea.Msg {
@@ -126,7 +213,7 @@ func (m *TerminalModel) Start() tea.Cmd {
 		}
 
 		// Create command
-		m.cmd = exec.Command(m.shell)
+		m.cmd = exec.Command(m.shell, m.shellArgs...)
 		m.cmd.Env = append(os.Environ(),
 			"TERM=xterm-256color",
 			"PS1=$ ",
@@ -166,6 +253,13 @@ func (m *TerminalModel) Start() tea.Cmd {
 			return TerminalOutputMsg{}
 		}
 
+		// Reap the shell as soon as it exits, so it never lingers as a zombie
+		m.exited = make(chan struct{})
+		go func(cmd *exec.Cmd, exited chan struct{}) {
+			_ = cmd.Wait()
+			close(exited)
+		}(m.cmd, m.exited)
+
 		// Set initial size
 		if m.width > 0 && m.height > 0 {
 			_ = pty.Setsize(m.pty, &pty.Winsize{
@@ -212,13 +306,7 @@ func (m *TerminalModel) readOutput() {
 				fmt.Fprintln(m.term, "\nTerminal closed")
 				m.running = false
 				m.mu.Unlock()
-
-				m.mu.RLock()
-				p := m.program
-				m.mu.RUnlock()
-				if p != nil {
-					p.Send(TerminalOutputMsg{})
-				}
+				m.notify()
 			}
 			return
 		}
@@ -227,26 +315,130 @@ func (m *TerminalModel) readOutput() {
 			m.mu.Lock()
 			// Direct Write to VT10x emulator
 			_, _ = m.term.Write(buf[:n])
+			m.trackModes(buf[:n])
+			if m.recorder != nil {
+				m.recorder.Output(buf[:n])
+			}
 			m.mu.Unlock()
+			m.notify()
+		}
+	}
+}
 
+// notify tells the TUI to redraw once the current output frame ends, so the
+// program gets at most one TerminalOutputMsg per frame however often the PTY
+// is read. Output arriving before then is drawn by the same redraw.
+func (m *TerminalModel) notify() {
+	if !m.notifyPending.CompareAndSwap(false, true) {
+		return
+	}
+	frame := outputFrame
+	if time.Since(time.Unix(0, m.lastNotify.Load())) < outputFloodFrame {
+		frame = outputFloodFrame // Still streaming since the last redraw
+	}
+	time.AfterFunc(frame, func() {
+		m.notifyPending.Store(false)
+		m.lastNotify.Store(time.Now().UnixNano())
+		m.mu.RLock()
+		p := m.program
+		m.mu.RUnlock()
+		if p != nil {
+			p.Send(TerminalOutputMsg{})
+		}
+	})
+}
+
+// Sequences a program prints to turn bracketed paste on and off. vt10x
+// doesn't track the mode, so trackModes does.
+const (
+	bracketedPasteOn  = "\x1b[?2004h"
+	bracketedPasteOff = "\x1b[?2004l"
+)
+
+// pasteChunk is the most bytes of a paste written to the PTY at once.
+const pasteChunk = 1024
+
+// trackModes updates the bracketed paste mode from output. Must hold mu.
+func (m *TerminalModel) trackModes(p []byte) {
+	data := append(m.modeTail, p...)
+	on := strings.LastIndex(string(data), bracketedPasteOn)
+	off := strings.LastIndex(string(data), bracketedPasteOff)
+	if on > off {
+		m.bracketedPaste = true
+	} else if off > on {
+		m.bracketedPaste = false
+	}
+	keep := len(bracketedPasteOn) - 1
+	if len(data) < keep {
+		keep = len(data)
+	}
+	m.modeTail = append(m.modeTail[:0], data[len(data)-keep:]...)
+}
+
+// Paste sends text as a paste: bracketed when the program in the terminal
+// asked for it (shells, vim), otherwise with newlines sent as carriage returns
+// like a real terminal does. The text is written in chunks from a goroutine,
+// so a large paste never blocks the UI on a full PTY.
+func (m *TerminalModel) Paste(text string) {
+	m.mu.RLock()
+	bracketed := m.bracketedPaste
+	m.mu.RUnlock()
+
+	text = strings.ReplaceAll(text, "\r\n", "\r")
+	text = strings.ReplaceAll(text, "\n", "\r")
+	if bracketed {
+		// A pasted end marker would end the paste early
+		text = "\x1b[200~" + strings.ReplaceAll(text, "\x1b[201~", "") + "\x1b[201~"
+	}
+
+	go func() {
+		m.pasteMu.Lock()
+		defer m.pasteMu.Unlock()
+		for len(text) > 0 {
+			n := min(pasteChunk, len(text))
 			m.mu.RLock()
-			p := m.program
+			ptyFile, running := m.pty, m.running
 			m.mu.RUnlock()
-			if p != nil {
-				p.Send(TerminalOutputMsg{})
+			if ptyFile == nil || !running {
+				return
+			}
+			if _, err := ptyFile.WriteString(text[:n]); err != nil {
+				return
 			}
+			text = text[n:]
 		}
+	}()
+}
+
+// pasteClipboard pastes the system clipboard into the terminal.
+func (m *TerminalModel) pasteClipboard() tea.Cmd {
+	return func() tea.Msg {
+		text, err := clipboard.ReadAll()
+		if err != nil {
+			m.mu.Lock()
+			fmt.Fprintf(m.term, "\r\n[clipboard unavailable: %v]\r\n", err)
+			m.mu.Unlock()
+			return TerminalOutputMsg{}
+		}
+		m.Paste(text)
+		return nil
 	}
 }
 
-// Stop closes the PTY and terminates the shell.
+// Stop closes the PTY and terminates the shell with everything it started,
+// also when the shell already exited and left background jobs behind. The
+// session is reaped in the background, so Stop doesn't block the UI while
+// the shell gets its time to exit.
 func (m *TerminalModel) Stop() {
 	m.mu.Lock()
-	if !m.running {
+	cmd, exited := m.cmd, m.exited
+	if cmd == nil {
 		m.mu.Unlock()
 		return
 	}
 	m.running = false
+	m.cmd = nil
+	m.exited = nil
 
 	if m.pty != nil {
 		m.pty.Close() // This will cause readOutput to exit err from Read
@@ -269,18 +461,24 @@ func (m *TerminalModel) Stop() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.cmd != nil && m.cmd.Process != nil {
-		_ = m.cmd.Process.Signal(syscall.SIGTERM)
-		// Cleanup process logic ... simplified for brevity, assume system handles orphans or eventual kill
-	}
-
-	if m.kubeconfigPath != "" {
-		_ = os.Remove(m.kubeconfigPath)
-		m.kubeconfigPath = ""
-	}
+	// The exiting session may still read its kubeconfig, so remove it last
+	kubeconfigPath := m.kubeconfigPath
+	m.kubeconfigPath = ""
+	go func() {
+		if cmd.Process != nil {
+			if left := terminateSession(cmd, exited); len(left) > 0 {
+				klog.Warningf("Terminal processes survived SIGKILL: %v", left)
+			}
+		}
+		if kubeconfigPath != "" {
+			_ = os.Remove(kubeconfigPath)
+		}
+	}()
 
 	// Reset terminal state
 	m.term = vt10x.New(vt10x.WithSize(80, 24))
+	m.bracketedPaste = false
+	m.modeTail = nil
 }
 
 // SetSize sets the terminal dimensions.
@@ -288,6 +486,7 @@ func (m *TerminalModel) SetSize(width, height int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	changed := width != m.width || height != m.height
 	m.width = width
 	m.height = height
 
@@ -301,14 +500,22 @@ func (m *TerminalModel) SetSize(width, height int) {
 		termH = 1
 	}
 
+	if cols, rows := m.term.Size(); cols == termW && rows == termH {
+		return
+	}
+
+	// Resize the emulator first, so the program's redraw after SIGWINCH lands
+	// on a screen of the new size
+	m.term.Resize(termW, termH)
 	if m.pty != nil {
 		_ = pty.Setsize(m.pty, &pty.Winsize{
 			Rows: uint16(termH),
 			Cols: uint16(termW),
 		})
 	}
-	// Resize emulator
-	m.term.Resize(termW, termH)
+	if m.recorder != nil && changed {
+		m.recorder.Resize(termW, termH)
+	}
 }
 
 // SetFocus sets the focus state.
@@ -342,6 +549,44 @@ func (m *TerminalModel) SendInput(input string) {
 	}
 }
 
+// ScreenLines returns the visible text of the terminal, one string per row.
+func (m *TerminalModel) ScreenLines() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cols, rows := m.term.Size()
+	lines := make([]string, 0, rows)
+	for y := 0; y < rows; y++ {
+		var line strings.Builder
+		for x := 0; x < cols; x++ {
+			c := m.term.Cell(x, y).Char
+			if c == 0 {
+				c = ' '
+			}
+			line.WriteRune(c)
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+	return lines
+}
+
+// RecentCommands returns up to n kubectl commands visible on screen, oldest first.
+func (m *TerminalModel) RecentCommands(n int) []string {
+	var commands []string
+	for _, line := range m.ScreenLines() {
+		i := strings.Index(line, "kubectl ")
+		// Only count lines where kubectl follows a shell prompt
+		if i <= 0 || !strings.ContainsAny(line[:i], "$#%>") {
+			continue
+		}
+		commands = append(commands, strings.TrimSpace(line[i:]))
+	}
+	if len(commands) > n {
+		commands = commands[len(commands)-n:]
+	}
+	return commands
+}
+
 // ScrollUp/Down - Not supported in basic vt10x without history wrapper, stubs for now
 func (m *TerminalModel) ScrollUp(lines int)   {}
 func (m *TerminalModel) ScrollDown(lines int) {}
@@ -359,56 +604,21 @@ func (m *TerminalModel) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.Paste {
-			// Still wrapping paste to be safe
-			m.SendInput("\x1b[200~" + string(msg.Runes) + "\x1b[201~")
+			m.Paste(string(msg.Runes))
 			return nil
 		}
+		if msg.String() == "alt+v" {
+			return m.pasteClipboard()
+		}
 
-		// Handle keys mapping to VT100 sequences
-		// Same as before
-		switch msg.Type {
-		case tea.KeyEnter:
-			m.SendInput("\r")
-		case tea.KeyBackspace:
-			m.SendInput("\x7f") // or \x08 depending on terminal config
-		case tea.KeyTab:
-			return nil
-		case tea.KeyCtrlC:
-			m.SendInput("\x03")
-		case tea.KeyCtrlD:
-			m.SendInput("\x04")
-		case tea.KeyCtrlZ:
-			m.SendInput("\x1a")
-		case tea.KeyCtrlL:
-			m.SendInput("\x0c")
-		case tea.KeyUp:
-			m.SendInput("\x1b[A")
-		case tea.KeyDown:
-			m.SendInput("\x1b[B")
-		case tea.KeyLeft:
-			m.SendInput("\x1b[D")
-		case tea.KeyRight:
-			m.SendInput("\x1b[C")
-		case tea.KeyHome:
-			m.SendInput("\x1b[H")
-		case tea.KeyEnd:
-			m.SendInput("\x1b[F")
-		case tea.KeyDelete:
-			m.SendInput("\x1b[3~")
-		case tea.KeyPgUp:
-			m.SendInput("\x1b[5~")
-		case tea.KeyPgDown:
-			m.SendInput("\x1b[6~")
-		case tea.KeyRunes:
-			m.SendInput(string(msg.Runes))
-		case tea.KeySpace:
-			m.SendInput(" ")
-		case tea.KeyEsc:
-			m.SendInput("\x1b")
-		default:
-			if s := msg.String(); len(s) == 1 {
-				m.SendInput(s)
-			}
+		if msg.Type == tea.KeyTab {
+			return nil // Tab moves focus out of the terminal
+		}
+		m.mu.RLock()
+		appCursor := m.term.Mode()&vt10x.ModeAppCursor != 0
+		m.mu.RUnlock()
+		if seq := keySequence(msg, appCursor); seq != "" {
+			m.SendInput(seq)
 		}
 		return nil
 	}
@@ -416,98 +626,27 @@ func (m *TerminalModel) Update(msg tea.Msg) tea.Cmd {
 }
 
 // View renders the terminal using vt10x state.
+// Rows whose cells and cursor are unchanged since the last View reuse their
+// styled output, so fast output only pays for the rows it touches.
 func (m *TerminalModel) View() string {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
 	var builder strings.Builder
 
 	cols, rows := m.term.Size()
 	cursor := m.term.Cursor()
-	cursorX, cursorY := cursor.X, cursor.Y
+	if len(m.rows) != rows {
+		m.rows = make([]renderedRow, rows)
+	}
 
 	// Iterate through visible rows
 	for y := 0; y < rows; y++ {
-		for x := 0; x < cols; x++ {
-			// Get cell info
-			cell := m.term.Cell(x, y)
-			c := cell.Char
-			fg := cell.FG
-			bg := cell.BG
-
-			style := lipgloss.NewStyle()
-
-			// Determine colors with contrast correction
-			var fgColor lipgloss.TerminalColor = lipgloss.Color("#cdd6f4") // Default text
-			var bgColor lipgloss.TerminalColor = lipgloss.NoColor{}
-
-			hasCustomBG := false
-			const DefaultFG_Int = 16777216
-			const DefaultBG_Int = 16777217
-
-			bgInt := int(bg)
-			fgInt := int(fg)
-
-			// Map Background
-			if bgInt == DefaultBG_Int {
-				bgColor = lipgloss.NoColor{} // Restore transparency
-				// hasCustomBG remains false
-			} else if bgInt == DefaultFG_Int {
-				// Inversed Default FG (was Light, now we map Default FG to Dark Text)
-				// If text is Dark (#4c4f69), then Inverse BG should be Dark (#4c4f69).
-				bgColor = lipgloss.Color("#4c4f69")
-				hasCustomBG = true
-			} else {
-				bgColor = lipgloss.Color(fmt.Sprintf("%d", bg))
-				hasCustomBG = true
-			}
-
-			// Map Foreground
-			if fgInt == DefaultFG_Int {
-				fgColor = lipgloss.Color("#4c4f69") // Switch to Dark Text (Latte Text)
-			} else if fgInt == DefaultBG_Int {
-				fgColor = lipgloss.Color("#eff1f5") // DefaultBG as FG -> Light (Latte Base)
-			} else {
-				fgColor = lipgloss.Color(fmt.Sprintf("%d", fg))
-			}
-
-			// Contrast Correction: Force black text on light backgrounds
-			if hasCustomBG {
-				isLight := false
-				if bgInt == DefaultFG_Int {
-					// BG is DefaultFG (#4c4f69 Dark). So isLight = False.
-					isLight = false
-				} else {
-					isLight = isLightColor(bgInt)
-				}
-
-				if isLight {
-					fgColor = lipgloss.Color("#000000") // Force Hex Black
-				}
-
-				// If BG is DefaultFG (#4c4f69 Dark), ensure Text is Light.
-				if bgInt == DefaultFG_Int {
-					fgColor = lipgloss.Color("#eff1f5")
-				}
-			}
-
-			style = style.Foreground(fgColor)
-			if hasCustomBG {
-				style = style.Background(bgColor)
-			}
-
-			// Cursor rendering
-			if m.focused && x == cursorX && y == cursorY {
-				style = style.Reverse(true)
-			}
-
-			// Handle empty cells
-			if c == 0 {
-				c = ' '
-			}
-
-			builder.WriteString(style.Render(string(c)))
+		cursorX := -1
+		if m.focused && y == cursor.Y && m.term.CursorVisible() { // Full-screen apps often hide it
+			cursorX = cursor.X
 		}
+		builder.WriteString(m.renderRow(y, cols, cursorX))
 		builder.WriteString("\n")
 	}
 
@@ -524,6 +663,151 @@ func (m *TerminalModel) View() string {
 		Render(m.styles.Title.Render(title) + "\n" + builder.String())
 }
 
+// renderRow returns row y styled, from the cache when nothing in it changed.
+// cursorX is the column to draw the cursor in, or -1.
+func (m *TerminalModel) renderRow(y, cols, cursorX int) string {
+	r := &m.rows[y]
+	if r.valid && r.cursor == cursorX && len(r.cells) == cols {
+		same := true
+		for x := 0; x < cols; x++ {
+			if m.term.Cell(x, y) != r.cells[x] {
+				same = false
+				break
+			}
+		}
+		if same {
+			return r.line
+		}
+	}
+
+	if cap(r.cells) < cols {
+		r.cells = make([]vt10x.Glyph, cols)
+	}
+	r.cells = r.cells[:cols]
+	for x := 0; x < cols; x++ {
+		r.cells[x] = m.term.Cell(x, y)
+	}
+
+	// Style runs of cells with the same colors and attributes in one go
+	var line, run strings.Builder
+	for x := 0; x < cols; {
+		cell := r.cells[x]
+		style := cellStyle(cell)
+		if x == cursorX {
+			run.WriteRune(printable(cell.Char))
+			line.WriteString(style.Reverse(true).Render(run.String()))
+			run.Reset()
+			x++
+			continue
+		}
+		end := x
+		for end < cols && end != cursorX && sameStyle(r.cells[end], cell) {
+			run.WriteRune(printable(r.cells[end].Char))
+			end++
+		}
+		line.WriteString(style.Render(run.String()))
+		run.Reset()
+		x = end
+	}
+
+	r.cursor = cursorX
+	r.line = line.String()
+	r.valid = true
+	return r.line
+}
+
+// printable maps empty cells to spaces.
+func printable(c rune) rune {
+	if c == 0 {
+		return ' '
+	}
+	return c
+}
+
+// Glyph attribute bits, as vt10x sets them in Glyph.Mode. Reverse video is
+// already applied to the colors by vt10x.
+const (
+	glyphUnderline int16 = 1 << 1
+	glyphBold      int16 = 1 << 2
+	glyphItalic    int16 = 1 << 4
+	glyphStyled          = glyphUnderline | glyphBold | glyphItalic
+)
+
+// sameStyle reports whether two cells render with the same style.
+func sameStyle(a, b vt10x.Glyph) bool {
+	return a.FG == b.FG && a.BG == b.BG && a.Mode&glyphStyled == b.Mode&glyphStyled
+}
+
+// cellStyle maps a vt10x cell's colors and attributes to a lipgloss style,
+// with contrast correction.
+func cellStyle(cell vt10x.Glyph) lipgloss.Style {
+	fg, bg := cell.FG, cell.BG
+	style := lipgloss.NewStyle().
+		Bold(cell.Mode&glyphBold != 0).
+		Underline(cell.Mode&glyphUnderline != 0).
+		Italic(cell.Mode&glyphItalic != 0)
+
+	// Determine colors with contrast correction
+	var fgColor lipgloss.TerminalColor = lipgloss.Color("#cdd6f4") // Default text
+	var bgColor lipgloss.TerminalColor = lipgloss.NoColor{}
+
+	hasCustomBG := false
+	const DefaultFG_Int = 16777216
+	const DefaultBG_Int = 16777217
+
+	bgInt := int(bg)
+	fgInt := int(fg)
+
+	// Map Background
+	if bgInt == DefaultBG_Int {
+		bgColor = lipgloss.NoColor{} // Restore transparency
+		// hasCustomBG remains false
+	} else if bgInt == DefaultFG_Int {
+		// Inversed Default FG (was Light, now we map Default FG to Dark Text)
+		// If text is Dark (#4c4f69), then Inverse BG should be Dark (#4c4f69).
+		bgColor = lipgloss.Color("#4c4f69")
+		hasCustomBG = true
+	} else {
+		bgColor = lipgloss.Color(fmt.Sprintf("%d", bg))
+		hasCustomBG = true
+	}
+
+	// Map Foreground
+	if fgInt == DefaultFG_Int {
+		fgColor = lipgloss.Color("#4c4f69") // Switch to Dark Text (Latte Text)
+	} else if fgInt == DefaultBG_Int {
+		fgColor = lipgloss.Color("#eff1f5") // DefaultBG as FG -> Light (Latte Base)
+	} else {
+		fgColor = lipgloss.Color(fmt.Sprintf("%d", fg))
+	}
+
+	// Contrast Correction: Force black text on light backgrounds
+	if hasCustomBG {
+		isLight := false
+		if bgInt == DefaultFG_Int {
+			// BG is DefaultFG (#4c4f69 Dark). So isLight = False.
+			isLight = false
+		} else {
+			isLight = isLightColor(bgInt)
+		}
+
+		if isLight {
+			fgColor = lipgloss.Color("#000000") // Force Hex Black
+		}
+
+		// If BG is DefaultFG (#4c4f69 Dark), ensure Text is Light.
+		if bgInt == DefaultFG_Int {
+			fgColor = lipgloss.Color("#eff1f5")
+		}
+	}
+
+	style = style.Foreground(fgColor)
+	if hasCustomBG {
+		style = style.Background(bgColor)
+	}
+	return style
+}
+
 func isLightColor(c int) bool {
 	// Standard Colors (0-15)
 	if c == 7 || c == 15 {
@@ -0,0 +1,63 @@
+package components
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Cursor keys in normal and application cursor mode (DECCKM). Full-screen
+// programs such as vim, htop and k9s switch to application mode and only
+// understand the SS3 form.
+var cursorKeys = map[tea.KeyType][2]string{
+	tea.KeyUp:    {"\x1b[A", "\x1bOA"},
+	tea.KeyDown:  {"\x1b[B", "\x1bOB"},
+	tea.KeyRight: {"\x1b[C", "\x1bOC"},
+	tea.KeyLeft:  {"\x1b[D", "\x1bOD"},
+	tea.KeyHome:  {"\x1b[H", "\x1bOH"},
+	tea.KeyEnd:   {"\x1b[F", "\x1bOF"},
+}
+
+// specialKeys are the xterm sequences of keys that don't depend on a mode.
+var specialKeys = map[tea.KeyType]string{
+	tea.KeyInsert:   "\x1b[2~",
+	tea.KeyDelete:   "\x1b[3~",
+	tea.KeyPgUp:     "\x1b[5~",
+	tea.KeyPgDown:   "\x1b[6~",
+	tea.KeyShiftTab: "\x1b[Z",
+	tea.KeySpace:    " ",
+	tea.KeyF1:       "\x1bOP",
+	tea.KeyF2:       "\x1bOQ",
+	tea.KeyF3:       "\x1bOR",
+	tea.KeyF4:       "\x1bOS",
+	tea.KeyF5:       "\x1b[15~",
+	tea.KeyF6:       "\x1b[17~",
+	tea.KeyF7:       "\x1b[18~",
+	tea.KeyF8:       "\x1b[19~",
+	tea.KeyF9:       "\x1b[20~",
+	tea.KeyF10:      "\x1b[21~",
+	tea.KeyF11:      "\x1b[23~",
+	tea.KeyF12:      "\x1b[24~",
+}
+
+// keySequence returns the bytes a terminal sends for key, or "" for keys it
+// doesn't forward. appCursor reports whether application cursor mode is on.
+func keySequence(key tea.KeyMsg, appCursor bool) string {
+	var seq string
+	switch {
+	case key.Type == tea.KeyRunes:
+		seq = string(key.Runes)
+	case key.Type >= 0 && key.Type <= tea.KeyBackspace:
+		// Control characters (Ctrl+A-Z, Enter, Esc, Backspace) are their own code
+		seq = string(rune(key.Type))
+	default:
+		if keys, ok := cursorKeys[key.Type]; ok {
+			seq = keys[0]
+			if appCursor {
+				seq = keys[1]
+			}
+		} else {
+			seq = specialKeys[key.Type]
+		}
+	}
+	if seq != "" && key.Alt {
+		seq = "\x1b" + seq // Meta sends ESC first
+	}
+	return seq
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"k8s-dojo/pkg/tui/components"
+)
+
+// confirmAction runs when its button of a confirm dialog is chosen. It
+// decides which view comes next; closeConfirm returns to the one the dialog
+// was opened from.
+type confirmAction func(m AppModel) (tea.Model, tea.Cmd)
+
+// openConfirm shows dialog over the current view. actions[i] runs when
+// button i is chosen.
+func (m AppModel) openConfirm(dialog components.ConfirmDialog, actions ...confirmAction) (tea.Model, tea.Cmd) {
+	m.confirm = dialog
+	m.confirmActions = actions
+	m.previousView = m.view
+	m.view = ViewConfirm
+	return m, nil
+}
+
+// closeConfirm is the action of buttons that back out.
+func closeConfirm(m AppModel) (tea.Model, tea.Cmd) {
+	m.view = m.previousView
+	return m, nil
+}
+
+func (m AppModel) updateConfirm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var choice int
+	m.confirm, choice = m.confirm.Update(msg)
+	if choice == components.NoChoice || choice >= len(m.confirmActions) {
+		return m, nil
+	}
+	action := m.confirmActions[choice]
+	m.confirmActions = nil
+	return action(m)
+}
+
+func (m AppModel) viewConfirm() string {
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.confirm.View())
+}
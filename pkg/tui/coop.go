@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/coop"
+)
+
+// coopInterval is how often co-op partners exchange their state.
+const coopInterval = 2 * time.Second
+
+type coopTickMsg time.Time
+
+type coopSyncMsg struct {
+	partner string      // Owner: the partner following along, "" for none
+	share   *coop.Share // Partner: the owner's latest state
+	err     error
+}
+
+// ShareSession shares the dojo for co-op under a new token, as owner. A
+// partner joins with the token and follows the scenarios started here.
+func (m *AppModel) ShareSession(store *coop.Store, owner string) (string, error) {
+	share, err := store.Open(owner, m.dojoCluster().Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to share the session: %w", err)
+	}
+	m.coop = store
+	m.share = share
+	return share.Token, nil
+}
+
+// JoinSession follows a shared dojo as its co-op partner: the owner's
+// scenarios run here too, in their cluster and namespace, and only the owner
+// starts, resets or cleans them up.
+func (m *AppModel) JoinSession(store *coop.Store, share *coop.Share, name string) {
+	m.coop = store
+	m.following = share
+	m.coopName = name
+	m.clusterName = share.Cluster
+}
+
+// tickCoop schedules the next exchange with the co-op partner, if any.
+func (m AppModel) tickCoop() tea.Cmd {
+	if m.coop == nil {
+		return nil
+	}
+	return tea.Tick(coopInterval, func(t time.Time) tea.Msg {
+		return coopTickMsg(t)
+	})
+}
+
+// syncCoop publishes the owner's state, or fetches it for the partner.
+func (m AppModel) syncCoop() tea.Cmd {
+	store := m.coop
+	if m.following != nil {
+		token, name := m.following.Token, m.coopName
+		return func() tea.Msg {
+			if err := store.Greet(token, name); err != nil {
+				return coopSyncMsg{err: err}
+			}
+			share, err := store.Get(token)
+			return coopSyncMsg{share: share, err: err}
+		}
+	}
+
+	share := *m.share
+	share.Scenario, share.Namespace, share.Started, share.Check, share.Solved = "", "", time.Time{}, "", false
+	if m.currentScenario != nil && m.view != ViewDashboard && !m.starting && m.engineInstance != nil {
+		share.Scenario = m.currentScenario.GetMetadata().ID
+		share.Namespace = m.currentScenario.GetNamespace()
+		share.Started = m.engineInstance.StartedAt()
+		share.Check = m.lastCheckResult.Message
+		share.Solved = m.lastCheckResult.Solved
+	}
+	return func() tea.Msg {
+		if err := store.Publish(&share); err != nil {
+			return coopSyncMsg{err: err}
+		}
+		partner, _ := store.Partner(share.Token)
+		return coopSyncMsg{partner: partner}
+	}
+}
+
+// handleCoopSync follows the owner's scenario as a partner, or notes who
+// joined as the owner.
+func (m AppModel) handleCoopSync(msg coopSyncMsg) (tea.Model, tea.Cmd) {
+	if m.following == nil {
+		if msg.err != nil {
+			m.statusbar.SetMessage(fmt.Sprintf("Co-op unavailable: %v", msg.err))
+		}
+		m.partner = msg.partner
+		m.refreshBanner()
+		return m, m.tickCoop()
+	}
+
+	if errors.Is(msg.err, coop.ErrNoShare) {
+		owner := m.following.Owner
+		var cmd tea.Cmd
+		if m.view == ViewScenarioRunning && m.engineInstance.Joined() {
+			cmd = m.leaveScenario()
+		}
+		m.coop, m.following = nil, nil
+		m.refreshBanner()
+		m.statusbar.SetMessage(fmt.Sprintf("%s ended the co-op session.", owner))
+		return m, cmd
+	}
+	if msg.err != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Co-op unavailable: %v", msg.err))
+		return m, m.tickCoop()
+	}
+	m.following = msg.share
+
+	share := msg.share
+	running := m.view == ViewScenarioRunning && m.currentScenario != nil
+	switch {
+	case running && !m.starting && !m.engineInstance.StartedAt().Equal(share.Started):
+		// The owner moved on, or left the scenario
+		cmd := m.leaveScenario()
+		return m, tea.Batch(cmd, m.tickCoop())
+	case m.view == ViewDashboard && share.Scenario != "" && !share.Started.Equal(m.coopLeft):
+		if s := m.registry.Get(share.Scenario); s != nil {
+			m.currentScenario = s
+			model, cmd := m.startSelectedScenario(s)
+			return model, tea.Batch(cmd, m.tickCoop())
+		}
+		m.statusbar.SetMessage(fmt.Sprintf("%s started %s, which this dojo doesn't have.", share.Owner, share.Scenario))
+	}
+	m.refreshBanner()
+	return m, m.tickCoop()
+}
+
+// joinScenario runs the owner's scenario without setting it up.
+func (m AppModel) joinScenario() tea.Cmd {
+	share := *m.following
+	return func() tea.Msg {
+		return scenarioStartedMsg{err: m.engineInstance.Join(share.Scenario, share.Namespace, share.Started)}
+	}
+}
+
+// coopBanner describes the co-op session for the header, "" outside one.
+func (m AppModel) coopBanner() string {
+	switch {
+	case m.following != nil:
+		return fmt.Sprintf("👥 Co-op with %s, who picks the scenario", m.following.Owner)
+	case m.share != nil && m.partner != "":
+		return fmt.Sprintf("👥 %s is troubleshooting with you", m.partner)
+	case m.share != nil:
+		return "👥 Co-op: your partner joins with k8s-dojo --join " + m.share.Token
+	}
+	return ""
+}
@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/diag"
+)
+
+// crashMsg carries a panic recovered inside a command goroutine back to the event loop.
+type crashMsg struct {
+	value interface{}
+	stack []byte
+}
+
+// CrashGuard wraps the app model and recovers panics in Init, Update, View
+// and the commands they return. On a panic the program quits normally, so
+// bubbletea restores the terminal, and Crash reports what happened.
+type CrashGuard struct {
+	model   tea.Model
+	crash   *crashMsg
+	program *tea.Program
+}
+
+// NewCrashGuard wraps a model with panic recovery.
+func NewCrashGuard(m tea.Model) *CrashGuard {
+	return &CrashGuard{model: m}
+}
+
+// SetProgram lets the guard stop the program when View panics,
+// since View cannot return a quit command.
+func (g *CrashGuard) SetProgram(p *tea.Program) {
+	g.program = p
+}
+
+// Init implements tea.Model.
+func (g *CrashGuard) Init() (cmd tea.Cmd) {
+	defer g.recover(&cmd)
+	return g.wrap(g.model.Init())
+}
+
+// Update implements tea.Model.
+func (g *CrashGuard) Update(msg tea.Msg) (_ tea.Model, cmd tea.Cmd) {
+	if c, ok := msg.(crashMsg); ok {
+		g.crash = &c
+		return g, tea.Quit
+	}
+	if g.crash != nil {
+		return g, tea.Quit
+	}
+
+	defer g.recover(&cmd)
+	var inner tea.Cmd
+	g.model, inner = g.model.Update(msg)
+	return g, g.wrap(inner)
+}
+
+// View implements tea.Model.
+func (g *CrashGuard) View() (view string) {
+	if g.crash != nil {
+		return ""
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash = &crashMsg{value: r, stack: debug.Stack()}
+			view = ""
+			if g.program != nil {
+				go g.program.Quit()
+			}
+		}
+	}()
+	return g.model.View()
+}
+
+// recover turns a panic in the current call into a quit command.
+func (g *CrashGuard) recover(cmd *tea.Cmd) {
+	if r := recover(); r != nil {
+		g.crash = &crashMsg{value: r, stack: debug.Stack()}
+		*cmd = tea.Quit
+	}
+}
+
+// wrap makes a command report panics as crashMsg instead of killing the program.
+// Commands batched via tea.Batch are wrapped as they are unpacked.
+func (g *CrashGuard) wrap(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = crashMsg{value: r, stack: debug.Stack()}
+			}
+		}()
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			wrapped := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				wrapped[i] = g.wrap(c)
+			}
+			msg = wrapped
+		}
+		return msg
+	}
+}
+
+// Crashed reports whether a panic was recovered.
+func (g *CrashGuard) Crashed() bool {
+	return g.crash != nil
+}
+
+// WriteBundle saves a diagnostic bundle for the recovered panic and returns its path.
+func (g *CrashGuard) WriteBundle() (string, error) {
+	if g.crash == nil {
+		return "", fmt.Errorf("no crash to report")
+	}
+	bundle := diag.Bundle{Panic: g.crash.value, Stack: g.crash.stack}
+	if app, ok := g.model.(AppModel); ok {
+		bundle.Sections = app.diagnostics()
+	} else if app, ok := g.model.(*AppModel); ok {
+		bundle.Sections = app.diagnostics()
+	}
+	return bundle.Write("")
+}
+
+// diagnostics describes the app state for a crash bundle.
+// It must not panic itself, so every lookup tolerates missing components.
+func (m AppModel) diagnostics() (sections []diag.Section) {
+	defer func() {
+		if r := recover(); r != nil {
+			sections = append(sections, diag.Section{Title: "Diagnostics", Body: fmt.Sprintf("collection failed: %v", r)})
+		}
+	}()
+
+	var app strings.Builder
+	fmt.Fprintf(&app, "View: %d\nFocus: %d\nWindow: %dx%d\n", m.view, m.focus, m.width, m.height)
+	fmt.Fprintf(&app, "Dev mode: %t\nGuided mode: %t\n", m.devMode, m.guidedMode)
+	if m.selectedVersion >= 0 && m.selectedVersion < len(m.versions) {
+		fmt.Fprintf(&app, "Kubernetes version: %s\n", m.versions[m.selectedVersion].Version)
+	}
+	sections = append(sections, diag.Section{Title: "App", Body: app.String()})
+
+	var active strings.Builder
+	if m.engineInstance != nil {
+		fmt.Fprintf(&active, "Engine state: %s\n", m.engineInstance.GetState())
+	}
+	if s := m.currentScenario; s != nil {
+		meta := s.GetMetadata()
+		fmt.Fprintf(&active, "ID: %s\nName: %s\nNamespace: %s\n", meta.ID, meta.Name, s.GetNamespace())
+		if m.engineInstance != nil {
+			fmt.Fprintf(&active, "Elapsed: %s\n", m.engineInstance.GetElapsedTime().Round(time.Second))
+		}
+		fmt.Fprintf(&active, "Last check: solved=%t %s\n", m.lastCheckResult.Solved, m.lastCheckResult.Message)
+	} else {
+		active.WriteString("none\n")
+	}
+	sections = append(sections, diag.Section{Title: "Active scenario", Body: active.String()})
+
+	info := fmt.Sprintf("Kind cluster: %s\nKubeconfig: %s\n", m.dojoCluster().Name(), m.kubeconfig)
+	if m.k8sClient != nil {
+		info += diag.ClusterInfo(m.k8sClient.Clientset)
+	} else {
+		info += "not connected\n"
+	}
+	sections = append(sections, diag.Section{Title: "Cluster", Body: info})
+
+	return sections
+}
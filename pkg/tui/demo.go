@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+const demoLabel = "🎬 Demo"
+
+const (
+	// demoStartDelay gives the shell time to print its prompt before typing starts.
+	demoStartDelay = 2 * time.Second
+
+	// demoStepPause leaves each command's output on screen before the next one is typed.
+	demoStepPause = 3 * time.Second
+)
+
+// demoState tracks the replay of a scenario's solution in demo mode.
+type demoState struct {
+	scenarioID  string
+	typingDelay time.Duration // Delay between typed characters
+	steps       []scenario.DemoStep
+	step        int // Index of the command being typed
+	typed       int // Characters of the current command typed so far
+}
+
+type demoTickMsg struct{}
+
+// EnableDemoMode skips the menus, starts the scenario on the default version and
+// types its canonical solution into the terminal with captions.
+func (m *AppModel) EnableDemoMode(scenarioID string, typingDelay time.Duration) {
+	m.demo = &demoState{scenarioID: scenarioID, typingDelay: typingDelay}
+	m.prepareBootstrap()
+}
+
+// startDemoScenario starts the demo scenario once the cluster is ready.
+func (m AppModel) startDemoScenario() (tea.Model, tea.Cmd) {
+	s := m.registry.Get(m.demo.scenarioID)
+	if s == nil {
+		m.bootstrapErr = fmt.Errorf("scenario not found: %s", m.demo.scenarioID)
+		return m, nil
+	}
+	m.currentScenario = s
+	return m.startSelectedScenario(s)
+}
+
+// playDemo loads the demo script of the started scenario and schedules the first keystroke.
+func (m *AppModel) playDemo() tea.Cmd {
+	if m.demo == nil || m.currentScenario == nil {
+		return nil
+	}
+	d := m.demo
+	d.steps = scenario.DemoScript(m.currentScenario)
+	d.step, d.typed = 0, 0
+	if len(d.steps) == 0 {
+		m.content.SetNote(demoLabel, "This scenario has no demo script.")
+		return nil
+	}
+	m.content.SetNote(demoLabel, d.caption())
+	return demoTick(demoStartDelay)
+}
+
+// handleDemoTick types the next character, or submits the command and moves on.
+func (m AppModel) handleDemoTick() (tea.Model, tea.Cmd) {
+	d := m.demo
+	if d == nil || m.view != ViewScenarioRunning || d.step >= len(d.steps) {
+		return m, nil
+	}
+
+	command := d.steps[d.step].Command
+	if d.typed < len(command) {
+		m.terminal.SendInput(command[d.typed : d.typed+1])
+		d.typed++
+		return m, demoTick(d.typingDelay)
+	}
+
+	m.terminal.SendInput("\r")
+	d.step++
+	d.typed = 0
+	if d.step == len(d.steps) {
+		m.content.SetNote(demoLabel, "Solution replayed. Waiting for the check to pass...")
+		return m, nil
+	}
+	m.content.SetNote(demoLabel, d.caption())
+	return m, demoTick(demoStepPause)
+}
+
+// caption describes the current step, e.g. "Step 2/4: Read the events".
+func (d *demoState) caption() string {
+	return fmt.Sprintf("Step %d/%d: %s", d.step+1, len(d.steps), d.steps[d.step].Caption)
+}
+
+func demoTick(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return demoTickMsg{}
+	})
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// focusNames label the panels in the statusbar's focus legend.
+var focusNames = map[FocusArea]string{
+	FocusSidebar:  "Sidebar",
+	FocusContent:  "Info",
+	FocusTerminal: "Terminal",
+}
+
+// focusOrder returns the panels Tab cycles through in the current view.
+// Views with a single focus, and the scenario view while an answer is typed or
+// the cheat sheet is open, have none.
+func (m AppModel) focusOrder() []FocusArea {
+	if m.view == ViewScenarioRunning && !m.answering && !m.cheatSheetOpen {
+		return []FocusArea{FocusSidebar, FocusContent, FocusTerminal}
+	}
+	return nil
+}
+
+// setFocus focuses a panel and restyles the panels to match.
+func (m *AppModel) setFocus(area FocusArea) {
+	m.focus = area
+	m.sidebar.SetFocus(area == FocusSidebar)
+	m.content.SetFocus(area == FocusContent)
+	m.terminal.SetFocus(area == FocusTerminal)
+}
+
+// handleFocusKey moves the focus on Tab and, backwards, Shift+Tab. The key is
+// consumed, so a Tab that leaves a panel never reaches the one it enters.
+func (m *AppModel) handleFocusKey(msg tea.KeyMsg) bool {
+	order := m.focusOrder()
+	if len(order) == 0 {
+		return false
+	}
+	step := 0
+	switch {
+	case key.Matches(msg, m.keymap.Tab):
+		step = 1
+	case key.Matches(msg, m.keymap.ShiftTab):
+		step = len(order) - 1
+	default:
+		return false
+	}
+	current := 0
+	for i, area := range order {
+		if area == m.focus {
+			current = i
+		}
+	}
+	m.setFocus(order[(current+step)%len(order)])
+	return true
+}
+
+// focusLegend returns the panels of the current view and the focused one's
+// index, for the statusbar.
+func (m AppModel) focusLegend() ([]string, int) {
+	var names []string
+	active := -1
+	for i, area := range m.focusOrder() {
+		names = append(names, focusNames[area])
+		if area == m.focus {
+			active = i
+		}
+	}
+	return names, active
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/guard"
+)
+
+// guardMsg delivers a guardrail violation, or the error that stopped the watcher.
+type guardMsg struct {
+	violation guard.Violation
+	err       error
+}
+
+// EnableGuardrails warns when nodes or objects in system namespaces are deleted
+// during the session.
+func (m *AppModel) EnableGuardrails() {
+	m.guardrails = true
+}
+
+// watchGuardrails starts the guardrail watcher and returns a command that
+// delivers its violations.
+func (m *AppModel) watchGuardrails() tea.Cmd {
+	if !m.guardrails {
+		return nil
+	}
+	m.violations = make(chan guardMsg, 16)
+	violations := m.violations
+	opts := guard.DefaultOptions()
+	opts.OnViolation = func(v guard.Violation) {
+		select {
+		case violations <- guardMsg{violation: v}:
+		default: // The user is already being warned
+		}
+	}
+	w := guard.New(m.k8sClient.Clientset, opts)
+	go func() {
+		if err := w.Start(context.Background()); err != nil {
+			violations <- guardMsg{err: err}
+		}
+	}()
+	return m.waitForViolation()
+}
+
+func (m AppModel) waitForViolation() tea.Cmd {
+	violations := m.violations
+	return func() tea.Msg {
+		return <-violations
+	}
+}
+
+func (m AppModel) handleGuard(msg guardMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Guardrails unavailable: %v", msg.err))
+		return m, nil
+	}
+	m.statusbar.SetMessage(fmt.Sprintf("⛔ %s outside the scenario", msg.violation))
+	if m.currentScenario != nil {
+		m.content.SetNote("⛔ Guardrails", fmt.Sprintf(
+			"%s. It is outside namespace %s, so other scenarios may break too. "+
+				"If the cluster misbehaves, run `kind delete cluster --name %s` and restart k8s-dojo.",
+			msg.violation, m.currentScenario.GetNamespace(), m.dojoCluster().Name()))
+	}
+	return m, m.waitForViolation()
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/cluster"
+)
+
+const (
+	// healthInterval is how often the API server is pinged.
+	healthInterval = 10 * time.Second
+
+	// healthTimeout bounds a single ping.
+	healthTimeout = 5 * time.Second
+)
+
+type healthTickMsg time.Time
+
+type healthResultMsg struct {
+	err error
+}
+
+type reconnectDoneMsg struct {
+	kubeconfig string
+	err        error
+}
+
+// tickHealth schedules the next API server ping.
+func (m AppModel) tickHealth() tea.Cmd {
+	return tea.Tick(healthInterval, func(t time.Time) tea.Msg {
+		return healthTickMsg(t)
+	})
+}
+
+// pingCluster checks that the API server still answers.
+func (m AppModel) pingCluster() tea.Cmd {
+	client := m.k8sClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+		defer cancel()
+		return healthResultMsg{err: client.Ping(ctx)}
+	}
+}
+
+// reconnect restarts stopped node containers (and the SSH tunnel in remote mode)
+// and fetches a fresh kubeconfig.
+func (m AppModel) reconnect() tea.Cmd {
+	remote, manager := m.remote, m.dojoCluster()
+	return func() tea.Msg {
+		kubeconfig, err := manager.Reconnect()
+		if err == nil && remote != nil {
+			kubeconfig, err = remote.Connect(context.Background(), kubeconfig)
+		}
+		return reconnectDoneMsg{kubeconfig: kubeconfig, err: err}
+	}
+}
+
+func (m AppModel) handleHealthResult(msg healthResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err == nil {
+		if m.connLost {
+			m.connLost = false
+			m.refreshBanner()
+			m.statusbar.SetMessage("Reconnected to cluster")
+		}
+		return m, m.tickHealth()
+	}
+
+	m.connLost = true
+	if m.reconnecting {
+		return m, m.tickHealth()
+	}
+	m.reconnecting = true
+	m.header.SetBanner("⚠ Cluster unreachable, reconnecting...")
+	return m, m.reconnect()
+}
+
+func (m AppModel) handleReconnectDone(msg reconnectDoneMsg) (tea.Model, tea.Cmd) {
+	m.reconnecting = false
+	if msg.err != nil {
+		m.header.SetBanner("⚠ Cluster unreachable, retrying...")
+		if errors.Is(msg.err, cluster.ErrDockerDown) {
+			m.header.SetBanner("⚠ Docker is not running, start it to reconnect...")
+		}
+		m.statusbar.SetMessage(fmt.Sprintf("Reconnect failed: %v", msg.err))
+		return m, m.tickHealth()
+	}
+
+	if err := m.k8sClient.Reload(msg.kubeconfig); err != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Reconnect failed: %v", err))
+		return m, m.tickHealth()
+	}
+	m.kubeconfig = msg.kubeconfig
+	if m.assistant != nil {
+		m.assistant.Secrets = append(m.assistant.Secrets, msg.kubeconfig)
+	}
+
+	// Learner credentials are reissued against the new connection instead
+	if restrict := m.restrictTerminal(); restrict != nil && m.learnerNamespace != "" {
+		return m, tea.Batch(restrict, m.tickHealth())
+	}
+	m.learnerNamespace = ""
+	if err := m.terminal.UpdateKubeconfig(msg.kubeconfig); err != nil {
+		m.statusbar.SetMessage(err.Error())
+	}
+	return m, m.tickHealth()
+}
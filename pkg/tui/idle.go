@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultIdleCleanup is how long without input before a forgotten scenario is
+// cleaned up.
+const DefaultIdleCleanup = 4 * time.Hour
+
+// IdlePolicy cleans up scenarios left running without input, so a forgotten
+// dojo doesn't keep a laptop busy overnight.
+type IdlePolicy struct {
+	After        time.Duration // Inactivity before cleanup, 0 disables
+	PauseCluster bool          // Also pause the cluster and quit
+}
+
+// SetIdlePolicy sets what happens to scenarios left running without input.
+func (m *AppModel) SetIdlePolicy(p IdlePolicy) {
+	m.idlePolicy = p
+}
+
+// idleExpired reports whether scenario resources have outlived the idle policy.
+func (m AppModel) idleExpired() bool {
+	if m.idlePolicy.After <= 0 || m.lastInput.IsZero() || m.quitting {
+		return false
+	}
+	return len(m.leftovers()) > 0 && time.Since(m.lastInput) > m.idlePolicy.After
+}
+
+// cleanupIdle tears down the running and explored scenarios after inactivity,
+// returning to the dashboard, or pauses the cluster and quits if the policy says so.
+func (m AppModel) cleanupIdle() (tea.Model, tea.Cmd) {
+	var names []string
+	for _, s := range m.leftovers() {
+		names = append(names, s.GetMetadata().Name)
+	}
+	idle := time.Since(m.lastInput).Round(time.Minute)
+
+	if m.idlePolicy.PauseCluster {
+		m.pauseOnQuit = true
+		m.quitting = true
+		return m, m.cleanup()
+	}
+
+	var cmds []tea.Cmd
+	if m.view == ViewSuccess {
+		model, cmd := m.handleReturnToDashboard()
+		m = model.(AppModel)
+		cmds = append(cmds, cmd)
+	} else if m.currentScenario != nil {
+		cmds = append(cmds, m.leaveScenario())
+	}
+	cmds = append(cmds, m.cleanupExplored(), m.pingCluster())
+	m.statusbar.SetMessage(fmt.Sprintf("Cleaned up %s after %s without input.", strings.Join(names, ", "), idle))
+	return m, tea.Batch(cmds...)
+}
@@ -1,7 +1,11 @@
 // Package tui provides keybinding definitions for the terminal user interface.
 package tui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"k8s-dojo/pkg/tui/components"
+)
 
 // KeyMap defines all keybindings for the application.
 type KeyMap struct {
@@ -26,16 +30,33 @@ type KeyMap struct {
 	Enter  key.Binding
 	Search key.Binding
 
+	// Dashboard
+	PauseCluster key.Binding
+	Stats        key.Binding
+	WhatsNew     key.Binding
+	CleanupKept  key.Binding
+
 	// Scenario Running
 	Check       key.Binding
 	ToggleHints key.Binding
 	NextHint    key.Binding
 	PrevHint    key.Binding
 	CopyCommand key.Binding
+	RunCommand  key.Binding
+	Explain     key.Binding
+	Assistant   key.Binding
+	Reset       key.Binding
+	Answer      key.Binding
+	Shell       key.Binding
+	Pause       key.Binding
+	Docs        key.Binding
+	CheatSheet  key.Binding
 
 	// Success View
-	Retry      key.Binding
-	ReturnMenu key.Binding
+	Retry       key.Binding
+	ReturnMenu  key.Binding
+	KeepEnv     key.Binding
+	Walkthrough key.Binding
 }
 
 // DefaultKeyMap returns the default keybindings.
@@ -107,6 +128,24 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("/", "search"),
 		),
 
+		// Dashboard
+		PauseCluster: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pause cluster & quit"),
+		),
+		Stats: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "skill stats"),
+		),
+		WhatsNew: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "what's new"),
+		),
+		CleanupKept: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "clean up kept scenario"),
+		),
+
 		// Scenario Running
 		Check: key.NewBinding(
 			key.WithKeys("c"),
@@ -128,6 +167,42 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("y"),
 			key.WithHelp("y", "copy"),
 		),
+		RunCommand: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "run quick command"),
+		),
+		Explain: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "explain error"),
+		),
+		Assistant: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "ask assistant"),
+		),
+		Reset: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "reset scenario"),
+		),
+		Answer: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "submit answer"),
+		),
+		Shell: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "native shell"),
+		),
+		Pause: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "pause timer"),
+		),
+		Docs: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "open docs"),
+		),
+		CheatSheet: key.NewBinding(
+			key.WithKeys("K", "f1"),
+			key.WithHelp("K/F1", "kubectl cheat sheet"),
+		),
 
 		// Success View
 		Retry: key.NewBinding(
@@ -138,6 +213,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("m"),
 			key.WithHelp("m", "menu"),
 		),
+		KeepEnv: key.NewBinding(
+			key.WithKeys("k"),
+			key.WithHelp("k", "keep exploring"),
+		),
+		Walkthrough: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "walkthrough"),
+		),
 	}
 }
 
@@ -177,5 +260,18 @@ func (k KeyMap) ScenarioRunningKeys() []key.Binding {
 
 // SuccessKeys returns keybindings for success view.
 func (k KeyMap) SuccessKeys() []key.Binding {
-	return []key.Binding{k.Enter, k.Retry, k.ReturnMenu, k.Quit}
+	return []key.Binding{k.Enter, k.Retry, k.ReturnMenu, k.KeepEnv, k.Walkthrough, k.Quit}
+}
+
+// SuccessKeyMap returns the bindings of the success screen's buttons.
+func (k KeyMap) SuccessKeyMap() components.SuccessKeyMap {
+	return components.SuccessKeyMap{
+		Prev:          []key.Binding{k.Left, k.ShiftTab, k.Up},
+		Next:          []key.Binding{k.Right, k.Tab, k.Down},
+		Choose:        k.Enter,
+		Continue:      []key.Binding{k.ReturnMenu, k.Escape},
+		Retry:         k.Retry,
+		KeepExploring: k.KeepEnv,
+		Walkthrough:   k.Walkthrough,
+	}
 }
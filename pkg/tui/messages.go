@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"time"
+
+	"k8s-dojo/pkg/cluster"
+	"k8s-dojo/pkg/scenario"
+)
+
+// Messages of the app's core flow. Features with their own file (co-op,
+// health, proctor, ...) declare their messages next to the code that sends
+// them.
+
+type bootstrapDoneMsg struct {
+	kubeconfig string
+	err        error
+	warnings   []string // Preflight warnings about host resources
+}
+
+type clusterStatusMsg struct {
+	status cluster.Status
+}
+
+type scenariosReloadedMsg struct {
+	err error
+}
+
+type scenarioStartedMsg struct {
+	err error
+}
+
+type scenarioResetMsg struct {
+	err error
+}
+
+type checkResultMsg struct {
+	result    scenario.Result
+	guideStep int
+	loop      int // Auto-check loop current when the check started
+}
+
+// tickMsg fires the auto-check loop identified by loop.
+type tickMsg struct {
+	loop int
+}
+
+type progressTickMsg time.Time
+type finalDelayMsg time.Time
+
+type assistantHintMsg struct {
+	scenarioID string
+	hint       string
+	err        error
+}
+
+type cleanupDoneMsg struct {
+	scenarioID string
+	err        error
+}
@@ -0,0 +1,27 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultIdlePause is how long without input pauses the scenario timer.
+const DefaultIdlePause = 5 * time.Minute
+
+// pause stops the scenario timer and auto-checks as of at. The next key
+// press resumes them.
+func (m *AppModel) pause(at time.Time) {
+	m.engineInstance.Pause(at)
+	if !m.engineInstance.Paused() {
+		return // Nothing running, or already solved
+	}
+	m.header.PauseTimer(at)
+	m.statusbar.SetMessage("⏸ Paused. Press any key to resume.")
+}
+
+// resume restarts the timer and auto-checks after a pause.
+func (m *AppModel) resume() {
+	away := m.engineInstance.Resume()
+	m.header.ResumeTimer()
+	m.statusbar.SetMessage(fmt.Sprintf("Resumed after %s away, the timer didn't count it.", away.Round(time.Second)))
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/cluster"
+	"k8s-dojo/pkg/proctor"
+)
+
+const (
+	// proctorInterval is how often a classroom session publishes its status
+	// and picks up hints from the instructor.
+	proctorInterval = 2 * time.Second
+
+	// proctorCommands is how many recent kubectl commands are published.
+	proctorCommands = 5
+
+	// noticeDuration is how long a notice stays in the header banner.
+	noticeDuration = 5 * time.Minute
+
+	instructorLabel = "📣 From your instructor"
+)
+
+type proctorTickMsg time.Time
+
+type proctorInboxMsg struct {
+	msgs []proctor.Message
+	err  error
+}
+
+// EnableSession runs the dojo as a classroom session on the given fleet
+// cluster, publishing its status to board for the instructor's live view.
+func (m *AppModel) EnableSession(session, clusterName string, board *proctor.Board) {
+	m.session = session
+	m.clusterName = clusterName
+	m.proctor = board
+}
+
+// dojoCluster returns the manager of the dojo's cluster: the session's
+// fleet cluster, or the usual k8s-dojo one.
+func (m AppModel) dojoCluster() *cluster.Manager {
+	opts := cluster.DefaultManagerOptions()
+	opts.Name = m.clusterName
+	return cluster.NewManagerWithOptions(opts)
+}
+
+// tickProctor schedules the next status update, if this is a classroom session.
+func (m AppModel) tickProctor() tea.Cmd {
+	if m.proctor == nil {
+		return nil
+	}
+	return tea.Tick(proctorInterval, func(t time.Time) tea.Msg {
+		return proctorTickMsg(t)
+	})
+}
+
+// proctorStatus is the session's current status.
+func (m AppModel) proctorStatus() proctor.Status {
+	status := proctor.Status{Session: m.session, Cluster: m.dojoCluster().Name(), Updated: time.Now()}
+	if m.currentScenario != nil && m.view != ViewDashboard {
+		meta := m.currentScenario.GetMetadata()
+		status.Scenario = meta.ID
+		status.Name = meta.Name
+		status.Check = m.lastCheckResult.Message
+		status.Solved = m.lastCheckResult.Solved
+		if m.engineInstance != nil {
+			status.Elapsed = m.engineInstance.GetElapsedTime()
+		}
+		status.Commands = m.terminal.RecentCommands(proctorCommands)
+	}
+	return status
+}
+
+// syncProctor publishes the session's status and takes the messages pushed to it.
+func (m AppModel) syncProctor() tea.Cmd {
+	board, status := m.proctor, m.proctorStatus()
+	return func() tea.Msg {
+		if err := board.Publish(status); err != nil {
+			return proctorInboxMsg{err: err}
+		}
+		msgs, err := board.Take(status.Session)
+		return proctorInboxMsg{msgs: msgs, err: err}
+	}
+}
+
+// handleProctorInbox shows the messages pushed to the session: notices in the
+// header banner, hints in the scenario panel.
+func (m AppModel) handleProctorInbox(msg proctorInboxMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Instructor view unavailable: %v", msg.err))
+	}
+	for _, in := range msg.msgs {
+		switch {
+		case in.Kind == proctor.KindNotice:
+			m.notice = in.Text
+			if in.From != "" {
+				m.notice = in.From + ": " + in.Text
+			}
+			m.noticeUntil = time.Now().Add(noticeDuration)
+		case m.view == ViewScenarioRunning:
+			m.content.SetNote(instructorLabel, in.Text)
+		default:
+			m.statusbar.SetMessage(instructorLabel + ": " + in.Text)
+		}
+	}
+	m.refreshBanner() // Also drops an expired notice
+	return m, m.tickProctor()
+}
+
+// activeNotice returns the notice to show in the header, "" once it expired.
+func (m AppModel) activeNotice() string {
+	if time.Now().After(m.noticeUntil) {
+		return ""
+	}
+	return m.notice
+}
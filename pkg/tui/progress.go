@@ -0,0 +1,33 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// setupProgressMsg carries a setup step of the scenario being started.
+type setupProgressMsg struct {
+	step string
+}
+
+// listenProgress relays the engine's setup steps to the content status. One
+// listener serves every scenario start, so it is only started once.
+func (m *AppModel) listenProgress() tea.Cmd {
+	if m.progressListening || m.engineInstance == nil {
+		return nil
+	}
+	m.progressListening = true
+	return m.nextProgress()
+}
+
+// nextProgress waits for the next setup step.
+func (m AppModel) nextProgress() tea.Cmd {
+	progress := m.engineInstance.Progress()
+	return func() tea.Msg {
+		return setupProgressMsg{step: <-progress}
+	}
+}
+
+func (m AppModel) handleSetupProgress(msg setupProgressMsg) (tea.Model, tea.Cmd) {
+	if m.starting && m.view == ViewScenarioRunning {
+		m.content.SetStatus("Setting up scenario environment: "+msg.step, false)
+	}
+	return m, m.nextProgress()
+}
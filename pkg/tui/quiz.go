@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+const answerLabel = "✍️ Your answer"
+
+// scenarioDescription is the scenario's description, followed by its question for quiz scenarios.
+func scenarioDescription(s scenario.Scenario) string {
+	description := s.GetMetadata().Description
+	if q, ok := s.(scenario.Quiz); ok {
+		description += "\n\n❓ " + q.Question() + " (press s to answer)"
+	}
+	return description
+}
+
+// startAnswer opens the answer field for the running quiz scenario.
+func (m AppModel) startAnswer() (tea.Model, tea.Cmd) {
+	if _, ok := m.currentScenario.(scenario.Quiz); !ok {
+		return m, nil
+	}
+	m.answerInput = textinput.New()
+	m.answerInput.Placeholder = "Type your answer"
+	m.answerInput.CharLimit = 256
+	m.answerInput.SetValue(m.engineInstance.Answer())
+	m.answering = true
+	cmd := m.answerInput.Focus()
+	m.content.SetNote(answerLabel, m.answerInput.View()+"\n\nenter: submit • esc: cancel")
+	return m, cmd
+}
+
+// updateAnswer handles input while the answer field is open. Enter submits
+// the answer and checks the scenario; Escape closes the field.
+func (m AppModel) updateAnswer(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, m.keymap.Enter):
+			m.answering = false
+			m.engineInstance.SubmitAnswer(m.answerInput.Value())
+			m.content.SetNote(answerLabel, m.engineInstance.Answer())
+			m.content.SetStatus("Checking your answer...", false)
+			m.checking = true // Not deduplicated: a check already running predates the answer
+			return m, m.checkScenario()
+		case key.Matches(keyMsg, m.keymap.Escape):
+			m.answering = false
+			m.content.SetNote("", "")
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.answerInput, cmd = m.answerInput.Update(msg)
+	m.content.SetNote(answerLabel, m.answerInput.View()+"\n\nenter: submit • esc: cancel")
+	return m, cmd
+}
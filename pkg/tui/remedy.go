@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s-dojo/pkg/cluster"
+	"k8s-dojo/pkg/engine"
+)
+
+// remediation returns what the user can do about a known failure, or ""
+// when there is no specific advice.
+func remediation(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, cluster.ErrDockerDown):
+		return "Start Docker Desktop, or the daemon with `sudo systemctl start docker`, check that `docker info` works, then restart k8s-dojo."
+	case errors.Is(err, cluster.ErrVersionUnsupported):
+		var versions []string
+		for _, v := range cluster.SupportedVersions() {
+			versions = append(versions, v.Version)
+		}
+		return fmt.Sprintf("Pick one of the supported versions: %s.", strings.Join(versions, ", "))
+	case errors.Is(err, engine.ErrImagePullTimeout):
+		return "The images are still downloading, which is common on a slow network or the first run. They keep pulling in the background: press R to try again in a minute."
+	case errors.Is(err, engine.ErrNamespaceTerminating):
+		return "The namespace of an earlier attempt is still being deleted. Wait a few seconds and press R. If it stays Terminating, `kubectl get ns -o wide` shows it; finalizers are the usual suspect."
+	}
+	return ""
+}
+
+// withRemediation appends the advice for err to msg, if there is any.
+func withRemediation(msg string, err error) string {
+	if hint := remediation(err); hint != "" {
+		return msg + "\n💡 " + hint
+	}
+	return msg
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/rbac"
+)
+
+// restrictedMsg carries learner credentials for the running scenario's namespace.
+type restrictedMsg struct {
+	namespace  string
+	kubeconfig string
+	err        error
+}
+
+// EnableRestrictedMode runs the terminal's kubectl as a ServiceAccount limited
+// to the scenario namespace, with read-only access to the rest of the cluster.
+// Scenarios that need cluster-wide changes keep full access.
+func (m *AppModel) EnableRestrictedMode() {
+	m.restricted = true
+}
+
+// restrictTerminal issues learner credentials for the current scenario, or
+// returns nil when restricted mode doesn't apply to it.
+func (m AppModel) restrictTerminal() tea.Cmd {
+	s := m.currentScenario
+	if !m.restricted || m.remote != nil || s == nil || s.GetMetadata().ClusterAccess {
+		return nil
+	}
+	clientset, admin, namespace := m.k8sClient.Clientset, m.kubeconfig, s.GetNamespace()
+	return func() tea.Msg {
+		kubeconfig, err := rbac.Provision(context.Background(), clientset, admin, namespace)
+		return restrictedMsg{namespace: namespace, kubeconfig: kubeconfig, err: err}
+	}
+}
+
+func (m AppModel) handleRestricted(msg restrictedMsg) (tea.Model, tea.Cmd) {
+	if m.currentScenario == nil || m.currentScenario.GetNamespace() != msg.namespace {
+		return m, nil // The scenario ended while the credentials were issued
+	}
+	if msg.err != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("⚠ Restricted mode unavailable, kubectl has full access: %v", msg.err))
+		return m, nil
+	}
+	if err := m.terminal.UpdateKubeconfig(msg.kubeconfig); err != nil {
+		m.statusbar.SetMessage(err.Error())
+		return m, nil
+	}
+	m.learnerNamespace = msg.namespace
+	m.statusbar.SetMessage(fmt.Sprintf("🔒 kubectl can only change namespace %s", msg.namespace))
+	return m, nil
+}
+
+// unrestrictTerminal gives the terminal full access again once the namespace
+// its learner credentials belong to is cleaned up.
+func (m *AppModel) unrestrictTerminal(namespace string) {
+	if m.learnerNamespace == "" || m.learnerNamespace != namespace {
+		return
+	}
+	m.learnerNamespace = ""
+	if err := m.terminal.UpdateKubeconfig(m.kubeconfig); err != nil {
+		m.statusbar.SetMessage(err.Error())
+	}
+}
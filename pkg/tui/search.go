@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/scenario"
+)
+
+// startSearch opens the search field on the dashboard, keeping the current query.
+func (m AppModel) startSearch() (tea.Model, tea.Cmd) {
+	m.searchInput = textinput.New()
+	m.searchInput.Placeholder = "dns, tag:cka, probes..."
+	m.searchInput.CharLimit = 64
+	m.searchInput.SetValue(m.searchQuery)
+	m.searching = true
+	return m, m.searchInput.Focus()
+}
+
+// updateSearch handles input while the search field is open. The sidebar is
+// filtered as the query is typed; Enter keeps the filter and Escape clears it.
+func (m AppModel) updateSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, m.keymap.Enter):
+			m.searching = false
+			return m, nil
+		case key.Matches(keyMsg, m.keymap.Escape):
+			m.searching = false
+			m.setSearchQuery("")
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	if m.searchInput.Value() != m.searchQuery {
+		m.setSearchQuery(m.searchInput.Value())
+	}
+	return m, cmd
+}
+
+// setSearchQuery filters the sidebar by query, "" for every scenario.
+func (m *AppModel) setSearchQuery(query string) {
+	m.searchQuery = query
+	m.buildSidebarItems()
+}
+
+// listedScenarios returns the scenarios matching the search query, best matches first.
+func (m AppModel) listedScenarios() []scenario.Scenario {
+	if m.searchQuery == "" {
+		return m.registry.List()
+	}
+	return scenario.NewIndex(m.registry.List()).Search(m.searchQuery)
+}
+
+// searchView renders the search line above the dashboard preview, "" when not searching.
+func (m AppModel) searchView() string {
+	switch {
+	case m.searching:
+		return "🔍 " + m.searchInput.View() + "\n" + m.styles.TextMuted.Render("enter: keep filter • esc: clear") + "\n\n"
+	case m.searchQuery != "":
+		return m.styles.TextMuted.Render("🔍 "+m.searchQuery+" (/ to edit, esc to clear)") + "\n\n"
+	}
+	return ""
+}
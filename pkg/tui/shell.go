@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// shellExitedMsg reports that the native shell opened with openShell exited.
+type shellExitedMsg struct {
+	kubeconfigPath string // Temporary kubeconfig to remove, "" for none
+	err            error
+}
+
+// openShell suspends the TUI and runs the terminal's shell in the real
+// terminal, for learners who prefer their own setup over the embedded one.
+// kubectl uses the embedded terminal's credentials with the scenario namespace
+// as its default; the dojo resumes when the shell exits.
+func (m AppModel) openShell() tea.Cmd {
+	if m.currentScenario == nil {
+		return nil
+	}
+	namespace := m.currentScenario.GetNamespace()
+	name, args := m.terminal.ShellCommand()
+
+	banner := fmt.Sprintf("k8s-dojo: namespace %s. Exit the shell to return to the dojo.", namespace)
+	env := os.Environ()
+	var path string
+	if m.remote == nil { // The remote shell already points at the host's kubeconfig
+		var err error
+		path, err = writeShellKubeconfig(m.terminal.Kubeconfig(), namespace)
+		if err != nil {
+			return func() tea.Msg { return shellExitedMsg{err: err} }
+		}
+		env = append(env, "KUBECONFIG="+path)
+		banner += "\nexport KUBECONFIG=" + path
+	}
+
+	// Print the banner from inside, since the TUI gives up the screen first
+	cmd := exec.Command("sh", append([]string{"-c", `printf '%s\n\n' "$1"; shift; exec "$@"`, "sh", banner, name}, args...)...)
+	cmd.Env = env
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return shellExitedMsg{kubeconfigPath: path, err: err}
+	})
+}
+
+func (m AppModel) handleShellExited(msg shellExitedMsg) (tea.Model, tea.Cmd) {
+	if msg.kubeconfigPath != "" {
+		_ = os.Remove(msg.kubeconfigPath)
+	}
+	var exitErr *exec.ExitError
+	if msg.err != nil && !errors.As(msg.err, &exitErr) { // A shell exits with its last command's status
+		m.statusbar.SetMessage(fmt.Sprintf("Native shell failed: %v", msg.err))
+		return m, nil
+	}
+	m.statusbar.SetMessage("Back in the dojo. Press c to check your fix.")
+	return m, nil
+}
+
+// writeShellKubeconfig writes kubeconfig to a temporary file with namespace
+// as the current context's default namespace, and returns its path.
+func writeShellKubeconfig(kubeconfig, namespace string) (string, error) {
+	config, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	if ctx, ok := config.Contexts[config.CurrentContext]; ok {
+		ctx.Namespace = namespace
+	}
+
+	f, err := os.CreateTemp("", "k8s-dojo-shell-*.kubeconfig")
+	if err != nil {
+		return "", fmt.Errorf("failed to create kubeconfig: %w", err)
+	}
+	f.Close()
+	if err := clientcmd.WriteToFile(*config, f.Name()); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	return f.Name(), nil
+}
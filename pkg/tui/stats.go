@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"k8s-dojo/pkg/skills"
+)
+
+const (
+	// statsBarWidth is the width of the completion bars.
+	statsBarWidth = 20
+
+	// statsRadarRadius is the radar chart's radius in rows.
+	statsRadarRadius = 6
+)
+
+// openStats switches to the statistics view, computed from the progress state.
+func (m AppModel) openStats() (tea.Model, tea.Cmd) {
+	m.skills = skills.ByCategory(m.registry.Metadata(), m.progress)
+	m.view = ViewStats
+	return m, nil
+}
+
+func (m AppModel) updateStats(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(keyMsg, m.keymap.Escape) || key.Matches(keyMsg, m.keymap.Stats) {
+			m.view = ViewDashboard
+		}
+	}
+	return m, nil
+}
+
+func (m AppModel) viewStats() string {
+	title := m.styles.Title.Render("📈 Skill Stats")
+
+	nameWidth := 0
+	for _, c := range m.skills {
+		nameWidth = max(nameWidth, len(c.Name))
+	}
+
+	var rows []string
+	var labels []string
+	var values []float64
+	completed, total := 0, 0
+	for _, c := range m.skills {
+		avg := "-"
+		if c.Solves > 0 {
+			avg = c.AverageSolve.String()
+		}
+		rows = append(rows, fmt.Sprintf("%s %s %s %s",
+			m.styles.Text.Render(fmt.Sprintf("%-*s", nameWidth, c.Name)),
+			m.styles.Highlight.Render(skills.Bar(c.Completion(), statsBarWidth)),
+			m.styles.Text.Render(fmt.Sprintf("%3.0f%% (%d/%d)", c.Completion()*100, c.Completed, c.Total)),
+			m.styles.TextMuted.Render("avg "+avg),
+		))
+		labels = append(labels, c.Name)
+		values = append(values, c.Completion())
+		completed += c.Completed
+		total += c.Total
+	}
+	summary := m.styles.TextMuted.Render(fmt.Sprintf("%d of %d scenarios completed", completed, total))
+	table := strings.Join(append([]string{title, summary, ""}, rows...), "\n")
+	if m.usage != nil && len(m.usage.Nodes) > 0 {
+		footprint := []string{"", m.styles.Title.Render("🐳 Dojo Footprint")}
+		for i, row := range usageRows(m.usage) {
+			style := m.styles.TextMuted
+			if i == 0 {
+				style = m.styles.Text
+			}
+			footprint = append(footprint, style.Render(row))
+		}
+		table += "\n" + strings.Join(footprint, "\n")
+	}
+
+	body := table
+	if len(labels) >= 3 {
+		radar := m.styles.Highlight.Render(skills.Radar(labels, values, statsRadarRadius))
+		if lipgloss.Width(table)+lipgloss.Width(radar)+4 <= m.width {
+			body = lipgloss.JoinHorizontal(lipgloss.Center, table, "    ", radar)
+		} else if lipgloss.Height(table)+lipgloss.Height(radar)+2 <= m.height {
+			body = lipgloss.JoinVertical(lipgloss.Left, table, "", radar)
+		}
+	}
+	help := m.styles.TextMuted.Render("s/esc back")
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Left, body, "", help))
+}
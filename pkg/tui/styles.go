@@ -1,7 +1,11 @@
 // Package tui provides derived styles from the theme.
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Styles provides pre-built styles using adaptive colors.
 type Styles struct {
@@ -168,12 +172,16 @@ func CategoryIcon(category string) string {
 		"Security":   "🔒",
 		"Storage":    "💾",
 		"Ops":        "⚙️",
+		"Operators":  "🧩",
 		"Resources":  "📊",
 		"Kernel":     "🐧",
 	}
 	if icon, ok := icons[category]; ok {
 		return icon
 	}
+	if strings.HasPrefix(category, "Pack: ") {
+		return "📦"
+	}
 	return "📁"
 }
 
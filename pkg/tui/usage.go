@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s-dojo/pkg/cluster"
+)
+
+const (
+	// usageInterval is how often the node containers' resource use is sampled.
+	usageInterval = 15 * time.Second
+
+	// usageWarnPercent is the share of the host's CPU or memory above which
+	// the meter is highlighted.
+	usageWarnPercent = 75
+)
+
+type usageTickMsg time.Time
+
+type usageMsg struct {
+	usage *cluster.Usage
+	err   error
+}
+
+// tickUsage schedules the next resource sample.
+func (m AppModel) tickUsage() tea.Cmd {
+	return tea.Tick(usageInterval, func(t time.Time) tea.Msg {
+		return usageTickMsg(t)
+	})
+}
+
+// sampleUsage reads the node containers' resource use with docker stats. A
+// remote cluster's containers aren't on this Docker host, so it isn't sampled.
+func (m AppModel) sampleUsage() tea.Cmd {
+	if m.remote != nil {
+		return nil
+	}
+	manager := m.dojoCluster()
+	return func() tea.Msg {
+		usage, err := manager.Usage()
+		return usageMsg{usage: usage, err: err}
+	}
+}
+
+func (m AppModel) handleUsage(msg usageMsg) (tea.Model, tea.Cmd) {
+	m.usage = msg.usage
+	if msg.err != nil {
+		m.statusbar.SetMeter("", false)
+		return m, m.tickUsage()
+	}
+	u := msg.usage
+	warn := u.HostCPUPercent() >= usageWarnPercent || u.HostMemoryPercent() >= usageWarnPercent
+	m.statusbar.SetMeter(usageMeter(u), warn)
+	return m, m.tickUsage()
+}
+
+// usageMeter renders the statusbar meter, e.g. "🐳 CPU 12% · RAM 1.9/7.7 GiB".
+func usageMeter(u *cluster.Usage) string {
+	if u.HostMemoryBytes <= 0 {
+		return fmt.Sprintf("🐳 CPU %.0f%% · RAM %s GiB", u.CPUPercent, gib(u.MemoryBytes))
+	}
+	return fmt.Sprintf("🐳 CPU %.0f%% · RAM %s/%s GiB", u.HostCPUPercent(), gib(u.MemoryBytes), gib(u.HostMemoryBytes))
+}
+
+// usageRows summarizes the resource use for the stats view, then lists it
+// for each node container.
+func usageRows(u *cluster.Usage) []string {
+	rows := []string{fmt.Sprintf("CPU %.0f%% of %d CPUs · RAM %s of %s GiB (%.0f%%)",
+		u.HostCPUPercent(), u.HostCPUs, gib(u.MemoryBytes), gib(u.HostMemoryBytes), u.HostMemoryPercent())}
+	width := 0
+	for _, n := range u.Nodes {
+		width = max(width, len(n.Name))
+	}
+	for _, n := range u.Nodes {
+		cpu := n.CPUPercent
+		if u.HostCPUs > 0 {
+			cpu /= float64(u.HostCPUs)
+		}
+		rows = append(rows, fmt.Sprintf("%-*s  CPU %5.1f%%  RAM %s GiB", width, n.Name, cpu, gib(n.MemoryBytes)))
+	}
+	return rows
+}
+
+func gib(n int64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/(1<<30)), ".0")
+}
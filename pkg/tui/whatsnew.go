@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"k8s-dojo/pkg/changelog"
+)
+
+// whatsNewMaxReleases caps the releases shown after a long-skipped upgrade.
+const whatsNewMaxReleases = 3
+
+// loadReleases reads the built-in changelog. After an upgrade it queues the
+// releases the user hasn't seen for the What's New view and starts the NEW
+// badge of the scenarios they added; a fresh install starts with nothing new.
+func (m *AppModel) loadReleases() {
+	releases, err := changelog.Load()
+	if err != nil || len(releases) == 0 {
+		return
+	}
+	m.releases = releases
+
+	latest := releases[0].Version
+	if m.progress.LastSeenRelease == latest {
+		return
+	}
+	m.whatsNew = changelog.Since(releases, m.progress.LastSeenRelease)
+	scenarios := changelog.Scenarios(m.whatsNew)
+	now := time.Now()
+	m.progress.SeeRelease(latest, scenarios, now)
+	if m.stateManager != nil {
+		_ = m.stateManager.MarkReleaseSeen(latest, scenarios, now)
+	}
+}
+
+// openWhatsNew shows the latest release's notes.
+func (m AppModel) openWhatsNew() (tea.Model, tea.Cmd) {
+	m.whatsNew = m.releases[:1]
+	m.view = ViewWhatsNew
+	return m, nil
+}
+
+func (m AppModel) updateWhatsNew(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if key.Matches(keyMsg, m.keymap.Escape) || key.Matches(keyMsg, m.keymap.Enter) || key.Matches(keyMsg, m.keymap.WhatsNew) {
+			m.whatsNew = nil
+			m.view = ViewDashboard
+		}
+	}
+	return m, nil
+}
+
+func (m AppModel) viewWhatsNew() string {
+	lines := []string{m.styles.Title.Render("✨ What's New")}
+	releases := m.whatsNew
+	if len(releases) > whatsNewMaxReleases {
+		releases = releases[:whatsNewMaxReleases]
+	}
+	for _, r := range releases {
+		heading := "v" + r.Version
+		if r.Date != "" {
+			heading += " · " + r.Date
+		}
+		lines = append(lines, "", m.styles.Highlight.Render(heading))
+		for _, f := range r.Features {
+			lines = append(lines, m.styles.Text.Render("  • "+f))
+		}
+		if len(r.Scenarios) > 0 {
+			lines = append(lines, m.styles.Text.Render("  New scenarios:"))
+			for _, id := range r.Scenarios {
+				name := id
+				if s := m.registry.Get(id); s != nil {
+					name = s.GetMetadata().Name
+				}
+				lines = append(lines, m.styles.TextMuted.Render("    NEW "+name))
+			}
+		}
+	}
+	if len(m.whatsNew) > len(releases) {
+		lines = append(lines, "", m.styles.TextMuted.Render("… and older releases"))
+	}
+	help := m.styles.TextMuted.Render("enter/esc back · w shows this again")
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Left, strings.Join(lines, "\n"), "", help))
+}
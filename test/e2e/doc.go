@@ -0,0 +1,7 @@
+// Package e2e runs every scenario end to end against a Kind cluster:
+// setup, a failing validation, the reference Solve, a passing validation and
+// cleanup. The tests need Docker and are behind the e2e build tag:
+//
+//	go test -tags e2e -timeout 60m ./test/e2e/
+//	go test -tags e2e ./test/e2e/ -run 'TestScenarios/image-pull-backoff' -e2e.keep
+package e2e
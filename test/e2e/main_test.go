@@ -0,0 +1,57 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"k8s-dojo/pkg/cluster"
+	"k8s-dojo/pkg/k8s"
+)
+
+var (
+	version = flag.String("e2e.k8s-version", "", "Kubernetes version for a new cluster (default: latest supported)")
+	keep    = flag.Bool("e2e.keep", false, "Keep a scenario's resources when one of its steps fails")
+)
+
+// client is the shared cluster fixture, connected once for all tests.
+var client *k8s.Client
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	var err error
+	client, err = connect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// connect reuses the dojo cluster, or creates it when there is none.
+func connect() (*k8s.Client, error) {
+	manager := cluster.NewManager()
+	exists, err := manager.ClusterExists()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check cluster existence: %w", err)
+	}
+
+	var kubeconfig string
+	if exists {
+		kubeconfig, err = manager.Reconnect()
+	} else {
+		var v cluster.SupportedVersion
+		if v, err = cluster.ParseVersion(*version); err != nil {
+			return nil, err
+		}
+		kubeconfig, err = manager.EnsureCluster(v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	return k8s.NewClientFromKubeconfig(kubeconfig)
+}
@@ -0,0 +1,85 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"k8s-dojo/pkg/engine"
+	"k8s-dojo/pkg/harness"
+	"k8s-dojo/pkg/scenario"
+)
+
+// TestScenarios runs each scenario as a subtest with one subtest per step.
+// Scenarios that get a fresh namespace per attempt run in parallel; those
+// that change cluster-scoped resources run one at a time first.
+func TestScenarios(t *testing.T) {
+	for _, s := range scenario.NewRegistry(client).List() {
+		meta := s.GetMetadata()
+		_, namespaced := s.(scenario.NamespaceAllocator)
+		parallel := namespaced && !meta.ClusterAccess
+
+		t.Run(meta.ID, func(t *testing.T) {
+			if parallel {
+				t.Parallel()
+			}
+			runScenario(t, meta.ID)
+		})
+	}
+}
+
+// runScenario steps through one scenario on an engine of its own, so
+// parallel scenarios don't share state.
+func runScenario(t *testing.T, id string) {
+	ctx := context.Background()
+	eng := engine.NewEngine(scenario.NewRegistry(client), client.Clientset)
+	opts := harness.DefaultOptions()
+
+	ok := t.Run("setup", func(t *testing.T) {
+		if err := eng.StartScenario(ctx, id); err != nil {
+			t.Fatalf("failed to start scenario: %v", err)
+		}
+	}) && t.Run("validate-negative", func(t *testing.T) {
+		res, err := eng.Check(ctx)
+		if err != nil {
+			t.Fatalf("failed to validate: %v", err)
+		}
+		// A pass still being confirmed is just as wrong before the fix
+		if confirming, _ := eng.Confirming(); res.Solved || confirming > 0 {
+			t.Fatal("scenario is solved before any fix was applied")
+		}
+		t.Log(res.Message)
+	}) && t.Run("solve", func(t *testing.T) {
+		solver, ok := eng.GetCurrentScenario().(scenario.Solver)
+		if !ok {
+			t.Skip("scenario does not implement Solve")
+		}
+		if err := solver.Solve(ctx); err != nil {
+			t.Fatalf("failed to apply solution: %v", err)
+		}
+	}) && t.Run("validate-positive", func(t *testing.T) {
+		if _, ok := eng.GetCurrentScenario().(scenario.Solver); !ok {
+			t.Skip("scenario does not implement Solve")
+		}
+		message, err := harness.WaitSolved(ctx, eng, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Log(message)
+	})
+
+	if !ok && *keep {
+		t.Logf("keeping the resources of %s", id)
+		return
+	}
+	t.Run("cleanup", func(t *testing.T) {
+		_, run := eng.BeginCleanup()
+		if run == nil {
+			return
+		}
+		if err := run(ctx); err != nil {
+			t.Fatalf("failed to clean up: %v", err)
+		}
+	})
+}